@@ -15,6 +15,16 @@ import (
 
 var requiredQuorums = []uint8{0, 1}
 
+// PaymentMode selects how the accountant pays for a blob that does not fit in the current reservation period.
+type PaymentMode int
+
+const (
+	// OnDemandFallback pays on demand for blobs that do not fit in the current reservation period.
+	OnDemandFallback PaymentMode = iota
+	// ReservationOnly waits until a reservation period has room for the blob, and never pays on demand.
+	ReservationOnly
+)
+
 type Accountant struct {
 	// on-chain states
 	accountID         string
@@ -32,6 +42,11 @@ type Accountant struct {
 
 	// number of bins in the circular accounting, restricted by minNumBins which is 3
 	numBins uint32
+
+	// how blobs that do not fit in the current reservation period are paid for; guarded by usageLock
+	paymentMode PaymentMode
+	// optional store the local accounting is persisted to after every accounted blob; guarded by usageLock
+	store AccountantStore
 }
 
 type BinRecord struct {
@@ -39,6 +54,19 @@ type BinRecord struct {
 	Usage uint64
 }
 
+// PaymentProjection describes how a blob would be paid for if it were dispersed now.
+type PaymentProjection struct {
+	// ReservationAvailable is true if the current reservation period has room for the blob.
+	ReservationAvailable bool
+	// NextReservationTime is when a reservation period next has room for the blob. It is zero if the reservation
+	// can never carry the blob, because the blob is larger than a period or uses quorums outside the reservation.
+	NextReservationTime time.Time
+	// OnDemandCost is the on-demand charge for the blob.
+	OnDemandCost *big.Int
+	// OnDemandAvailable is true if the on-demand deposit covers the charge on top of the payments made so far.
+	OnDemandAvailable bool
+}
+
 func NewAccountant(accountID string, reservation *core.ReservedPayment, onDemand *core.OnDemandPayment, reservationWindow uint32, pricePerSymbol uint32, minNumSymbols uint32, numBins uint32) *Accountant {
 	//TODO: client storage; currently every instance starts fresh but on-chain or a small store makes more sense
 	// Also client is currently responsible for supplying network params, we need to add RPC in order to be automatic
@@ -74,45 +102,226 @@ func (a *Accountant) BlobPaymentInfo(ctx context.Context, numSymbols uint32, quo
 
 	a.usageLock.Lock()
 	defer a.usageLock.Unlock()
-	relativeBinRecord := a.GetRelativeBinRecord(currentReservationPeriod)
-	relativeBinRecord.Usage += symbolUsage
+
+	// the charge is undone if the blob cannot be paid for, or if it cannot be persisted, so that a failed charge
+	// neither counts against later blobs nor is persisted by them
+	snapshot := a.snapshot()
 
 	// first attempt to use the active reservation
-	binLimit := a.reservation.SymbolsPerSecond * uint64(a.reservationWindow)
-	if relativeBinRecord.Usage <= binLimit {
+	if a.recordReservationUsage(currentReservationPeriod, symbolUsage) {
 		if err := QuorumCheck(quorumNumbers, a.reservation.QuorumNumbers); err != nil {
+			a.restore(snapshot)
 			return 0, big.NewInt(0), err
 		}
-		return currentReservationPeriod, big.NewInt(0), nil
-	}
-
-	overflowBinRecord := a.GetRelativeBinRecord(currentReservationPeriod + 2)
-	// Allow one overflow when the overflow bin is empty, the current usage and new length are both less than the limit
-	if overflowBinRecord.Usage == 0 && relativeBinRecord.Usage-symbolUsage < binLimit && symbolUsage <= binLimit {
-		overflowBinRecord.Usage += relativeBinRecord.Usage - binLimit
-		if err := QuorumCheck(quorumNumbers, a.reservation.QuorumNumbers); err != nil {
+		if err := a.persist(); err != nil {
+			a.restore(snapshot)
 			return 0, big.NewInt(0), err
 		}
 		return currentReservationPeriod, big.NewInt(0), nil
 	}
 
-	// reservation not available, rollback reservation records, attempt on-demand
-	//todo: rollback on-demand if disperser respond with some type of rejection?
-	relativeBinRecord.Usage -= symbolUsage
+	// reservation not available, attempt on-demand
 	incrementRequired := big.NewInt(int64(a.PaymentCharged(numSymbols)))
 	a.cumulativePayment.Add(a.cumulativePayment, incrementRequired)
 	if a.cumulativePayment.Cmp(a.onDemand.CumulativePayment) <= 0 {
 		if err := QuorumCheck(quorumNumbers, requiredQuorums); err != nil {
+			a.restore(snapshot)
 			return 0, big.NewInt(0), err
 		}
-		return 0, a.cumulativePayment, nil
+		if err := a.persist(); err != nil {
+			a.restore(snapshot)
+			return 0, big.NewInt(0), err
+		}
+		// the payment is copied, since later payments add to the cumulative payment in place
+		return 0, new(big.Int).Set(a.cumulativePayment), nil
 	}
+	a.restore(snapshot)
 	return 0, big.NewInt(0), fmt.Errorf("neither reservation nor on-demand payment is available")
 }
 
-// AccountBlob accountant provides and records payment information
+// accountingSnapshot is a copy of the local accounting of an Accountant.
+type accountingSnapshot struct {
+	binRecords        []BinRecord
+	cumulativePayment *big.Int
+}
+
+// snapshot copies the local accounting. Must be called with usageLock held.
+func (a *Accountant) snapshot() accountingSnapshot {
+	return accountingSnapshot{
+		binRecords:        append([]BinRecord(nil), a.binRecords...),
+		cumulativePayment: new(big.Int).Set(a.cumulativePayment),
+	}
+}
+
+// restore resets the local accounting to a snapshot. Must be called with usageLock held.
+func (a *Accountant) restore(snapshot accountingSnapshot) {
+	copy(a.binRecords, snapshot.binRecords)
+	a.cumulativePayment = snapshot.cumulativePayment
+}
+
+// recordReservationUsage records a blob against the bin of a reservation period if the reservation has room for it,
+// allowing one overflow into the bin two periods later. If the blob does not fit, the bins are left unchanged and
+// false is returned. Must be called with usageLock held.
+func (a *Accountant) recordReservationUsage(reservationPeriod uint32, symbolUsage uint64) bool {
+	relativeBinRecord := a.GetRelativeBinRecord(reservationPeriod)
+	relativeBinRecord.Usage += symbolUsage
+
+	binLimit := a.reservation.SymbolsPerSecond * uint64(a.reservationWindow)
+	if relativeBinRecord.Usage <= binLimit {
+		return true
+	}
+
+	overflowBinRecord := a.GetRelativeBinRecord(reservationPeriod + 2)
+	// Allow one overflow when the overflow bin is empty, the current usage and new length are both less than the limit
+	if overflowBinRecord.Usage == 0 && relativeBinRecord.Usage-symbolUsage < binLimit && symbolUsage <= binLimit {
+		overflowBinRecord.Usage += relativeBinRecord.Usage - binLimit
+		return true
+	}
+
+	relativeBinRecord.Usage -= symbolUsage
+	return false
+}
+
+// SetPaymentMode sets how blobs that do not fit in the current reservation period are paid for.
+func (a *Accountant) SetPaymentMode(mode PaymentMode) {
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+	a.paymentMode = mode
+}
+
+// NextReservationCapacity returns when the reservation next has room for a blob of numSymbols symbols: now if the
+// current reservation period has room, and otherwise the start of the first later period that does. It returns an
+// error if the blob is larger than the reservation allows in a single period. Nothing is recorded.
+func (a *Accountant) NextReservationCapacity(numSymbols uint32) (time.Time, error) {
+	now := time.Now()
+	currentReservationPeriod := meterer.GetReservationPeriod(uint64(now.Unix()), a.reservationWindow)
+	symbolUsage := uint64(a.SymbolsCharged(numSymbols))
+
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	binLimit := a.reservation.SymbolsPerSecond * uint64(a.reservationWindow)
+	if symbolUsage > binLimit {
+		return time.Time{}, fmt.Errorf("blob of %d symbols exceeds the reservation limit of %d symbols per period", symbolUsage, binLimit)
+	}
+
+	// Periods past the tracked bins have no usage, so the loop ends within a few periods.
+	for period := currentReservationPeriod; ; period++ {
+		if a.reservationFits(period, symbolUsage, binLimit) {
+			if period == currentReservationPeriod {
+				return now, nil
+			}
+			return time.Unix(int64(period)*int64(a.reservationWindow), 0), nil
+		}
+	}
+}
+
+// reservationFits reports whether recordReservationUsage would accept a blob in the given period, without claiming
+// any bin. Must be called with usageLock held.
+func (a *Accountant) reservationFits(reservationPeriod uint32, symbolUsage uint64, binLimit uint64) bool {
+	usage := a.binUsage(reservationPeriod)
+	if usage+symbolUsage <= binLimit {
+		return true
+	}
+	return a.binUsage(reservationPeriod+2) == 0 && usage < binLimit && symbolUsage <= binLimit
+}
+
+// binUsage returns the usage recorded for a reservation period. Unlike GetRelativeBinRecord, it does not reset the
+// bin if the bin holds another period. Must be called with usageLock held.
+func (a *Accountant) binUsage(reservationPeriod uint32) uint64 {
+	record := a.binRecords[reservationPeriod%a.numBins]
+	if record.Index != reservationPeriod {
+		return 0
+	}
+	return record.Usage
+}
+
+// WaitForReservation blocks until a reservation period has room for the blob, then records the blob against that
+// period and returns it. It returns an error if the reservation can never carry the blob, or if the context is done
+// first.
+func (a *Accountant) WaitForReservation(ctx context.Context, numSymbols uint32, quorumNumbers []uint8) (uint32, error) {
+	if err := QuorumCheck(quorumNumbers, a.reservation.QuorumNumbers); err != nil {
+		return 0, err
+	}
+	symbolUsage := uint64(a.SymbolsCharged(numSymbols))
+
+	for {
+		next, err := a.NextReservationCapacity(numSymbols)
+		if err != nil {
+			return 0, err
+		}
+		if wait := time.Until(next); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return 0, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		// Other blobs may have taken the room in the meantime, in which case the next capacity is recomputed.
+		a.usageLock.Lock()
+		snapshot := a.snapshot()
+		currentReservationPeriod := meterer.GetReservationPeriod(uint64(time.Now().Unix()), a.reservationWindow)
+		recorded := a.recordReservationUsage(currentReservationPeriod, symbolUsage)
+		if recorded {
+			err = a.persist()
+			if err != nil {
+				a.restore(snapshot)
+			}
+		}
+		a.usageLock.Unlock()
+		if err != nil {
+			return 0, err
+		}
+		if recorded {
+			return currentReservationPeriod, nil
+		}
+	}
+}
+
+// ProjectPayment projects how a blob would be paid for if it were dispersed now, so that callers can decide whether
+// to wait for the reservation or to pay on demand. Nothing is recorded.
+func (a *Accountant) ProjectPayment(numSymbols uint32, quorumNumbers []uint8) *PaymentProjection {
+	projection := &PaymentProjection{
+		OnDemandCost: new(big.Int).SetUint64(a.PaymentCharged(numSymbols)),
+	}
+
+	if QuorumCheck(quorumNumbers, a.reservation.QuorumNumbers) == nil {
+		next, err := a.NextReservationCapacity(numSymbols)
+		if err == nil {
+			projection.NextReservationTime = next
+			projection.ReservationAvailable = !next.After(time.Now())
+		}
+	}
+
+	if QuorumCheck(quorumNumbers, requiredQuorums) == nil {
+		a.usageLock.Lock()
+		projected := new(big.Int).Add(a.cumulativePayment, projection.OnDemandCost)
+		projection.OnDemandAvailable = projected.Cmp(a.onDemand.CumulativePayment) <= 0
+		a.usageLock.Unlock()
+	}
+
+	return projection
+}
+
+// AccountBlob accountant provides and records payment information. In the ReservationOnly payment mode, it waits
+// until a reservation period has room for the blob.
 func (a *Accountant) AccountBlob(ctx context.Context, numSymbols uint32, quorums []uint8, salt uint32) (*core.PaymentMetadata, error) {
-	reservationPeriod, cumulativePayment, err := a.BlobPaymentInfo(ctx, numSymbols, quorums)
+	a.usageLock.Lock()
+	paymentMode := a.paymentMode
+	a.usageLock.Unlock()
+
+	var reservationPeriod uint32
+	var cumulativePayment *big.Int
+	var err error
+	if paymentMode == ReservationOnly {
+		reservationPeriod, err = a.WaitForReservation(ctx, numSymbols, quorums)
+		cumulativePayment = big.NewInt(0)
+	} else {
+		reservationPeriod, cumulativePayment, err = a.BlobPaymentInfo(ctx, numSymbols, quorums)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +336,48 @@ func (a *Accountant) AccountBlob(ctx context.Context, numSymbols uint32, quorums
 	return pm, nil
 }
 
+// RollbackBlob undoes the accounting of a blob whose payment the disperser rejected, so that the rejected charge is
+// not counted again when the accountant is synced with the disperser, which keeps the larger of the local and the
+// disperser's usage. The payment must have been returned by AccountBlob for a blob of numSymbols symbols.
+//
+// An on-demand payment is only rolled back if no other blob has been paid on demand since, since the cumulative
+// payments of later blobs build on it. A reservation usage is removed from its period, along with the usage the blob
+// overflowed into a later period. Only the last blob recorded in a period can overflow, so a period over its limit
+// is taken to have overflowed with the blob being rolled back.
+func (a *Accountant) RollbackBlob(payment *core.PaymentMetadata, numSymbols uint32) error {
+	symbolUsage := uint64(a.SymbolsCharged(numSymbols))
+
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	if payment.CumulativePayment != nil && payment.CumulativePayment.Sign() > 0 {
+		if payment.CumulativePayment.Cmp(a.cumulativePayment) != 0 {
+			return nil
+		}
+		charge := new(big.Int).SetUint64(a.PaymentCharged(numSymbols))
+		a.cumulativePayment = new(big.Int).Sub(a.cumulativePayment, charge)
+		if a.cumulativePayment.Sign() < 0 {
+			a.cumulativePayment = big.NewInt(0)
+		}
+		return a.persist()
+	}
+
+	record := &a.binRecords[payment.ReservationPeriod%a.numBins]
+	if record.Index != payment.ReservationPeriod {
+		// the period is no longer tracked
+		return nil
+	}
+	binLimit := a.reservation.SymbolsPerSecond * uint64(a.reservationWindow)
+	if record.Usage > binLimit {
+		overflow := &a.binRecords[(payment.ReservationPeriod+2)%a.numBins]
+		if overflow.Index == payment.ReservationPeriod+2 {
+			overflow.Usage -= min(record.Usage-binLimit, symbolUsage, overflow.Usage)
+		}
+	}
+	record.Usage -= min(symbolUsage, record.Usage)
+	return a.persist()
+}
+
 // TODO: PaymentCharged and SymbolsCharged copied from meterer, should be refactored
 // PaymentCharged returns the chargeable price for a given data length
 func (a *Accountant) PaymentCharged(numSymbols uint32) uint64 {
@@ -155,6 +406,81 @@ func (a *Accountant) GetRelativeBinRecord(index uint32) *BinRecord {
 	return &a.binRecords[relativeIndex]
 }
 
+// SetStore loads the local accounting persisted in the store, if any, and persists the accounting to the store
+// after every accounted blob from then on.
+func (a *Accountant) SetStore(store AccountantStore) error {
+	state, err := store.Load(a.accountID)
+	if err != nil {
+		return fmt.Errorf("failed to load accountant state: %w", err)
+	}
+
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+	if state != nil {
+		a.binRecords = mergeBinRecords(a.numBins, state.BinRecords)
+		if state.CumulativePayment != nil {
+			a.cumulativePayment = new(big.Int).Set(state.CumulativePayment)
+		}
+	}
+	a.store = store
+	return nil
+}
+
+// persist saves the local accounting to the store, if there is one. Must be called with usageLock held, so that
+// the saved states are ordered like the accounted blobs.
+func (a *Accountant) persist() error {
+	if a.store == nil {
+		return nil
+	}
+	err := a.store.Save(a.accountID, &AccountantState{
+		BinRecords:        a.binRecords,
+		CumulativePayment: a.cumulativePayment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to persist accountant state: %w", err)
+	}
+	return nil
+}
+
+// SyncPaymentState reconciles the accountant with the disperser's payment state. The parameters and on-chain
+// state are taken from the disperser, while the bin usage and cumulative payment keep the larger of the local and
+// the disperser's values: blobs that are still in flight are not yet counted by the disperser, and accounting them
+// again would produce payments the disperser rejects as duplicates.
+func (a *Accountant) SyncPaymentState(paymentState *disperser_rpc.GetPaymentStateReply) error {
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	localBinRecords := a.binRecords
+	localCumulativePayment := a.cumulativePayment
+	if err := a.setPaymentState(paymentState); err != nil {
+		return err
+	}
+
+	a.binRecords = mergeBinRecords(a.numBins, localBinRecords, a.binRecords)
+	if localCumulativePayment.Cmp(a.cumulativePayment) > 0 {
+		a.cumulativePayment = localCumulativePayment
+	}
+	return a.persist()
+}
+
+// mergeBinRecords places bin records in the slots of a circular accounting of numBins bins. When several records
+// fall in the same slot, the most recent period wins, and for the same period the largest usage wins.
+func mergeBinRecords(numBins uint32, recordSets ...[]BinRecord) []BinRecord {
+	merged := make([]BinRecord, numBins)
+	for i := range merged {
+		merged[i] = BinRecord{Index: uint32(i), Usage: 0}
+	}
+	for _, records := range recordSets {
+		for _, record := range records {
+			slot := &merged[record.Index%numBins]
+			if record.Index > slot.Index || (record.Index == slot.Index && record.Usage > slot.Usage) {
+				*slot = record
+			}
+		}
+	}
+	return merged
+}
+
 // SetPaymentState sets the accountant's state from the disperser's response
 // We require disperser to return a valid set of global parameters, but optional
 // account level on/off-chain state. If on-chain fields are not present, we use
@@ -162,6 +488,13 @@ func (a *Accountant) GetRelativeBinRecord(index uint32) *BinRecord {
 // If off-chain fields are not present, we assume the account has no payment history
 // and set accoutant state to use initial values.
 func (a *Accountant) SetPaymentState(paymentState *disperser_rpc.GetPaymentStateReply) error {
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+	return a.setPaymentState(paymentState)
+}
+
+// setPaymentState implements SetPaymentState. Must be called with usageLock held.
+func (a *Accountant) setPaymentState(paymentState *disperser_rpc.GetPaymentStateReply) error {
 	if paymentState == nil {
 		return fmt.Errorf("payment state cannot be nil")
 	} else if paymentState.GetPaymentGlobalParams() == nil {
@@ -225,7 +558,8 @@ func (a *Accountant) SetPaymentState(paymentState *disperser_rpc.GetPaymentState
 			}
 		}
 	}
-	a.binRecords = binRecords
+	// the disperser returns the records in period order, while the accountant keeps each period in its slot
+	a.binRecords = mergeBinRecords(a.numBins, binRecords)
 	return nil
 }
 
@@ -0,0 +1,159 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+)
+
+// AccountantState is the local accounting of an Accountant, persisted so that a restarted client resumes from its
+// own records rather than from a payment state that does not yet reflect the blobs still in flight.
+type AccountantState struct {
+	BinRecords        []BinRecord
+	CumulativePayment *big.Int
+}
+
+// AccountantStore persists the local accounting of accountants.
+type AccountantStore interface {
+	// Load returns the persisted state of an account, or nil if no state was persisted for it.
+	Load(accountID string) (*AccountantState, error)
+	// Save atomically replaces the persisted state of an account.
+	Save(accountID string, state *AccountantState) error
+}
+
+// accountantStateJSON is the serialized form of an AccountantState.
+type accountantStateJSON struct {
+	BinRecords []BinRecord `json:"binRecords"`
+	// CumulativePayment is a decimal string, since it may not fit in a JSON number.
+	CumulativePayment string `json:"cumulativePayment"`
+}
+
+func marshalAccountantState(state *AccountantState) ([]byte, error) {
+	cumulativePayment := big.NewInt(0)
+	if state.CumulativePayment != nil {
+		cumulativePayment = state.CumulativePayment
+	}
+	return json.Marshal(&accountantStateJSON{
+		BinRecords:        state.BinRecords,
+		CumulativePayment: cumulativePayment.String(),
+	})
+}
+
+func unmarshalAccountantState(data []byte) (*AccountantState, error) {
+	serialized := &accountantStateJSON{}
+	if err := json.Unmarshal(data, serialized); err != nil {
+		return nil, fmt.Errorf("failed to parse accountant state: %w", err)
+	}
+	cumulativePayment, ok := new(big.Int).SetString(serialized.CumulativePayment, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid cumulative payment %q", serialized.CumulativePayment)
+	}
+	return &AccountantState{
+		BinRecords:        serialized.BinRecords,
+		CumulativePayment: cumulativePayment,
+	}, nil
+}
+
+type fileAccountantStore struct {
+	dir string
+}
+
+var _ AccountantStore = (*fileAccountantStore)(nil)
+
+// NewFileAccountantStore creates an AccountantStore that keeps the state of each account in a JSON file in dir.
+// Files are replaced with a rename, so a crash never leaves a partially written state behind.
+func NewFileAccountantStore(dir string) (AccountantStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create accountant store directory: %w", err)
+	}
+	return &fileAccountantStore{dir: dir}, nil
+}
+
+func (s *fileAccountantStore) path(accountID string) string {
+	return filepath.Join(s.dir, filepath.Base(accountID)+".json")
+}
+
+func (s *fileAccountantStore) Load(accountID string) (*AccountantState, error) {
+	data, err := os.ReadFile(s.path(accountID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accountant state: %w", err)
+	}
+	return unmarshalAccountantState(data)
+}
+
+func (s *fileAccountantStore) Save(accountID string, state *AccountantState) error {
+	data, err := marshalAccountantState(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize accountant state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, filepath.Base(accountID)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create accountant state file: %w", err)
+	}
+	defer func() {
+		// no-op once the file is renamed
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write accountant state: %w", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync accountant state: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close accountant state file: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), s.path(accountID)); err != nil {
+		return fmt.Errorf("failed to replace accountant state: %w", err)
+	}
+	return nil
+}
+
+// accountantStateKeyPrefix prefixes the keys of accountant states in a kvstore.
+const accountantStateKeyPrefix = "accountant-state/"
+
+type kvAccountantStore struct {
+	store kvstore.Store[[]byte]
+}
+
+var _ AccountantStore = (*kvAccountantStore)(nil)
+
+// NewKVAccountantStore creates an AccountantStore that keeps the state of each account under its own key in a
+// kvstore, e.g. a LevelDB store shared with other client data.
+func NewKVAccountantStore(store kvstore.Store[[]byte]) AccountantStore {
+	return &kvAccountantStore{store: store}
+}
+
+func (s *kvAccountantStore) Load(accountID string) (*AccountantState, error) {
+	data, err := s.store.Get([]byte(accountantStateKeyPrefix + accountID))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accountant state: %w", err)
+	}
+	return unmarshalAccountantState(data)
+}
+
+func (s *kvAccountantStore) Save(accountID string, state *AccountantState) error {
+	data, err := marshalAccountantState(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize accountant state: %w", err)
+	}
+	if err = s.store.Put([]byte(accountantStateKeyPrefix+accountID), data); err != nil {
+		return fmt.Errorf("failed to write accountant state: %w", err)
+	}
+	return nil
+}
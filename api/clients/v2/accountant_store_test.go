@@ -0,0 +1,170 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAccountantStore(t *testing.T, store AccountantStore) {
+	state, err := store.Load("account")
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	expected := &AccountantState{
+		BinRecords:        []BinRecord{{Index: 9, Usage: 100}, {Index: 10, Usage: 0}, {Index: 11, Usage: 30}},
+		CumulativePayment: new(big.Int).Lsh(big.NewInt(1), 100),
+	}
+	require.NoError(t, store.Save("account", expected))
+	require.NoError(t, store.Save("other", &AccountantState{CumulativePayment: big.NewInt(1)}))
+
+	state, err = store.Load("account")
+	require.NoError(t, err)
+	assert.Equal(t, expected.BinRecords, state.BinRecords)
+	assert.Equal(t, 0, expected.CumulativePayment.Cmp(state.CumulativePayment))
+
+	// saving replaces the previous state
+	require.NoError(t, store.Save("account", &AccountantState{CumulativePayment: big.NewInt(5)}))
+	state, err = store.Load("account")
+	require.NoError(t, err)
+	assert.Empty(t, state.BinRecords)
+	assert.Equal(t, big.NewInt(5), state.CumulativePayment)
+}
+
+func TestFileAccountantStore(t *testing.T) {
+	store, err := NewFileAccountantStore(t.TempDir())
+	require.NoError(t, err)
+	testAccountantStore(t, store)
+}
+
+func TestKVAccountantStore(t *testing.T) {
+	testAccountantStore(t, NewKVAccountantStore(mapstore.NewStore()))
+}
+
+func newTestAccountantWithStore(t *testing.T, store AccountantStore) *Accountant {
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 200,
+		StartTimestamp:   100,
+		EndTimestamp:     200,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(10000),
+	}
+	accountant := NewAccountant("account", reservation, onDemand, 5, 1, 100, numBins)
+	require.NoError(t, accountant.SetStore(store))
+	return accountant
+}
+
+func TestAccountantResumesFromStore(t *testing.T) {
+	store := NewKVAccountantStore(mapstore.NewStore())
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+
+	accountant := newTestAccountantWithStore(t, store)
+	_, err := accountant.AccountBlob(ctx, 800, quorums, salt)
+	require.NoError(t, err)
+	_, err = accountant.AccountBlob(ctx, 500, quorums, salt)
+	require.NoError(t, err)
+	header, err := accountant.AccountBlob(ctx, 300, quorums, salt)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(300), header.CumulativePayment)
+
+	// a restarted accountant resumes from the persisted accounting
+	restarted := newTestAccountantWithStore(t, store)
+	assert.Equal(t, accountant.binRecords, restarted.binRecords)
+	assert.Equal(t, big.NewInt(300), restarted.cumulativePayment)
+
+	header, err = restarted.AccountBlob(ctx, 100, quorums, salt)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(400), header.CumulativePayment)
+}
+
+// failingAccountantStore is an AccountantStore whose saves fail while failSaves is set.
+type failingAccountantStore struct {
+	AccountantStore
+	failSaves bool
+}
+
+func (s *failingAccountantStore) Save(accountID string, state *AccountantState) error {
+	if s.failSaves {
+		return errors.New("save failed")
+	}
+	return s.AccountantStore.Save(accountID, state)
+}
+
+func TestAccountantRestoresAccountingWhenPersistFails(t *testing.T) {
+	store := &failingAccountantStore{AccountantStore: NewKVAccountantStore(mapstore.NewStore())}
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+
+	accountant := newTestAccountantWithStore(t, store)
+	_, err := accountant.AccountBlob(ctx, 800, quorums, salt)
+	require.NoError(t, err)
+	_, err = accountant.AccountBlob(ctx, 500, quorums, salt)
+	require.NoError(t, err)
+	binRecords := append([]BinRecord(nil), accountant.binRecords...)
+
+	// neither a reservation nor an on-demand charge that cannot be persisted is kept in memory
+	store.failSaves = true
+	_, err = accountant.AccountBlob(ctx, 300, quorums, salt)
+	require.Error(t, err)
+	assert.Equal(t, binRecords, accountant.binRecords)
+	assert.Equal(t, big.NewInt(0), accountant.cumulativePayment)
+
+	store.failSaves = false
+	header, err := accountant.AccountBlob(ctx, 300, quorums, salt)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(300), header.CumulativePayment)
+}
+
+func TestSyncPaymentState(t *testing.T) {
+	store := NewKVAccountantStore(mapstore.NewStore())
+	accountant := newTestAccountantWithStore(t, store)
+	accountant.binRecords = []BinRecord{{Index: 30, Usage: 500}, {Index: 31, Usage: 100}, {Index: 29, Usage: 50}}
+	accountant.cumulativePayment = big.NewInt(700)
+
+	paymentState := &disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+			MinNumSymbols:     100,
+			PricePerSymbol:    2,
+			ReservationWindow: 5,
+		},
+		// the disperser has seen more usage in period 30, and has not seen period 31 yet
+		BinRecords: []*disperser_rpc.BinRecord{
+			{Index: 30, Usage: 800},
+			{Index: 32, Usage: 0},
+			nil,
+		},
+		CumulativePayment:        big.NewInt(600).Bytes(),
+		OnchainCumulativePayment: big.NewInt(5000).Bytes(),
+	}
+	require.NoError(t, accountant.SyncPaymentState(paymentState))
+
+	assert.Equal(t, uint32(2), accountant.pricePerSymbol)
+	assert.Equal(t, big.NewInt(5000), accountant.onDemand.CumulativePayment)
+	// the local cumulative payment is ahead of the disperser's
+	assert.Equal(t, big.NewInt(700), accountant.cumulativePayment)
+	// period 32 replaces period 29 in its slot, period 30 keeps the disperser's usage and period 31 the local usage
+	assert.Equal(t, []BinRecord{{Index: 30, Usage: 800}, {Index: 31, Usage: 100}, {Index: 32, Usage: 0}},
+		accountant.binRecords)
+
+	// the reconciled state is persisted
+	state, err := store.Load("account")
+	require.NoError(t, err)
+	assert.Equal(t, accountant.binRecords, state.BinRecords)
+	assert.Equal(t, big.NewInt(700), state.CumulativePayment)
+
+	// the disperser's cumulative payment wins when it is ahead
+	paymentState.CumulativePayment = big.NewInt(900).Bytes()
+	require.NoError(t, accountant.SyncPaymentState(paymentState))
+	assert.Equal(t, big.NewInt(900), accountant.cumulativePayment)
+}
@@ -331,6 +331,53 @@ func TestAccountBlob_ReservationWithOneOverflow(t *testing.T) {
 	assert.Equal(t, isRotation([]uint64{1300, 0, 300}, mapRecordUsage(accountant.binRecords)), true)
 }
 
+func TestRollbackBlob(t *testing.T) {
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 200,
+		StartTimestamp:   100,
+		EndTimestamp:     200,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(1000),
+	}
+	reservationWindow := uint32(5)
+	pricePerSymbol := uint32(1)
+	minNumSymbols := uint32(100)
+
+	privateKey1, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	accountId := hex.EncodeToString(privateKey1.D.Bytes())
+	accountant := NewAccountant(accountId, reservation, onDemand, reservationWindow, pricePerSymbol, minNumSymbols, numBins)
+
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+
+	reserved, err := accountant.AccountBlob(ctx, 800, quorums, salt)
+	assert.NoError(t, err)
+	overflowed, err := accountant.AccountBlob(ctx, 500, quorums, salt)
+	assert.NoError(t, err)
+	assert.Equal(t, isRotation([]uint64{1300, 0, 300}, mapRecordUsage(accountant.binRecords)), true)
+	firstOnDemand, err := accountant.AccountBlob(ctx, 200, quorums, salt)
+	assert.NoError(t, err)
+	secondOnDemand, err := accountant.AccountBlob(ctx, 100, quorums, salt)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(300), accountant.cumulativePayment)
+
+	// an on-demand payment that later payments build on is kept
+	assert.NoError(t, accountant.RollbackBlob(firstOnDemand, 200))
+	assert.Equal(t, big.NewInt(300), accountant.cumulativePayment)
+	assert.NoError(t, accountant.RollbackBlob(secondOnDemand, 100))
+	assert.Equal(t, big.NewInt(200), accountant.cumulativePayment)
+
+	// rolling back the overflowing blob also removes its overflow
+	assert.NoError(t, accountant.RollbackBlob(overflowed, 500))
+	assert.Equal(t, isRotation([]uint64{800, 0, 0}, mapRecordUsage(accountant.binRecords)), true)
+	assert.NoError(t, accountant.RollbackBlob(reserved, 800))
+	assert.Equal(t, isRotation([]uint64{0, 0, 0}, mapRecordUsage(accountant.binRecords)), true)
+}
+
 func TestAccountBlob_ReservationOverflowReset(t *testing.T) {
 	reservation := &core.ReservedPayment{
 		SymbolsPerSecond: 1000,
@@ -374,6 +421,162 @@ func TestAccountBlob_ReservationOverflowReset(t *testing.T) {
 	assert.Equal(t, isRotation([]uint64{1000, 500, 0}, mapRecordUsage(accountant.binRecords)), true)
 }
 
+func TestNextReservationCapacity(t *testing.T) {
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 200,
+		StartTimestamp:   100,
+		EndTimestamp:     200,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(1000),
+	}
+	reservationWindow := uint32(5)
+	pricePerSymbol := uint32(1)
+	minNumSymbols := uint32(100)
+
+	privateKey1, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	accountId := hex.EncodeToString(privateKey1.D.Bytes())
+	accountant := NewAccountant(accountId, reservation, onDemand, reservationWindow, pricePerSymbol, minNumSymbols, numBins)
+
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+
+	// the current period has room
+	next, err := accountant.NextReservationCapacity(800)
+	assert.NoError(t, err)
+	assert.False(t, next.After(time.Now()))
+
+	header, err := accountant.AccountBlob(ctx, 800, quorums, salt)
+	assert.NoError(t, err)
+	period := header.ReservationPeriod
+
+	// the overflow into a later bin is still allowed
+	next, err = accountant.NextReservationCapacity(500)
+	assert.NoError(t, err)
+	assert.False(t, next.After(time.Now()))
+	_, err = accountant.AccountBlob(ctx, 500, quorums, salt)
+	assert.NoError(t, err)
+
+	// the current period is full and the overflow is used, so the next period is the first with room
+	next, err = accountant.NextReservationCapacity(200)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(int64(period+1)*int64(reservationWindow), 0), next)
+
+	// checking the capacity records nothing
+	assert.Equal(t, isRotation([]uint64{1300, 0, 300}, mapRecordUsage(accountant.binRecords)), true)
+
+	// a blob larger than a period never fits
+	_, err = accountant.NextReservationCapacity(1001)
+	assert.Error(t, err)
+}
+
+func TestProjectPayment(t *testing.T) {
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 200,
+		StartTimestamp:   100,
+		EndTimestamp:     200,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(1500),
+	}
+	reservationWindow := uint32(5)
+	pricePerSymbol := uint32(2)
+	minNumSymbols := uint32(100)
+
+	privateKey1, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	accountId := hex.EncodeToString(privateKey1.D.Bytes())
+	accountant := NewAccountant(accountId, reservation, onDemand, reservationWindow, pricePerSymbol, minNumSymbols, numBins)
+
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+
+	projection := accountant.ProjectPayment(250, quorums)
+	assert.True(t, projection.ReservationAvailable)
+	assert.False(t, projection.NextReservationTime.After(time.Now()))
+	// charged for 300 symbols, rounded up to a multiple of the minimum
+	assert.Equal(t, big.NewInt(600), projection.OnDemandCost)
+	assert.True(t, projection.OnDemandAvailable)
+
+	// fill the reservation, including the overflow, then pay on demand
+	_, err = accountant.AccountBlob(ctx, 800, quorums, salt)
+	assert.NoError(t, err)
+	_, err = accountant.AccountBlob(ctx, 500, quorums, salt)
+	assert.NoError(t, err)
+	header, err := accountant.AccountBlob(ctx, 700, quorums, salt)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(700*2), header.CumulativePayment)
+
+	projection = accountant.ProjectPayment(100, quorums)
+	assert.False(t, projection.ReservationAvailable)
+	assert.True(t, projection.NextReservationTime.After(time.Now()))
+	assert.Equal(t, big.NewInt(200), projection.OnDemandCost)
+	// 1400 of a deposit of 1500 was paid, so the blob cannot be paid on demand
+	assert.False(t, projection.OnDemandAvailable)
+
+	// quorums outside the reservation
+	projection = accountant.ProjectPayment(100, []uint8{0, 2})
+	assert.False(t, projection.ReservationAvailable)
+	assert.True(t, projection.NextReservationTime.IsZero())
+	assert.False(t, projection.OnDemandAvailable)
+}
+
+func TestAccountBlob_ReservationOnly(t *testing.T) {
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 1000,
+		StartTimestamp:   100,
+		EndTimestamp:     200,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(1000),
+	}
+	reservationWindow := uint32(1) // Set to 1 second for testing
+	pricePerSymbol := uint32(1)
+	minNumSymbols := uint32(100)
+
+	privateKey1, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	accountId := hex.EncodeToString(privateKey1.D.Bytes())
+	accountant := NewAccountant(accountId, reservation, onDemand, reservationWindow, pricePerSymbol, minNumSymbols, numBins)
+	accountant.SetPaymentMode(ReservationOnly)
+
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+
+	// full reservation
+	header, err := accountant.AccountBlob(ctx, 1000, quorums, salt)
+	assert.NoError(t, err)
+	period := header.ReservationPeriod
+
+	// waits for the next period instead of paying on demand
+	header, err = accountant.AccountBlob(ctx, 500, quorums, salt)
+	assert.NoError(t, err)
+	assert.Greater(t, header.ReservationPeriod, period)
+	assert.Equal(t, big.NewInt(0), header.CumulativePayment)
+	assert.Equal(t, big.NewInt(0), accountant.cumulativePayment)
+
+	// fill the current period, then give up before the next one
+	_, err = accountant.AccountBlob(ctx, 500, quorums, salt)
+	assert.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	_, err = accountant.AccountBlob(timeoutCtx, 1000, quorums, salt)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// blobs the reservation can never carry fail immediately
+	_, err = accountant.AccountBlob(ctx, 1001, quorums, salt)
+	assert.Error(t, err)
+	_, err = accountant.AccountBlob(ctx, 100, []uint8{0, 2}, salt)
+	assert.Error(t, err)
+}
+
 func TestQuorumCheck(t *testing.T) {
 	tests := []struct {
 		name           string
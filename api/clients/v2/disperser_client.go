@@ -2,8 +2,10 @@ package clients
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
@@ -15,10 +17,19 @@ import (
 	"google.golang.org/grpc"
 )
 
+// paymentStateNonceLength is the number of random bytes signed with each GetPaymentState request
+const paymentStateNonceLength = 16
+
 type DisperserClientConfig struct {
 	Hostname          string
 	Port              string
 	UseSecureGrpcFlag bool
+	// AccountantStore optionally persists the accounting of the accountant created by the client, so that it
+	// survives restarts. It is not used if the client is given an accountant.
+	AccountantStore AccountantStore
+	// RetentionTier is the retention tier of the dispersed blobs. Longer tiers are billed as proportionally larger
+	// blobs. Defaults to the standard tier.
+	RetentionTier corev2.RetentionTier
 }
 
 type DisperserClient interface {
@@ -26,6 +37,7 @@ type DisperserClient interface {
 	DisperseBlob(ctx context.Context, data []byte, blobVersion corev2.BlobVersion, quorums []core.QuorumID, salt uint32) (*dispv2.BlobStatus, corev2.BlobKey, error)
 	GetBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*disperser_rpc.BlobStatusReply, error)
 	GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error)
+	GetBlobSymbolProofs(ctx context.Context, blobKey corev2.BlobKey, symbolIndices []uint64, batched bool) (*disperser_rpc.BlobSymbolProofsReply, error)
 }
 
 type disperserClient struct {
@@ -71,6 +83,9 @@ func NewDisperserClient(config *DisperserClientConfig, signer corev2.BlobRequest
 	if config.Port == "" {
 		return nil, api.NewErrorInvalidArg("port must be provided")
 	}
+	if err := config.RetentionTier.Validate(); err != nil {
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
 	if signer == nil {
 		return nil, api.NewErrorInvalidArg("signer must be provided")
 	}
@@ -84,14 +99,21 @@ func NewDisperserClient(config *DisperserClientConfig, signer corev2.BlobRequest
 	}, nil
 }
 
-// PopulateAccountant populates the accountant with the payment state from the disperser.
+// PopulateAccountant populates the accountant with the payment state from the disperser. If the accountant already
+// has local accounting, e.g. persisted in its store, it is reconciled with the disperser's payment state.
 func (c *disperserClient) PopulateAccountant(ctx context.Context) error {
 	if c.accountant == nil {
 		accountId, err := c.signer.GetAccountID()
 		if err != nil {
 			return fmt.Errorf("error getting account ID: %w", err)
 		}
-		c.accountant = NewAccountant(accountId, nil, nil, 0, 0, 0, 0)
+		accountant := NewAccountant(accountId, nil, nil, 0, 0, 0, 0)
+		if c.config.AccountantStore != nil {
+			if err = accountant.SetStore(c.config.AccountantStore); err != nil {
+				return fmt.Errorf("error loading accountant state: %w", err)
+			}
+		}
+		c.accountant = accountant
 	}
 
 	paymentState, err := c.GetPaymentState(ctx)
@@ -99,7 +121,7 @@ func (c *disperserClient) PopulateAccountant(ctx context.Context) error {
 		return fmt.Errorf("error getting payment state for initializing accountant: %w", err)
 	}
 
-	err = c.accountant.SetPaymentState(paymentState)
+	err = c.accountant.SyncPaymentState(paymentState)
 	if err != nil {
 		return fmt.Errorf("error setting payment state for accountant: %w", err)
 	}
@@ -138,81 +160,154 @@ func (c *disperserClient) DisperseBlob(
 		return nil, [32]byte{}, api.NewErrorInternal("uninitialized signer for authenticated dispersal")
 	}
 
-	symbolLength := encoding.GetBlobLengthPowerOf2(uint(len(data)))
-	payment, err := c.accountant.AccountBlob(ctx, uint32(symbolLength), quorums, salt)
+	// The blob is validated and committed to before it is accounted, so that a blob that is never sent is not
+	// charged.
+	err = validateBlob(data, quorums)
 	if err != nil {
-		return nil, [32]byte{}, fmt.Errorf("error accounting blob: %w", err)
+		return nil, [32]byte{}, err
 	}
 
-	if len(quorums) == 0 {
-		return nil, [32]byte{}, api.NewErrorInvalidArg("quorum numbers must be provided")
+	blobCommitments, err := c.getBlobCommitments(ctx, data)
+	if err != nil {
+		return nil, [32]byte{}, err
 	}
 
-	for _, q := range quorums {
-		if q > corev2.MaxQuorumID {
-			return nil, [32]byte{}, api.NewErrorInvalidArg("quorum number must be less than 256")
+	symbolLength := c.config.RetentionTier.BilledSymbols(encoding.GetBlobLengthPowerOf2(uint(len(data))))
+	payment, err := c.accountant.AccountBlob(ctx, uint32(symbolLength), quorums, salt)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("error accounting blob: %w", err)
+	}
+
+	reply, err := c.sendBlob(ctx, data, blobVersion, blobCommitments, quorums, payment, uint32(symbolLength))
+	if api.IsPaymentRejected(err) {
+		// The disperser rejected the payment, e.g. because the local accounting fell behind the disperser's after
+		// a restart. The rejected charge is rolled back, since the disperser did not record it, and the accountant
+		// is reconciled with the disperser's payment state before retrying once. Other errors, such as rate limits,
+		// are not retried.
+		if rollbackErr := c.accountant.RollbackBlob(payment, uint32(symbolLength)); rollbackErr != nil {
+			return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w (rolling back payment: %v)", err, rollbackErr)
 		}
+		if syncErr := c.PopulateAccountant(ctx); syncErr != nil {
+			return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w (resyncing payment state: %v)", err, syncErr)
+		}
+		payment, err = c.accountant.AccountBlob(ctx, uint32(symbolLength), quorums, salt)
+		if err != nil {
+			return nil, [32]byte{}, fmt.Errorf("error accounting blob: %w", err)
+		}
+		reply, err = c.sendBlob(ctx, data, blobVersion, blobCommitments, quorums, payment, uint32(symbolLength))
+	}
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w", err)
 	}
 
-	// check every 32 bytes of data are within the valid range for a bn254 field element
-	_, err = rs.ToFrArray(data)
+	blobStatus, err := dispv2.BlobStatusFromProtobuf(reply.GetResult())
 	if err != nil {
-		return nil, [32]byte{}, fmt.Errorf("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617 %w", err)
+		return nil, [32]byte{}, err
 	}
 
-	var blobCommitments encoding.BlobCommitments
-	if c.prover == nil {
-		// if prover is not configured, get blob commitments from disperser
-		commitments, err := c.GetBlobCommitment(ctx, data)
-		if err != nil {
-			return nil, [32]byte{}, fmt.Errorf("error getting blob commitments: %w", err)
+	return &blobStatus, corev2.BlobKey(reply.GetBlobKey()), nil
+}
+
+// sendBlob signs a blob header with the given payment and sends the blob to the disperser. The payment, accounted
+// for a blob of numSymbols symbols, is rolled back if the request cannot be built, since it is never sent.
+func (c *disperserClient) sendBlob(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	blobCommitments encoding.BlobCommitments,
+	quorums []core.QuorumID,
+	payment *core.PaymentMetadata,
+	numSymbols uint32,
+) (*disperser_rpc.DisperseBlobReply, error) {
+	request, err := newDisperseBlobRequest(c.signer, data, blobVersion, blobCommitments, quorums, payment, c.config.RetentionTier)
+	if err != nil {
+		if rollbackErr := c.accountant.RollbackBlob(payment, numSymbols); rollbackErr != nil {
+			return nil, fmt.Errorf("%w (rolling back payment: %v)", err, rollbackErr)
 		}
-		deserialized, err := encoding.BlobCommitmentsFromProtobuf(commitments.GetBlobCommitment())
+		return nil, err
+	}
+	return c.client.DisperseBlob(ctx, request)
+}
+
+// getBlobCommitments computes the commitments of a blob with the prover if one is configured, and otherwise asks
+// the disperser for them.
+func (c *disperserClient) getBlobCommitments(ctx context.Context, data []byte) (encoding.BlobCommitments, error) {
+	if c.prover != nil {
+		blobCommitments, err := c.prover.GetCommitmentsForPaddedLength(data)
 		if err != nil {
-			return nil, [32]byte{}, fmt.Errorf("error deserializing blob commitments: %w", err)
+			return encoding.BlobCommitments{}, fmt.Errorf("error getting blob commitments: %w", err)
 		}
-		blobCommitments = *deserialized
-	} else {
-		// if prover is configured, get commitments from prover
+		return blobCommitments, nil
+	}
 
-		blobCommitments, err = c.prover.GetCommitmentsForPaddedLength(data)
-		if err != nil {
-			return nil, [32]byte{}, fmt.Errorf("error getting blob commitments: %w", err)
+	commitments, err := c.GetBlobCommitment(ctx, data)
+	if err != nil {
+		return encoding.BlobCommitments{}, fmt.Errorf("error getting blob commitments: %w", err)
+	}
+	return deserializeBlobCommitments(commitments)
+}
+
+// deserializeBlobCommitments converts the blob commitments computed by a disperser.
+func deserializeBlobCommitments(reply *disperser_rpc.BlobCommitmentReply) (encoding.BlobCommitments, error) {
+	deserialized, err := encoding.BlobCommitmentsFromProtobuf(reply.GetBlobCommitment())
+	if err != nil {
+		return encoding.BlobCommitments{}, fmt.Errorf("error deserializing blob commitments: %w", err)
+	}
+	return *deserialized, nil
+}
+
+// validateBlob checks the quorums of a dispersal, and that its data is a valid sequence of field elements.
+func validateBlob(data []byte, quorums []core.QuorumID) error {
+	if len(quorums) == 0 {
+		return api.NewErrorInvalidArg("quorum numbers must be provided")
+	}
+
+	for _, q := range quorums {
+		if q > corev2.MaxQuorumID {
+			return api.NewErrorInvalidArg("quorum number must be less than 256")
 		}
 	}
 
+	// check every 32 bytes of data are within the valid range for a bn254 field element
+	_, err := rs.ToFrArray(data)
+	if err != nil {
+		return fmt.Errorf("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617 %w", err)
+	}
+	return nil
+}
+
+// newDisperseBlobRequest signs a blob header with the given payment, and builds the request that disperses it.
+// The blob key is derived from the signed header, so every disperser that accepts the request returns the same key.
+func newDisperseBlobRequest(
+	signer corev2.BlobRequestSigner,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	blobCommitments encoding.BlobCommitments,
+	quorums []core.QuorumID,
+	payment *core.PaymentMetadata,
+	retentionTier corev2.RetentionTier,
+) (*disperser_rpc.DisperseBlobRequest, error) {
 	blobHeader := &corev2.BlobHeader{
 		BlobVersion:     blobVersion,
 		BlobCommitments: blobCommitments,
 		QuorumNumbers:   quorums,
 		PaymentMetadata: *payment,
+		RetentionTier:   retentionTier,
 	}
 
-	sig, err := c.signer.SignBlobRequest(blobHeader)
+	sig, err := signer.SignBlobRequest(blobHeader)
 	if err != nil {
-		return nil, [32]byte{}, fmt.Errorf("error signing blob request: %w", err)
+		return nil, fmt.Errorf("error signing blob request: %w", err)
 	}
 	blobHeader.Signature = sig
 	blobHeaderProto, err := blobHeader.ToProtobuf()
 	if err != nil {
-		return nil, [32]byte{}, fmt.Errorf("error converting blob header to protobuf: %w", err)
+		return nil, fmt.Errorf("error converting blob header to protobuf: %w", err)
 	}
-	request := &disperser_rpc.DisperseBlobRequest{
+	return &disperser_rpc.DisperseBlobRequest{
 		Data:       data,
 		BlobHeader: blobHeaderProto,
-	}
-
-	reply, err := c.client.DisperseBlob(ctx, request)
-	if err != nil {
-		return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w", err)
-	}
-
-	blobStatus, err := dispv2.BlobStatusFromProtobuf(reply.GetResult())
-	if err != nil {
-		return nil, [32]byte{}, err
-	}
-
-	return &blobStatus, corev2.BlobKey(reply.GetBlobKey()), nil
+	}, nil
 }
 
 // GetBlobStatus returns the status of a blob with the given blob key.
@@ -240,7 +335,14 @@ func (c *disperserClient) GetPaymentState(ctx context.Context) (*disperser_rpc.G
 		return nil, fmt.Errorf("error getting signer's account ID: %w", err)
 	}
 
-	signature, err := c.signer.SignPaymentStateRequest()
+	// The timestamp and nonce make the signature unique, so that it cannot be replayed
+	timestamp := uint64(time.Now().UnixNano())
+	nonce := make([]byte, paymentStateNonceLength)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	signature, err := c.signer.SignPaymentStateRequest(timestamp, nonce)
 	if err != nil {
 		return nil, fmt.Errorf("error signing payment state request: %w", err)
 	}
@@ -248,6 +350,8 @@ func (c *disperserClient) GetPaymentState(ctx context.Context) (*disperser_rpc.G
 	request := &disperser_rpc.GetPaymentStateRequest{
 		AccountId: accountID,
 		Signature: signature,
+		Timestamp: timestamp,
+		Nonce:     nonce,
 	}
 	return c.client.GetPaymentState(ctx, request)
 }
@@ -268,6 +372,23 @@ func (c *disperserClient) GetBlobCommitment(ctx context.Context, data []byte) (*
 	return c.client.GetBlobCommitment(ctx, request)
 }
 
+// GetBlobSymbolProofs returns kzg opening proofs for the symbols at the given indices of a dispersed blob.
+// If batched is true, a single multi-point proof is returned for all symbols. The proofs can be checked
+// with verification.VerifyBlobSymbolProofs.
+func (c *disperserClient) GetBlobSymbolProofs(ctx context.Context, blobKey corev2.BlobKey, symbolIndices []uint64, batched bool) (*disperser_rpc.BlobSymbolProofsReply, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorInternal(err.Error())
+	}
+
+	request := &disperser_rpc.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: symbolIndices,
+		Batched:       batched,
+	}
+	return c.client.GetBlobSymbolProofs(ctx, request)
+}
+
 // initOnceGrpcConnection initializes the grpc connection and client if they are not already initialized.
 // If initialization fails, it caches the error and will return it on every subsequent call.
 func (c *disperserClient) initOnceGrpcConnection() error {
@@ -0,0 +1,235 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeDisperserRPC rejects the first dispersal as the meterer would, unless another rejection is set, and serves a
+// fixed payment state.
+type fakeDisperserRPC struct {
+	disperser_rpc.DisperserClient
+
+	paymentState *disperser_rpc.GetPaymentStateReply
+	rejection    error
+	requests     []*disperser_rpc.DisperseBlobRequest
+}
+
+func (f *fakeDisperserRPC) DisperseBlob(
+	ctx context.Context,
+	in *disperser_rpc.DisperseBlobRequest,
+	opts ...grpc.CallOption) (*disperser_rpc.DisperseBlobReply, error) {
+
+	f.requests = append(f.requests, in)
+	if len(f.requests) == 1 {
+		if f.rejection != nil {
+			return nil, f.rejection
+		}
+		return nil, api.NewErrorPaymentRejected("insufficient cumulative payment increment")
+	}
+	return &disperser_rpc.DisperseBlobReply{Result: disperser_rpc.BlobStatus_QUEUED, BlobKey: make([]byte, 32)}, nil
+}
+
+func (f *fakeDisperserRPC) GetPaymentState(
+	ctx context.Context,
+	in *disperser_rpc.GetPaymentStateRequest,
+	opts ...grpc.CallOption) (*disperser_rpc.GetPaymentStateReply, error) {
+
+	return f.paymentState, nil
+}
+
+func TestDisperseBlobResyncsAfterPaymentRejection(t *testing.T) {
+	signer := authv2.NewLocalBlobRequestSigner("0x000000000000000000000000000000000000000000000000000000000000000a")
+	accountID, err := signer.GetAccountID()
+	require.NoError(t, err)
+
+	// a fresh accountant without a reservation, e.g. after a restart without a store
+	accountant := NewAccountant(accountID, nil, nil, 0, 0, 0, 0)
+	rpc := &fakeDisperserRPC{
+		paymentState: &disperser_rpc.GetPaymentStateReply{
+			PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+				MinNumSymbols:     1,
+				PricePerSymbol:    1,
+				ReservationWindow: 1,
+			},
+			CumulativePayment:        big.NewInt(1000).Bytes(),
+			OnchainCumulativePayment: big.NewInt(10000).Bytes(),
+		},
+	}
+	require.NoError(t, accountant.SetPaymentState(&disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams:      rpc.paymentState.PaymentGlobalParams,
+		OnchainCumulativePayment: rpc.paymentState.OnchainCumulativePayment,
+	}))
+
+	prover := &encmock.MockEncoder{}
+	prover.On("GetCommitmentsForPaddedLength", mock.Anything).Return(encoding.BlobCommitments{
+		Commitment:       &encoding.G1Commitment{},
+		LengthCommitment: &encoding.G2Commitment{},
+		LengthProof:      &encoding.LengthProof{},
+		Length:           1,
+	}, nil)
+
+	client, err := NewDisperserClient(&DisperserClientConfig{Hostname: "localhost", Port: "1"}, signer, prover, accountant)
+	require.NoError(t, err)
+	client.initOnceGrpc.Do(func() {})
+	client.client = rpc
+
+	_, _, err = client.DisperseBlob(context.Background(), make([]byte, 32), 0, []uint8{0, 1}, 0)
+	require.NoError(t, err)
+
+	// the first request paid on top of a stale cumulative payment, the retry on top of the disperser's
+	require.Len(t, rpc.requests, 2)
+	first := new(big.Int).SetBytes(rpc.requests[0].GetBlobHeader().GetPaymentHeader().GetCumulativePayment())
+	retry := new(big.Int).SetBytes(rpc.requests[1].GetBlobHeader().GetPaymentHeader().GetCumulativePayment())
+	assert.Equal(t, big.NewInt(1), first)
+	assert.Equal(t, big.NewInt(1001), retry)
+}
+
+func TestDisperseBlobRetentionTier(t *testing.T) {
+	signer := authv2.NewLocalBlobRequestSigner("0x000000000000000000000000000000000000000000000000000000000000000a")
+	accountID, err := signer.GetAccountID()
+	require.NoError(t, err)
+
+	accountant := NewAccountant(accountID, nil, nil, 0, 0, 0, 0)
+	rpc := &fakeDisperserRPC{
+		paymentState: &disperser_rpc.GetPaymentStateReply{
+			PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+				MinNumSymbols:     1,
+				PricePerSymbol:    1,
+				ReservationWindow: 1,
+			},
+			OnchainCumulativePayment: big.NewInt(10000).Bytes(),
+		},
+	}
+	require.NoError(t, accountant.SetPaymentState(rpc.paymentState))
+
+	prover := &encmock.MockEncoder{}
+	prover.On("GetCommitmentsForPaddedLength", mock.Anything).Return(encoding.BlobCommitments{
+		Commitment:       &encoding.G1Commitment{},
+		LengthCommitment: &encoding.G2Commitment{},
+		LengthProof:      &encoding.LengthProof{},
+		Length:           1,
+	}, nil)
+
+	_, err = NewDisperserClient(&DisperserClientConfig{
+		Hostname:      "localhost",
+		Port:          "1",
+		RetentionTier: corev2.MaxRetentionTier + 1,
+	}, signer, prover, accountant)
+	require.Error(t, err)
+
+	client, err := NewDisperserClient(&DisperserClientConfig{
+		Hostname:      "localhost",
+		Port:          "1",
+		RetentionTier: corev2.RetentionTierExtended,
+	}, signer, prover, accountant)
+	require.NoError(t, err)
+	client.initOnceGrpc.Do(func() {})
+	client.client = rpc
+
+	_, _, err = client.DisperseBlob(context.Background(), make([]byte, 32), 0, []uint8{0, 1}, 0)
+	require.NoError(t, err)
+
+	// a single symbol blob of the extended tier is billed as two symbols, and the rejected charge is rolled back
+	// before the retry rather than counted twice
+	require.Len(t, rpc.requests, 2)
+	for _, request := range rpc.requests {
+		assert.Equal(t, uint32(corev2.RetentionTierExtended), request.GetBlobHeader().GetRetentionTier())
+		payment := new(big.Int).SetBytes(request.GetBlobHeader().GetPaymentHeader().GetCumulativePayment())
+		assert.Equal(t, big.NewInt(2), payment)
+	}
+}
+
+func TestDisperseBlobDoesNotRetryRateLimits(t *testing.T) {
+	signer := authv2.NewLocalBlobRequestSigner("0x000000000000000000000000000000000000000000000000000000000000000a")
+	accountID, err := signer.GetAccountID()
+	require.NoError(t, err)
+
+	accountant := NewAccountant(accountID, nil, nil, 0, 0, 0, 0)
+	rpc := &fakeDisperserRPC{
+		paymentState: &disperser_rpc.GetPaymentStateReply{
+			PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+				MinNumSymbols:     1,
+				PricePerSymbol:    1,
+				ReservationWindow: 1,
+			},
+			OnchainCumulativePayment: big.NewInt(10000).Bytes(),
+		},
+		rejection: api.NewErrorResourceExhausted("too many recent requests"),
+	}
+	require.NoError(t, accountant.SetPaymentState(rpc.paymentState))
+
+	prover := &encmock.MockEncoder{}
+	prover.On("GetCommitmentsForPaddedLength", mock.Anything).Return(encoding.BlobCommitments{
+		Commitment:       &encoding.G1Commitment{},
+		LengthCommitment: &encoding.G2Commitment{},
+		LengthProof:      &encoding.LengthProof{},
+		Length:           1,
+	}, nil)
+
+	client, err := NewDisperserClient(&DisperserClientConfig{Hostname: "localhost", Port: "1"}, signer, prover, accountant)
+	require.NoError(t, err)
+	client.initOnceGrpc.Do(func() {})
+	client.client = rpc
+
+	_, _, err = client.DisperseBlob(context.Background(), make([]byte, 32), 0, []uint8{0, 1}, 0)
+	require.Error(t, err)
+	require.False(t, api.IsPaymentRejected(err))
+	require.Len(t, rpc.requests, 1)
+}
+
+func TestDisperseBlobDoesNotChargeInvalidBlobs(t *testing.T) {
+	signer := authv2.NewLocalBlobRequestSigner("0x000000000000000000000000000000000000000000000000000000000000000a")
+	accountID, err := signer.GetAccountID()
+	require.NoError(t, err)
+
+	accountant := NewAccountant(accountID, nil, nil, 0, 0, 0, 0)
+	rpc := &fakeDisperserRPC{
+		paymentState: &disperser_rpc.GetPaymentStateReply{
+			PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+				MinNumSymbols:     1,
+				PricePerSymbol:    1,
+				ReservationWindow: 1,
+			},
+			OnchainCumulativePayment: big.NewInt(10000).Bytes(),
+		},
+	}
+	require.NoError(t, accountant.SetPaymentState(rpc.paymentState))
+
+	prover := &encmock.MockEncoder{}
+	prover.On("GetCommitmentsForPaddedLength", mock.Anything).Return(
+		encoding.BlobCommitments{}, errors.New("commitment failed")).Once()
+
+	client, err := NewDisperserClient(&DisperserClientConfig{Hostname: "localhost", Port: "1"}, signer, prover, accountant)
+	require.NoError(t, err)
+	client.initOnceGrpc.Do(func() {})
+	client.client = rpc
+
+	// the data is not a valid sequence of field elements
+	invalid := make([]byte, 32)
+	for i := range invalid {
+		invalid[i] = 0xff
+	}
+	_, _, err = client.DisperseBlob(context.Background(), invalid, 0, []uint8{0, 1}, 0)
+	require.Error(t, err)
+
+	// the commitments cannot be computed
+	_, _, err = client.DisperseBlob(context.Background(), make([]byte, 32), 0, []uint8{0, 1}, 0)
+	require.Error(t, err)
+
+	require.Empty(t, rpc.requests)
+	assert.Equal(t, big.NewInt(0), accountant.cumulativePayment)
+}
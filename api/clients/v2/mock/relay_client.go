@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/stretchr/testify/mock"
 )
@@ -39,6 +40,14 @@ func (c *MockRelayClient) GetChunksByIndex(ctx context.Context, relayKey corev2.
 	return args.Get(0).([][]byte), args.Error(1)
 }
 
+func (c *MockRelayClient) GetBlobSymbolProofs(ctx context.Context, relayKey corev2.RelayKey, blobKey corev2.BlobKey, symbolIndices []uint64, batched bool) (*disperser_rpc.BlobSymbolProofsReply, error) {
+	args := c.Called(relayKey, blobKey, symbolIndices, batched)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*disperser_rpc.BlobSymbolProofsReply), args.Error(1)
+}
+
 func (c *MockRelayClient) GetSockets() map[corev2.RelayKey]string {
 	args := c.Called()
 	if args.Get(0) == nil {
@@ -0,0 +1,614 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultUnhealthyThreshold is the default number of consecutive failures after which a disperser is unhealthy.
+	defaultUnhealthyThreshold = 3
+	// defaultUnhealthyBackoff is the default time for which an unhealthy disperser is only used as a last resort.
+	defaultUnhealthyBackoff = 30 * time.Second
+	// latencyEWMAWeight is the weight of the latest request in the moving average of the latency of a disperser.
+	latencyEWMAWeight = 0.2
+	// maxTrackedBlobOwners is the maximum number of blobs whose disperser is remembered. When exceeded, the least
+	// recently used blob is forgotten, and its status and proofs are requested from every disperser.
+	maxTrackedBlobOwners = 65536
+)
+
+type MultiDisperserClientConfig struct {
+	// Dispersers are the endpoints blobs are dispersed to. Their AccountantStore is ignored.
+	Dispersers []*DisperserClientConfig
+	// HedgeDelay is how long to wait for the preferred disperser to answer a read, such as GetBlobCommitment, before
+	// sending the same read to the next disperser as well. Hedging is disabled if zero.
+	HedgeDelay time.Duration
+	// HedgeDispersals also hedges DisperseBlob requests after HedgeDelay. The hedged request is the same signed
+	// request, with the same blob key and payment, so it is charged once by the accountant, and a disperser that
+	// reports the blob already exists is taken to have accepted it. Every disperser that accepts the request meters
+	// its payment though, so dispersals are not hedged by default.
+	HedgeDispersals bool
+	// UnhealthyThreshold is the number of consecutive failures after which a disperser is considered unhealthy.
+	// Defaults to 3.
+	UnhealthyThreshold int
+	// UnhealthyBackoff is how long an unhealthy disperser is only used after every healthy disperser has been tried.
+	// Defaults to 30s.
+	UnhealthyBackoff time.Duration
+	// AccountantStore optionally persists the accounting of the accountant created by the client, so that it
+	// survives restarts. It is not used if the client is given an accountant.
+	AccountantStore AccountantStore
+	// RetentionTier is the retention tier of the dispersed blobs. The RetentionTier of the Dispersers is ignored.
+	// Defaults to the standard tier.
+	RetentionTier corev2.RetentionTier
+}
+
+// disperserEndpoint tracks the health and latency of one of the dispersers of a multiDisperserClient.
+type disperserEndpoint struct {
+	address string
+	client  *disperserClient
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	unhealthyUntil      time.Time
+	// latency is the moving average of the latency of the successful requests, or zero before the first one.
+	latency time.Duration
+}
+
+type multiDisperserClient struct {
+	config             *MultiDisperserClientConfig
+	signer             corev2.BlobRequestSigner
+	prover             encoding.Prover
+	accountant         *Accountant
+	initOnceAccountant sync.Once
+	endpoints          []*disperserEndpoint
+
+	// blobOwners maps the key of each dispersed blob to the disperser that accepted it.
+	blobOwners *lru.Cache[corev2.BlobKey, *disperserEndpoint]
+}
+
+var _ DisperserClient = &multiDisperserClient{}
+
+// NewMultiDisperserClient creates a DisperserClient that disperses through several dispersers, e.g. a self-hosted
+// disperser next to the public one.
+//
+// Requests go to the healthiest disperser first: dispersers that failed repeatedly are only tried after the others,
+// and healthy dispersers are ordered by their observed latency. A DisperseBlob request is sent to the next disperser
+// when the previous one could not be reached, and also when the previous one is slow to answer if HedgeDispersals is
+// set. If HedgeDelay is set, reads are also sent to the next disperser when the preferred one is slow to answer.
+//
+// Every blob is accounted exactly once by the single accountant of the client, which tracks the largest usage
+// reported by any of the dispersers. The status and proofs of a blob are requested from the disperser that accepted
+// it.
+func NewMultiDisperserClient(config *MultiDisperserClientConfig, signer corev2.BlobRequestSigner, prover encoding.Prover, accountant *Accountant) (*multiDisperserClient, error) {
+	if config == nil {
+		return nil, api.NewErrorInvalidArg("config must be provided")
+	}
+	if len(config.Dispersers) == 0 {
+		return nil, api.NewErrorInvalidArg("at least one disperser must be provided")
+	}
+	if config.HedgeDelay < 0 {
+		return nil, api.NewErrorInvalidArg("hedge delay must not be negative")
+	}
+	if err := config.RetentionTier.Validate(); err != nil {
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
+	if config.UnhealthyThreshold == 0 {
+		config.UnhealthyThreshold = defaultUnhealthyThreshold
+	}
+	if config.UnhealthyBackoff == 0 {
+		config.UnhealthyBackoff = defaultUnhealthyBackoff
+	}
+
+	endpoints := make([]*disperserEndpoint, 0, len(config.Dispersers))
+	for _, disperserConfig := range config.Dispersers {
+		// The disperser clients only carry requests, so they need neither a prover nor an accountant.
+		client, err := NewDisperserClient(disperserConfig, signer, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, &disperserEndpoint{
+			address: fmt.Sprintf("%v:%v", disperserConfig.Hostname, disperserConfig.Port),
+			client:  client,
+		})
+	}
+
+	blobOwners, err := lru.New[corev2.BlobKey, *disperserEndpoint](maxTrackedBlobOwners)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob owner cache: %w", err)
+	}
+
+	return &multiDisperserClient{
+		config:     config,
+		signer:     signer,
+		prover:     prover,
+		accountant: accountant,
+		endpoints:  endpoints,
+		blobOwners: blobOwners,
+	}, nil
+}
+
+// PopulateAccountant populates the accountant with the payment state of every disperser that returns it, so that the
+// payments of the accountant are acceptable to each of them.
+func (c *multiDisperserClient) PopulateAccountant(ctx context.Context) error {
+	if c.accountant == nil {
+		accountId, err := c.signer.GetAccountID()
+		if err != nil {
+			return fmt.Errorf("error getting account ID: %w", err)
+		}
+		accountant := NewAccountant(accountId, nil, nil, 0, 0, 0, 0)
+		if c.config.AccountantStore != nil {
+			if err = accountant.SetStore(c.config.AccountantStore); err != nil {
+				return fmt.Errorf("error loading accountant state: %w", err)
+			}
+		}
+		c.accountant = accountant
+	}
+
+	var errs []error
+	synced := false
+	for _, endpoint := range c.endpoints {
+		err := c.syncPaymentState(ctx, endpoint)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		synced = true
+	}
+	if !synced {
+		return fmt.Errorf("error getting payment state for initializing accountant: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// syncPaymentState merges the payment state of a disperser into the accountant.
+func (c *multiDisperserClient) syncPaymentState(ctx context.Context, endpoint *disperserEndpoint) error {
+	paymentState, err := endpoint.client.GetPaymentState(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", endpoint.address, err)
+	}
+	if err = c.accountant.SyncPaymentState(paymentState); err != nil {
+		return fmt.Errorf("error setting payment state for accountant: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connections to every disperser.
+func (c *multiDisperserClient) Close() error {
+	var errs []error
+	for _, endpoint := range c.endpoints {
+		if err := endpoint.client.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint.address, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *multiDisperserClient) DisperseBlob(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	quorums []core.QuorumID,
+	salt uint32,
+) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+	err := c.initOncePopulateAccountant(ctx)
+	if err != nil {
+		return nil, [32]byte{}, api.NewErrorFailover(err)
+	}
+
+	err = validateBlob(data, quorums)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	blobCommitments, err := c.getBlobCommitments(ctx, data)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	symbolLength := c.config.RetentionTier.BilledSymbols(encoding.GetBlobLengthPowerOf2(uint(len(data))))
+	payment, err := c.accountant.AccountBlob(ctx, uint32(symbolLength), quorums, salt)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("error accounting blob: %w", err)
+	}
+	request, err := newDisperseBlobRequest(c.signer, data, blobVersion, blobCommitments, quorums, payment, c.config.RetentionTier)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	reply, endpoint, err := c.disperse(ctx, request)
+	if api.IsPaymentRejected(err) {
+		// The disperser that rejected the payment has seen usage the accountant does not know about. Undo the charge,
+		// reconcile the accountant with the payment state of that disperser, and retry once.
+		if rollbackErr := c.accountant.RollbackBlob(payment, uint32(symbolLength)); rollbackErr != nil {
+			return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w (rolling back payment: %v)", err, rollbackErr)
+		}
+		if syncErr := c.syncPaymentState(ctx, endpoint); syncErr != nil {
+			return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w (resyncing payment state: %v)", err, syncErr)
+		}
+		payment, err = c.accountant.AccountBlob(ctx, uint32(symbolLength), quorums, salt)
+		if err != nil {
+			return nil, [32]byte{}, fmt.Errorf("error accounting blob: %w", err)
+		}
+		request, err = newDisperseBlobRequest(c.signer, data, blobVersion, blobCommitments, quorums, payment, c.config.RetentionTier)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+		reply, endpoint, err = c.disperse(ctx, request)
+	}
+	if err != nil && endpoint == nil {
+		// No disperser could be reached, so none of them metered the payment.
+		if rollbackErr := c.accountant.RollbackBlob(payment, uint32(symbolLength)); rollbackErr != nil {
+			return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w (rolling back payment: %v)", err, rollbackErr)
+		}
+	}
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w", err)
+	}
+
+	blobStatus, err := dispv2.BlobStatusFromProtobuf(reply.GetResult())
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	blobKey := corev2.BlobKey(reply.GetBlobKey())
+	c.blobOwners.Add(blobKey, endpoint)
+	return &blobStatus, blobKey, nil
+}
+
+// dispersalResult is the answer of one disperser to a DisperseBlob request.
+type dispersalResult struct {
+	endpoint *disperserEndpoint
+	reply    *disperser_rpc.DisperseBlobReply
+	err      error
+}
+
+// disperse sends a DisperseBlob request to the dispersers in order of preference. The request moves on to the next
+// disperser when the previous one could not be reached, or, if HedgeDispersals is set, when the hedge delay expires
+// without an answer. Any other failure, including a timeout, may come from a disperser that already metered the
+// payment, so no further disperser is tried, and the failure is returned along with the disperser that answered
+// unless a request still in flight succeeds. The disperser is nil if no disperser could be reached.
+func (c *multiDisperserClient) disperse(
+	ctx context.Context,
+	request *disperser_rpc.DisperseBlobRequest,
+) (*disperser_rpc.DisperseBlobReply, *disperserEndpoint, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	endpoints := c.orderedEndpoints()
+	results := make(chan *dispersalResult, len(endpoints))
+	next := 0
+	inFlight := 0
+	send := func() {
+		endpoint := endpoints[next]
+		next++
+		inFlight++
+		go func() {
+			start := time.Now()
+			reply, err := endpoint.disperseBlob(ctx, request)
+			if ctx.Err() == nil {
+				// Requests abandoned by the caller, or once another disperser answered, say nothing about the
+				// health of the disperser.
+				endpoint.record(time.Since(start), err, c.config.UnhealthyThreshold, c.config.UnhealthyBackoff)
+			}
+			results <- &dispersalResult{endpoint: endpoint, reply: reply, err: err}
+		}()
+	}
+
+	send()
+	var hedge <-chan time.Time
+	if c.config.HedgeDispersals && c.config.HedgeDelay > 0 && len(endpoints) > 1 {
+		timer := time.NewTimer(c.config.HedgeDelay)
+		defer timer.Stop()
+		hedge = timer.C
+	}
+
+	var errs []error
+	var failure *dispersalResult
+	for inFlight > 0 {
+		select {
+		case <-hedge:
+			hedge = nil
+			if next < len(endpoints) && failure == nil {
+				send()
+			}
+		case result := <-results:
+			inFlight--
+			if result.err == nil {
+				return result.reply, result.endpoint, nil
+			}
+			if next > 1 && status.Code(result.err) == codes.AlreadyExists {
+				// The request was sent to several dispersers, and the blob key is derived from the signed request,
+				// so the blob was dispersed by this request through one of them.
+				reply, err := alreadyDispersedReply(request)
+				if err != nil {
+					return nil, result.endpoint, err
+				}
+				return reply, result.endpoint, nil
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", result.endpoint.address, result.err))
+			if !isUnreachable(result.err) || ctx.Err() != nil {
+				if failure == nil {
+					failure = result
+				}
+				continue
+			}
+			if next < len(endpoints) && failure == nil {
+				send()
+			}
+		}
+	}
+	if failure != nil {
+		return nil, failure.endpoint, fmt.Errorf("%s: %w", failure.endpoint.address, failure.err)
+	}
+	return nil, nil, errors.Join(errs...)
+}
+
+// alreadyDispersedReply builds the reply to a DisperseBlob request whose blob a disperser already has. The blob is
+// reported as queued, its actual status is polled from the disperser like that of any other dispersed blob.
+func alreadyDispersedReply(request *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(request.GetBlobHeader())
+	if err != nil {
+		return nil, fmt.Errorf("error converting blob header: %w", err)
+	}
+	blobKey, err := blobHeader.BlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("error computing blob key: %w", err)
+	}
+	return &disperser_rpc.DisperseBlobReply{Result: disperser_rpc.BlobStatus_QUEUED, BlobKey: blobKey[:]}, nil
+}
+
+// GetBlobStatus returns the status of a blob from the disperser that accepted it. The status of a blob that was not
+// dispersed by this client is requested from each disperser in order of preference.
+func (c *multiDisperserClient) GetBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*disperser_rpc.BlobStatusReply, error) {
+	if owner, ok := c.blobOwners.Get(blobKey); ok {
+		reply, err := owner.client.GetBlobStatus(ctx, blobKey)
+		if err != nil {
+			return nil, err
+		}
+		if isTerminalBlobStatus(reply.GetStatus()) {
+			// The status of the blob no longer changes, so the client no longer needs to remember its disperser.
+			c.blobOwners.Remove(blobKey)
+		}
+		return reply, nil
+	}
+
+	return hedgedRead(ctx, c, func(ctx context.Context, client *disperserClient) (*disperser_rpc.BlobStatusReply, error) {
+		return client.GetBlobStatus(ctx, blobKey)
+	})
+}
+
+// GetBlobCommitment returns the commitments of a blob computed by the preferred disperser that answers.
+func (c *multiDisperserClient) GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error) {
+	return hedgedRead(ctx, c, func(ctx context.Context, client *disperserClient) (*disperser_rpc.BlobCommitmentReply, error) {
+		return client.GetBlobCommitment(ctx, data)
+	})
+}
+
+// GetBlobSymbolProofs returns kzg opening proofs for the symbols of a blob from the disperser that accepted it, or
+// from each disperser in order of preference if the blob was not dispersed by this client.
+func (c *multiDisperserClient) GetBlobSymbolProofs(ctx context.Context, blobKey corev2.BlobKey, symbolIndices []uint64, batched bool) (*disperser_rpc.BlobSymbolProofsReply, error) {
+	if owner, ok := c.blobOwners.Get(blobKey); ok {
+		return owner.client.GetBlobSymbolProofs(ctx, blobKey, symbolIndices, batched)
+	}
+
+	return hedgedRead(ctx, c, func(ctx context.Context, client *disperserClient) (*disperser_rpc.BlobSymbolProofsReply, error) {
+		return client.GetBlobSymbolProofs(ctx, blobKey, symbolIndices, batched)
+	})
+}
+
+// readResult is the answer of one disperser to a read.
+type readResult[T any] struct {
+	address string
+	reply   T
+	err     error
+}
+
+// hedgedRead sends an idempotent read to the dispersers in order of preference, until one answers. The read is sent
+// to the next disperser when the previous one fails, or when the hedge delay expires without an answer. The reads
+// still in flight are cancelled once a disperser answers. A bad request is not sent to the other dispersers.
+func hedgedRead[T any](
+	ctx context.Context,
+	c *multiDisperserClient,
+	read func(ctx context.Context, client *disperserClient) (T, error),
+) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	endpoints := c.orderedEndpoints()
+	results := make(chan *readResult[T], len(endpoints))
+	next := 0
+	inFlight := 0
+	send := func() {
+		endpoint := endpoints[next]
+		next++
+		inFlight++
+		go func() {
+			reply, err := read(ctx, endpoint.client)
+			results <- &readResult[T]{address: endpoint.address, reply: reply, err: err}
+		}()
+	}
+
+	send()
+	var hedge <-chan time.Time
+	if c.config.HedgeDelay > 0 && len(endpoints) > 1 {
+		timer := time.NewTimer(c.config.HedgeDelay)
+		defer timer.Stop()
+		hedge = timer.C
+	}
+
+	var errs []error
+	for inFlight > 0 {
+		select {
+		case <-hedge:
+			hedge = nil
+			if next < len(endpoints) {
+				send()
+			}
+		case result := <-results:
+			inFlight--
+			if result.err == nil {
+				return result.reply, nil
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", result.address, result.err))
+			if status.Code(result.err) == codes.InvalidArgument {
+				// Every disperser would reject a malformed request.
+				var zero T
+				return zero, result.err
+			}
+			if next < len(endpoints) && ctx.Err() == nil {
+				send()
+			}
+		}
+	}
+	var zero T
+	return zero, errors.Join(errs...)
+}
+
+// DisperserAddress returns the address of the disperser that accepted a blob dispersed by this client. It returns
+// false if the blob was not dispersed by this client, if its status was already reported as final, or if too many
+// blobs were dispersed since.
+func (c *multiDisperserClient) DisperserAddress(blobKey corev2.BlobKey) (string, bool) {
+	owner, ok := c.blobOwners.Peek(blobKey)
+	if !ok {
+		return "", false
+	}
+	return owner.address, true
+}
+
+// getBlobCommitments computes the commitments of a blob with the prover if one is configured, and otherwise asks
+// the dispersers for them.
+func (c *multiDisperserClient) getBlobCommitments(ctx context.Context, data []byte) (encoding.BlobCommitments, error) {
+	if c.prover != nil {
+		blobCommitments, err := c.prover.GetCommitmentsForPaddedLength(data)
+		if err != nil {
+			return encoding.BlobCommitments{}, fmt.Errorf("error getting blob commitments: %w", err)
+		}
+		return blobCommitments, nil
+	}
+
+	commitments, err := c.GetBlobCommitment(ctx, data)
+	if err != nil {
+		return encoding.BlobCommitments{}, fmt.Errorf("error getting blob commitments: %w", err)
+	}
+	return deserializeBlobCommitments(commitments)
+}
+
+// orderedEndpoints returns the dispersers in order of preference: healthy dispersers before unhealthy ones, and
+// faster dispersers first. Dispersers without a latency measurement are tried first, so that every disperser gets
+// measured, and ties keep the configured order.
+func (c *multiDisperserClient) orderedEndpoints() []*disperserEndpoint {
+	now := time.Now()
+	type ranking struct {
+		endpoint  *disperserEndpoint
+		unhealthy bool
+		latency   time.Duration
+	}
+	rankings := make([]ranking, len(c.endpoints))
+	for i, endpoint := range c.endpoints {
+		endpoint.mu.Lock()
+		rankings[i] = ranking{
+			endpoint:  endpoint,
+			unhealthy: now.Before(endpoint.unhealthyUntil),
+			latency:   endpoint.latency,
+		}
+		endpoint.mu.Unlock()
+	}
+	sort.SliceStable(rankings, func(i, j int) bool {
+		if rankings[i].unhealthy != rankings[j].unhealthy {
+			return !rankings[i].unhealthy
+		}
+		return rankings[i].latency < rankings[j].latency
+	})
+
+	endpoints := make([]*disperserEndpoint, len(rankings))
+	for i, r := range rankings {
+		endpoints[i] = r.endpoint
+	}
+	return endpoints
+}
+
+// initOncePopulateAccountant initializes the accountant if it is not already initialized.
+// If initialization fails, it caches the error and will return it on every subsequent call.
+func (c *multiDisperserClient) initOncePopulateAccountant(ctx context.Context) error {
+	var initErr error
+	c.initOnceAccountant.Do(func() {
+		if c.accountant == nil {
+			err := c.PopulateAccountant(ctx)
+			if err != nil {
+				initErr = err
+				return
+			}
+		}
+	})
+	if initErr != nil {
+		return fmt.Errorf("populating accountant: %w", initErr)
+	}
+	return nil
+}
+
+// disperseBlob sends a signed DisperseBlob request to the disperser.
+func (e *disperserEndpoint) disperseBlob(
+	ctx context.Context,
+	request *disperser_rpc.DisperseBlobRequest,
+) (*disperser_rpc.DisperseBlobReply, error) {
+	err := e.client.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorFailover(err)
+	}
+	return e.client.client.DisperseBlob(ctx, request)
+}
+
+// record updates the health and latency of the disperser with the outcome of a request. Only errors that point at
+// the disperser itself, rather than at the request, count as failures.
+func (e *disperserEndpoint) record(latency time.Duration, err error, unhealthyThreshold int, unhealthyBackoff time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err == nil {
+		e.consecutiveFailures = 0
+		e.unhealthyUntil = time.Time{}
+		if e.latency == 0 {
+			e.latency = latency
+		} else {
+			e.latency = time.Duration(latencyEWMAWeight*float64(latency) + (1-latencyEWMAWeight)*float64(e.latency))
+		}
+		return
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.Aborted:
+		e.consecutiveFailures++
+		if e.consecutiveFailures >= unhealthyThreshold {
+			e.unhealthyUntil = time.Now().Add(unhealthyBackoff)
+		}
+	}
+}
+
+// isUnreachable returns whether a request failed because the disperser could not be reached, in which case the
+// disperser cannot have accepted it.
+func isUnreachable(err error) bool {
+	return errors.Is(err, &api.ErrorFailover{}) || status.Code(err) == codes.Unavailable
+}
+
+// isTerminalBlobStatus returns whether a blob status is final.
+func isTerminalBlobStatus(blobStatus disperser_rpc.BlobStatus) bool {
+	switch blobStatus {
+	case disperser_rpc.BlobStatus_CERTIFIED, disperser_rpc.BlobStatus_FAILED, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,360 @@
+package clients
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptedDisperserRPC answers dispersals and status requests with configurable functions, and records the requests
+// it receives.
+type scriptedDisperserRPC struct {
+	disperser_rpc.DisperserClient
+
+	disperse     func(ctx context.Context) error
+	status       func(ctx context.Context) error
+	paymentState *disperser_rpc.GetPaymentStateReply
+
+	mu                   sync.Mutex
+	requests             []*disperser_rpc.DisperseBlobRequest
+	statusRequests       int
+	paymentStateRequests int
+}
+
+func (f *scriptedDisperserRPC) DisperseBlob(
+	ctx context.Context,
+	in *disperser_rpc.DisperseBlobRequest,
+	opts ...grpc.CallOption) (*disperser_rpc.DisperseBlobReply, error) {
+
+	f.mu.Lock()
+	f.requests = append(f.requests, in)
+	f.mu.Unlock()
+
+	if f.disperse != nil {
+		if err := f.disperse(ctx); err != nil {
+			return nil, err
+		}
+	}
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(in.GetBlobHeader())
+	if err != nil {
+		return nil, err
+	}
+	blobKey, err := blobHeader.BlobKey()
+	if err != nil {
+		return nil, err
+	}
+	return &disperser_rpc.DisperseBlobReply{Result: disperser_rpc.BlobStatus_QUEUED, BlobKey: blobKey[:]}, nil
+}
+
+func (f *scriptedDisperserRPC) GetBlobStatus(
+	ctx context.Context,
+	in *disperser_rpc.BlobStatusRequest,
+	opts ...grpc.CallOption) (*disperser_rpc.BlobStatusReply, error) {
+
+	f.mu.Lock()
+	f.statusRequests++
+	f.mu.Unlock()
+
+	if f.status != nil {
+		if err := f.status(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_CERTIFIED}, nil
+}
+
+func (f *scriptedDisperserRPC) GetPaymentState(
+	ctx context.Context,
+	in *disperser_rpc.GetPaymentStateRequest,
+	opts ...grpc.CallOption) (*disperser_rpc.GetPaymentStateReply, error) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paymentStateRequests++
+	if f.paymentState == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	return f.paymentState, nil
+}
+
+func (f *scriptedDisperserRPC) dispersals() []*disperser_rpc.DisperseBlobRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*disperser_rpc.DisperseBlobRequest{}, f.requests...)
+}
+
+// newTestMultiDisperserClient creates a multi disperser client backed by the given RPCs, with an on-demand accountant.
+func newTestMultiDisperserClient(
+	t *testing.T,
+	config *MultiDisperserClientConfig,
+	rpcs ...*scriptedDisperserRPC) (*multiDisperserClient, *Accountant) {
+
+	signer := authv2.NewLocalBlobRequestSigner("0x000000000000000000000000000000000000000000000000000000000000000a")
+	accountID, err := signer.GetAccountID()
+	require.NoError(t, err)
+	accountant := NewAccountant(accountID, nil, nil, 0, 0, 0, 0)
+	require.NoError(t, accountant.SetPaymentState(&disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+			MinNumSymbols:     1,
+			PricePerSymbol:    1,
+			ReservationWindow: 1,
+		},
+		OnchainCumulativePayment: big.NewInt(10000).Bytes(),
+	}))
+
+	prover := &encmock.MockEncoder{}
+	prover.On("GetCommitmentsForPaddedLength", mock.Anything).Return(encoding.BlobCommitments{
+		Commitment:       &encoding.G1Commitment{},
+		LengthCommitment: &encoding.G2Commitment{},
+		LengthProof:      &encoding.LengthProof{},
+		Length:           1,
+	}, nil)
+
+	for i := range rpcs {
+		config.Dispersers = append(config.Dispersers, &DisperserClientConfig{Hostname: "disperser", Port: string(rune('0' + i))})
+	}
+	client, err := NewMultiDisperserClient(config, signer, prover, accountant)
+	require.NoError(t, err)
+	for i, rpc := range rpcs {
+		client.endpoints[i].client.initOnceGrpc.Do(func() {})
+		client.endpoints[i].client.client = rpc
+	}
+	return client, accountant
+}
+
+func TestMultiDisperserClientFailover(t *testing.T) {
+	unavailable := &scriptedDisperserRPC{disperse: func(ctx context.Context) error {
+		return status.Error(codes.Unavailable, "connection refused")
+	}}
+	healthy := &scriptedDisperserRPC{}
+	client, accountant := newTestMultiDisperserClient(t, &MultiDisperserClientConfig{UnhealthyThreshold: 1}, unavailable, healthy)
+
+	_, blobKey, err := client.DisperseBlob(context.Background(), []byte{1, 2, 3}, 0, []core.QuorumID{0}, 0)
+	require.NoError(t, err)
+
+	// the same signed request was sent to the next disperser, and charged once
+	require.Len(t, unavailable.dispersals(), 1)
+	require.Len(t, healthy.dispersals(), 1)
+	assert.Equal(t, unavailable.dispersals()[0], healthy.dispersals()[0])
+	assert.Equal(t, big.NewInt(1), accountant.cumulativePayment)
+
+	address, ok := client.DisperserAddress(blobKey)
+	require.True(t, ok)
+	assert.Equal(t, "disperser:1", address)
+
+	// the status is requested from the disperser that accepted the blob, and forgotten once final
+	reply, err := client.GetBlobStatus(context.Background(), blobKey)
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_CERTIFIED, reply.GetStatus())
+	assert.Equal(t, 0, unavailable.statusRequests)
+	assert.Equal(t, 1, healthy.statusRequests)
+	_, ok = client.DisperserAddress(blobKey)
+	assert.False(t, ok)
+
+	// the unhealthy disperser is now tried last
+	_, _, err = client.DisperseBlob(context.Background(), []byte{1, 2, 3}, 0, []core.QuorumID{0}, 0)
+	require.NoError(t, err)
+	assert.Len(t, unavailable.dispersals(), 1)
+	assert.Len(t, healthy.dispersals(), 2)
+}
+
+func TestMultiDisperserClientDoesNotHedgeDispersals(t *testing.T) {
+	slow := &scriptedDisperserRPC{disperse: func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return api.NewErrorDeadlineExceeded("timed out")
+	}}
+	other := &scriptedDisperserRPC{}
+	client, _ := newTestMultiDisperserClient(t, &MultiDisperserClientConfig{HedgeDelay: time.Millisecond}, slow, other)
+
+	// dispersals are only hedged if HedgeDispersals is set, and the slow disperser may have accepted the request
+	// before timing out, so it is not sent to another disperser
+	_, _, err := client.DisperseBlob(context.Background(), []byte{1, 2, 3}, 0, []core.QuorumID{0}, 0)
+	require.Error(t, err)
+	assert.Len(t, slow.dispersals(), 1)
+	assert.Empty(t, other.dispersals())
+}
+
+func TestMultiDisperserClientHedgesDispersals(t *testing.T) {
+	slow := &scriptedDisperserRPC{disperse: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	fast := &scriptedDisperserRPC{}
+	client, accountant := newTestMultiDisperserClient(t,
+		&MultiDisperserClientConfig{HedgeDelay: 10 * time.Millisecond, HedgeDispersals: true}, slow, fast)
+
+	_, blobKey, err := client.DisperseBlob(context.Background(), []byte{1, 2, 3}, 0, []core.QuorumID{0}, 0)
+	require.NoError(t, err)
+
+	// the same signed request was hedged to the next disperser, and charged once
+	require.Len(t, slow.dispersals(), 1)
+	require.Len(t, fast.dispersals(), 1)
+	assert.Equal(t, slow.dispersals()[0], fast.dispersals()[0])
+	assert.Equal(t, big.NewInt(1), accountant.cumulativePayment)
+
+	address, ok := client.DisperserAddress(blobKey)
+	require.True(t, ok)
+	assert.Equal(t, "disperser:1", address)
+}
+
+func TestMultiDisperserClientAcceptsHedgedDispersalThatAlreadyExists(t *testing.T) {
+	slow := &scriptedDisperserRPC{disperse: func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return api.NewErrorDeadlineExceeded("timed out")
+	}}
+	// the disperser shares its blob store with the slow disperser, which already accepted the blob
+	existing := &scriptedDisperserRPC{disperse: func(ctx context.Context) error {
+		return api.NewErrorAlreadyExists("blob already exists")
+	}}
+	client, accountant := newTestMultiDisperserClient(t,
+		&MultiDisperserClientConfig{HedgeDelay: time.Millisecond, HedgeDispersals: true}, slow, existing)
+
+	blobStatus, blobKey, err := client.DisperseBlob(context.Background(), []byte{1, 2, 3}, 0, []core.QuorumID{0}, 0)
+	require.NoError(t, err)
+	assert.NotNil(t, blobStatus)
+	assert.Equal(t, big.NewInt(1), accountant.cumulativePayment)
+
+	// the blob key is the key of the signed request
+	require.Len(t, existing.dispersals(), 1)
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(existing.dispersals()[0].GetBlobHeader())
+	require.NoError(t, err)
+	expectedKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	assert.Equal(t, expectedKey, blobKey)
+
+	address, ok := client.DisperserAddress(blobKey)
+	require.True(t, ok)
+	assert.Equal(t, "disperser:1", address)
+}
+
+func TestMultiDisperserClientRollsBackUnreachableDispersals(t *testing.T) {
+	unavailable := func(ctx context.Context) error {
+		return status.Error(codes.Unavailable, "connection refused")
+	}
+	first := &scriptedDisperserRPC{disperse: unavailable}
+	second := &scriptedDisperserRPC{disperse: unavailable}
+	client, accountant := newTestMultiDisperserClient(t, &MultiDisperserClientConfig{}, first, second)
+
+	_, _, err := client.DisperseBlob(context.Background(), []byte{1, 2, 3}, 0, []core.QuorumID{0}, 0)
+	require.Error(t, err)
+	assert.Len(t, first.dispersals(), 1)
+	assert.Len(t, second.dispersals(), 1)
+
+	// no disperser metered the payment, so it is not charged
+	assert.Zero(t, accountant.cumulativePayment.Sign())
+}
+
+func TestMultiDisperserClientHedgesReads(t *testing.T) {
+	cancelled := make(chan struct{})
+	slow := &scriptedDisperserRPC{status: func(ctx context.Context) error {
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	}}
+	fast := &scriptedDisperserRPC{}
+	client, _ := newTestMultiDisperserClient(t, &MultiDisperserClientConfig{HedgeDelay: 10 * time.Millisecond}, slow, fast)
+
+	reply, err := client.GetBlobStatus(context.Background(), corev2.BlobKey{1})
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_CERTIFIED, reply.GetStatus())
+
+	// the slow read is cancelled once the hedged one succeeds
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("slow read was not cancelled")
+	}
+}
+
+func TestMultiDisperserClientSyncsFromRejectingDisperser(t *testing.T) {
+	rejected := false
+	rejecting := &scriptedDisperserRPC{
+		disperse: func(ctx context.Context) error {
+			if !rejected {
+				rejected = true
+				return api.NewErrorPaymentRejected("insufficient cumulative payment increment")
+			}
+			return nil
+		},
+		paymentState: &disperser_rpc.GetPaymentStateReply{
+			PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+				MinNumSymbols:     1,
+				PricePerSymbol:    1,
+				ReservationWindow: 1,
+			},
+			OnchainCumulativePayment: big.NewInt(10000).Bytes(),
+			CumulativePayment:        big.NewInt(5).Bytes(),
+		},
+	}
+	other := &scriptedDisperserRPC{}
+	client, accountant := newTestMultiDisperserClient(t, &MultiDisperserClientConfig{}, rejecting, other)
+
+	_, blobKey, err := client.DisperseBlob(context.Background(), []byte{1, 2, 3}, 0, []core.QuorumID{0}, 0)
+	require.NoError(t, err)
+
+	// the rejected charge is undone and the accountant resumes from the payment state of the rejecting disperser
+	require.Len(t, rejecting.dispersals(), 2)
+	assert.Equal(t, big.NewInt(1).Bytes(), rejecting.dispersals()[0].GetBlobHeader().GetPaymentHeader().GetCumulativePayment())
+	assert.Equal(t, big.NewInt(6).Bytes(), rejecting.dispersals()[1].GetBlobHeader().GetPaymentHeader().GetCumulativePayment())
+	assert.Equal(t, big.NewInt(6), accountant.cumulativePayment)
+	assert.Equal(t, 1, rejecting.paymentStateRequests)
+	assert.Equal(t, 0, other.paymentStateRequests)
+	assert.Empty(t, other.dispersals())
+
+	address, ok := client.DisperserAddress(blobKey)
+	require.True(t, ok)
+	assert.Equal(t, "disperser:0", address)
+}
+
+func TestMultiDisperserClientInvalidRequest(t *testing.T) {
+	invalid := &scriptedDisperserRPC{disperse: func(ctx context.Context) error {
+		return api.NewErrorInvalidArg("blob too large")
+	}}
+	other := &scriptedDisperserRPC{}
+	client, _ := newTestMultiDisperserClient(t, &MultiDisperserClientConfig{}, invalid, other)
+
+	_, _, err := client.DisperseBlob(context.Background(), []byte{1, 2, 3}, 0, []core.QuorumID{0}, 0)
+	require.Error(t, err)
+	assert.Empty(t, other.dispersals())
+}
+
+func TestMultiDisperserClientPopulatesAccountantFromEveryDisperser(t *testing.T) {
+	paymentState := func(cumulativePayment int64) *disperser_rpc.GetPaymentStateReply {
+		return &disperser_rpc.GetPaymentStateReply{
+			PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+				MinNumSymbols:     1,
+				PricePerSymbol:    1,
+				ReservationWindow: 1,
+			},
+			OnchainCumulativePayment: big.NewInt(10000).Bytes(),
+			CumulativePayment:        big.NewInt(cumulativePayment).Bytes(),
+		}
+	}
+	behind := &scriptedDisperserRPC{paymentState: paymentState(3)}
+	ahead := &scriptedDisperserRPC{paymentState: paymentState(7)}
+	unreachable := &scriptedDisperserRPC{}
+	client, accountant := newTestMultiDisperserClient(t, &MultiDisperserClientConfig{}, behind, ahead, unreachable)
+
+	// the accountant resumes from the largest payment seen by any disperser, whichever answers first
+	require.NoError(t, client.PopulateAccountant(context.Background()))
+	assert.Equal(t, big.NewInt(7), accountant.cumulativePayment)
+	assert.Equal(t, 1, behind.paymentStateRequests)
+	assert.Equal(t, 1, ahead.paymentStateRequests)
+	assert.Equal(t, 1, unreachable.paymentStateRequests)
+}
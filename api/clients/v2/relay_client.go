@@ -9,6 +9,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/relay/auth"
 
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -48,6 +49,10 @@ type RelayClient interface {
 	// The returned slice has the same length and ordering as the input slice, and the i-th element is the bundle for the i-th request.
 	// Each bundle is a sequence of frames in raw form (i.e., serialized core.Bundle bytearray).
 	GetChunksByIndex(ctx context.Context, relayKey corev2.RelayKey, requests []*ChunkRequestByIndex) ([][]byte, error)
+	// GetBlobSymbolProofs retrieves kzg opening proofs for the symbols at the given indices of a blob from a relay.
+	// If batched is true, a single multi-point proof is returned for all symbols. The proofs can be checked with
+	// verification.VerifyBlobSymbolProofs.
+	GetBlobSymbolProofs(ctx context.Context, relayKey corev2.RelayKey, blobKey corev2.BlobKey, symbolIndices []uint64, batched bool) (*disperser_rpc.BlobSymbolProofsReply, error)
 	// GetSockets returns the relay sockets
 	GetSockets() map[corev2.RelayKey]string
 	Close() error
@@ -106,6 +111,25 @@ func (c *relayClient) GetBlob(ctx context.Context, relayKey corev2.RelayKey, blo
 	return res.GetBlob(), nil
 }
 
+func (c *relayClient) GetBlobSymbolProofs(
+	ctx context.Context,
+	relayKey corev2.RelayKey,
+	blobKey corev2.BlobKey,
+	symbolIndices []uint64,
+	batched bool) (*disperser_rpc.BlobSymbolProofsReply, error) {
+
+	client, err := c.getClient(relayKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetBlobSymbolProofs(ctx, &disperser_rpc.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: symbolIndices,
+		Batched:       batched,
+	})
+}
+
 // signGetChunksRequest signs the GetChunksRequest with the operator's private key
 // and sets the signature in the request.
 func (c *relayClient) signGetChunksRequest(ctx context.Context, request *relaygrpc.GetChunksRequest) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients"
 	grpcnode "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
@@ -30,15 +31,19 @@ type retrievalClient struct {
 	indexedChainState core.IndexedChainState
 	verifier          encoding.Verifier
 	numConnections    int
+	signer            corev2.BlobRequestSigner
 }
 
-// NewRetrievalClient creates a new retrieval client.
+// NewRetrievalClient creates a new retrieval client. If signer is not nil, GetChunks requests are signed by its
+// account, and validators rate limit them per account on top of the limits of the client IP address. Otherwise the
+// requests are not signed.
 func NewRetrievalClient(
 	logger logging.Logger,
 	ethClient core.Reader,
 	chainState core.IndexedChainState,
 	verifier encoding.Verifier,
 	numConnections int,
+	signer corev2.BlobRequestSigner,
 ) RetrievalClient {
 	return &retrievalClient{
 		logger:            logger.With("component", "RetrievalClient"),
@@ -46,6 +51,7 @@ func NewRetrievalClient(
 		indexedChainState: chainState,
 		verifier:          verifier,
 		numConnections:    numConnections,
+		signer:            signer,
 	}
 }
 
@@ -176,6 +182,17 @@ func (r *retrievalClient) getChunksFromOperator(
 		BlobKey:  blobKey[:],
 		QuorumId: uint32(quorumID),
 	}
+	if r.signer != nil {
+		err = r.signGetChunksRequest(request)
+		if err != nil {
+			chunksChan <- clients.RetrievedChunks{
+				OperatorID: opID,
+				Err:        err,
+				Chunks:     nil,
+			}
+			return
+		}
+	}
 
 	reply, err := n.GetChunks(ctx, request)
 	if err != nil {
@@ -208,3 +225,21 @@ func (r *retrievalClient) getChunksFromOperator(
 		Chunks:     chunks,
 	}
 }
+
+// signGetChunksRequest sets the account, timestamp and signature of a GetChunks request.
+func (r *retrievalClient) signGetChunksRequest(request *grpcnode.GetChunksRequest) error {
+	accountID, err := r.signer.GetAccountID()
+	if err != nil {
+		return fmt.Errorf("failed to get account ID: %w", err)
+	}
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := r.signer.SignGetChunksRequest(request.GetBlobKey(), request.GetQuorumId(), timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to sign GetChunks request: %w", err)
+	}
+
+	request.AccountId = accountID
+	request.Timestamp = timestamp
+	request.Signature = signature
+	return nil
+}
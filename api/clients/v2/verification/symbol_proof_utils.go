@@ -0,0 +1,91 @@
+package verification
+
+import (
+	"errors"
+	"fmt"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// SymbolOpeningsFromProtobuf parses the proofs in a GetBlobSymbolProofs reply. Exactly one of the returned values is
+// non-nil: the individual openings for a non-batched reply, or the multi symbol opening for a batched reply.
+func SymbolOpeningsFromProtobuf(
+	reply *disperser_rpc.BlobSymbolProofsReply) ([]encoding.SymbolOpening, *encoding.MultiSymbolOpening, error) {
+
+	if reply == nil || len(reply.GetSymbolProofs()) == 0 {
+		return nil, nil, errors.New("reply contains no symbol proofs")
+	}
+
+	values := make([]fr.Element, len(reply.GetSymbolProofs()))
+	for i, symbolProof := range reply.GetSymbolProofs() {
+		if err := values[i].SetBytesCanonical(symbolProof.GetValue()); err != nil {
+			return nil, nil, fmt.Errorf("invalid value for symbol %d: %w", symbolProof.GetIndex(), err)
+		}
+	}
+
+	if len(reply.GetBatchedProof()) != 0 {
+		var proof encoding.Proof
+		if _, err := proof.SetBytes(reply.GetBatchedProof()); err != nil {
+			return nil, nil, fmt.Errorf("invalid batched proof: %w", err)
+		}
+
+		indices := make([]uint64, len(reply.GetSymbolProofs()))
+		for i, symbolProof := range reply.GetSymbolProofs() {
+			indices[i] = symbolProof.GetIndex()
+		}
+
+		return nil, &encoding.MultiSymbolOpening{
+			Indices: indices,
+			Values:  values,
+			Proof:   proof,
+		}, nil
+	}
+
+	openings := make([]encoding.SymbolOpening, len(reply.GetSymbolProofs()))
+	for i, symbolProof := range reply.GetSymbolProofs() {
+		var proof encoding.Proof
+		if _, err := proof.SetBytes(symbolProof.GetProof()); err != nil {
+			return nil, nil, fmt.Errorf("invalid proof for symbol %d: %w", symbolProof.GetIndex(), err)
+		}
+		openings[i] = encoding.SymbolOpening{
+			Index: symbolProof.GetIndex(),
+			Value: values[i],
+			Proof: proof,
+		}
+	}
+
+	return openings, nil, nil
+}
+
+// VerifyBlobSymbolProofs verifies the proofs in a GetBlobSymbolProofs reply against the commitments of the blob, which
+// should come from a trusted source such as the blob certificate rather than from the reply itself. It returns the
+// opened symbol values in the order of the reply.
+func VerifyBlobSymbolProofs(
+	verifier encoding.Verifier,
+	commitments encoding.BlobCommitments,
+	reply *disperser_rpc.BlobSymbolProofsReply) ([]encoding.Symbol, error) {
+
+	openings, multiOpening, err := SymbolOpeningsFromProtobuf(reply)
+	if err != nil {
+		return nil, err
+	}
+
+	if multiOpening != nil {
+		if err := verifier.VerifyMultiSymbolOpening(commitments, multiOpening); err != nil {
+			return nil, fmt.Errorf("verify multi symbol opening: %w", err)
+		}
+		return multiOpening.Values, nil
+	}
+
+	if err := verifier.VerifySymbolOpenings(commitments, openings); err != nil {
+		return nil, fmt.Errorf("verify symbol openings: %w", err)
+	}
+
+	values := make([]encoding.Symbol, len(openings))
+	for i := range openings {
+		values[i] = openings[i].Value
+	}
+	return values, nil
+}
@@ -0,0 +1,76 @@
+package verification
+
+import (
+	"runtime"
+	"testing"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlobSymbolProofs(t *testing.T) {
+	testRandom := random.NewTestRandom(t)
+	blobBytes := getRandomPaddedBytes(testRandom, 100+testRandom.Intn(1000))
+	blobFr, err := rs.ToFrArray(blobBytes)
+	require.NoError(t, err)
+
+	kzgConfig := &kzg.KzgConfig{
+		G1Path:          g1Path,
+		G2Path:          "../../../../inabox/resources/kzg/g2.point",
+		CacheDir:        "../../../../inabox/resources/kzg/SRSTables",
+		SRSOrder:        3000,
+		SRSNumberToLoad: 3000,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+		LoadG2Points:    true,
+	}
+	p, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+	v, err := verifier.NewVerifier(kzgConfig, nil)
+	require.NoError(t, err)
+
+	commitments, err := p.GetCommitmentsForPaddedLength(blobBytes)
+	require.NoError(t, err)
+
+	indices := []uint64{0, 2, uint64(len(blobFr) - 1)}
+
+	openings, err := p.OpenSymbols(blobBytes, uint64(commitments.Length), indices)
+	require.NoError(t, err)
+	reply := &disperser_rpc.BlobSymbolProofsReply{}
+	for _, opening := range openings {
+		value := opening.Value.Bytes()
+		proof := opening.Proof.Bytes()
+		reply.SymbolProofs = append(reply.SymbolProofs, &disperser_rpc.SymbolProof{
+			Index: opening.Index,
+			Value: value[:],
+			Proof: proof[:],
+		})
+	}
+	values, err := VerifyBlobSymbolProofs(v, commitments, reply)
+	require.NoError(t, err)
+	require.Len(t, values, len(indices))
+
+	multiOpening, err := p.OpenSymbolsBatch(blobBytes, uint64(commitments.Length), indices)
+	require.NoError(t, err)
+	batchedProof := multiOpening.Proof.Bytes()
+	batchedReply := &disperser_rpc.BlobSymbolProofsReply{BatchedProof: batchedProof[:]}
+	for i := range multiOpening.Indices {
+		value := multiOpening.Values[i].Bytes()
+		batchedReply.SymbolProofs = append(batchedReply.SymbolProofs, &disperser_rpc.SymbolProof{
+			Index: multiOpening.Indices[i],
+			Value: value[:],
+		})
+	}
+	batchedValues, err := VerifyBlobSymbolProofs(v, commitments, batchedReply)
+	require.NoError(t, err)
+	require.Equal(t, values, batchedValues)
+
+	// a proof for a different index must not verify
+	reply.SymbolProofs[1].Index = 1
+	_, err = VerifyBlobSymbolProofs(v, commitments, reply)
+	require.Error(t, err)
+}
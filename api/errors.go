@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -23,11 +24,44 @@ func newErrorGRPC(code codes.Code, msg string) error {
 	return status.Error(code, msg)
 }
 
+// statusError is a gRPC error that wraps the error that caused it.
+type statusError struct {
+	status *status.Status
+	cause  error
+}
+
+func (e *statusError) Error() string {
+	return e.status.Err().Error()
+}
+
+func (e *statusError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+func (e *statusError) Unwrap() error {
+	return e.cause
+}
+
+// WrapError returns err, one of the errors of this package, with the message of cause appended to its message. The
+// returned error wraps cause, so that it can still be matched with errors.Is and errors.As on the server side, while
+// clients receive the gRPC status of err.
+func WrapError(err error, cause error) error {
+	st := status.Convert(err)
+	p := st.Proto()
+	p.Message = fmt.Sprintf("%s: %v", st.Message(), cause)
+	return &statusError{status: status.FromProto(p), cause: cause}
+}
+
 // HTTP Mapping: 400 Bad Request
 func NewErrorInvalidArg(msg string) error {
 	return newErrorGRPC(codes.InvalidArgument, msg)
 }
 
+// HTTP Mapping: 401 Unauthorized
+func NewErrorUnauthenticated(msg string) error {
+	return newErrorGRPC(codes.Unauthenticated, msg)
+}
+
 // HTTP Mapping: 404 Not Found
 func NewErrorNotFound(msg string) error {
 	return newErrorGRPC(codes.NotFound, msg)
@@ -38,6 +72,40 @@ func NewErrorResourceExhausted(msg string) error {
 	return newErrorGRPC(codes.ResourceExhausted, msg)
 }
 
+// ErrorReasonPaymentRejected is the reason of the error info attached to the errors of NewErrorPaymentRejected.
+const ErrorReasonPaymentRejected = "PAYMENT_REJECTED"
+
+// errorInfoDomain is the domain of the error info attached to errors.
+const errorInfoDomain = "eigenda.xyz"
+
+// NewErrorPaymentRejected is returned when a disperser rejects the payment of a blob. It has the same code as
+// NewErrorResourceExhausted, and carries an error info detail, which survives the gRPC transport, so that clients
+// can tell it apart from rate limits with IsPaymentRejected.
+//
+// HTTP Mapping: 429 Too Many Requests
+func NewErrorPaymentRejected(msg string) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: ErrorReasonPaymentRejected, Domain: errorInfoDomain})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// IsPaymentRejected returns whether an error, or an error it wraps, was created by NewErrorPaymentRejected.
+func IsPaymentRejected(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == ErrorReasonPaymentRejected {
+			return true
+		}
+	}
+	return false
+}
+
 // HTTP Mapping: 500 Internal Server Error
 func NewErrorInternal(msg string) error {
 	return newErrorGRPC(codes.Internal, msg)
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestErrorFailoverErrorsIs(t *testing.T) {
@@ -44,3 +47,43 @@ func TestErrorFailoverZeroValue(t *testing.T) {
 		t.Error("should return 'Failover' for zero value")
 	}
 }
+
+func TestIsPaymentRejected(t *testing.T) {
+	paymentErr := NewErrorPaymentRejected("insufficient cumulative payment increment")
+
+	if !IsPaymentRejected(paymentErr) {
+		t.Error("should match payment rejection")
+	}
+
+	if !IsPaymentRejected(fmt.Errorf("wrapped: %w", paymentErr)) {
+		t.Error("should match payment rejection even when wrapped")
+	}
+
+	if IsPaymentRejected(NewErrorResourceExhausted("rate limit exceeded")) {
+		t.Error("should not match other resource exhausted errors")
+	}
+
+	if IsPaymentRejected(fmt.Errorf("some other error")) {
+		t.Error("should not match non grpc errors")
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	cause := fmt.Errorf("invalid signature")
+	err := WrapError(NewErrorUnauthenticated("auth failed"), cause)
+
+	if !errors.Is(err, cause) {
+		t.Error("should match the cause")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatal("should have a gRPC status")
+	}
+	if st.Code() != codes.Unauthenticated {
+		t.Errorf("unexpected code %v", st.Code())
+	}
+	if st.Message() != "auth failed: invalid signature" {
+		t.Errorf("unexpected message %q", st.Message())
+	}
+}
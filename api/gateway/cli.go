@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	httpPortFlagName       = "gateway.http-port"
+	allowedOriginsFlagName = "gateway.allowed-origins"
+	maxRequestSizeFlagName = "gateway.max-request-size"
+	readTimeoutFlagName    = "gateway.read-timeout"
+)
+
+type Config struct {
+	// HTTPPort is the port of the HTTP/JSON gateway. The gateway is disabled if empty.
+	HTTPPort string
+	// AllowedOrigins are the origins allowed to make cross-origin requests to the gateway, or "*" for any origin.
+	// Cross-origin requests are not allowed if empty.
+	AllowedOrigins []string
+	// MaxRequestSize is the maximum size in bytes of a request body.
+	MaxRequestSize int64
+	// ReadTimeout is the maximum duration for reading a request, including its body. Defaults to
+	// DefaultReadTimeout if zero.
+	ReadTimeout time.Duration
+}
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     httpPortFlagName,
+			Usage:    "Port of the HTTP/JSON gateway to the gRPC API. The gateway is disabled if empty",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "GATEWAY_HTTP_PORT"),
+		},
+		cli.StringSliceFlag{
+			Name:     allowedOriginsFlagName,
+			Usage:    "Origins allowed to make cross-origin requests to the HTTP/JSON gateway, or * for any origin",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "GATEWAY_ALLOWED_ORIGINS"),
+		},
+		cli.Int64Flag{
+			Name:     maxRequestSizeFlagName,
+			Usage:    "Maximum size in bytes of a request body to the HTTP/JSON gateway",
+			Required: false,
+			Value:    32 * 1024 * 1024,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "GATEWAY_MAX_REQUEST_SIZE"),
+		},
+		cli.DurationFlag{
+			Name:     readTimeoutFlagName,
+			Usage:    "Maximum duration for reading a request to the HTTP/JSON gateway, including its body",
+			Required: false,
+			Value:    DefaultReadTimeout,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "GATEWAY_READ_TIMEOUT"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context) Config {
+	return Config{
+		HTTPPort:       ctx.GlobalString(httpPortFlagName),
+		AllowedOrigins: ctx.GlobalStringSlice(allowedOriginsFlagName),
+		MaxRequestSize: ctx.GlobalInt64(maxRequestSizeFlagName),
+		ReadTimeout:    ctx.GlobalDuration(readTimeoutFlagName),
+	}
+}
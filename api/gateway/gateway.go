@@ -0,0 +1,236 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// The gateway serves the v2 gRPC APIs over HTTP/JSON, for clients that cannot bundle a gRPC client, e.g. browsers
+// and serverless functions. Requests are handled in-process by the gRPC service implementation, so they go through
+// the same validation, authentication and rate limiting as gRPC requests.
+//
+// Messages are encoded with the canonical protobuf JSON mapping, using the field names of the .proto files. Bytes
+// fields are base64 encoded. Errors are returned as an ErrorResponse, with the HTTP status code that corresponds to the
+// gRPC status code of the error.
+
+const (
+	// OpenAPIPath is the path at which the OpenAPI document of a gateway is served.
+	OpenAPIPath = "/openapi.json"
+
+	// ContentTypeJSON is the content type of JSON requests and replies.
+	ContentTypeJSON = "application/json"
+	// ContentTypeOctetStream is the content type of raw-bytes requests and replies, e.g. a blob.
+	ContentTypeOctetStream = "application/octet-stream"
+
+	// DefaultReadTimeout is the default maximum duration for reading a request, which leaves a slow client about
+	// 500KiB/s to send a request of the default maximum size.
+	DefaultReadTimeout = 60 * time.Second
+	// idleTimeout is how long an idle keep-alive connection is kept open.
+	idleTimeout = 120 * time.Second
+
+	// BlobHeaderHeader is the HTTP header that carries the signed blob header of a raw-bytes dispersal, as the base64
+	// encoding of the protobuf serialized common.v2.BlobHeader.
+	BlobHeaderHeader = "EigenDA-Blob-Header"
+)
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// ErrorResponse is the body of a failed gateway request.
+type ErrorResponse struct {
+	// Code is the name of the gRPC status code of the error, e.g. "InvalidArgument".
+	Code string `json:"code"`
+	// Error is the error message.
+	Error string `json:"error"`
+}
+
+// NewRouter creates a router for a gateway, which serves the given OpenAPI document at OpenAPIPath. Services register
+// their routes on the returned router.
+func NewRouter(config Config, openAPI []byte) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	if len(config.AllowedOrigins) > 0 {
+		corsConfig := cors.DefaultConfig()
+		corsConfig.AllowMethods = []string{"GET", "POST", "HEAD", "OPTIONS"}
+		corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, BlobHeaderHeader)
+		if len(config.AllowedOrigins) == 1 && config.AllowedOrigins[0] == "*" {
+			corsConfig.AllowAllOrigins = true
+		} else {
+			corsConfig.AllowOrigins = config.AllowedOrigins
+		}
+		router.Use(cors.New(corsConfig))
+	}
+
+	router.GET(OpenAPIPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, ContentTypeJSON, openAPI)
+	})
+	return router
+}
+
+// Start serves the router on the configured port until the context is cancelled. It does nothing if the port is
+// empty.
+func Start(ctx context.Context, logger logging.Logger, config Config, router http.Handler) {
+	if config.HTTPPort == "" {
+		return
+	}
+	readTimeout := config.ReadTimeout
+	if readTimeout == 0 {
+		readTimeout = DefaultReadTimeout
+	}
+	// the timeouts bound how long a slow or idle client can hold a connection
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%s", config.HTTPPort),
+		Handler:           router,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       readTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	go func() {
+		logger.Info("Starting HTTP gateway", "port", config.HTTPPort)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("HTTP gateway failed", "err", err)
+		}
+	}()
+}
+
+// IncomingContext returns the context of an HTTP request as the gRPC service implementation expects it: the HTTP
+// headers are exposed as incoming metadata, so that e.g. a configured client IP header is honored, and the remote
+// address of the request is exposed as the peer.
+func IncomingContext(c *gin.Context) context.Context {
+	md := metadata.MD{}
+	for key, values := range c.Request.Header {
+		md.Append(strings.ToLower(key), values...)
+	}
+	ctx := metadata.NewIncomingContext(c.Request.Context(), md)
+
+	if addr, err := net.ResolveTCPAddr("tcp", c.Request.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	return ctx
+}
+
+// Invoke calls a unary method of a gRPC service through the interceptor of its gRPC server, e.g. the one collecting
+// the gRPC metrics, so that gateway requests are reported alongside gRPC requests. fullMethod is the gRPC method name,
+// e.g. "/relay.Relay/GetChunks". The method is called directly if the interceptor is nil.
+func Invoke[Req any, Reply any](
+	ctx context.Context,
+	interceptor grpc.UnaryServerInterceptor,
+	server any,
+	fullMethod string,
+	req *Req,
+	method func(context.Context, *Req) (*Reply, error),
+) (*Reply, error) {
+	if interceptor == nil {
+		return method(ctx, req)
+	}
+
+	info := &grpc.UnaryServerInfo{Server: server, FullMethod: fullMethod}
+	reply, err := interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return method(ctx, req.(*Req))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.(*Reply), nil
+}
+
+// ReadBody reads the body of a request, up to maxSize bytes.
+func ReadBody(c *gin.Context, maxSize int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxSize+1))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, status.Errorf(codes.InvalidArgument, "request body is larger than %d bytes", maxSize)
+	}
+	return body, nil
+}
+
+// ReadJSON reads a JSON encoded message from the body of a request, up to maxSize bytes.
+func ReadJSON(c *gin.Context, maxSize int64, msg proto.Message) error {
+	body, err := ReadBody(c, maxSize)
+	if err != nil {
+		return err
+	}
+	if err := unmarshalOptions.Unmarshal(body, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to parse request body: %v", err)
+	}
+	return nil
+}
+
+// WriteJSON writes a message as the JSON body of a successful reply.
+func WriteJSON(c *gin.Context, msg proto.Message) {
+	data, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		WriteError(c, status.Errorf(codes.Internal, "failed to encode reply: %v", err))
+		return
+	}
+	c.Data(http.StatusOK, ContentTypeJSON, data)
+}
+
+// WriteError writes an error reply, whose HTTP status code corresponds to the gRPC status code of the error.
+func WriteError(c *gin.Context, err error) {
+	s := status.Convert(err)
+	c.AbortWithStatusJSON(HTTPStatusFromCode(s.Code()), ErrorResponse{
+		Code:  s.Code().String(),
+		Error: s.Message(),
+	})
+}
+
+// HTTPStatusFromCode maps a gRPC status code to an HTTP status code, following
+// https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		// 499 Client Closed Request
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
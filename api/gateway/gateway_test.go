@@ -0,0 +1,134 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestWriteErrorStatusCodes(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code int
+	}{
+		{api.NewErrorInvalidArg("invalid blob key"), http.StatusBadRequest},
+		{api.NewErrorNotFound("no such blob"), http.StatusNotFound},
+		{api.WrapError(api.NewErrorUnauthenticated("auth failed"), assert.AnError), http.StatusUnauthorized},
+		{api.NewErrorResourceExhausted("rate limited"), http.StatusTooManyRequests},
+		{api.NewErrorInternal("failed"), http.StatusInternalServerError},
+		{api.NewErrorUnimplemented(), http.StatusNotImplemented},
+		{api.NewErrorDeadlineExceeded("timed out"), http.StatusGatewayTimeout},
+		{api.NewErrorAlreadyExists("blob already exists"), http.StatusConflict},
+		// errors without a gRPC status are internal errors
+		{assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		WriteError(c, tc.err)
+
+		assert.Equal(t, tc.code, recorder.Code, tc.err.Error())
+		response := ErrorResponse{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.NotEmpty(t, response.Code)
+		assert.NotEmpty(t, response.Error)
+	}
+}
+
+func TestReadWriteJSON(t *testing.T) {
+	body := `{"account_id": "0x1234", "signature": "AQID", "timestamp": "42", "unknown_field": 1}`
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	req := &pb.GetPaymentStateRequest{}
+	require.NoError(t, ReadJSON(c, 1024, req))
+	assert.Equal(t, "0x1234", req.GetAccountId())
+	assert.Equal(t, []byte{1, 2, 3}, req.GetSignature())
+	assert.Equal(t, uint64(42), req.GetTimestamp())
+
+	WriteJSON(c, &pb.DisperseBlobReply{Result: pb.BlobStatus_QUEUED, BlobKey: []byte{1, 2, 3}})
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"result": "QUEUED", "blob_key": "AQID"}`, recorder.Body.String())
+
+	// bodies larger than the limit are rejected
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	err := ReadJSON(c, 8, req)
+	require.Error(t, err)
+}
+
+func TestIncomingContext(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.RemoteAddr = "1.2.3.4:5678"
+	c.Request.Header.Set("X-Real-IP", "5.6.7.8")
+
+	ctx := IncomingContext(c)
+	md, ok := metadata.FromIncomingContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{"5.6.7.8"}, md.Get("x-real-ip"))
+	p, ok := peer.FromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "1.2.3.4:5678", p.Addr.String())
+}
+
+func TestInvoke(t *testing.T) {
+	method := func(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, error) {
+		if len(req.GetBlobKey()) == 0 {
+			return nil, api.NewErrorInvalidArg("invalid blob key")
+		}
+		return &pb.BlobStatusReply{Status: pb.BlobStatus_CERTIFIED}, nil
+	}
+
+	// without an interceptor, the method is called directly
+	reply, err := Invoke(context.Background(), nil, nil, pb.Disperser_GetBlobStatus_FullMethodName,
+		&pb.BlobStatusRequest{BlobKey: []byte{1}}, method)
+	require.NoError(t, err)
+	require.Equal(t, pb.BlobStatus_CERTIFIED, reply.GetStatus())
+
+	// with an interceptor, the method is called through it
+	var calls []string
+	interceptor := func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		calls = append(calls, info.FullMethod)
+		return handler(ctx, req)
+	}
+	reply, err = Invoke(context.Background(), interceptor, nil, pb.Disperser_GetBlobStatus_FullMethodName,
+		&pb.BlobStatusRequest{BlobKey: []byte{1}}, method)
+	require.NoError(t, err)
+	require.Equal(t, pb.BlobStatus_CERTIFIED, reply.GetStatus())
+	_, err = Invoke(context.Background(), interceptor, nil, pb.Disperser_GetBlobStatus_FullMethodName,
+		&pb.BlobStatusRequest{}, method)
+	require.Error(t, err)
+	require.Equal(t, []string{pb.Disperser_GetBlobStatus_FullMethodName, pb.Disperser_GetBlobStatus_FullMethodName}, calls)
+}
+
+func TestOpenAPIDocuments(t *testing.T) {
+	for _, document := range [][]byte{DisperserV2OpenAPI, RelayOpenAPI} {
+		router := NewRouter(Config{AllowedOrigins: []string{"*"}}, document)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		parsed := struct {
+			OpenAPI string                    `json:"openapi"`
+			Paths   map[string]map[string]any `json:"paths"`
+		}{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &parsed))
+		assert.Equal(t, "3.0.3", parsed.OpenAPI)
+		assert.NotEmpty(t, parsed.Paths)
+	}
+}
@@ -0,0 +1,15 @@
+package gateway
+
+import (
+	_ "embed"
+)
+
+// DisperserV2OpenAPI is the OpenAPI document of the HTTP/JSON gateway of the v2 disperser API.
+//
+//go:embed openapi/disperser_v2.json
+var DisperserV2OpenAPI []byte
+
+// RelayOpenAPI is the OpenAPI document of the HTTP/JSON gateway of the relay API.
+//
+//go:embed openapi/relay.json
+var RelayOpenAPI []byte
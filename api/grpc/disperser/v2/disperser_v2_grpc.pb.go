@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Disperser_DisperseBlob_FullMethodName      = "/disperser.v2.Disperser/DisperseBlob"
-	Disperser_GetBlobStatus_FullMethodName     = "/disperser.v2.Disperser/GetBlobStatus"
-	Disperser_GetBlobCommitment_FullMethodName = "/disperser.v2.Disperser/GetBlobCommitment"
-	Disperser_GetPaymentState_FullMethodName   = "/disperser.v2.Disperser/GetPaymentState"
+	Disperser_DisperseBlob_FullMethodName        = "/disperser.v2.Disperser/DisperseBlob"
+	Disperser_GetBlobStatus_FullMethodName       = "/disperser.v2.Disperser/GetBlobStatus"
+	Disperser_GetBlobCommitment_FullMethodName   = "/disperser.v2.Disperser/GetBlobCommitment"
+	Disperser_GetPaymentState_FullMethodName     = "/disperser.v2.Disperser/GetPaymentState"
+	Disperser_GetBlobSymbolProofs_FullMethodName = "/disperser.v2.Disperser/GetBlobSymbolProofs"
 )
 
 // DisperserClient is the client API for Disperser service.
@@ -40,6 +41,9 @@ type DisperserClient interface {
 	GetBlobCommitment(ctx context.Context, in *BlobCommitmentRequest, opts ...grpc.CallOption) (*BlobCommitmentReply, error)
 	// GetPaymentState is a utility method to get the payment state of a given account.
 	GetPaymentState(ctx context.Context, in *GetPaymentStateRequest, opts ...grpc.CallOption) (*GetPaymentStateReply, error)
+	// GetBlobSymbolProofs returns KZG opening proofs for symbols of a dispersed blob.
+	// Each proof can be verified against the commitment in the blob header.
+	GetBlobSymbolProofs(ctx context.Context, in *BlobSymbolProofsRequest, opts ...grpc.CallOption) (*BlobSymbolProofsReply, error)
 }
 
 type disperserClient struct {
@@ -86,6 +90,15 @@ func (c *disperserClient) GetPaymentState(ctx context.Context, in *GetPaymentSta
 	return out, nil
 }
 
+func (c *disperserClient) GetBlobSymbolProofs(ctx context.Context, in *BlobSymbolProofsRequest, opts ...grpc.CallOption) (*BlobSymbolProofsReply, error) {
+	out := new(BlobSymbolProofsReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBlobSymbolProofs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DisperserServer is the server API for Disperser service.
 // All implementations must embed UnimplementedDisperserServer
 // for forward compatibility
@@ -101,6 +114,9 @@ type DisperserServer interface {
 	GetBlobCommitment(context.Context, *BlobCommitmentRequest) (*BlobCommitmentReply, error)
 	// GetPaymentState is a utility method to get the payment state of a given account.
 	GetPaymentState(context.Context, *GetPaymentStateRequest) (*GetPaymentStateReply, error)
+	// GetBlobSymbolProofs returns KZG opening proofs for symbols of a dispersed blob.
+	// Each proof can be verified against the commitment in the blob header.
+	GetBlobSymbolProofs(context.Context, *BlobSymbolProofsRequest) (*BlobSymbolProofsReply, error)
 	mustEmbedUnimplementedDisperserServer()
 }
 
@@ -120,6 +136,9 @@ func (UnimplementedDisperserServer) GetBlobCommitment(context.Context, *BlobComm
 func (UnimplementedDisperserServer) GetPaymentState(context.Context, *GetPaymentStateRequest) (*GetPaymentStateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentState not implemented")
 }
+func (UnimplementedDisperserServer) GetBlobSymbolProofs(context.Context, *BlobSymbolProofsRequest) (*BlobSymbolProofsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobSymbolProofs not implemented")
+}
 func (UnimplementedDisperserServer) mustEmbedUnimplementedDisperserServer() {}
 
 // UnsafeDisperserServer may be embedded to opt out of forward compatibility for this service.
@@ -205,6 +224,24 @@ func _Disperser_GetPaymentState_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_GetBlobSymbolProofs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobSymbolProofsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBlobSymbolProofs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBlobSymbolProofs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBlobSymbolProofs(ctx, req.(*BlobSymbolProofsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Disperser_ServiceDesc is the grpc.ServiceDesc for Disperser service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -228,6 +265,10 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPaymentState",
 			Handler:    _Disperser_GetPaymentState_Handler,
 		},
+		{
+			MethodName: "GetBlobSymbolProofs",
+			Handler:    _Disperser_GetBlobSymbolProofs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "disperser/v2/disperser_v2.proto",
@@ -8,6 +8,7 @@ package relay
 
 import (
 	context "context"
+	v2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -19,8 +20,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Relay_GetBlob_FullMethodName   = "/relay.Relay/GetBlob"
-	Relay_GetChunks_FullMethodName = "/relay.Relay/GetChunks"
+	Relay_GetBlob_FullMethodName             = "/relay.Relay/GetBlob"
+	Relay_GetChunks_FullMethodName           = "/relay.Relay/GetChunks"
+	Relay_GetBlobSymbolProofs_FullMethodName = "/relay.Relay/GetBlobSymbolProofs"
 )
 
 // RelayClient is the client API for Relay service.
@@ -31,6 +33,10 @@ type RelayClient interface {
 	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobReply, error)
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(ctx context.Context, in *GetChunksRequest, opts ...grpc.CallOption) (*GetChunksReply, error)
+	// GetBlobSymbolProofs returns KZG opening proofs for symbols of a blob stored by the relay. It takes the same
+	// request and returns the same reply as Disperser.GetBlobSymbolProofs, so that the replies of both are verified
+	// in the same way.
+	GetBlobSymbolProofs(ctx context.Context, in *v2.BlobSymbolProofsRequest, opts ...grpc.CallOption) (*v2.BlobSymbolProofsReply, error)
 }
 
 type relayClient struct {
@@ -59,6 +65,15 @@ func (c *relayClient) GetChunks(ctx context.Context, in *GetChunksRequest, opts
 	return out, nil
 }
 
+func (c *relayClient) GetBlobSymbolProofs(ctx context.Context, in *v2.BlobSymbolProofsRequest, opts ...grpc.CallOption) (*v2.BlobSymbolProofsReply, error) {
+	out := new(v2.BlobSymbolProofsReply)
+	err := c.cc.Invoke(ctx, Relay_GetBlobSymbolProofs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RelayServer is the server API for Relay service.
 // All implementations must embed UnimplementedRelayServer
 // for forward compatibility
@@ -67,6 +82,10 @@ type RelayServer interface {
 	GetBlob(context.Context, *GetBlobRequest) (*GetBlobReply, error)
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error)
+	// GetBlobSymbolProofs returns KZG opening proofs for symbols of a blob stored by the relay. It takes the same
+	// request and returns the same reply as Disperser.GetBlobSymbolProofs, so that the replies of both are verified
+	// in the same way.
+	GetBlobSymbolProofs(context.Context, *v2.BlobSymbolProofsRequest) (*v2.BlobSymbolProofsReply, error)
 	mustEmbedUnimplementedRelayServer()
 }
 
@@ -80,6 +99,9 @@ func (UnimplementedRelayServer) GetBlob(context.Context, *GetBlobRequest) (*GetB
 func (UnimplementedRelayServer) GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChunks not implemented")
 }
+func (UnimplementedRelayServer) GetBlobSymbolProofs(context.Context, *v2.BlobSymbolProofsRequest) (*v2.BlobSymbolProofsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobSymbolProofs not implemented")
+}
 func (UnimplementedRelayServer) mustEmbedUnimplementedRelayServer() {}
 
 // UnsafeRelayServer may be embedded to opt out of forward compatibility for this service.
@@ -129,6 +151,24 @@ func _Relay_GetChunks_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Relay_GetBlobSymbolProofs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v2.BlobSymbolProofsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelayServer).GetBlobSymbolProofs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Relay_GetBlobSymbolProofs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelayServer).GetBlobSymbolProofs(ctx, req.(*v2.BlobSymbolProofsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Relay_ServiceDesc is the grpc.ServiceDesc for Relay service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -144,6 +184,10 @@ var Relay_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChunks",
 			Handler:    _Relay_GetChunks_Handler,
 		},
+		{
+			MethodName: "GetBlobSymbolProofs",
+			Handler:    _Relay_GetBlobSymbolProofs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "relay/relay.proto",
@@ -21,6 +21,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BlobDecoding is how the retriever turns a reconstructed blob into the data of a BlobReply.
+type BlobDecoding int32
+
+const (
+	// The blob is decoded with its payload codec header, and the payload is returned to the exact
+	// length recorded in the header. Blobs without a valid codec header are rejected.
+	BlobDecoding_PAYLOAD BlobDecoding = 0
+	// The blob is returned as reconstructed, cut to its length in the blob commitments, with its
+	// padding. Clients decode it themselves, which is the only lossless option for blobs that were
+	// not encoded with a payload codec.
+	BlobDecoding_RAW BlobDecoding = 1
+	// Trailing zero bytes are trimmed from the blob, and the padding byte at the start of every
+	// symbol is removed. This truncates data that ends in zero bytes, and is only kept for clients
+	// that rely on the format the retriever returned before decodings were introduced.
+	BlobDecoding_TRIM_PADDING BlobDecoding = 2
+)
+
+// Enum value maps for BlobDecoding.
+var (
+	BlobDecoding_name = map[int32]string{
+		0: "PAYLOAD",
+		1: "RAW",
+		2: "TRIM_PADDING",
+	}
+	BlobDecoding_value = map[string]int32{
+		"PAYLOAD":      0,
+		"RAW":          1,
+		"TRIM_PADDING": 2,
+	}
+)
+
+func (x BlobDecoding) Enum() *BlobDecoding {
+	p := new(BlobDecoding)
+	*p = x
+	return p
+}
+
+func (x BlobDecoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlobDecoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_retriever_v2_retriever_proto_enumTypes[0].Descriptor()
+}
+
+func (BlobDecoding) Type() protoreflect.EnumType {
+	return &file_retriever_v2_retriever_proto_enumTypes[0]
+}
+
+func (x BlobDecoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlobDecoding.Descriptor instead.
+func (BlobDecoding) EnumDescriptor() ([]byte, []int) {
+	return file_retriever_v2_retriever_proto_rawDescGZIP(), []int{0}
+}
+
 type BlobRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -33,6 +91,14 @@ type BlobRequest struct {
 	// Which quorum of the blob this is requesting for (note a blob can participate in
 	// multiple quorums).
 	QuorumId uint32 `protobuf:"varint,3,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	// Quorums to retrieve the blob from, in order of preference. The retriever falls back to
+	// the next quorum if the blob cannot be reconstructed from the chunks of the previous one.
+	// Every quorum must be one of the quorums of the blob. If empty, quorum_id is tried first,
+	// followed by the other quorums of the blob.
+	QuorumIds []uint32 `protobuf:"varint,4,rep,packed,name=quorum_ids,json=quorumIds,proto3" json:"quorum_ids,omitempty"`
+	// How the reconstructed blob is turned into the data of the reply. Defaults to PAYLOAD, which
+	// returns exactly the payload that was dispersed.
+	Decoding BlobDecoding `protobuf:"varint,5,opt,name=decoding,proto3,enum=retriever.v2.BlobDecoding" json:"decoding,omitempty"`
 }
 
 func (x *BlobRequest) Reset() {
@@ -88,6 +154,20 @@ func (x *BlobRequest) GetQuorumId() uint32 {
 	return 0
 }
 
+func (x *BlobRequest) GetQuorumIds() []uint32 {
+	if x != nil {
+		return x.QuorumIds
+	}
+	return nil
+}
+
+func (x *BlobRequest) GetDecoding() BlobDecoding {
+	if x != nil {
+		return x.Decoding
+	}
+	return BlobDecoding_PAYLOAD
+}
+
 type BlobReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -143,7 +223,7 @@ var file_retriever_v2_retriever_proto_rawDesc = []byte{
 	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c,
 	0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x1a, 0x16, 0x63, 0x6f,
 	0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x76, 0x32, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x98, 0x01, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xef, 0x01, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x68, 0x65, 0x61,
 	0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
 	0x6f, 0x6e, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
@@ -152,19 +232,27 @@ var file_retriever_v2_retriever_proto_rawDesc = []byte{
 	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72, 0x65,
 	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62,
 	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x22,
-	0x1f, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x32, 0x51, 0x0a, 0x09, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x12, 0x44, 0x0a,
-	0x0c, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x19, 0x2e,
-	0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f,
-	0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69,
-	0x65, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65,
-	0x6e, 0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x72, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x73, 0x12, 0x36,
+	0x0a, 0x08, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1a, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e,
+	0x42, 0x6c, 0x6f, 0x62, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x64, 0x65,
+	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x1f, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x2a, 0x36, 0x0a, 0x0c, 0x42, 0x6c, 0x6f, 0x62, 0x44,
+	0x65, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x59, 0x4c, 0x4f,
+	0x41, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x41, 0x57, 0x10, 0x01, 0x12, 0x10, 0x0a,
+	0x0c, 0x54, 0x52, 0x49, 0x4d, 0x5f, 0x50, 0x41, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x32,
+	0x51, 0x0a, 0x09, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0c,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x19, 0x2e, 0x72,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e,
+	0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x72, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -179,21 +267,24 @@ func file_retriever_v2_retriever_proto_rawDescGZIP() []byte {
 	return file_retriever_v2_retriever_proto_rawDescData
 }
 
+var file_retriever_v2_retriever_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_retriever_v2_retriever_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_retriever_v2_retriever_proto_goTypes = []interface{}{
-	(*BlobRequest)(nil),   // 0: retriever.v2.BlobRequest
-	(*BlobReply)(nil),     // 1: retriever.v2.BlobReply
-	(*v2.BlobHeader)(nil), // 2: common.v2.BlobHeader
+	(BlobDecoding)(0),     // 0: retriever.v2.BlobDecoding
+	(*BlobRequest)(nil),   // 1: retriever.v2.BlobRequest
+	(*BlobReply)(nil),     // 2: retriever.v2.BlobReply
+	(*v2.BlobHeader)(nil), // 3: common.v2.BlobHeader
 }
 var file_retriever_v2_retriever_proto_depIdxs = []int32{
-	2, // 0: retriever.v2.BlobRequest.blob_header:type_name -> common.v2.BlobHeader
-	0, // 1: retriever.v2.Retriever.RetrieveBlob:input_type -> retriever.v2.BlobRequest
-	1, // 2: retriever.v2.Retriever.RetrieveBlob:output_type -> retriever.v2.BlobReply
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 0: retriever.v2.BlobRequest.blob_header:type_name -> common.v2.BlobHeader
+	0, // 1: retriever.v2.BlobRequest.decoding:type_name -> retriever.v2.BlobDecoding
+	1, // 2: retriever.v2.Retriever.RetrieveBlob:input_type -> retriever.v2.BlobRequest
+	2, // 3: retriever.v2.Retriever.RetrieveBlob:output_type -> retriever.v2.BlobReply
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_retriever_v2_retriever_proto_init() }
@@ -232,13 +323,14 @@ func file_retriever_v2_retriever_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_retriever_v2_retriever_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_retriever_v2_retriever_proto_goTypes,
 		DependencyIndexes: file_retriever_v2_retriever_proto_depIdxs,
+		EnumInfos:         file_retriever_v2_retriever_proto_enumTypes,
 		MessageInfos:      file_retriever_v2_retriever_proto_msgTypes,
 	}.Build()
 	File_retriever_v2_retriever_proto = out.File
@@ -17,6 +17,7 @@ var (
 	FragmentParallelismConstantFlagName = "aws.fragment-parallelism-constant"
 	FragmentReadTimeoutFlagName         = "aws.fragment-read-timeout"
 	FragmentWriteTimeoutFlagName        = "aws.fragment-write-timeout"
+	LocalStoragePathFlagName            = "aws.local-storage-path"
 )
 
 type ClientConfig struct {
@@ -37,6 +38,10 @@ type ClientConfig struct {
 	// FragmentParallelismConstant helps determine the size of the pool of workers to help upload/download files.
 	// A non-zero value for this parameter adds a constant number of workers. Default is 0.
 	FragmentParallelismConstant int
+
+	// LocalStoragePath is a directory of the local filesystem to store S3 objects in. If this is set, S3 is not used,
+	// and the other S3 settings are ignored. Intended for tests and single machine deployments.
+	LocalStoragePath string
 }
 
 func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
@@ -103,6 +108,13 @@ func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
 			Value:    30 * time.Second,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "FRAGMENT_WRITE_TIMEOUT"),
 		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, LocalStoragePathFlagName),
+			Usage:    "Store S3 objects in this directory of the local filesystem instead of S3",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_LOCAL_STORAGE_PATH"),
+		},
 	}
 }
 
@@ -114,6 +126,7 @@ func ReadClientConfig(ctx *cli.Context, flagPrefix string) ClientConfig {
 		EndpointURL:                 ctx.GlobalString(common.PrefixFlag(flagPrefix, EndpointURLFlagName)),
 		FragmentParallelismFactor:   ctx.GlobalInt(common.PrefixFlag(flagPrefix, FragmentParallelismFactorFlagName)),
 		FragmentParallelismConstant: ctx.GlobalInt(common.PrefixFlag(flagPrefix, FragmentParallelismConstantFlagName)),
+		LocalStoragePath:            ctx.GlobalString(common.PrefixFlag(flagPrefix, LocalStoragePathFlagName)),
 	}
 }
 
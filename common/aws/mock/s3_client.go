@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 )
 
 type S3Client struct {
+	mu     sync.Mutex
 	bucket map[string][]byte
+	tags   map[string][]s3.Tag
 	Called map[string]int
 }
 
@@ -18,10 +21,12 @@ var _ s3.Client = (*S3Client)(nil)
 func NewS3Client() *S3Client {
 	return &S3Client{
 		bucket: make(map[string][]byte),
+		tags:   make(map[string][]s3.Tag),
 		Called: map[string]int{
 			"DownloadObject":           0,
 			"HeadObject":               0,
 			"UploadObject":             0,
+			"CopyObject":               0,
 			"DeleteObject":             0,
 			"ListObjects":              0,
 			"CreateBucket":             0,
@@ -32,6 +37,8 @@ func NewS3Client() *S3Client {
 }
 
 func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["DownloadObject"]++
 	data, ok := s.bucket[key]
 	if !ok {
@@ -41,6 +48,8 @@ func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string
 }
 
 func (s *S3Client) HeadObject(ctx context.Context, bucket string, key string) (*int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["HeadObject"]++
 	data, ok := s.bucket[key]
 	if !ok {
@@ -50,19 +59,52 @@ func (s *S3Client) HeadObject(ctx context.Context, bucket string, key string) (*
 	return &size, nil
 }
 
-func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string, data []byte, tags ...s3.Tag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["UploadObject"]++
 	s.bucket[key] = data
+	s.tags[key] = tags
+	return nil
+}
+
+// GetTags returns the tags an object was uploaded with.
+func (s *S3Client) GetTags(key string) []s3.Tag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tags[key]
+}
+
+func (s *S3Client) CopyObject(
+	ctx context.Context,
+	bucket string,
+	sourceKey string,
+	destinationKey string,
+	tags ...s3.Tag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Called["CopyObject"]++
+	data, ok := s.bucket[sourceKey]
+	if !ok {
+		return s3.ErrObjectNotFound
+	}
+	s.bucket[destinationKey] = data
+	s.tags[destinationKey] = tags
 	return nil
 }
 
 func (s *S3Client) DeleteObject(ctx context.Context, bucket string, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["DeleteObject"]++
 	delete(s.bucket, key)
+	delete(s.tags, key)
 	return nil
 }
 
 func (s *S3Client) ListObjects(ctx context.Context, bucket string, prefix string) ([]s3.Object, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["ListObjects"]++
 	objects := make([]s3.Object, 0, 1000)
 	for k, v := range s.bucket {
@@ -74,6 +116,8 @@ func (s *S3Client) ListObjects(ctx context.Context, bucket string, prefix string
 }
 
 func (s *S3Client) CreateBucket(ctx context.Context, bucket string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["CreateBucket"]++
 	return nil
 }
@@ -83,7 +127,10 @@ func (s *S3Client) FragmentedUploadObject(
 	bucket string,
 	key string,
 	data []byte,
-	fragmentSize int) error {
+	fragmentSize int,
+	tags ...s3.Tag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["FragmentedUploadObject"]++
 	fragments, err := s3.BreakIntoFragments(key, data, fragmentSize)
 	if err != nil {
@@ -91,6 +138,7 @@ func (s *S3Client) FragmentedUploadObject(
 	}
 	for _, fragment := range fragments {
 		s.bucket[fragment.FragmentKey] = fragment.Data
+		s.tags[fragment.FragmentKey] = tags
 	}
 	return nil
 }
@@ -101,6 +149,8 @@ func (s *S3Client) FragmentedDownloadObject(
 	key string,
 	fileSize int,
 	fragmentSize int) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["FragmentedDownloadObject"]++
 	if fileSize <= 0 {
 		return nil, errors.New("fileSize must be greater than 0")
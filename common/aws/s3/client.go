@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"net/url"
 	"runtime"
 	"sync"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -45,7 +47,12 @@ type client struct {
 
 var _ Client = (*client)(nil)
 
+// NewClient creates a Client backed by S3, or by the local filesystem if cfg.LocalStoragePath is set.
 func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger logging.Logger) (Client, error) {
+	if cfg.LocalStoragePath != "" {
+		return NewFilesystemClient(cfg.LocalStoragePath, logger)
+	}
+
 	var err error
 	once.Do(func() {
 		customResolver := aws.EndpointResolverWithOptionsFunc(
@@ -154,7 +161,7 @@ func (s *client) HeadObject(ctx context.Context, bucket string, key string) (*in
 	return output.ContentLength, nil
 }
 
-func (s *client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+func (s *client) UploadObject(ctx context.Context, bucket string, key string, data []byte, tags ...Tag) error {
 	var partMiBs int64 = 10
 	uploader := manager.NewUploader(s.s3Client, func(u *manager.Uploader) {
 		u.PartSize = partMiBs * 1024 * 1024 // 10MiB per part
@@ -162,9 +169,10 @@ func (s *client) UploadObject(ctx context.Context, bucket string, key string, da
 	})
 
 	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-		Body:   bytes.NewReader(data),
+		Bucket:  aws.String(bucket),
+		Key:     aws.String(key),
+		Body:    bytes.NewReader(data),
+		Tagging: encodeTags(tags),
 	})
 	if err != nil {
 		return err
@@ -173,6 +181,33 @@ func (s *client) UploadObject(ctx context.Context, bucket string, key string, da
 	return nil
 }
 
+func (s *client) CopyObject(
+	ctx context.Context,
+	bucket string,
+	sourceKey string,
+	destinationKey string,
+	tags ...Tag) error {
+
+	_, err := s.s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(destinationKey),
+		CopySource: aws.String((&url.URL{Path: bucket + "/" + sourceKey}).EscapedPath()),
+		// S3 only copies an object onto itself if something about it changes, so the metadata is always replaced
+		MetadataDirective: types.MetadataDirectiveReplace,
+		TaggingDirective:  types.TaggingDirectiveReplace,
+		Tagging:           encodeTags(tags),
+	})
+	if err != nil {
+		// S3 reports a missing source with an error code that the SDK does not model for CopyObject
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchKey" {
+			return ErrObjectNotFound
+		}
+		return err
+	}
+	return nil
+}
+
 func (s *client) DeleteObject(ctx context.Context, bucket string, key string) error {
 	_, err := s.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(bucket),
@@ -225,7 +260,8 @@ func (s *client) FragmentedUploadObject(
 	bucket string,
 	key string,
 	data []byte,
-	fragmentSize int) error {
+	fragmentSize int,
+	tags ...Tag) error {
 
 	fragments, err := BreakIntoFragments(key, data, fragmentSize)
 	if err != nil {
@@ -240,7 +276,7 @@ func (s *client) FragmentedUploadObject(
 			defer func() {
 				<-s.concurrencyLimiter
 			}()
-			s.fragmentedWriteTask(ctx, resultChannel, fragmentCapture, bucket)
+			s.fragmentedWriteTask(ctx, resultChannel, fragmentCapture, bucket, tags)
 		}()
 	}
 
@@ -258,13 +294,15 @@ func (s *client) fragmentedWriteTask(
 	ctx context.Context,
 	resultChannel chan error,
 	fragment *Fragment,
-	bucket string) {
+	bucket string,
+	tags []Tag) {
 
 	_, err := s.s3Client.PutObject(ctx,
 		&s3.PutObjectInput{
-			Bucket: aws.String(bucket),
-			Key:    aws.String(fragment.FragmentKey),
-			Body:   bytes.NewReader(fragment.Data),
+			Bucket:  aws.String(bucket),
+			Key:     aws.String(fragment.FragmentKey),
+			Body:    bytes.NewReader(fragment.Data),
+			Tagging: encodeTags(tags),
 		})
 
 	resultChannel <- err
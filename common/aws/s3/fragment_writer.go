@@ -0,0 +1,190 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FragmentWriter uploads a file in the same fragments as FragmentedUploadObject, while the file is still being
+// produced. Since the name of the last fragment depends on the number of fragments, the size of the file must be
+// known up front. Each fragment is uploaded as soon as it is full, and at most maxInFlight fragments are buffered
+// at a time, so memory usage scales with the fragment size rather than with the file size.
+//
+// A file written with a FragmentWriter can be downloaded with FragmentedDownloadObject.
+type FragmentWriter struct {
+	ctx          context.Context
+	client       Client
+	bucket       string
+	key          string
+	fileSize     int
+	fragmentSize int
+	tags         []Tag
+
+	fragmentCount int
+	// the fragment currently being filled
+	buffer []byte
+	// index of the fragment currently being filled
+	index int
+	// number of bytes written so far
+	written int
+	closed  bool
+
+	inFlight chan struct{}
+	wg       sync.WaitGroup
+
+	errLock sync.Mutex
+	err     error
+}
+
+// NewFragmentWriter creates a new FragmentWriter for a file of exactly fileSize bytes. Every fragment is tagged
+// with the given tags.
+func NewFragmentWriter(
+	ctx context.Context,
+	client Client,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int,
+	maxInFlight int,
+	tags ...Tag) (*FragmentWriter, error) {
+
+	if fileSize <= 0 {
+		return nil, errors.New("fileSize must be greater than 0")
+	}
+	if fragmentSize <= 0 {
+		return nil, errors.New("fragmentSize must be greater than 0")
+	}
+	if maxInFlight <= 0 {
+		return nil, errors.New("maxInFlight must be greater than 0")
+	}
+
+	w := &FragmentWriter{
+		ctx:           ctx,
+		client:        client,
+		bucket:        bucket,
+		key:           key,
+		fileSize:      fileSize,
+		fragmentSize:  fragmentSize,
+		tags:          tags,
+		fragmentCount: getFragmentCount(fileSize, fragmentSize),
+		inFlight:      make(chan struct{}, maxInFlight),
+	}
+	w.buffer = make([]byte, 0, w.currentFragmentSize())
+
+	return w, nil
+}
+
+// currentFragmentSize returns the size of the fragment currently being filled.
+func (w *FragmentWriter) currentFragmentSize() int {
+	remaining := w.fileSize - w.index*w.fragmentSize
+	if remaining < w.fragmentSize {
+		return remaining
+	}
+	return w.fragmentSize
+}
+
+// Write buffers the data, uploading every fragment that becomes full. It blocks while maxInFlight fragments are
+// being uploaded. Writing more than fileSize bytes in total is an error.
+func (w *FragmentWriter) Write(data []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("fragment writer is closed")
+	}
+	if err := w.getErr(); err != nil {
+		return 0, err
+	}
+	if w.written+len(data) > w.fileSize {
+		return 0, fmt.Errorf("cannot write %d bytes, only %d of %d bytes remain",
+			len(data), w.fileSize-w.written, w.fileSize)
+	}
+
+	n := 0
+	for n < len(data) {
+		available := cap(w.buffer) - len(w.buffer)
+		toCopy := len(data) - n
+		if toCopy > available {
+			toCopy = available
+		}
+		w.buffer = append(w.buffer, data[n:n+toCopy]...)
+		n += toCopy
+		w.written += toCopy
+
+		if len(w.buffer) == cap(w.buffer) {
+			if err := w.flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// flush uploads the current fragment in the background and starts the next one.
+func (w *FragmentWriter) flush() error {
+	fragmentKey, err := getFragmentKey(w.key, w.fragmentCount, w.index)
+	if err != nil {
+		return err
+	}
+	fragmentData := w.buffer
+
+	select {
+	case w.inFlight <- struct{}{}:
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer func() {
+			<-w.inFlight
+			w.wg.Done()
+		}()
+		err := w.client.UploadObject(w.ctx, w.bucket, fragmentKey, fragmentData, w.tags...)
+		if err != nil {
+			w.setErr(fmt.Errorf("failed to upload fragment %s: %w", fragmentKey, err))
+		}
+	}()
+
+	w.index++
+	if w.index < w.fragmentCount {
+		w.buffer = make([]byte, 0, w.currentFragmentSize())
+	} else {
+		w.buffer = nil
+	}
+	return nil
+}
+
+// Close waits for all fragments to be uploaded. It returns an error if any upload failed, or if fewer than fileSize
+// bytes were written.
+//
+// Note: as with FragmentedUploadObject, a failed write may leave some fragments in S3.
+func (w *FragmentWriter) Close() error {
+	if w.closed {
+		return errors.New("fragment writer is already closed")
+	}
+	w.closed = true
+	w.wg.Wait()
+
+	if err := w.getErr(); err != nil {
+		return err
+	}
+	if w.written != w.fileSize {
+		return fmt.Errorf("expected %d bytes to be written, got %d", w.fileSize, w.written)
+	}
+	return w.ctx.Err()
+}
+
+func (w *FragmentWriter) getErr() error {
+	w.errLock.Lock()
+	defer w.errLock.Unlock()
+	return w.err
+}
+
+func (w *FragmentWriter) setErr(err error) {
+	w.errLock.Lock()
+	defer w.errLock.Unlock()
+	if w.err == nil {
+		w.err = err
+	}
+}
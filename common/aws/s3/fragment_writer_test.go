@@ -0,0 +1,63 @@
+package s3_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFragmentWriter(t *testing.T) {
+	tu.InitializeRandom()
+
+	for _, fileSize := range []int{1, 99, 100, 101, 1000, rand.Intn(10000) + 1} {
+		client := mock.NewS3Client()
+		fileKey := tu.RandomString(10)
+		data := tu.RandomBytes(fileSize)
+		fragmentSize := 100
+
+		writer, err := s3.NewFragmentWriter(context.Background(), client, "bucket", fileKey, fileSize, fragmentSize, 2)
+		require.NoError(t, err)
+
+		// write in chunks that do not line up with fragments
+		for written := 0; written < fileSize; {
+			n := rand.Intn(250) + 1
+			if written+n > fileSize {
+				n = fileSize - written
+			}
+			count, err := writer.Write(data[written : written+n])
+			require.NoError(t, err)
+			require.Equal(t, n, count)
+			written += n
+		}
+		require.NoError(t, writer.Close())
+
+		downloaded, err := client.FragmentedDownloadObject(context.Background(), "bucket", fileKey, fileSize, fragmentSize)
+		require.NoError(t, err)
+		require.Equal(t, data, downloaded)
+	}
+}
+
+func TestFragmentWriterSizeMismatch(t *testing.T) {
+	tu.InitializeRandom()
+
+	client := mock.NewS3Client()
+	data := tu.RandomBytes(150)
+
+	// too much data
+	writer, err := s3.NewFragmentWriter(context.Background(), client, "bucket", "key", 100, 64, 1)
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.Error(t, err)
+
+	// too little data
+	writer, err = s3.NewFragmentWriter(context.Background(), client, "bucket", "key", 200, 64, 1)
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.Error(t, writer.Close())
+}
@@ -0,0 +1,285 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// stagingDirName is the directory objects are written to before they are moved into their bucket. S3 bucket names
+// cannot start with a dot, so it never collides with a bucket.
+const stagingDirName = ".staging"
+
+// fsClient is a Client that stores objects on the local filesystem, so that blobs and chunks can be stored without
+// S3 on a single machine. The object with key "a/b/c" in bucket "bucket" is the file "<root>/bucket/a/b/c", and
+// fragmented objects use the same fragment keys as in S3. Unlike in S3, a key cannot be both an object and a prefix
+// of other objects' keys followed by a "/".
+//
+// Objects are written to a staging file and renamed into place, so readers never observe a partially written
+// object. Tags are accepted but not stored, as there are no lifecycle rules to apply them to; expired objects must
+// be deleted explicitly.
+type fsClient struct {
+	root   string
+	logger logging.Logger
+}
+
+var _ Client = (*fsClient)(nil)
+
+// NewFilesystemClient creates a Client that stores objects in the given directory, creating it if needed.
+func NewFilesystemClient(root string, logger logging.Logger) (Client, error) {
+	if root == "" {
+		return nil, errors.New("root directory must be provided")
+	}
+	if err := os.MkdirAll(filepath.Join(root, stagingDirName), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", root, err)
+	}
+	return &fsClient{
+		root:   root,
+		logger: logger.With("component", "FilesystemS3Client"),
+	}, nil
+}
+
+// bucketPath returns the directory of a bucket.
+func (c *fsClient) bucketPath(bucket string) (string, error) {
+	if bucket == "" || strings.HasPrefix(bucket, ".") || strings.ContainsAny(bucket, `/\`) {
+		return "", fmt.Errorf("invalid bucket name %q", bucket)
+	}
+	return filepath.Join(c.root, bucket), nil
+}
+
+// objectPath returns the file of an object. Keys that would resolve outside of the bucket are rejected.
+func (c *fsClient) objectPath(bucket string, key string) (string, error) {
+	bucketPath, err := c.bucketPath(bucket)
+	if err != nil {
+		return "", err
+	}
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") || path.Clean(key) != key ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(bucketPath, filepath.FromSlash(key)), nil
+}
+
+func (c *fsClient) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	objectPath, err := c.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(objectPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *fsClient) HeadObject(ctx context.Context, bucket string, key string) (*int64, error) {
+	objectPath, err := c.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(objectPath)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	return &size, nil
+}
+
+func (c *fsClient) UploadObject(ctx context.Context, bucket string, key string, data []byte, tags ...Tag) error {
+	objectPath, err := c.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	return c.writeAtomically(objectPath, data)
+}
+
+// writeAtomically writes data to a staging file, and renames it to the given path once it is durable.
+func (c *fsClient) writeAtomically(objectPath string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(objectPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", objectPath, err)
+	}
+
+	file, err := os.CreateTemp(filepath.Join(c.root, stagingDirName), "object-*")
+	if err != nil {
+		return fmt.Errorf("failed to create staging file: %w", err)
+	}
+	stagingPath := file.Name()
+	defer func() {
+		// a no-op once the staging file is renamed
+		_ = os.Remove(stagingPath)
+	}()
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write staging file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to sync staging file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close staging file: %w", err)
+	}
+	if err := os.Rename(stagingPath, objectPath); err != nil {
+		return fmt.Errorf("failed to move staging file to %s: %w", objectPath, err)
+	}
+	return nil
+}
+
+func (c *fsClient) CopyObject(
+	ctx context.Context,
+	bucket string,
+	sourceKey string,
+	destinationKey string,
+	tags ...Tag) error {
+
+	data, err := c.DownloadObject(ctx, bucket, sourceKey)
+	if err != nil {
+		return err
+	}
+	return c.UploadObject(ctx, bucket, destinationKey, data, tags...)
+}
+
+func (c *fsClient) DeleteObject(ctx context.Context, bucket string, key string) error {
+	objectPath, err := c.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	// like S3, deleting an object that does not exist succeeds
+	if err := os.Remove(objectPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (c *fsClient) ListObjects(ctx context.Context, bucket string, prefix string) ([]Object, error) {
+	bucketPath, err := c.bucketPath(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the directory holding the keys with the prefix needs to be walked
+	walkRoot := bucketPath
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		walkRoot = filepath.Join(bucketPath, filepath.FromSlash(prefix[:i]))
+	}
+	if walkRoot != bucketPath && !strings.HasPrefix(walkRoot, bucketPath+string(filepath.Separator)) {
+		return nil, fmt.Errorf("invalid prefix %q", prefix)
+	}
+
+	objects := make([]Object, 0)
+	err = filepath.WalkDir(walkRoot, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(bucketPath, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relativePath)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{
+			Key:  key,
+			Size: info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+func (c *fsClient) CreateBucket(ctx context.Context, bucket string) error {
+	bucketPath, err := c.bucketPath(bucket)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(bucketPath, 0o755)
+}
+
+func (c *fsClient) FragmentedUploadObject(
+	ctx context.Context,
+	bucket string,
+	key string,
+	data []byte,
+	fragmentSize int,
+	tags ...Tag) error {
+
+	fragments, err := BreakIntoFragments(key, data, fragmentSize)
+	if err != nil {
+		return err
+	}
+	for _, fragment := range fragments {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := c.UploadObject(ctx, bucket, fragment.FragmentKey, fragment.Data, tags...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *fsClient) FragmentedDownloadObject(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int) ([]byte, error) {
+	if fileSize <= 0 {
+		return nil, errors.New("fileSize must be greater than 0")
+	}
+
+	if fragmentSize <= 0 {
+		return nil, errors.New("fragmentSize must be greater than 0")
+	}
+
+	fragmentKeys, err := GetFragmentKeys(key, getFragmentCount(fileSize, fragmentSize))
+	if err != nil {
+		return nil, err
+	}
+
+	fragments := make([]*Fragment, len(fragmentKeys))
+	for i, fragmentKey := range fragmentKeys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := c.DownloadObject(ctx, bucket, fragmentKey)
+		if err != nil {
+			return nil, err
+		}
+		fragments[i] = &Fragment{
+			FragmentKey: fragmentKey,
+			Data:        data,
+			Index:       i,
+		}
+	}
+
+	return recombineFragments(fragments)
+}
@@ -0,0 +1,117 @@
+package s3_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemClient(t *testing.T) {
+	tu.InitializeRandom()
+	ctx := context.Background()
+	root := t.TempDir()
+
+	config := aws.DefaultClientConfig()
+	config.LocalStoragePath = root
+	client, err := s3.NewClient(ctx, *config, logging.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, client.CreateBucket(ctx, "bucket"))
+
+	blobKey := corev2.BlobKey{1, 2, 3}
+	key := s3.ScopedBlobKey(blobKey)
+	data := tu.RandomBytes(100)
+
+	_, err = client.DownloadObject(ctx, "bucket", key)
+	require.ErrorIs(t, err, s3.ErrObjectNotFound)
+	_, err = client.HeadObject(ctx, "bucket", key)
+	require.ErrorIs(t, err, s3.ErrObjectNotFound)
+
+	require.NoError(t, client.UploadObject(ctx, "bucket", key, data))
+	downloaded, err := client.DownloadObject(ctx, "bucket", key)
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+	size, err := client.HeadObject(ctx, "bucket", key)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(data)), *size)
+
+	// objects are plain files under the bucket directory
+	stored, err := os.ReadFile(filepath.Join(root, "bucket", filepath.FromSlash(key)))
+	require.NoError(t, err)
+	require.Equal(t, data, stored)
+
+	// overwriting replaces the object, and leaves no staging files behind
+	data = tu.RandomBytes(50)
+	require.NoError(t, client.UploadObject(ctx, "bucket", key, data))
+	downloaded, err = client.DownloadObject(ctx, "bucket", key)
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+	staged, err := os.ReadDir(filepath.Join(root, ".staging"))
+	require.NoError(t, err)
+	require.Empty(t, staged)
+
+	// objects can be copied, including onto themselves
+	copyKey := s3.ScopedBlobKey(corev2.BlobKey{4, 5, 6})
+	require.NoError(t, client.CopyObject(ctx, "bucket", key, copyKey))
+	downloaded, err = client.DownloadObject(ctx, "bucket", copyKey)
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+	require.NoError(t, client.CopyObject(ctx, "bucket", key, key))
+	downloaded, err = client.DownloadObject(ctx, "bucket", key)
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+	err = client.CopyObject(ctx, "bucket", s3.ScopedBlobKey(corev2.BlobKey{7}), copyKey)
+	require.ErrorIs(t, err, s3.ErrObjectNotFound)
+	require.NoError(t, client.DeleteObject(ctx, "bucket", copyKey))
+
+	// fragments use the same keys as in S3
+	chunkKey := s3.ScopedChunkKey(blobKey)
+	chunks := tu.RandomBytes(250)
+	require.NoError(t, client.FragmentedUploadObject(ctx, "bucket", chunkKey, chunks, 100))
+	downloaded, err = client.FragmentedDownloadObject(ctx, "bucket", chunkKey, len(chunks), 100)
+	require.NoError(t, err)
+	require.Equal(t, chunks, downloaded)
+
+	objects, err := client.ListObjects(ctx, "bucket", chunkKey)
+	require.NoError(t, err)
+	keys := make([]string, len(objects))
+	for i, object := range objects {
+		keys[i] = object.Key
+	}
+	expectedKeys, err := s3.GetFragmentKeys(chunkKey, 3)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, expectedKeys, keys)
+
+	objects, err = client.ListObjects(ctx, "bucket", "")
+	require.NoError(t, err)
+	assert.Len(t, objects, 4)
+	objects, err = client.ListObjects(ctx, "bucket", "nonexistent/prefix")
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+
+	// deleting is idempotent
+	require.NoError(t, client.DeleteObject(ctx, "bucket", key))
+	require.NoError(t, client.DeleteObject(ctx, "bucket", key))
+	_, err = client.DownloadObject(ctx, "bucket", key)
+	require.ErrorIs(t, err, s3.ErrObjectNotFound)
+	_, err = client.FragmentedDownloadObject(ctx, "bucket", key, 100, 100)
+	require.Error(t, err)
+
+	// keys and buckets cannot escape the root directory
+	for _, invalidKey := range []string{"", "/abs", "../escape", "a/../../escape", "a//b", "dir/"} {
+		require.Error(t, client.UploadObject(ctx, "bucket", invalidKey, data), invalidKey)
+	}
+	for _, invalidBucket := range []string{"", ".staging", "a/b", ".."} {
+		require.Error(t, client.UploadObject(ctx, invalidBucket, key, data), invalidBucket)
+	}
+	_, err = client.ListObjects(ctx, "bucket", "../other/")
+	require.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package s3
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// RetentionTierTagKey is the key of the tag holding the retention tier of the blob an object belongs to.
+const RetentionTierTagKey = "eigenda-retention-tier"
+
+// RetentionTierTags returns the tags of the objects of a blob with the given retention tier.
+func RetentionTierTags(tier corev2.RetentionTier) []Tag {
+	return []Tag{{Key: RetentionTierTagKey, Value: tier.String()}}
+}
+
+// RetentionLifecycleRules returns bucket lifecycle rules that expire the objects of each retention tier once the
+// tier's retention period has passed, given the standard retention period. S3 expires objects by the earliest
+// matching rule, so a bucket using these rules must not also have a rule expiring all objects.
+//
+// The rules are not applied by EigenDA components, which never configure the buckets they use. Operators install
+// them on the blob store bucket of the disperser, with PutBucketLifecycleConfiguration or
+// `aws s3api put-bucket-lifecycle-configuration`, using the on-chain TTL of v2 blobs as the standard retention
+// period. One rule per tier matches the RetentionTierTagKey tag with the tier name (standard, extended, archival)
+// and expires objects after the tier's retention period, rounded up to whole days. Without the rules, objects of
+// blobs that are never deleted through the blob store are kept forever.
+func RetentionLifecycleRules(standard time.Duration) []types.LifecycleRule {
+	rules := make([]types.LifecycleRule, 0, int(corev2.MaxRetentionTier)+1)
+	for tier := corev2.RetentionTierStandard; tier <= corev2.MaxRetentionTier; tier++ {
+		// S3 expires objects in whole days
+		days := int32(math.Ceil(tier.Retention(standard).Hours() / 24))
+		rules = append(rules, types.LifecycleRule{
+			ID:     aws.String(fmt.Sprintf("expire-%s", tier)),
+			Status: types.ExpirationStatusEnabled,
+			Filter: &types.LifecycleRuleFilterMemberTag{
+				Value: types.Tag{
+					Key:   aws.String(RetentionTierTagKey),
+					Value: aws.String(tier.String()),
+				},
+			},
+			Expiration: &types.LifecycleExpiration{
+				Days: aws.Int32(days),
+			},
+		})
+	}
+	return rules
+}
+
+// encodeTags encodes tags as the URL query parameters expected by S3, or returns nil if there are no tags.
+func encodeTags(tags []Tag) *string {
+	if len(tags) == 0 {
+		return nil
+	}
+	values := url.Values{}
+	for _, tag := range tags {
+		values.Add(tag.Key, tag.Value)
+	}
+	return aws.String(values.Encode())
+}
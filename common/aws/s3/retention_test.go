@@ -0,0 +1,41 @@
+package s3
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionLifecycleRules(t *testing.T) {
+	rules := RetentionLifecycleRules(36 * time.Hour)
+	require.Len(t, rules, int(corev2.MaxRetentionTier)+1)
+
+	expectedDays := map[string]int32{
+		"standard": 2,
+		"extended": 3,
+		"archival": 6,
+	}
+	for _, rule := range rules {
+		filter, ok := rule.Filter.(*types.LifecycleRuleFilterMemberTag)
+		require.True(t, ok)
+		assert.Equal(t, RetentionTierTagKey, *filter.Value.Key)
+		assert.Equal(t, types.ExpirationStatusEnabled, rule.Status)
+		assert.Equal(t, expectedDays[*filter.Value.Value], *rule.Expiration.Days, *filter.Value.Value)
+	}
+}
+
+func TestEncodeTags(t *testing.T) {
+	assert.Nil(t, encodeTags(nil))
+
+	encoded := encodeTags([]Tag{{Key: "a", Value: "1"}, {Key: "b c", Value: "2&3"}})
+	require.NotNil(t, encoded)
+	values, err := url.ParseQuery(*encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "1", values.Get("a"))
+	assert.Equal(t, "2&3", values.Get("b c"))
+}
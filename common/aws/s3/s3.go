@@ -2,6 +2,12 @@ package s3
 
 import "context"
 
+// Tag is a tag of an S3 object. Tags can be used to filter the objects that bucket lifecycle rules apply to.
+type Tag struct {
+	Key   string
+	Value string
+}
+
 // Client encapsulates the functionality of an S3 client.
 type Client interface {
 
@@ -11,8 +17,13 @@ type Client interface {
 	// HeadObject retrieves the size of an object in S3. Returns error if the object does not exist.
 	HeadObject(ctx context.Context, bucket string, key string) (*int64, error)
 
-	// UploadObject uploads an object to S3.
-	UploadObject(ctx context.Context, bucket string, key string, data []byte) error
+	// UploadObject uploads an object to S3, tagged with the given tags.
+	UploadObject(ctx context.Context, bucket string, key string, data []byte, tags ...Tag) error
+
+	// CopyObject copies an object within a bucket without downloading it, tagging the copy with the given tags rather
+	// than the tags of the source. The source and destination keys may be the same, which restarts the lifetime of
+	// the object under the bucket lifecycle rules. Returns ErrObjectNotFound if the source does not exist.
+	CopyObject(ctx context.Context, bucket string, sourceKey string, destinationKey string, tags ...Tag) error
 
 	// DeleteObject deletes an object from S3.
 	DeleteObject(ctx context.Context, bucket string, key string) error
@@ -34,13 +45,14 @@ type Client interface {
 	//
 	// Note: if this operation fails partway through, some file fragments may have made it to S3 and others may not.
 	// In order to prevent long term accumulation of fragments, it is suggested to use this method in conjunction with
-	// a bucket configured to have a TTL.
+	// a bucket configured to have a TTL. Every fragment is tagged with the given tags.
 	FragmentedUploadObject(
 		ctx context.Context,
 		bucket string,
 		key string,
 		data []byte,
-		fragmentSize int) error
+		fragmentSize int,
+		tags ...Tag) error
 
 	// FragmentedDownloadObject downloads a file from S3, as written by Upload. The fileSize (in bytes) and fragmentSize
 	// must be the same as the values used in the FragmentedUploadObject call.
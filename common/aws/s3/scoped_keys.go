@@ -21,6 +21,21 @@ const (
 
 	// proofNamespace is the namespace for a proof key.
 	proofNamespace = "proof"
+
+	// blobReferenceNamespace is the namespace for the key of the object naming the content of a blob.
+	blobReferenceNamespace = "blobref"
+
+	// contentNamespace is the namespace for a content key.
+	contentNamespace = "content"
+
+	// contentReferenceNamespace is the namespace for the keys of the references to a content.
+	contentReferenceNamespace = "contentref"
+
+	// encodingNamespace is the namespace for an encoding key.
+	encodingNamespace = "encoding"
+
+	// encodingReferenceNamespace is the namespace for the key of the object naming the encoding of a blob.
+	encodingReferenceNamespace = "encodingref"
 )
 
 // ScopedKey returns a key that is scoped to a "namespace". Keys take the form of "prefix/namespace/baseKey".
@@ -54,3 +69,38 @@ func ScopedChunkKey(blobKey corev2.BlobKey) string {
 func ScopedProofKey(blobKey corev2.BlobKey) string {
 	return ScopedKey(proofNamespace, blobKey.Hex(), prefixLength)
 }
+
+// ScopedBlobReferenceKey returns a key scoped to the blob reference namespace. Used to name files holding the
+// content hash of a blob whose bytes are stored under ScopedContentKey.
+func ScopedBlobReferenceKey(blobKey corev2.BlobKey) string {
+	return ScopedKey(blobReferenceNamespace, blobKey.Hex(), prefixLength)
+}
+
+// ScopedContentKey returns a key scoped to the content namespace. Used to name files containing blob bytes, which
+// are shared by all blobs with the same content hash.
+func ScopedContentKey(contentHash string) string {
+	return ScopedKey(contentNamespace, contentHash, prefixLength)
+}
+
+// ScopedContentReferencePrefix returns the prefix of the keys of every reference to a content. Listing the objects
+// with this prefix yields the blobs that reference the content.
+func ScopedContentReferencePrefix(contentHash string) string {
+	return ScopedKey(contentReferenceNamespace, contentHash, prefixLength) + "/"
+}
+
+// ScopedContentReferenceKey returns the key of the reference of a blob to a content.
+func ScopedContentReferenceKey(contentHash string, blobKey corev2.BlobKey) string {
+	return ScopedContentReferencePrefix(contentHash) + blobKey.Hex()
+}
+
+// ScopedEncodingKey returns a key scoped to the encoding namespace. Used to name files recording which blob holds
+// the chunks of an encoding, so that the chunks can be reused by other blobs with the same encoding.
+func ScopedEncodingKey(encodingHash string) string {
+	return ScopedKey(encodingNamespace, encodingHash, prefixLength)
+}
+
+// ScopedEncodingReferenceKey returns a key scoped to the encoding reference namespace. Used to name files holding the
+// encoding hash of the chunks of a blob, so that the encoding recorded for the blob can be deleted with its chunks.
+func ScopedEncodingReferenceKey(blobKey corev2.BlobKey) string {
+	return ScopedKey(encodingReferenceNamespace, blobKey.Hex(), prefixLength)
+}
@@ -49,6 +49,7 @@ var clientBuilders = []*clientBuilder{
 			return nil
 		},
 	},
+	newFilesystemClientBuilder(),
 	{
 		start: func() error {
 			return setupLocalstack()
@@ -92,6 +93,40 @@ var clientBuilders = []*clientBuilder{
 	},
 }
 
+func newFilesystemClientBuilder() *clientBuilder {
+	var root string
+	return &clientBuilder{
+		start: func() error {
+			var err error
+			root, err = os.MkdirTemp("", "s3-filesystem-client")
+			return err
+		},
+		build: func() (s3.Client, error) {
+			logger, err := common.NewLogger(common.DefaultLoggerConfig())
+			if err != nil {
+				return nil, err
+			}
+
+			config := aws.DefaultClientConfig()
+			config.LocalStoragePath = root
+			client, err := s3.NewClient(context.Background(), *config, logger)
+			if err != nil {
+				return nil, err
+			}
+
+			err = client.CreateBucket(context.Background(), bucket)
+			if err != nil {
+				return nil, err
+			}
+
+			return client, nil
+		},
+		finish: func() error {
+			return os.RemoveAll(root)
+		},
+	}
+}
+
 func setupLocalstack() error {
 	deployLocalStack := !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
 
@@ -11,6 +11,10 @@ var (
 	privateKeyFlagName       = "chain.private-key"
 	numConfirmationsFlagName = "chain.num-confirmations"
 	numRetriesFlagName       = "chain.num-retries"
+
+	quorumReadMethodsFlagName = "chain.quorum-read-methods"
+	quorumReadSizeFlagName    = "chain.quorum-read-size"
+	quorumReadMaxLagFlagName  = "chain.quorum-read-max-lag"
 )
 
 type EthClientConfig struct {
@@ -18,6 +22,7 @@ type EthClientConfig struct {
 	PrivateKeyString string
 	NumConfirmations int
 	NumRetries       int
+	QuorumRead       QuorumReadConfig
 }
 
 func EthClientFlags(envPrefix string) []cli.Flag {
@@ -55,6 +60,34 @@ func EthClientFlags(envPrefix string) []cli.Flag {
 			Value:    2,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_RETRIES"),
 		},
+		cli.StringSliceFlag{
+			Name:     quorumReadMethodsFlagName,
+			Usage:    "RPC methods to read from several providers and compare (BlockNumber, HeaderByNumber, TransactionReceipt, CallContract)",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "CHAIN_QUORUM_READ_METHODS"),
+		},
+		cli.IntFlag{
+			Name:     quorumReadSizeFlagName,
+			Usage:    "Number of providers queried by each quorum read. Quorum reads are disabled if less than 2",
+			Required: false,
+			Value:    0,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "CHAIN_QUORUM_READ_SIZE"),
+		},
+		cli.Uint64Flag{
+			Name:     quorumReadMaxLagFlagName,
+			Usage:    "Number of blocks a provider may lag behind the others in a quorum read before it is faulted",
+			Required: false,
+			Value:    2,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "CHAIN_QUORUM_READ_MAX_LAG"),
+		},
+	}
+}
+
+func readQuorumReadConfig(ctx *cli.Context) QuorumReadConfig {
+	return QuorumReadConfig{
+		Methods: ctx.GlobalStringSlice(quorumReadMethodsFlagName),
+		Size:    ctx.GlobalInt(quorumReadSizeFlagName),
+		MaxLag:  ctx.GlobalUint64(quorumReadMaxLagFlagName),
 	}
 }
 
@@ -64,6 +97,7 @@ func ReadEthClientConfig(ctx *cli.Context) EthClientConfig {
 	cfg.PrivateKeyString = ctx.GlobalString(privateKeyFlagName)
 	cfg.NumConfirmations = ctx.GlobalInt(numConfirmationsFlagName)
 	cfg.NumRetries = ctx.GlobalInt(numRetriesFlagName)
+	cfg.QuorumRead = readQuorumReadConfig(ctx)
 
 	fallbackRPCURL := ctx.GlobalString(rpcFallbackUrlFlagName)
 	if len(fallbackRPCURL) > 0 {
@@ -80,6 +114,7 @@ func ReadEthClientConfigRPCOnly(ctx *cli.Context) EthClientConfig {
 	cfg.RPCURLs = ctx.GlobalStringSlice(rpcUrlFlagName)
 	cfg.NumConfirmations = ctx.GlobalInt(numConfirmationsFlagName)
 	cfg.NumRetries = ctx.GlobalInt(numRetriesFlagName)
+	cfg.QuorumRead = readQuorumReadConfig(ctx)
 
 	fallbackRPCURL := ctx.GlobalString(rpcFallbackUrlFlagName)
 	if len(fallbackRPCURL) > 0 {
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type MultiHomingClient struct {
@@ -21,6 +22,8 @@ type MultiHomingClient struct {
 	lastRPCIndex uint64
 	*FailoverController
 	mu sync.Mutex
+	// quorumReads is nil unless quorum reads are enabled
+	quorumReads *quorumReader
 }
 
 var _ dacommon.EthClient = (*MultiHomingClient)(nil)
@@ -63,9 +66,36 @@ func NewMultiHomingClient(config EthClientConfig, senderAddress gethcommon.Addre
 		client.RPCs = append(client.RPCs, rpc)
 	}
 
+	if err := client.EnableQuorumReads(config.QuorumRead); err != nil {
+		return nil, err
+	}
+
 	return client, nil
 }
 
+// EnableQuorumReads makes the configured methods query several providers and compare their responses, instead of
+// only querying the provider in use. Providers that fail, disagree with the others, or lag behind are recorded in
+// the fault metrics, see RegisterMetrics.
+func (m *MultiHomingClient) EnableQuorumReads(config QuorumReadConfig) error {
+	quorumReads, err := newQuorumReader(config, m.UrlDomains, m.Logger)
+	if err != nil {
+		return err
+	}
+	if quorumReads != nil {
+		m.Logger.Info("Quorum reads enabled", "methods", config.Methods, "size", config.Size)
+	}
+	m.quorumReads = quorumReads
+	return nil
+}
+
+// RegisterMetrics registers the quorum read metrics with the registry. It is a no-op if quorum reads are disabled.
+func (m *MultiHomingClient) RegisterMetrics(registry *prometheus.Registry) {
+	if m.quorumReads == nil {
+		return
+	}
+	registry.MustRegister(m.quorumReads.faults, m.quorumReads.results)
+}
+
 func (m *MultiHomingClient) GetRPCInstance() (int, dacommon.EthClient) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -99,6 +129,22 @@ func (m *MultiHomingClient) SuggestGasTipCap(ctx context.Context) (*big.Int, err
 }
 
 func (m *MultiHomingClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if m.quorumReads.enabled(QuorumReadHeaderByNumber) {
+		call := func(ctx context.Context, instance dacommon.EthClient) (*types.Header, error) {
+			return instance.HeaderByNumber(ctx, number)
+		}
+		if number == nil {
+			return quorumReadMax(ctx, m, QuorumReadHeaderByNumber, call, func(header *types.Header) uint64 {
+				return header.Number.Uint64()
+			})
+		}
+		return quorumRead(ctx, m, QuorumReadHeaderByNumber, call, func(header *types.Header) string {
+			return header.Hash().Hex()
+		}, func(header *types.Header) uint64 {
+			return header.Number.Uint64()
+		})
+	}
+
 	var errLast error
 	for i := 0; i < m.NumRetries+1; i++ {
 		rpcIndex, instance := m.GetRPCInstance()
@@ -155,6 +201,22 @@ func (m *MultiHomingClient) SendTransaction(ctx context.Context, tx *types.Trans
 }
 
 func (m *MultiHomingClient) TransactionReceipt(ctx context.Context, txHash gethcommon.Hash) (*types.Receipt, error) {
+	if m.quorumReads.enabled(QuorumReadTransactionReceipt) {
+		call := func(ctx context.Context, instance dacommon.EthClient) (*types.Receipt, error) {
+			return instance.TransactionReceipt(ctx, txHash)
+		}
+		return quorumRead(ctx, m, QuorumReadTransactionReceipt, call, func(receipt *types.Receipt) string {
+			// the consensus encoding does not cover the block the receipt was included in
+			encoded, err := receipt.MarshalBinary()
+			if err != nil {
+				return err.Error()
+			}
+			return hashKey(encoded, receipt.BlockHash[:])
+		}, func(receipt *types.Receipt) uint64 {
+			return receipt.BlockNumber.Uint64()
+		})
+	}
+
 	var errLast error
 	for i := 0; i < m.NumRetries+1; i++ {
 		rpcIndex, instance := m.GetRPCInstance()
@@ -174,6 +236,15 @@ func (m *MultiHomingClient) TransactionReceipt(ctx context.Context, txHash gethc
 }
 
 func (m *MultiHomingClient) BlockNumber(ctx context.Context) (uint64, error) {
+	if m.quorumReads.enabled(QuorumReadBlockNumber) {
+		call := func(ctx context.Context, instance dacommon.EthClient) (uint64, error) {
+			return instance.BlockNumber(ctx)
+		}
+		return quorumReadMax(ctx, m, QuorumReadBlockNumber, call, func(blockNumber uint64) uint64 {
+			return blockNumber
+		})
+	}
+
 	var errLast error
 	for i := 0; i < m.NumRetries+1; i++ {
 		rpcIndex, instance := m.GetRPCInstance()
@@ -255,6 +326,31 @@ func (m *MultiHomingClient) CallContract(
 	call ethereum.CallMsg,
 	blockNumber *big.Int,
 ) ([]byte, error) {
+	if m.quorumReads.enabled(QuorumReadCallContract) {
+		if blockNumber == nil {
+			// Providers a block apart disagree on the latest state, so the call is pinned to the highest block.
+			head, err := quorumReadMax(ctx, m, QuorumReadCallContract, func(ctx context.Context, instance dacommon.EthClient) (uint64, error) {
+				return instance.BlockNumber(ctx)
+			}, func(blockNumber uint64) uint64 {
+				return blockNumber
+			})
+			if err != nil {
+				return nil, err
+			}
+			blockNumber = new(big.Int).SetUint64(head)
+		}
+		var height func([]byte) uint64
+		if blockNumber.Sign() >= 0 {
+			// negative block numbers are tags, such as pending, which no provider lags behind
+			height = func([]byte) uint64 {
+				return blockNumber.Uint64()
+			}
+		}
+		return quorumRead(ctx, m, QuorumReadCallContract, func(ctx context.Context, instance dacommon.EthClient) ([]byte, error) {
+			return instance.CallContract(ctx, call, blockNumber)
+		}, bytesKey, height)
+	}
+
 	var errLast error
 	for i := 0; i < m.NumRetries+1; i++ {
 		rpcIndex, instance := m.GetRPCInstance()
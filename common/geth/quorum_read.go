@@ -0,0 +1,346 @@
+package geth
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	dacommon "github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The methods that support quorum reads.
+const (
+	QuorumReadBlockNumber        = "BlockNumber"
+	QuorumReadHeaderByNumber     = "HeaderByNumber"
+	QuorumReadTransactionReceipt = "TransactionReceipt"
+	QuorumReadCallContract       = "CallContract"
+)
+
+// ErrNoQuorum is returned by a quorum read when no response was returned by a majority of the queried providers.
+var ErrNoQuorum = errors.New("rpc providers did not reach a quorum")
+
+// The reasons a provider is faulted in a quorum read.
+const (
+	faultError        = "error"
+	faultDisagreement = "disagreement"
+	faultLagging      = "lagging"
+)
+
+// QuorumReadConfig configures the MultiHomingClient to query several providers for the configured methods, so that
+// a provider that returns stale or wrong data is detected.
+type QuorumReadConfig struct {
+	// Methods are the methods that are read from a quorum of providers. Reads of the latest block number or header
+	// return the highest block among the providers, and contract calls on the latest state are pinned to that block.
+	// Other reads return the response of a majority of the providers that are not lagging behind, or ErrNoQuorum.
+	Methods []string
+	// Size is the number of providers queried by each quorum read. Quorum reads are disabled if it is less than 2.
+	Size int
+	// MaxLag is the number of blocks a provider may be behind the highest block before it is faulted as lagging.
+	MaxLag uint64
+}
+
+// quorumReader runs the quorum reads of a MultiHomingClient.
+type quorumReader struct {
+	methods map[string]struct{}
+	size    int
+	maxLag  uint64
+	// providers holds the URL domain of each provider, which labels the fault metrics
+	providers []string
+
+	faults  *prometheus.CounterVec
+	results *prometheus.CounterVec
+	logger  logging.Logger
+}
+
+func newQuorumReader(config QuorumReadConfig, providers []string, logger logging.Logger) (*quorumReader, error) {
+	if config.Size < 2 || len(config.Methods) == 0 {
+		return nil, nil
+	}
+	if config.Size > len(providers) {
+		return nil, fmt.Errorf("quorum read size %d exceeds the number of rpc providers %d", config.Size, len(providers))
+	}
+
+	methods := make(map[string]struct{}, len(config.Methods))
+	for _, method := range config.Methods {
+		switch method {
+		case QuorumReadBlockNumber, QuorumReadHeaderByNumber, QuorumReadTransactionReceipt, QuorumReadCallContract:
+			methods[method] = struct{}{}
+		default:
+			return nil, fmt.Errorf("quorum reads are not supported for method %s", method)
+		}
+	}
+
+	return &quorumReader{
+		methods:   methods,
+		size:      config.Size,
+		maxLag:    config.MaxLag,
+		providers: providers,
+		faults: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "eth_rpc_quorum_read_fault_count",
+				Help: "Number of quorum reads in which a provider returned an error, disagreed with the quorum, or lagged behind",
+			},
+			[]string{"provider", "method", "reason"},
+		),
+		results: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "eth_rpc_quorum_read_count",
+				Help: "Number of quorum reads, by whether the providers reached a quorum",
+			},
+			[]string{"method", "result"},
+		),
+		logger: logger,
+	}, nil
+}
+
+// enabled returns whether the method is read from a quorum of providers. It is safe to call on a nil quorumReader.
+func (q *quorumReader) enabled(method string) bool {
+	if q == nil {
+		return false
+	}
+	_, ok := q.methods[method]
+	return ok
+}
+
+func (q *quorumReader) reportFault(rpcIndex int, method string, reason string) {
+	q.faults.WithLabelValues(q.providers[rpcIndex], method, reason).Inc()
+}
+
+// quorumResponse is the response of a provider to a quorum read.
+type quorumResponse[T any] struct {
+	rpcIndex int
+	value    T
+	err      error
+}
+
+// queryProviders calls the next q.size providers, starting from the provider currently in use, concurrently.
+func queryProviders[T any](
+	ctx context.Context,
+	m *MultiHomingClient,
+	call func(ctx context.Context, instance dacommon.EthClient) (T, error),
+) []quorumResponse[T] {
+	start, _ := m.GetRPCInstance()
+
+	responses := make([]quorumResponse[T], m.quorumReads.size)
+	var wg sync.WaitGroup
+	for i := range responses {
+		rpcIndex := (start + i) % len(m.RPCs)
+		wg.Add(1)
+		go func(i int, rpcIndex int) {
+			defer wg.Done()
+			value, err := call(ctx, m.RPCs[rpcIndex])
+			responses[i] = quorumResponse[T]{rpcIndex: rpcIndex, value: value, err: err}
+		}(i, rpcIndex)
+	}
+	wg.Wait()
+	return responses
+}
+
+// quorumRead returns the response returned by a majority of the queried providers, comparing the responses by key.
+// A not found error is a valid response, on which the providers may agree, unless it comes from a provider whose
+// latest block is below the block of the other responses, as returned by height: such a provider is lagging, and does
+// not take part in the vote. Errors are handled by the FailoverController, and the read is retried up to NumRetries
+// times while errors keep the providers from reaching a quorum.
+func quorumRead[T any](
+	ctx context.Context,
+	m *MultiHomingClient,
+	method string,
+	call func(ctx context.Context, instance dacommon.EthClient) (T, error),
+	key func(T) string,
+	height func(T) uint64,
+) (T, error) {
+	q := m.quorumReads
+
+	var zero T
+	var errLast error
+	for attempt := 0; attempt < m.NumRetries+1; attempt++ {
+		responses := queryProviders(ctx, m, call)
+
+		const notFoundKey = "not found"
+		keys := make([]string, len(responses))
+		retry := false
+		for i, response := range responses {
+			switch {
+			case response.err == nil:
+				keys[i] = key(response.value)
+			case isNotFound(response.err):
+				keys[i] = notFoundKey
+			default:
+				errLast = response.err
+				q.reportFault(response.rpcIndex, method, faultError)
+				q.logger.Warn("Quorum read failed on provider", "method", method, "provider", q.providers[response.rpcIndex], "err", response.err)
+				if !m.ProcessError(response.err, response.rpcIndex, method) {
+					retry = true
+				}
+			}
+		}
+
+		lagging := excludeLagging(ctx, m, method, responses, keys, notFoundKey, height)
+
+		counts := make(map[string]int)
+		for _, k := range keys {
+			if k != "" {
+				counts[k]++
+			}
+		}
+
+		// the lagging providers cannot answer, so the majority is taken among the others
+		threshold := (q.size - lagging) / 2
+		for i, response := range responses {
+			if keys[i] == "" || counts[keys[i]] <= threshold {
+				continue
+			}
+
+			// a majority of the providers returned this response, the others disagree
+			for j, other := range responses {
+				if keys[j] != "" && keys[j] != keys[i] {
+					q.reportFault(other.rpcIndex, method, faultDisagreement)
+					q.logger.Warn("Provider disagreed with the quorum", "method", method, "provider", q.providers[other.rpcIndex])
+				}
+			}
+			q.results.WithLabelValues(method, "agreed").Inc()
+			if keys[i] == notFoundKey {
+				return zero, response.err
+			}
+			return response.value, nil
+		}
+
+		if retry && ctx.Err() == nil {
+			continue
+		}
+		q.results.WithLabelValues(method, "no_quorum").Inc()
+		if len(counts) == 0 {
+			return zero, errLast
+		}
+		return zero, fmt.Errorf("%w for %s: %d distinct responses from %d providers", ErrNoQuorum, method, len(counts), q.size)
+	}
+
+	q.results.WithLabelValues(method, "no_quorum").Inc()
+	return zero, errLast
+}
+
+// excludeLagging clears the key of the providers that returned not found because their latest block is below the
+// lowest block of the other responses, and returns how many there are.
+func excludeLagging[T any](
+	ctx context.Context,
+	m *MultiHomingClient,
+	method string,
+	responses []quorumResponse[T],
+	keys []string,
+	notFoundKey string,
+	height func(T) uint64,
+) int {
+	if height == nil {
+		return 0
+	}
+	q := m.quorumReads
+
+	lowest := uint64(math.MaxUint64)
+	for _, response := range responses {
+		if response.err == nil {
+			lowest = min(lowest, height(response.value))
+		}
+	}
+	if lowest == math.MaxUint64 {
+		return 0
+	}
+
+	lagging := 0
+	for i, response := range responses {
+		if keys[i] != notFoundKey {
+			continue
+		}
+		head, err := m.RPCs[response.rpcIndex].BlockNumber(ctx)
+		if err != nil || head >= lowest {
+			// the provider has the block, so its answer stands
+			continue
+		}
+		keys[i] = ""
+		lagging++
+		q.reportFault(response.rpcIndex, method, faultLagging)
+		q.logger.Warn("Provider is lagging behind", "method", method, "provider", q.providers[response.rpcIndex], "block", head, "expected", lowest)
+	}
+	return lagging
+}
+
+// quorumReadMax returns the response with the highest block among the queried providers. The providers that are
+// more than q.maxLag blocks behind it are faulted as lagging. Errors are handled by the FailoverController, and the
+// read is retried up to NumRetries times while no provider answers.
+func quorumReadMax[T any](
+	ctx context.Context,
+	m *MultiHomingClient,
+	method string,
+	call func(ctx context.Context, instance dacommon.EthClient) (T, error),
+	height func(T) uint64,
+) (T, error) {
+	q := m.quorumReads
+
+	var zero T
+	var errLast error
+	for attempt := 0; attempt < m.NumRetries+1; attempt++ {
+		responses := queryProviders(ctx, m, call)
+
+		retry := false
+		best := -1
+		for i, response := range responses {
+			if response.err != nil {
+				errLast = response.err
+				q.reportFault(response.rpcIndex, method, faultError)
+				q.logger.Warn("Quorum read failed on provider", "method", method, "provider", q.providers[response.rpcIndex], "err", response.err)
+				if !m.ProcessError(response.err, response.rpcIndex, method) {
+					retry = true
+				}
+				continue
+			}
+			if best < 0 || height(response.value) > height(responses[best].value) {
+				best = i
+			}
+		}
+
+		if best < 0 {
+			if retry && ctx.Err() == nil {
+				continue
+			}
+			break
+		}
+
+		highest := height(responses[best].value)
+		for _, response := range responses {
+			if response.err == nil && height(response.value)+q.maxLag < highest {
+				q.reportFault(response.rpcIndex, method, faultLagging)
+				q.logger.Warn("Provider is lagging behind", "method", method, "provider", q.providers[response.rpcIndex], "block", height(response.value), "highest", highest)
+			}
+		}
+		q.results.WithLabelValues(method, "agreed").Inc()
+		return responses[best].value, nil
+	}
+
+	q.results.WithLabelValues(method, "no_quorum").Inc()
+	return zero, errLast
+}
+
+// isNotFound returns whether a provider reported that it does not have the requested data, including the block a read
+// is pinned to.
+func isNotFound(err error) bool {
+	if errors.Is(err, ethereum.NotFound) {
+		return true
+	}
+	message := err.Error()
+	return strings.Contains(message, "header not found") || strings.Contains(message, "unknown block")
+}
+
+func hashKey(data ...[]byte) string {
+	return crypto.Keccak256Hash(data...).Hex()
+}
+
+func bytesKey(data []byte) string {
+	return hex.EncodeToString(data)
+}
@@ -0,0 +1,192 @@
+package geth_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/geth"
+	damock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// makeQuorumReadClient creates a client over one mock per rpc URL, with quorum reads of all the providers enabled
+// for the given methods.
+func makeQuorumReadClient(t *testing.T, methods ...string) (*geth.MultiHomingClient, []*damock.MockEthClient, *prometheus.Registry) {
+	return makeQuorumReadClientOfSize(t, len(rpcURLs), methods...)
+}
+
+// makeQuorumReadClientOfSize creates a client like makeQuorumReadClient, with quorum reads of the given size.
+func makeQuorumReadClientOfSize(t *testing.T, size int, methods ...string) (*geth.MultiHomingClient, []*damock.MockEthClient, *prometheus.Registry) {
+	logger := logging.NewNoopLogger()
+	controller, err := geth.NewFailoverController(logger, rpcURLs)
+	require.NoError(t, err)
+
+	client := &geth.MultiHomingClient{
+		Logger:             logger,
+		NumRetries:         2,
+		FailoverController: controller,
+	}
+	mocks := make([]*damock.MockEthClient, len(rpcURLs))
+	for i := range rpcURLs {
+		mocks[i] = &damock.MockEthClient{}
+		client.RPCs = append(client.RPCs, mocks[i])
+	}
+
+	err = client.EnableQuorumReads(geth.QuorumReadConfig{
+		Methods: methods,
+		Size:    size,
+		MaxLag:  2,
+	})
+	require.NoError(t, err)
+	registry := prometheus.NewRegistry()
+	client.RegisterMetrics(registry)
+	return client, mocks, registry
+}
+
+func TestQuorumReadBlockNumber(t *testing.T) {
+	client, mocks, registry := makeQuorumReadClient(t, geth.QuorumReadBlockNumber)
+	mocks[0].On("BlockNumber").Return(uint64(100))
+	mocks[1].On("BlockNumber").Return(uint64(102))
+	mocks[2].On("BlockNumber").Return(uint64(99))
+
+	blockNumber, err := client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(102), blockNumber)
+
+	// only the provider more than 2 blocks behind is faulted
+	count, err := testutil.GatherAndCount(registry, "eth_rpc_quorum_read_fault_count")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestQuorumReadCallContract(t *testing.T) {
+	client, mocks, registry := makeQuorumReadClient(t, geth.QuorumReadCallContract)
+	for _, m := range mocks {
+		m.On("BlockNumber").Return(uint64(100))
+	}
+	mocks[0].On("CallContract").Return([]byte{1}, nil)
+	mocks[1].On("CallContract").Return([]byte{2}, nil)
+	mocks[2].On("CallContract").Return([]byte{1}, nil)
+
+	result, err := client.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, result)
+	count, err := testutil.GatherAndCount(registry, "eth_rpc_quorum_read_fault_count")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	// no response is returned by a majority of the providers
+	client, mocks, _ = makeQuorumReadClient(t, geth.QuorumReadCallContract)
+	for i, m := range mocks {
+		m.On("BlockNumber").Return(uint64(100))
+		m.On("CallContract").Return([]byte{byte(i)}, nil)
+	}
+	_, err = client.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	require.ErrorIs(t, err, geth.ErrNoQuorum)
+}
+
+func TestQuorumReadCallContractPinsLatestBlock(t *testing.T) {
+	client, mocks, registry := makeQuorumReadClient(t, geth.QuorumReadCallContract)
+	mocks[0].On("BlockNumber").Return(uint64(101))
+	mocks[1].On("BlockNumber").Return(uint64(101))
+	mocks[2].On("BlockNumber").Return(uint64(100))
+	mocks[0].On("CallContract").Return([]byte{1}, nil)
+	mocks[1].On("CallContract").Return([]byte{1}, nil)
+	mocks[2].On("CallContract").Return([]byte(nil), errors.New("header not found"))
+
+	// the provider a block behind cannot answer at the highest block, which is lag rather than disagreement
+	result, err := client.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, result)
+	require.Equal(t, 1.0, faultCount(t, registry, "lagging"))
+	require.Equal(t, uint64(0), client.GetTotalNumberRpcFault())
+
+	// with two providers, one lagging provider does not prevent a quorum
+	client, mocks, _ = makeQuorumReadClientOfSize(t, 2, geth.QuorumReadCallContract)
+	mocks[0].On("BlockNumber").Return(uint64(101))
+	mocks[1].On("BlockNumber").Return(uint64(100))
+	mocks[0].On("CallContract").Return([]byte{1}, nil)
+	mocks[1].On("CallContract").Return([]byte(nil), errors.New("header not found"))
+	result, err = client.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, result)
+}
+
+func TestQuorumReadRetriesErrors(t *testing.T) {
+	client, mocks, _ := makeQuorumReadClient(t, geth.QuorumReadCallContract)
+	for _, m := range mocks {
+		m.On("BlockNumber").Return(uint64(100))
+	}
+	mocks[0].On("CallContract").Return([]byte(nil), errors.New("connection reset")).Once()
+	mocks[0].On("CallContract").Return([]byte{1}, nil)
+	mocks[1].On("CallContract").Return([]byte{1}, nil)
+	mocks[2].On("CallContract").Return([]byte{2}, nil)
+
+	// the error keeps the providers from a quorum, so it is handled by the failover controller and the read retried
+	result, err := client.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, result)
+	require.Equal(t, uint64(1), client.GetTotalNumberRpcFault())
+	mocks[0].AssertNumberOfCalls(t, "CallContract", 2)
+}
+
+func TestQuorumReadTransactionReceipt(t *testing.T) {
+	client, mocks, _ := makeQuorumReadClient(t, geth.QuorumReadTransactionReceipt)
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(10)}
+	mocks[0].On("TransactionReceipt", mock.Anything).Return(receipt, nil)
+	mocks[1].On("TransactionReceipt", mock.Anything).Return(nil, ethereum.NotFound)
+	mocks[2].On("TransactionReceipt", mock.Anything).Return(nil, ethereum.NotFound)
+	mocks[1].On("BlockNumber").Return(uint64(12))
+	mocks[2].On("BlockNumber").Return(uint64(12))
+
+	// a majority of the providers have seen the block of the receipt, but not the transaction
+	_, err := client.TransactionReceipt(context.Background(), receipt.TxHash)
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// the providers that have not seen the block of the receipt yet are lagging
+	client, mocks, registry := makeQuorumReadClient(t, geth.QuorumReadTransactionReceipt)
+	mocks[0].On("TransactionReceipt", mock.Anything).Return(receipt, nil)
+	mocks[1].On("TransactionReceipt", mock.Anything).Return(nil, ethereum.NotFound)
+	mocks[2].On("TransactionReceipt", mock.Anything).Return(nil, ethereum.NotFound)
+	mocks[1].On("BlockNumber").Return(uint64(9))
+	mocks[2].On("BlockNumber").Return(uint64(9))
+	result, err := client.TransactionReceipt(context.Background(), receipt.TxHash)
+	require.NoError(t, err)
+	require.Equal(t, receipt, result)
+	require.Equal(t, 2.0, faultCount(t, registry, "lagging"))
+}
+
+// faultCount returns the number of quorum read faults with the given reason, across providers and methods.
+func faultCount(t *testing.T, registry *prometheus.Registry, reason string) float64 {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	total := 0.0
+	for _, family := range families {
+		if family.GetName() != "eth_rpc_quorum_read_fault_count" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "reason" && label.GetValue() == reason {
+					total += metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return total
+}
+
+func TestQuorumReadConfig(t *testing.T) {
+	client, _ := makeTestMultihomingClient(2, nil)
+	require.Error(t, client.EnableQuorumReads(geth.QuorumReadConfig{Methods: []string{"SendTransaction"}, Size: 2}))
+	require.Error(t, client.EnableQuorumReads(geth.QuorumReadConfig{Methods: []string{geth.QuorumReadBlockNumber}, Size: 4}))
+	require.NoError(t, client.EnableQuorumReads(geth.QuorumReadConfig{Methods: []string{geth.QuorumReadBlockNumber}, Size: 1}))
+}
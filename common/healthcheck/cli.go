@@ -0,0 +1,68 @@
+package healthcheck
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	httpPortFlagName      = "health.http-port"
+	checkIntervalFlagName = "health.check-interval"
+	probeTimeoutFlagName  = "health.probe-timeout"
+	maxBlockAgeFlagName   = "health.max-block-age"
+)
+
+type Config struct {
+	// HTTPPort is the port of the /healthz and /readyz endpoints. They are not served if empty.
+	HTTPPort string
+	// CheckInterval is how often the probes run.
+	CheckInterval time.Duration
+	// ProbeTimeout bounds each run of a probe.
+	ProbeTimeout time.Duration
+	// MaxBlockAge is the age of the latest block past which the eth RPC is considered unhealthy.
+	MaxBlockAge time.Duration
+}
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     httpPortFlagName,
+			Usage:    "Port of the /healthz and /readyz HTTP endpoints. They are not served if empty",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "HEALTH_HTTP_PORT"),
+		},
+		cli.DurationFlag{
+			Name:     checkIntervalFlagName,
+			Usage:    "How often the health probes of the dependencies run",
+			Required: false,
+			Value:    defaultCheckInterval,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "HEALTH_CHECK_INTERVAL"),
+		},
+		cli.DurationFlag{
+			Name:     probeTimeoutFlagName,
+			Usage:    "Timeout of each run of a health probe",
+			Required: false,
+			Value:    defaultProbeTimeout,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "HEALTH_PROBE_TIMEOUT"),
+		},
+		cli.DurationFlag{
+			Name:     maxBlockAgeFlagName,
+			Usage:    "Age of the latest block served by the chain rpc past which it is considered unhealthy",
+			Required: false,
+			Value:    2 * time.Minute,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "HEALTH_MAX_BLOCK_AGE"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context) Config {
+	return Config{
+		HTTPPort:      ctx.GlobalString(httpPortFlagName),
+		CheckInterval: ctx.GlobalDuration(checkIntervalFlagName),
+		ProbeTimeout:  ctx.GlobalDuration(probeTimeoutFlagName),
+		MaxBlockAge:   ctx.GlobalDuration(maxBlockAgeFlagName),
+	}
+}
@@ -0,0 +1,104 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HeaderReader reads block headers, e.g. a geth.MultiHomingClient.
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// BlockFreshnessProbe checks that the latest block served by an eth client is at most maxAge old. It fails if the RPC
+// endpoint is unreachable, or if it lags behind the chain.
+func BlockFreshnessProbe(client HeaderReader, maxAge time.Duration) Probe {
+	return func(ctx context.Context) error {
+		header, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block header: %w", err)
+		}
+		age := time.Since(time.Unix(int64(header.Time), 0))
+		if age > maxAge {
+			return fmt.Errorf("latest block %d is %v old, more than %v", header.Number.Uint64(), age.Round(time.Second), maxAge)
+		}
+		return nil
+	}
+}
+
+// GRPCReachabilityProbe checks that a gRPC server answers health checks. The serving status it reports is ignored,
+// since a server that is busy or degraded is still reachable.
+func GRPCReachabilityProbe(address string) Probe {
+	return func(ctx context.Context) error {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("failed to dial %s: %w", address, err)
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		if err != nil {
+			return fmt.Errorf("%s is unreachable: %w", address, err)
+		}
+		return nil
+	}
+}
+
+// SaturationProbe checks that the usage of a bounded resource, e.g. a request queue, is below the given fraction of
+// its capacity.
+func SaturationProbe(usage func() (used int, capacity int), threshold float64) Probe {
+	return func(ctx context.Context) error {
+		used, capacity := usage()
+		if capacity <= 0 {
+			return nil
+		}
+		if float64(used) >= threshold*float64(capacity) {
+			return fmt.Errorf("%d of %d in use, at least %.0f%% of capacity", used, capacity, threshold*100)
+		}
+		return nil
+	}
+}
+
+// Heartbeat records the last success of a periodic task, e.g. the refresh of on-chain state.
+type Heartbeat struct {
+	last atomic.Int64
+}
+
+// Beat records a success of the task.
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+// Last returns the time of the last success of the task, or zero if it never succeeded.
+func (h *Heartbeat) Last() time.Time {
+	last := h.last.Load()
+	if last == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, last)
+}
+
+// Probe checks that the task succeeded within the last maxAge.
+func (h *Heartbeat) Probe(maxAge time.Duration) Probe {
+	return func(ctx context.Context) error {
+		last := h.Last()
+		if last.IsZero() {
+			return errors.New("never succeeded")
+		}
+		if age := time.Since(last); age > maxAge {
+			return fmt.Errorf("last succeeded %v ago, more than %v", age.Round(time.Second), maxAge)
+		}
+		return nil
+	}
+}
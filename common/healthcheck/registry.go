@@ -0,0 +1,302 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Probe checks one dependency of a service, and returns an error if the dependency is unhealthy.
+type Probe func(ctx context.Context) error
+
+// ProbeKind says what a failing probe means for the service.
+type ProbeKind string
+
+const (
+	// Readiness probes check the dependencies a service needs to serve requests, e.g. a database or an RPC endpoint.
+	// A failing readiness probe means the service should not receive traffic until the dependency recovers.
+	Readiness ProbeKind = "readiness"
+	// Liveness probes check that the service itself makes progress, e.g. that a background loop still runs. A
+	// failing liveness probe means the service should be restarted. It also fails readiness.
+	Liveness ProbeKind = "liveness"
+	// Detail probes report the state of the service, e.g. how loaded it is, alongside the readiness probes. They never
+	// fail liveness or readiness, since a busy service should still receive traffic.
+	Detail ProbeKind = "detail"
+)
+
+const (
+	defaultCheckInterval = 10 * time.Second
+	defaultProbeTimeout  = 5 * time.Second
+)
+
+// ProbeStatus is the outcome of the latest run of a probe.
+type ProbeStatus struct {
+	Name    string    `json:"name"`
+	Kind    ProbeKind `json:"kind"`
+	Healthy bool      `json:"healthy"`
+	// Error is the error returned by the probe, if it failed.
+	Error string `json:"error,omitempty"`
+	// LastChecked is when the probe last ran, or zero if it never ran.
+	LastChecked time.Time `json:"lastChecked"`
+	// LastHealthy is when the probe last succeeded, or zero if it never succeeded.
+	LastHealthy time.Time `json:"lastHealthy"`
+	// Latency is how long the latest run of the probe took.
+	Latency time.Duration `json:"latency"`
+}
+
+// Report is the aggregate health of the probes of a registry.
+type Report struct {
+	Healthy bool          `json:"healthy"`
+	Probes  []ProbeStatus `json:"probes"`
+}
+
+type registeredProbe struct {
+	probe  Probe
+	status ProbeStatus
+}
+
+// Registry runs the health probes registered by the components of a service, and reports their aggregate health
+// through the gRPC health service and through the /healthz and /readyz HTTP endpoints.
+//
+// Probes run periodically in the background once the registry is started, so health requests never wait for a
+// dependency. Until its first run, a probe is reported as unhealthy.
+type Registry struct {
+	logger        logging.Logger
+	checkInterval time.Duration
+	probeTimeout  time.Duration
+
+	mu            sync.RWMutex
+	probes        []*registeredProbe
+	healthServers []*namedHealthServer
+}
+
+type namedHealthServer struct {
+	name   string
+	server *health.Server
+}
+
+// NewRegistry creates a registry that runs its probes every checkInterval, each with the given timeout. Zero values
+// select defaults of 10s and 5s.
+func NewRegistry(logger logging.Logger, checkInterval time.Duration, probeTimeout time.Duration) *Registry {
+	if checkInterval <= 0 {
+		checkInterval = defaultCheckInterval
+	}
+	if probeTimeout <= 0 {
+		probeTimeout = defaultProbeTimeout
+	}
+	return &Registry{
+		logger:        logger.With("component", "HealthRegistry"),
+		checkInterval: checkInterval,
+		probeTimeout:  probeTimeout,
+	}
+}
+
+// Register adds a named probe to the registry. Probe names should be unique, e.g. "BlobMetadataStore".
+func (r *Registry) Register(name string, kind ProbeKind, probe Probe) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.probes = append(r.probes, &registeredProbe{
+		probe: probe,
+		status: ProbeStatus{
+			Name:  name,
+			Kind:  kind,
+			Error: "not checked yet",
+		},
+	})
+}
+
+// RegisterHealthServer registers a gRPC health server with the given gRPC server, whose serving status follows the
+// readiness of the registry. On a nil registry, it falls back to RegisterHealthServer, which always reports SERVING.
+func (r *Registry) RegisterHealthServer(name string, server *grpc.Server) {
+	if r == nil {
+		RegisterHealthServer(name, server)
+		return
+	}
+
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.healthServers = append(r.healthServers, &namedHealthServer{name: name, server: healthServer})
+	setServingStatus(healthServer, name, r.report(nil).Healthy)
+}
+
+// Start runs the probes once, and then periodically until the context is cancelled.
+func (r *Registry) Start(ctx context.Context) {
+	r.Check(ctx)
+	go func() {
+		ticker := time.NewTicker(r.checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.Check(ctx)
+			}
+		}
+	}()
+}
+
+// Check runs every probe concurrently, records their outcomes, and updates the gRPC health servers.
+func (r *Registry) Check(ctx context.Context) {
+	r.mu.RLock()
+	probes := append([]*registeredProbe{}, r.probes...)
+	r.mu.RUnlock()
+
+	statuses := make([]ProbeStatus, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func(i int, p *registeredProbe) {
+			defer wg.Done()
+			statuses[i] = r.runProbe(ctx, p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, p := range probes {
+		previous := p.status
+		p.status = statuses[i]
+		if !p.status.Healthy {
+			p.status.LastHealthy = previous.LastHealthy
+			if previous.Healthy || previous.LastChecked.IsZero() {
+				r.logger.Warn("health probe failed", "probe", p.status.Name, "kind", p.status.Kind, "err", p.status.Error)
+			}
+		} else if !previous.Healthy && !previous.LastChecked.IsZero() {
+			r.logger.Info("health probe recovered", "probe", p.status.Name, "kind", p.status.Kind)
+		}
+	}
+
+	ready := r.report(nil).Healthy
+	for _, s := range r.healthServers {
+		setServingStatus(s.server, s.name, ready)
+	}
+}
+
+// runProbe runs a single probe with the probe timeout. A panicking probe is reported as unhealthy.
+func (r *Registry) runProbe(ctx context.Context, p *registeredProbe) (status ProbeStatus) {
+	ctx, cancel := context.WithTimeout(ctx, r.probeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	status = ProbeStatus{
+		Name:        p.status.Name,
+		Kind:        p.status.Kind,
+		LastChecked: start,
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			status.Healthy = false
+			status.Error = fmt.Sprintf("probe panicked: %v", recovered)
+		}
+		status.Latency = time.Since(start)
+	}()
+
+	if err := p.probe(ctx); err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Healthy = true
+	status.LastHealthy = start
+	return status
+}
+
+// Liveness reports the liveness probes. The service is live if every liveness probe succeeded.
+func (r *Registry) Liveness() Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	kind := Liveness
+	return r.report(&kind)
+}
+
+// Readiness reports every probe. The service is ready if every probe other than the detail probes succeeded.
+func (r *Registry) Readiness() Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.report(nil)
+}
+
+// report aggregates the probes of the given kind, or every probe if kind is nil. The caller must hold the lock.
+func (r *Registry) report(kind *ProbeKind) Report {
+	report := Report{Healthy: true, Probes: []ProbeStatus{}}
+	for _, p := range r.probes {
+		if kind != nil && p.status.Kind != *kind {
+			continue
+		}
+		report.Probes = append(report.Probes, p.status)
+		if p.status.Kind != Detail {
+			report.Healthy = report.Healthy && p.status.Healthy
+		}
+	}
+	return report
+}
+
+// RegisterHTTPHandlers serves the liveness report at /healthz and the readiness report at /readyz. Both return 200
+// if healthy and 503 otherwise, with the status of each probe as JSON.
+func (r *Registry) RegisterHTTPHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeReport(w, r.Liveness())
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		writeReport(w, r.Readiness())
+	})
+}
+
+// StartHTTPServer serves the health endpoints on the given port until the context is cancelled. It does nothing if the
+// port is empty.
+func (r *Registry) StartHTTPServer(ctx context.Context, httpPort string) {
+	if httpPort == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	r.RegisterHTTPHandlers(mux)
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%s", httpPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	go func() {
+		r.logger.Info("Starting health server", "port", httpPort)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			r.logger.Error("health server failed", "err", err)
+		}
+	}()
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	if report.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+func setServingStatus(server *health.Server, name string, serving bool) {
+	status := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	// The empty service name reports the health of the server as a whole.
+	server.SetServingStatus("", status)
+	server.SetServingStatus(name, status)
+}
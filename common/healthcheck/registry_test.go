@@ -0,0 +1,138 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestRegistryAggregatesProbes(t *testing.T) {
+	registry := NewRegistry(logging.NewNoopLogger(), time.Hour, time.Second)
+
+	var storeErr error
+	registry.Register("BlobMetadataStore", Readiness, func(ctx context.Context) error { return storeErr })
+	registry.Register("RefreshLoop", Liveness, func(ctx context.Context) error { return nil })
+	server := grpc.NewServer()
+	registry.RegisterHealthServer("test.Service", server)
+
+	// probes are unhealthy until they first run
+	assert.False(t, registry.Readiness().Healthy)
+	assert.False(t, registry.Liveness().Healthy)
+	assertServing(t, registry, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	registry.Check(context.Background())
+	assert.True(t, registry.Readiness().Healthy)
+	assert.True(t, registry.Liveness().Healthy)
+	assertServing(t, registry, grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// a failing readiness probe does not fail liveness
+	storeErr = errors.New("table not found")
+	registry.Check(context.Background())
+	readiness := registry.Readiness()
+	assert.False(t, readiness.Healthy)
+	require.Len(t, readiness.Probes, 2)
+	assert.Equal(t, "table not found", readiness.Probes[0].Error)
+	assert.False(t, readiness.Probes[0].LastHealthy.IsZero())
+	assert.True(t, registry.Liveness().Healthy)
+	assertServing(t, registry, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestRegistryDetailProbes(t *testing.T) {
+	registry := NewRegistry(logging.NewNoopLogger(), time.Hour, time.Second)
+	registry.Register("BlobMetadataStore", Readiness, func(ctx context.Context) error { return nil })
+	registry.Register("EncoderQueue", Detail, func(ctx context.Context) error { return errors.New("saturated") })
+	server := grpc.NewServer()
+	registry.RegisterHealthServer("test.Service", server)
+
+	// a failing detail probe is reported, but the service stays ready
+	registry.Check(context.Background())
+	readiness := registry.Readiness()
+	assert.True(t, readiness.Healthy)
+	require.Len(t, readiness.Probes, 2)
+	assert.Equal(t, Detail, readiness.Probes[1].Kind)
+	assert.Equal(t, "saturated", readiness.Probes[1].Error)
+	assert.True(t, registry.Liveness().Healthy)
+	assertServing(t, registry, grpc_health_v1.HealthCheckResponse_SERVING)
+}
+
+func TestRegistryProbeTimeoutAndPanic(t *testing.T) {
+	registry := NewRegistry(logging.NewNoopLogger(), time.Hour, 10*time.Millisecond)
+	registry.Register("Slow", Readiness, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	registry.Register("Panicking", Readiness, func(ctx context.Context) error {
+		panic("boom")
+	})
+
+	registry.Check(context.Background())
+	report := registry.Readiness()
+	assert.False(t, report.Healthy)
+	assert.Contains(t, report.Probes[0].Error, "deadline exceeded")
+	assert.Contains(t, report.Probes[1].Error, "boom")
+}
+
+func TestRegistryHTTPHandlers(t *testing.T) {
+	registry := NewRegistry(logging.NewNoopLogger(), time.Hour, time.Second)
+	registry.Register("EthClient", Readiness, func(ctx context.Context) error { return errors.New("stale") })
+	registry.Register("RefreshLoop", Liveness, func(ctx context.Context) error { return nil })
+	registry.Check(context.Background())
+
+	mux := http.NewServeMux()
+	registry.RegisterHTTPHandlers(mux)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	report := Report{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.False(t, report.Healthy)
+	require.Len(t, report.Probes, 2)
+	assert.Equal(t, "EthClient", report.Probes[0].Name)
+	assert.Equal(t, "stale", report.Probes[0].Error)
+}
+
+func TestHeartbeatProbe(t *testing.T) {
+	heartbeat := &Heartbeat{}
+	probe := heartbeat.Probe(time.Minute)
+	assert.Error(t, probe(context.Background()))
+
+	heartbeat.Beat()
+	assert.NoError(t, probe(context.Background()))
+
+	heartbeat.last.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	assert.Error(t, probe(context.Background()))
+}
+
+func TestSaturationProbe(t *testing.T) {
+	used := 0
+	probe := SaturationProbe(func() (int, int) { return used, 10 }, 0.9)
+	assert.NoError(t, probe(context.Background()))
+
+	used = 9
+	assert.Error(t, probe(context.Background()))
+}
+
+func assertServing(t *testing.T, registry *Registry, expected grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	for _, service := range []string{"", "test.Service"} {
+		reply, err := registry.healthServers[0].server.Check(
+			context.Background(),
+			&grpc_health_v1.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, expected, reply.GetStatus())
+	}
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+// Default limits of a SymbolOpeningLimiter. Opening a symbol costs a polynomial division over the whole blob and an
+// MSM, so the budgets are counted in opened symbols rather than requests.
+const (
+	DefaultMaxSymbolOpeningsPerSecond       = 1024
+	DefaultSymbolOpeningsBurstiness         = 1024
+	DefaultMaxSymbolOpeningsPerSecondClient = 32
+	DefaultSymbolOpeningsBurstinessClient   = 128
+)
+
+// maxTrackedOpeningClients is the maximum number of clients whose budgets are tracked. When exceeded, the least
+// recently seen client is forgotten, and starts over with a full budget when seen again.
+const maxTrackedOpeningClients = 65536
+
+// SymbolOpeningLimitConfig is the configuration of a SymbolOpeningLimiter. Zero values are replaced by the defaults.
+type SymbolOpeningLimitConfig struct {
+	// MaxSymbolOpeningsPerSecond is the maximum number of symbols opened per second, over all clients.
+	MaxSymbolOpeningsPerSecond float64
+	// SymbolOpeningsBurstiness is the maximum number of symbols opened at once, over all clients.
+	SymbolOpeningsBurstiness int
+	// MaxSymbolOpeningsPerSecondClient is the maximum number of symbols opened per second for a single client.
+	MaxSymbolOpeningsPerSecondClient float64
+	// SymbolOpeningsBurstinessClient is the maximum number of symbols opened at once for a single client. It must
+	// allow the largest request, or such requests are always rejected.
+	SymbolOpeningsBurstinessClient int
+}
+
+// SymbolOpeningLimiter enforces global and per-client budgets on the number of symbols opened by symbol proof
+// requests. Every opened symbol is charged, whether it is proven separately or in a batch. A nil
+// SymbolOpeningLimiter does not limit anything.
+type SymbolOpeningLimiter struct {
+	config SymbolOpeningLimitConfig
+
+	// global enforces the budget over all clients.
+	global *rate.Limiter
+	// clients holds the budget of each client, keyed by client ID.
+	clients *lru.Cache[string, *rate.Limiter]
+
+	// lock makes checking and charging both budgets atomic
+	lock sync.Mutex
+}
+
+// NewSymbolOpeningLimiter creates a new SymbolOpeningLimiter.
+func NewSymbolOpeningLimiter(config SymbolOpeningLimitConfig) (*SymbolOpeningLimiter, error) {
+	if config.MaxSymbolOpeningsPerSecond == 0 {
+		config.MaxSymbolOpeningsPerSecond = DefaultMaxSymbolOpeningsPerSecond
+	}
+	if config.SymbolOpeningsBurstiness == 0 {
+		config.SymbolOpeningsBurstiness = DefaultSymbolOpeningsBurstiness
+	}
+	if config.MaxSymbolOpeningsPerSecondClient == 0 {
+		config.MaxSymbolOpeningsPerSecondClient = DefaultMaxSymbolOpeningsPerSecondClient
+	}
+	if config.SymbolOpeningsBurstinessClient == 0 {
+		config.SymbolOpeningsBurstinessClient = DefaultSymbolOpeningsBurstinessClient
+	}
+
+	clients, err := lru.New[string, *rate.Limiter](maxTrackedOpeningClients)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client cache: %w", err)
+	}
+
+	return &SymbolOpeningLimiter{
+		config:  config,
+		global:  rate.NewLimiter(rate.Limit(config.MaxSymbolOpeningsPerSecond), config.SymbolOpeningsBurstiness),
+		clients: clients,
+	}, nil
+}
+
+// AllowOpenings charges the given number of opened symbols to the budgets of the client and of all clients. If it
+// returns an error, nothing is charged and the symbols should not be opened.
+func (l *SymbolOpeningLimiter) AllowOpenings(now time.Time, clientID string, openings int) error {
+	if l == nil {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	client, ok := l.clients.Get(clientID)
+	if !ok {
+		client = rate.NewLimiter(
+			rate.Limit(l.config.MaxSymbolOpeningsPerSecondClient),
+			l.config.SymbolOpeningsBurstinessClient)
+		l.clients.Add(clientID, client)
+	}
+
+	if client.TokensAt(now) < float64(openings) {
+		return fmt.Errorf("client limit of %0.1f symbol openings per second exceeded, try again later",
+			l.config.MaxSymbolOpeningsPerSecondClient)
+	}
+	if l.global.TokensAt(now) < float64(openings) {
+		return fmt.Errorf("global limit of %0.1f symbol openings per second exceeded, try again later",
+			l.config.MaxSymbolOpeningsPerSecond)
+	}
+
+	client.AllowN(now, openings)
+	l.global.AllowN(now, openings)
+	return nil
+}
@@ -0,0 +1,37 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymbolOpeningLimiter(t *testing.T) {
+	limiter, err := ratelimit.NewSymbolOpeningLimiter(ratelimit.SymbolOpeningLimitConfig{
+		MaxSymbolOpeningsPerSecond:       10,
+		SymbolOpeningsBurstiness:         20,
+		MaxSymbolOpeningsPerSecondClient: 1,
+		SymbolOpeningsBurstinessClient:   8,
+	})
+	require.NoError(t, err)
+	now := time.Now()
+
+	// every opening is charged, so a client runs out of budget after a few requests
+	require.NoError(t, limiter.AllowOpenings(now, "a", 5))
+	require.Error(t, limiter.AllowOpenings(now, "a", 4))
+	require.NoError(t, limiter.AllowOpenings(now, "a", 3))
+	require.Error(t, limiter.AllowOpenings(now, "a", 1))
+
+	// other clients have their own budget, but share the global one
+	require.NoError(t, limiter.AllowOpenings(now, "b", 8))
+	require.Error(t, limiter.AllowOpenings(now, "c", 8))
+
+	// the budget of a client refills over time
+	require.NoError(t, limiter.AllowOpenings(now.Add(2*time.Second), "a", 2))
+
+	// a nil limiter does not limit anything
+	var nilLimiter *ratelimit.SymbolOpeningLimiter
+	require.NoError(t, nilLimiter.AllowOpenings(now, "a", 1000))
+}
@@ -16,6 +16,9 @@ import (
 
 var (
 	privateKeyHex = "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	paymentStateTimestamp = uint64(1_700_000_000_000_000_000)
+	paymentStateNonce     = []byte{1, 2, 3, 4, 5, 6, 7, 8}
 )
 
 func TestAuthentication(t *testing.T) {
@@ -121,20 +124,55 @@ func TestAuthenticatePaymentStateRequestValid(t *testing.T) {
 	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
 	authenticator := auth.NewAuthenticator()
 
-	signature, err := signer.SignPaymentStateRequest()
+	signature, err := signer.SignPaymentStateRequest(paymentStateTimestamp, paymentStateNonce)
+	assert.NoError(t, err)
+
+	accountId, err := signer.GetAccountID()
+	assert.NoError(t, err)
+
+	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId, paymentStateTimestamp, paymentStateNonce)
 	assert.NoError(t, err)
+}
+
+func TestAuthenticatePaymentStateRequestLegacy(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	authenticator := auth.NewAuthenticator()
 
 	accountId, err := signer.GetAccountID()
 	assert.NoError(t, err)
 
-	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId)
+	// legacy clients sign over the account ID only
+	hash := sha256.Sum256([]byte(accountId))
+	signature, err := crypto.Sign(hash[:], signer.PrivateKey)
+	assert.NoError(t, err)
+
+	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId, 0, nil)
+	assert.NoError(t, err)
+
+	// a legacy signature cannot be used with a timestamp and nonce
+	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId, paymentStateTimestamp, paymentStateNonce)
+	assert.Error(t, err)
+}
+
+func TestAuthenticatePaymentStateRequestWrongTimestamp(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	authenticator := auth.NewAuthenticator()
+
+	signature, err := signer.SignPaymentStateRequest(paymentStateTimestamp, paymentStateNonce)
+	assert.NoError(t, err)
+
+	accountId, err := signer.GetAccountID()
 	assert.NoError(t, err)
+
+	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId, paymentStateTimestamp+1, paymentStateNonce)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "signature doesn't match with provided public key")
 }
 
 func TestAuthenticatePaymentStateRequestInvalidSignatureLength(t *testing.T) {
 	authenticator := auth.NewAuthenticator()
 
-	err := authenticator.AuthenticatePaymentStateRequest([]byte{1, 2, 3}, "0x123")
+	err := authenticator.AuthenticatePaymentStateRequest([]byte{1, 2, 3}, "0x123", paymentStateTimestamp, paymentStateNonce)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "signature length is unexpected")
 }
@@ -142,7 +180,7 @@ func TestAuthenticatePaymentStateRequestInvalidSignatureLength(t *testing.T) {
 func TestAuthenticatePaymentStateRequestInvalidPublicKey(t *testing.T) {
 	authenticator := auth.NewAuthenticator()
 
-	err := authenticator.AuthenticatePaymentStateRequest(make([]byte, 65), "not-hex-encoded")
+	err := authenticator.AuthenticatePaymentStateRequest(make([]byte, 65), "not-hex-encoded", paymentStateTimestamp, paymentStateNonce)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to recover public key from signature")
 }
@@ -158,10 +196,10 @@ func TestAuthenticatePaymentStateRequestSignatureMismatch(t *testing.T) {
 	accountId, err := signer.GetAccountID()
 	assert.NoError(t, err)
 
-	signature, err := wrongSigner.SignPaymentStateRequest()
+	signature, err := wrongSigner.SignPaymentStateRequest(paymentStateTimestamp, paymentStateNonce)
 	assert.NoError(t, err)
 
-	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId)
+	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId, paymentStateTimestamp, paymentStateNonce)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "signature doesn't match with provided public key")
 }
@@ -173,13 +211,43 @@ func TestAuthenticatePaymentStateRequestCorruptedSignature(t *testing.T) {
 	accountId, err := signer.GetAccountID()
 	assert.NoError(t, err)
 
-	hash := sha256.Sum256([]byte(accountId))
+	hash := auth.PaymentStateRequestHash(accountId, paymentStateTimestamp, paymentStateNonce)
 	signature, err := crypto.Sign(hash[:], signer.PrivateKey)
 	assert.NoError(t, err)
 
 	// Corrupt the signature
 	signature[0] ^= 0x01
 
-	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId)
+	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId, paymentStateTimestamp, paymentStateNonce)
+	assert.Error(t, err)
+}
+
+func TestAuthenticateGetChunksRequest(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	authenticator := auth.NewAuthenticator()
+
+	blobKey := []byte{1, 2, 3, 4}
+	quorumID := uint32(1)
+
+	signature, err := signer.SignGetChunksRequest(blobKey, quorumID, paymentStateTimestamp)
+	assert.NoError(t, err)
+
+	accountId, err := signer.GetAccountID()
+	assert.NoError(t, err)
+
+	err = authenticator.AuthenticateGetChunksRequest(signature, accountId, blobKey, quorumID, paymentStateTimestamp)
+	assert.NoError(t, err)
+
+	// the signature does not cover another quorum, blob or time
+	err = authenticator.AuthenticateGetChunksRequest(signature, accountId, blobKey, quorumID+1, paymentStateTimestamp)
+	assert.Error(t, err)
+	err = authenticator.AuthenticateGetChunksRequest(signature, accountId, []byte{1, 2, 3, 5}, quorumID, paymentStateTimestamp)
+	assert.Error(t, err)
+	err = authenticator.AuthenticateGetChunksRequest(signature, accountId, blobKey, quorumID, paymentStateTimestamp+1)
 	assert.Error(t, err)
+
+	// nor another account
+	err = authenticator.AuthenticateGetChunksRequest(signature, "0x0000000000000000000000000000000000000001", blobKey, quorumID, paymentStateTimestamp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "signature doesn't match with provided public key")
 }
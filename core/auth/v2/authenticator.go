@@ -2,6 +2,7 @@ package v2
 
 import (
 	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"fmt"
 
@@ -18,6 +19,45 @@ func NewAuthenticator() *authenticator {
 
 var _ core.BlobRequestAuthenticator = &authenticator{}
 
+// PaymentStateRequestHash returns the hash signed by a GetPaymentState request. The timestamp and nonce make every
+// request unique, so that a captured signature cannot be replayed. A request with a zero timestamp and no nonce is a
+// legacy request, and its hash covers only the account ID.
+func PaymentStateRequestHash(accountId string, timestamp uint64, nonce []byte) [32]byte {
+	if IsLegacyPaymentStateRequest(timestamp, nonce) {
+		return sha256.Sum256([]byte(accountId))
+	}
+
+	hasher := sha256.New()
+	_ = binary.Write(hasher, binary.BigEndian, uint32(len(accountId)))
+	hasher.Write([]byte(accountId))
+	_ = binary.Write(hasher, binary.BigEndian, timestamp)
+	hasher.Write(nonce)
+
+	var hash [32]byte
+	copy(hash[:], hasher.Sum(nil))
+	return hash
+}
+
+// IsLegacyPaymentStateRequest returns true if the request was signed by a client that only signs over the account ID.
+func IsLegacyPaymentStateRequest(timestamp uint64, nonce []byte) bool {
+	return timestamp == 0 && len(nonce) == 0
+}
+
+// GetChunksRequestHash returns the hash signed by an authenticated node GetChunks request. The timestamp lets nodes
+// reject old requests, so that a captured signature can only be replayed for a short time.
+func GetChunksRequestHash(accountId string, blobKey []byte, quorumID uint32, timestamp uint64) [32]byte {
+	hasher := sha256.New()
+	_ = binary.Write(hasher, binary.BigEndian, uint32(len(accountId)))
+	hasher.Write([]byte(accountId))
+	hasher.Write(blobKey)
+	_ = binary.Write(hasher, binary.BigEndian, quorumID)
+	_ = binary.Write(hasher, binary.BigEndian, timestamp)
+
+	var hash [32]byte
+	copy(hash[:], hasher.Sum(nil))
+	return hash
+}
+
 func (*authenticator) AuthenticateBlobRequest(header *core.BlobHeader) error {
 	sig := header.Signature
 
@@ -48,14 +88,49 @@ func (*authenticator) AuthenticateBlobRequest(header *core.BlobHeader) error {
 	return nil
 }
 
-func (*authenticator) AuthenticatePaymentStateRequest(sig []byte, accountId string) error {
+// AuthenticatePaymentStateRequest checks that the signature was produced by the account over the account ID, timestamp
+// and nonce of the request. It does not check that the request is fresh, or that it has not been seen before.
+func (*authenticator) AuthenticatePaymentStateRequest(sig []byte, accountId string, timestamp uint64, nonce []byte) error {
 	// Ensure the signature is 65 bytes (Recovery ID is the last byte)
 	if len(sig) != 65 {
 		return fmt.Errorf("signature length is unexpected: %d", len(sig))
 	}
 
 	// Verify the signature
-	hash := sha256.Sum256([]byte(accountId))
+	hash := PaymentStateRequestHash(accountId, timestamp, nonce)
+	sigPublicKeyECDSA, err := crypto.SigToPub(hash[:], sig)
+	if err != nil {
+		return fmt.Errorf("failed to recover public key from signature: %v", err)
+	}
+
+	accountAddr := common.HexToAddress(accountId)
+	pubKeyAddr := crypto.PubkeyToAddress(*sigPublicKeyECDSA)
+
+	if accountAddr.Cmp(pubKeyAddr) != 0 {
+		return errors.New("signature doesn't match with provided public key")
+	}
+
+	return nil
+}
+
+// AuthenticateGetChunksRequest checks that the signature was produced by the account over the blob key, quorum and
+// timestamp of a node GetChunks request. It does not check that the request is fresh.
+func (*authenticator) AuthenticateGetChunksRequest(
+	sig []byte,
+	accountId string,
+	blobKey []byte,
+	quorumID uint32,
+	timestamp uint64,
+) error {
+	// Ensure the signature is 65 bytes (Recovery ID is the last byte)
+	if len(sig) != 65 {
+		return fmt.Errorf("signature length is unexpected: %d", len(sig))
+	}
+	if !common.IsHexAddress(accountId) {
+		return fmt.Errorf("invalid account ID: %s", accountId)
+	}
+
+	hash := GetChunksRequestHash(accountId, blobKey, quorumID, timestamp)
 	sigPublicKeyECDSA, err := crypto.SigToPub(hash[:], sig)
 	if err != nil {
 		return fmt.Errorf("failed to recover public key from signature: %v", err)
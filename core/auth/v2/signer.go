@@ -2,7 +2,6 @@ package v2
 
 import (
 	"crypto/ecdsa"
-	"crypto/sha256"
 	"fmt"
 	"log"
 
@@ -44,14 +43,31 @@ func (s *LocalBlobRequestSigner) SignBlobRequest(header *core.BlobHeader) ([]byt
 	return sig, nil
 }
 
-func (s *LocalBlobRequestSigner) SignPaymentStateRequest() ([]byte, error) {
+// SignPaymentStateRequest signs the account ID along with the timestamp and nonce of the request.
+func (s *LocalBlobRequestSigner) SignPaymentStateRequest(timestamp uint64, nonce []byte) ([]byte, error) {
 	accountId, err := s.GetAccountID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account ID: %v", err)
 	}
 
-	hash := sha256.Sum256([]byte(accountId))
-	// Sign the account ID using the private key
+	hash := PaymentStateRequestHash(accountId, timestamp, nonce)
+	// Sign the request using the private key
+	sig, err := crypto.Sign(hash[:], s.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash: %v", err)
+	}
+
+	return sig, nil
+}
+
+// SignGetChunksRequest signs the blob key, quorum and timestamp of a node GetChunks request.
+func (s *LocalBlobRequestSigner) SignGetChunksRequest(blobKey []byte, quorumID uint32, timestamp uint64) ([]byte, error) {
+	accountId, err := s.GetAccountID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account ID: %v", err)
+	}
+
+	hash := GetChunksRequestHash(accountId, blobKey, quorumID, timestamp)
 	sig, err := crypto.Sign(hash[:], s.PrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign hash: %v", err)
@@ -78,10 +94,14 @@ func (s *LocalNoopSigner) SignBlobRequest(header *core.BlobHeader) ([]byte, erro
 	return nil, fmt.Errorf("noop signer cannot sign blob request")
 }
 
-func (s *LocalNoopSigner) SignPaymentStateRequest() ([]byte, error) {
+func (s *LocalNoopSigner) SignPaymentStateRequest(timestamp uint64, nonce []byte) ([]byte, error) {
 	return nil, fmt.Errorf("noop signer cannot sign payment state request")
 }
 
+func (s *LocalNoopSigner) SignGetChunksRequest(blobKey []byte, quorumID uint32, timestamp uint64) ([]byte, error) {
+	return nil, fmt.Errorf("noop signer cannot sign get chunks request")
+}
+
 func (s *LocalNoopSigner) GetAccountID() (string, error) {
 	return "", fmt.Errorf("noop signer cannot get accountID")
 }
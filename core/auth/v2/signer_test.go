@@ -103,10 +103,12 @@ func TestSignPaymentStateRequest(t *testing.T) {
 	expectedAddr := "0x1aa8226f6d354380dDE75eE6B634875c4203e522"
 	accountID, err := signer.GetAccountID()
 	require.NoError(t, err)
-	hash := sha256.Sum256([]byte(accountID))
+	timestamp := uint64(1_700_000_000_000_000_000)
+	nonce := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	hash := PaymentStateRequestHash(accountID, timestamp, nonce)
 
 	// Sign payment state request
-	signature, err := signer.SignPaymentStateRequest()
+	signature, err := signer.SignPaymentStateRequest(timestamp, nonce)
 	require.NoError(t, err)
 	require.NotNil(t, signature)
 
@@ -117,6 +119,26 @@ func TestSignPaymentStateRequest(t *testing.T) {
 	// Verify that the recovered address matches the signer's address
 	recoveredAddr := crypto.PubkeyToAddress(*pubKey).Hex()
 	assert.Equal(t, expectedAddr, recoveredAddr)
+
+	// A request without timestamp and nonce is signed over the account ID, as by legacy clients
+	signature, err = signer.SignPaymentStateRequest(0, nil)
+	require.NoError(t, err)
+	legacyHash := sha256.Sum256([]byte(accountID))
+	pubKey, err = crypto.SigToPub(legacyHash[:], signature)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddr, crypto.PubkeyToAddress(*pubKey).Hex())
+}
+
+func TestPaymentStateRequestHash(t *testing.T) {
+	accountID := "0x1aa8226f6d354380dDE75eE6B634875c4203e522"
+	nonce := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	hash := PaymentStateRequestHash(accountID, 1, nonce)
+
+	// every field is covered by the hash
+	assert.NotEqual(t, hash, PaymentStateRequestHash(accountID, 2, nonce))
+	assert.NotEqual(t, hash, PaymentStateRequestHash(accountID, 1, []byte{1, 2, 3, 4, 5, 6, 7, 9}))
+	assert.NotEqual(t, hash, PaymentStateRequestHash("0x1aa8226f6d354380dDE75eE6B634875c4203e523", 1, nonce))
+	assert.Equal(t, sha256.Sum256([]byte(accountID)), PaymentStateRequestHash(accountID, 0, nil))
 }
 
 func TestNoopSigner(t *testing.T) {
@@ -130,7 +152,7 @@ func TestNoopSigner(t *testing.T) {
 	})
 
 	t.Run("SignPaymentStateRequest", func(t *testing.T) {
-		sig, err := signer.SignPaymentStateRequest()
+		sig, err := signer.SignPaymentStateRequest(0, nil)
 		assert.Error(t, err)
 		assert.Nil(t, sig)
 		assert.Equal(t, "noop signer cannot sign payment state request", err.Error())
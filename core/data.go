@@ -545,6 +545,69 @@ func (pm *PaymentMetadata) Hash() ([32]byte, error) {
 	return hash, nil
 }
 
+// HashWithRetentionTier returns the Keccak256 hash of the PaymentMetadata extended with a retention tier. It is the
+// payment header hash of blobs with a retention tier other than the standard one, whose hash is given by Hash.
+func (pm *PaymentMetadata) HashWithRetentionTier(retentionTier uint8) ([32]byte, error) {
+	if pm == nil {
+		return [32]byte{}, errors.New("payment metadata is nil")
+	}
+	paymentHeaderType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{
+			Name: "accountID",
+			Type: "string",
+		},
+		{
+			Name: "reservationPeriod",
+			Type: "uint32",
+		},
+		{
+			Name: "cumulativePayment",
+			Type: "uint256",
+		},
+		{
+			Name: "salt",
+			Type: "uint32",
+		},
+		{
+			Name: "retentionTier",
+			Type: "uint8",
+		},
+	})
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	arguments := abi.Arguments{
+		{
+			Type: paymentHeaderType,
+		},
+	}
+
+	bytes, err := arguments.Pack(struct {
+		AccountID         string
+		ReservationPeriod uint32
+		CumulativePayment *big.Int
+		Salt              uint32
+		RetentionTier     uint8
+	}{
+		AccountID:         pm.AccountID,
+		ReservationPeriod: pm.ReservationPeriod,
+		CumulativePayment: pm.CumulativePayment,
+		Salt:              pm.Salt,
+		RetentionTier:     retentionTier,
+	})
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	var hash [32]byte
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(bytes)
+	copy(hash[:], hasher.Sum(nil)[:32])
+
+	return hash, nil
+}
+
 func (pm *PaymentMetadata) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
 	if pm == nil {
 		return nil, errors.New("payment metadata is nil")
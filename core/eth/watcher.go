@@ -0,0 +1,419 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	relayreg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDARelayRegistry"
+	eigendasrvmg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	thresholdreg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAThresholdRegistry"
+	paymentvault "github.com/Layr-Labs/eigenda/contracts/bindings/PaymentVault"
+	regcoordinator "github.com/Layr-Labs/eigenda/contracts/bindings/RegistryCoordinator"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// OnchainUpdateKind identifies the kind of on-chain state that changed.
+type OnchainUpdateKind uint8
+
+const (
+	// BlobVersionAdded is emitted when a new blob version is added to the threshold registry.
+	BlobVersionAdded OnchainUpdateKind = iota
+	// ReservationUpdated is emitted when the reservation of an account is updated in the payment vault.
+	ReservationUpdated
+	// OnDemandDepositUpdated is emitted when an account deposits on-demand payment in the payment vault.
+	OnDemandDepositUpdated
+	// PaymentParamsUpdated is emitted when the global payment parameters of the payment vault are updated.
+	PaymentParamsUpdated
+	// RelayAdded is emitted when a relay is added to the relay registry.
+	RelayAdded
+	// QuorumParamsUpdated is emitted when the quorum thresholds, the required quorums or the quorum set changes.
+	QuorumParamsUpdated
+)
+
+func (k OnchainUpdateKind) String() string {
+	switch k {
+	case BlobVersionAdded:
+		return "BlobVersionAdded"
+	case ReservationUpdated:
+		return "ReservationUpdated"
+	case OnDemandDepositUpdated:
+		return "OnDemandDepositUpdated"
+	case PaymentParamsUpdated:
+		return "PaymentParamsUpdated"
+	case RelayAdded:
+		return "RelayAdded"
+	case QuorumParamsUpdated:
+		return "QuorumParamsUpdated"
+	default:
+		return fmt.Sprintf("OnchainUpdateKind(%d)", uint8(k))
+	}
+}
+
+// OnchainStateUpdate describes a change of on-chain state observed by the OnchainStateWatcher.
+type OnchainStateUpdate struct {
+	Kind OnchainUpdateKind
+	// BlockNumber is the block in which the change was made
+	BlockNumber uint64
+	// Account is the account whose payment changed. Set for ReservationUpdated and OnDemandDepositUpdated.
+	Account gethcommon.Address
+	// BlobVersion is the version that was added. Set for BlobVersionAdded.
+	BlobVersion uint16
+	// RelayKey is the key of the relay that was added. Set for RelayAdded.
+	RelayKey uint32
+}
+
+// OnchainStateContracts are the addresses of the contracts watched by the OnchainStateWatcher. Zero addresses are
+// not watched.
+type OnchainStateContracts struct {
+	ServiceManager      gethcommon.Address
+	RegistryCoordinator gethcommon.Address
+	ThresholdRegistry   gethcommon.Address
+	PaymentVault        gethcommon.Address
+	RelayRegistry       gethcommon.Address
+}
+
+const (
+	// onchainUpdateBufferSize is the number of updates buffered for each subscriber
+	onchainUpdateBufferSize     = 64
+	defaultWatcherPollInterval  = 12 * time.Second
+	defaultWatcherMaxBlockRange = 1000
+)
+
+type logParser func(log types.Log) (OnchainStateUpdate, error)
+
+type eventKey struct {
+	address gethcommon.Address
+	topic   gethcommon.Hash
+}
+
+type subscription struct {
+	kinds   map[OnchainUpdateKind]struct{}
+	updates chan OnchainStateUpdate
+}
+
+// OnchainStateWatcher filters the logs of the EigenDA contracts for events that change the state cached by the
+// disperser, the relays and the nodes, and pushes typed updates to its subscribers. Components keep polling the
+// chain on their own interval as a fallback, since updates are dropped when a subscriber falls behind and the RPC
+// node may miss logs.
+type OnchainStateWatcher struct {
+	client common.EthClient
+	logger logging.Logger
+	config OnchainStateWatcherConfig
+
+	addresses []gethcommon.Address
+	topics    []gethcommon.Hash
+	parsers   map[eventKey]logParser
+
+	// nextBlock is the first block that has not been filtered yet
+	nextBlock uint64
+
+	mu            sync.Mutex
+	subscriptions []*subscription
+}
+
+// NewOnchainStateWatcher creates a watcher for the contracts known to the reader.
+func NewOnchainStateWatcher(
+	logger logging.Logger,
+	client common.EthClient,
+	reader *Reader,
+	config OnchainStateWatcherConfig,
+) (*OnchainStateWatcher, error) {
+	return newOnchainStateWatcher(logger, client, OnchainStateContracts{
+		ServiceManager:      reader.bindings.ServiceManagerAddr,
+		RegistryCoordinator: reader.bindings.RegCoordinatorAddr,
+		ThresholdRegistry:   reader.bindings.ThresholdRegistryAddr,
+		PaymentVault:        reader.bindings.PaymentVaultAddr,
+		RelayRegistry:       reader.bindings.RelayRegistryAddr,
+	}, config)
+}
+
+func newOnchainStateWatcher(
+	logger logging.Logger,
+	client common.EthClient,
+	contracts OnchainStateContracts,
+	config OnchainStateWatcherConfig,
+) (*OnchainStateWatcher, error) {
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultWatcherPollInterval
+	}
+	if config.MaxBlockRange == 0 {
+		config.MaxBlockRange = defaultWatcherMaxBlockRange
+	}
+
+	w := &OnchainStateWatcher{
+		client:  client,
+		logger:  logger.With("component", "OnchainStateWatcher"),
+		config:  config,
+		parsers: make(map[eventKey]logParser),
+	}
+	if err := w.registerEvents(contracts); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// registerEvents registers a parser for each watched event.
+func (w *OnchainStateWatcher) registerEvents(contracts OnchainStateContracts) error {
+	if addr := contracts.ServiceManager; addr != (gethcommon.Address{}) {
+		filterer, err := eigendasrvmg.NewContractEigenDAServiceManagerFilterer(addr, w.client)
+		if err != nil {
+			return err
+		}
+		err = w.register(addr, eigendasrvmg.ContractEigenDAServiceManagerMetaData, map[string]logParser{
+			"VersionedBlobParamsAdded": func(log types.Log) (OnchainStateUpdate, error) {
+				event, err := filterer.ParseVersionedBlobParamsAdded(log)
+				if err != nil {
+					return OnchainStateUpdate{}, err
+				}
+				return OnchainStateUpdate{Kind: BlobVersionAdded, BlobVersion: event.Version}, nil
+			},
+			"QuorumAdversaryThresholdPercentagesUpdated":    quorumParamsUpdated,
+			"QuorumConfirmationThresholdPercentagesUpdated": quorumParamsUpdated,
+			"QuorumNumbersRequiredUpdated":                  quorumParamsUpdated,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if addr := contracts.ThresholdRegistry; addr != (gethcommon.Address{}) {
+		filterer, err := thresholdreg.NewContractEigenDAThresholdRegistryFilterer(addr, w.client)
+		if err != nil {
+			return err
+		}
+		err = w.register(addr, thresholdreg.ContractEigenDAThresholdRegistryMetaData, map[string]logParser{
+			"VersionedBlobParamsAdded": func(log types.Log) (OnchainStateUpdate, error) {
+				event, err := filterer.ParseVersionedBlobParamsAdded(log)
+				if err != nil {
+					return OnchainStateUpdate{}, err
+				}
+				return OnchainStateUpdate{Kind: BlobVersionAdded, BlobVersion: event.Version}, nil
+			},
+			"QuorumAdversaryThresholdPercentagesUpdated":    quorumParamsUpdated,
+			"QuorumConfirmationThresholdPercentagesUpdated": quorumParamsUpdated,
+			"QuorumNumbersRequiredUpdated":                  quorumParamsUpdated,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if addr := contracts.RegistryCoordinator; addr != (gethcommon.Address{}) {
+		err := w.register(addr, regcoordinator.ContractRegistryCoordinatorMetaData, map[string]logParser{
+			"OperatorSetParamsUpdated": quorumParamsUpdated,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if addr := contracts.PaymentVault; addr != (gethcommon.Address{}) {
+		filterer, err := paymentvault.NewContractPaymentVaultFilterer(addr, w.client)
+		if err != nil {
+			return err
+		}
+		err = w.register(addr, paymentvault.ContractPaymentVaultMetaData, map[string]logParser{
+			"ReservationUpdated": func(log types.Log) (OnchainStateUpdate, error) {
+				event, err := filterer.ParseReservationUpdated(log)
+				if err != nil {
+					return OnchainStateUpdate{}, err
+				}
+				return OnchainStateUpdate{Kind: ReservationUpdated, Account: event.Account}, nil
+			},
+			"OnDemandPaymentUpdated": func(log types.Log) (OnchainStateUpdate, error) {
+				event, err := filterer.ParseOnDemandPaymentUpdated(log)
+				if err != nil {
+					return OnchainStateUpdate{}, err
+				}
+				return OnchainStateUpdate{Kind: OnDemandDepositUpdated, Account: event.Account}, nil
+			},
+			"GlobalSymbolsPerPeriodUpdated":    paymentParamsUpdated,
+			"GlobalRatePeriodIntervalUpdated":  paymentParamsUpdated,
+			"PriceParamsUpdated":               paymentParamsUpdated,
+			"ReservationPeriodIntervalUpdated": paymentParamsUpdated,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if addr := contracts.RelayRegistry; addr != (gethcommon.Address{}) {
+		filterer, err := relayreg.NewContractEigenDARelayRegistryFilterer(addr, w.client)
+		if err != nil {
+			return err
+		}
+		err = w.register(addr, relayreg.ContractEigenDARelayRegistryMetaData, map[string]logParser{
+			"RelayAdded": func(log types.Log) (OnchainStateUpdate, error) {
+				event, err := filterer.ParseRelayAdded(log)
+				if err != nil {
+					return OnchainStateUpdate{}, err
+				}
+				return OnchainStateUpdate{Kind: RelayAdded, RelayKey: event.Key}, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func quorumParamsUpdated(types.Log) (OnchainStateUpdate, error) {
+	return OnchainStateUpdate{Kind: QuorumParamsUpdated}, nil
+}
+
+func paymentParamsUpdated(types.Log) (OnchainStateUpdate, error) {
+	return OnchainStateUpdate{Kind: PaymentParamsUpdated}, nil
+}
+
+// register registers the parsers of the named events emitted by the contract at addr.
+func (w *OnchainStateWatcher) register(addr gethcommon.Address, metadata *bind.MetaData, parsers map[string]logParser) error {
+	contractAbi, err := metadata.GetAbi()
+	if err != nil {
+		return err
+	}
+	w.addresses = append(w.addresses, addr)
+	for name, parser := range parsers {
+		event, ok := contractAbi.Events[name]
+		if !ok {
+			return fmt.Errorf("event %s not found in the ABI of contract %s", name, addr.Hex())
+		}
+		w.addTopic(event)
+		w.parsers[eventKey{address: addr, topic: event.ID}] = parser
+	}
+	return nil
+}
+
+func (w *OnchainStateWatcher) addTopic(event abi.Event) {
+	for _, topic := range w.topics {
+		if topic == event.ID {
+			return
+		}
+	}
+	w.topics = append(w.topics, event.ID)
+}
+
+// Subscribe returns a channel that receives the updates of the given kinds, or of every kind if none is given.
+// Subscribing to a nil watcher returns a nil channel, so that callers without a watcher fall back to polling.
+func (w *OnchainStateWatcher) Subscribe(kinds ...OnchainUpdateKind) <-chan OnchainStateUpdate {
+	if w == nil {
+		return nil
+	}
+
+	sub := &subscription{
+		kinds:   make(map[OnchainUpdateKind]struct{}, len(kinds)),
+		updates: make(chan OnchainStateUpdate, onchainUpdateBufferSize),
+	}
+	for _, kind := range kinds {
+		sub.kinds[kind] = struct{}{}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscriptions = append(w.subscriptions, sub)
+	return sub.updates
+}
+
+// Start starts watching for updates from the current block onwards.
+func (w *OnchainStateWatcher) Start(ctx context.Context) error {
+	head, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+	w.nextBlock = head + 1
+
+	go func() {
+		ticker := time.NewTicker(w.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := w.poll(ctx); err != nil && !errors.Is(err, context.Canceled) {
+					w.logger.Warn("failed to filter on-chain state updates", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// poll filters the logs of the blocks produced since the last poll, at most MaxBlockRange blocks per request, and
+// publishes the updates. The blocks of a failed request are filtered again on the next poll.
+func (w *OnchainStateWatcher) poll(ctx context.Context) error {
+	head, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+
+	for w.nextBlock <= head {
+		toBlock := min(w.nextBlock+w.config.MaxBlockRange-1, head)
+		logs, err := w.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(w.nextBlock),
+			ToBlock:   new(big.Int).SetUint64(toBlock),
+			Addresses: w.addresses,
+			Topics:    [][]gethcommon.Hash{w.topics},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to filter logs in blocks %d to %d: %w", w.nextBlock, toBlock, err)
+		}
+
+		for _, log := range logs {
+			w.handleLog(log)
+		}
+		w.nextBlock = toBlock + 1
+	}
+
+	return nil
+}
+
+func (w *OnchainStateWatcher) handleLog(log types.Log) {
+	if log.Removed || len(log.Topics) == 0 {
+		return
+	}
+	parser, ok := w.parsers[eventKey{address: log.Address, topic: log.Topics[0]}]
+	if !ok {
+		return
+	}
+	update, err := parser(log)
+	if err != nil {
+		w.logger.Warn("failed to parse on-chain state update", "address", log.Address.Hex(), "txHash", log.TxHash.Hex(), "err", err)
+		return
+	}
+	update.BlockNumber = log.BlockNumber
+	w.publish(update)
+}
+
+// publish sends the update to the subscribers of its kind. Updates for subscribers that are not keeping up are
+// dropped; the subscriber picks up the change on its next poll of the chain.
+func (w *OnchainStateWatcher) publish(update OnchainStateUpdate) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, sub := range w.subscriptions {
+		if len(sub.kinds) > 0 {
+			if _, ok := sub.kinds[update.Kind]; !ok {
+				continue
+			}
+		}
+		select {
+		case sub.updates <- update:
+		default:
+			w.logger.Warn("dropping on-chain state update for a slow subscriber", "kind", update.Kind, "block", update.BlockNumber)
+		}
+	}
+}
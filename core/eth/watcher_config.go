@@ -0,0 +1,53 @@
+package eth
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+const (
+	OnchainStateWatcherEnabledFlagName       = "onchain-state-watcher.enabled"
+	OnchainStateWatcherPollIntervalFlagName  = "onchain-state-watcher.poll-interval"
+	OnchainStateWatcherMaxBlockRangeFlagName = "onchain-state-watcher.max-block-range"
+)
+
+type OnchainStateWatcherConfig struct {
+	// Enabled enables refreshing the on-chain state when the contracts emit events, in addition to polling
+	Enabled bool
+	// PollInterval is the interval at which new blocks are filtered for events
+	PollInterval time.Duration
+	// MaxBlockRange is the maximum number of blocks filtered in one request
+	MaxBlockRange uint64
+}
+
+func OnchainStateWatcherCLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:   OnchainStateWatcherEnabledFlagName,
+			Usage:  "Refresh the on-chain state as soon as the contracts emit events, instead of only on the refresh interval",
+			EnvVar: common.PrefixEnvVar(envPrefix, "ONCHAIN_STATE_WATCHER_ENABLED"),
+		},
+		cli.DurationFlag{
+			Name:   OnchainStateWatcherPollIntervalFlagName,
+			Usage:  "Interval at which new blocks are filtered for on-chain state events",
+			Value:  defaultWatcherPollInterval,
+			EnvVar: common.PrefixEnvVar(envPrefix, "ONCHAIN_STATE_WATCHER_POLL_INTERVAL"),
+		},
+		cli.Uint64Flag{
+			Name:   OnchainStateWatcherMaxBlockRangeFlagName,
+			Usage:  "Maximum number of blocks filtered for on-chain state events in one request",
+			Value:  defaultWatcherMaxBlockRange,
+			EnvVar: common.PrefixEnvVar(envPrefix, "ONCHAIN_STATE_WATCHER_MAX_BLOCK_RANGE"),
+		},
+	}
+}
+
+func ReadOnchainStateWatcherCLIConfig(ctx *cli.Context) OnchainStateWatcherConfig {
+	return OnchainStateWatcherConfig{
+		Enabled:       ctx.GlobalBool(OnchainStateWatcherEnabledFlagName),
+		PollInterval:  ctx.GlobalDuration(OnchainStateWatcherPollIntervalFlagName),
+		MaxBlockRange: ctx.GlobalUint64(OnchainStateWatcherMaxBlockRangeFlagName),
+	}
+}
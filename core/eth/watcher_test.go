@@ -0,0 +1,112 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	damock "github.com/Layr-Labs/eigenda/common/mock"
+	relayreg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDARelayRegistry"
+	paymentvault "github.com/Layr-Labs/eigenda/contracts/bindings/PaymentVault"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	paymentVaultAddr  = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	relayRegistryAddr = gethcommon.HexToAddress("0x1000000000000000000000000000000000000002")
+)
+
+func newTestWatcher(t *testing.T, client *damock.MockEthClient) *OnchainStateWatcher {
+	w, err := newOnchainStateWatcher(logging.NewNoopLogger(), client, OnchainStateContracts{
+		PaymentVault:  paymentVaultAddr,
+		RelayRegistry: relayRegistryAddr,
+	}, OnchainStateWatcherConfig{Enabled: true, MaxBlockRange: 10})
+	require.NoError(t, err)
+	return w
+}
+
+func relayAddedLog(t *testing.T, key uint32, blockNumber uint64) types.Log {
+	contractAbi, err := relayreg.ContractEigenDARelayRegistryMetaData.GetAbi()
+	require.NoError(t, err)
+	event := contractAbi.Events["RelayAdded"]
+	data, err := event.Inputs.NonIndexed().Pack("localhost:32011")
+	require.NoError(t, err)
+	return types.Log{
+		Address: relayRegistryAddr,
+		Topics: []gethcommon.Hash{
+			event.ID,
+			gethcommon.BytesToHash(gethcommon.HexToAddress("0x2").Bytes()),
+			gethcommon.BigToHash(big.NewInt(int64(key))),
+		},
+		Data:        data,
+		BlockNumber: blockNumber,
+	}
+}
+
+func reservationUpdatedLog(t *testing.T, account gethcommon.Address, blockNumber uint64) types.Log {
+	contractAbi, err := paymentvault.ContractPaymentVaultMetaData.GetAbi()
+	require.NoError(t, err)
+	event := contractAbi.Events["ReservationUpdated"]
+	data, err := event.Inputs.NonIndexed().Pack(paymentvault.IPaymentVaultReservation{
+		SymbolsPerSecond: 100,
+		StartTimestamp:   1,
+		EndTimestamp:     2,
+		QuorumNumbers:    []byte{0},
+		QuorumSplits:     []byte{100},
+	})
+	require.NoError(t, err)
+	return types.Log{
+		Address:     paymentVaultAddr,
+		Topics:      []gethcommon.Hash{event.ID, gethcommon.BytesToHash(account.Bytes())},
+		Data:        data,
+		BlockNumber: blockNumber,
+	}
+}
+
+func TestWatcherPublishesUpdates(t *testing.T) {
+	client := &damock.MockEthClient{}
+	w := newTestWatcher(t, client)
+	w.nextBlock = 100
+
+	account := gethcommon.HexToAddress("0x3")
+	client.On("BlockNumber").Return(uint64(115))
+	client.On("FilterLogs", mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return q.FromBlock.Uint64() == 100 && q.ToBlock.Uint64() == 109
+	})).Return([]types.Log{relayAddedLog(t, 7, 105)}, nil)
+	client.On("FilterLogs", mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return q.FromBlock.Uint64() == 110 && q.ToBlock.Uint64() == 115
+	})).Return([]types.Log{reservationUpdatedLog(t, account, 112)}, nil)
+
+	relays := w.Subscribe(RelayAdded)
+	all := w.Subscribe()
+
+	require.NoError(t, w.poll(context.Background()))
+	require.Equal(t, uint64(116), w.nextBlock)
+
+	require.Equal(t, OnchainStateUpdate{Kind: RelayAdded, BlockNumber: 105, RelayKey: 7}, <-relays)
+	require.Empty(t, relays)
+
+	require.Equal(t, OnchainStateUpdate{Kind: RelayAdded, BlockNumber: 105, RelayKey: 7}, <-all)
+	require.Equal(t, OnchainStateUpdate{Kind: ReservationUpdated, BlockNumber: 112, Account: account}, <-all)
+}
+
+func TestWatcherDropsUpdatesForSlowSubscribers(t *testing.T) {
+	client := &damock.MockEthClient{}
+	w := newTestWatcher(t, client)
+
+	updates := w.Subscribe(RelayAdded)
+	for i := 0; i < onchainUpdateBufferSize+1; i++ {
+		w.handleLog(relayAddedLog(t, uint32(i), 1))
+	}
+	require.Len(t, updates, onchainUpdateBufferSize)
+}
+
+func TestNilWatcherSubscribe(t *testing.T) {
+	var w *OnchainStateWatcher
+	require.Nil(t, w.Subscribe(RelayAdded))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 )
@@ -18,7 +19,7 @@ type Config struct {
 	// ChainReadTimeout is the timeout for reading payment state from chain
 	ChainReadTimeout time.Duration
 
-	// UpdateInterval is the interval for refreshing the on-chain state
+	// UpdateInterval is the interval for refreshing the on-chain state. Polling is disabled if it is not positive.
 	UpdateInterval time.Duration
 }
 
@@ -32,6 +33,10 @@ type Meterer struct {
 	// OffchainStore uses DynamoDB to track metering and used to validate requests
 	OffchainStore OffchainStore
 
+	// onchainStateUpdates receives the payment events that are applied before the next refresh. It is nil if no
+	// watcher is configured.
+	onchainStateUpdates <-chan eth.OnchainStateUpdate
+
 	logger logging.Logger
 }
 
@@ -39,6 +44,7 @@ func NewMeterer(
 	config Config,
 	paymentChainState OnchainPayment,
 	offchainStore OffchainStore,
+	onchainStateWatcher *eth.OnchainStateWatcher,
 	logger logging.Logger,
 ) *Meterer {
 	return &Meterer{
@@ -47,22 +53,38 @@ func NewMeterer(
 		ChainPaymentState: paymentChainState,
 		OffchainStore:     offchainStore,
 
+		onchainStateUpdates: onchainStateWatcher.Subscribe(
+			eth.ReservationUpdated,
+			eth.OnDemandDepositUpdated,
+			eth.PaymentParamsUpdated,
+			eth.QuorumParamsUpdated,
+		),
+
 		logger: logger.With("component", "Meterer"),
 	}
 }
 
-// Start starts to periodically refreshing the on-chain state
+// Start starts to periodically refreshing the on-chain state, and to apply on-chain payment updates as they are
+// observed. Periodic refreshes are disabled if UpdateInterval is not positive.
 func (m *Meterer) Start(ctx context.Context) {
 	go func() {
-		ticker := time.NewTicker(m.UpdateInterval)
-		defer ticker.Stop()
+		var tick <-chan time.Time
+		if m.UpdateInterval > 0 {
+			ticker := time.NewTicker(m.UpdateInterval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
 
 		for {
 			select {
-			case <-ticker.C:
+			case <-tick:
 				if err := m.ChainPaymentState.RefreshOnchainPaymentState(ctx); err != nil {
 					m.logger.Error("Failed to refresh on-chain state", "error", err)
 				}
+			case update := <-m.onchainStateUpdates:
+				if err := m.ChainPaymentState.ApplyOnchainUpdate(ctx, update); err != nil {
+					m.logger.Error("Failed to apply on-chain state update", "kind", update.Kind, "error", err)
+				}
 			case <-ctx.Done():
 				return
 			}
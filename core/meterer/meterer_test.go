@@ -163,6 +163,7 @@ func setup(_ *testing.M) {
 		config,
 		paymentChainState,
 		store,
+		nil,
 		logger,
 		// metrics.NewNoopMetrics(),
 	)
@@ -482,6 +483,48 @@ func TestMeterer_symbolsCharged(t *testing.T) {
 	}
 }
 
+func TestMetererStartPollsUntilStopped(t *testing.T) {
+	interval := 10 * time.Millisecond
+	refreshed := make(chan struct{}, 100)
+	chainState := &mock.MockOnchainPaymentState{}
+	chainState.On("RefreshOnchainPaymentState").Return(nil).Run(func(testifymock.Arguments) {
+		refreshed <- struct{}{}
+	})
+
+	m := meterer.NewMeterer(meterer.Config{UpdateInterval: interval}, chainState, meterer.OffchainStore{}, nil, logging.NewNoopLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	m.Start(ctx)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-refreshed:
+		case <-time.After(time.Second):
+			t.Fatal("meterer did not poll the on-chain payment state")
+		}
+	}
+
+	// A refresh may already be in flight when the context is cancelled, but none may start afterwards.
+	cancel()
+	time.Sleep(5 * interval)
+	for len(refreshed) > 0 {
+		<-refreshed
+	}
+	time.Sleep(5 * interval)
+	assert.Empty(t, refreshed)
+}
+
+func TestMetererStartWithoutUpdateIntervalDoesNotPoll(t *testing.T) {
+	chainState := &mock.MockOnchainPaymentState{}
+
+	m := meterer.NewMeterer(meterer.Config{}, chainState, meterer.OffchainStore{}, nil, logging.NewNoopLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Start(ctx)
+
+	time.Sleep(50 * time.Millisecond)
+	chainState.AssertNotCalled(t, "RefreshOnchainPaymentState")
+}
+
 func createPaymentHeader(reservationPeriod uint32, cumulativePayment *big.Int, accountID gethcommon.Address) *core.PaymentMetadata {
 	return &core.PaymentMetadata{
 		AccountID:         accountID.Hex(),
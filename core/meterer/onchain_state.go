@@ -15,6 +15,7 @@ import (
 // OnchainPaymentState is an interface for getting information about the current chain state for payments.
 type OnchainPayment interface {
 	RefreshOnchainPaymentState(ctx context.Context) error
+	ApplyOnchainUpdate(ctx context.Context, update eth.OnchainStateUpdate) error
 	GetReservedPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*core.ReservedPayment, error)
 	GetOnDemandPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*core.OnDemandPayment, error)
 	GetOnDemandQuorumNumbers(ctx context.Context) ([]uint8, error)
@@ -145,6 +146,48 @@ func (pcs *OnchainPaymentState) RefreshOnchainPaymentState(ctx context.Context)
 	return nil
 }
 
+// ApplyOnchainUpdate refreshes the part of the payment state changed by an on-chain event. Payments of accounts that
+// are not cached are left alone, since they are read from the chain on first use.
+func (pcs *OnchainPaymentState) ApplyOnchainUpdate(ctx context.Context, update eth.OnchainStateUpdate) error {
+	switch update.Kind {
+	case eth.ReservationUpdated:
+		pcs.ReservationsLock.RLock()
+		_, ok := pcs.ReservedPayments[update.Account]
+		pcs.ReservationsLock.RUnlock()
+		if !ok {
+			return nil
+		}
+		res, err := pcs.tx.GetReservedPaymentByAccount(ctx, update.Account)
+		if err != nil {
+			return err
+		}
+		pcs.ReservationsLock.Lock()
+		pcs.ReservedPayments[update.Account] = res
+		pcs.ReservationsLock.Unlock()
+	case eth.OnDemandDepositUpdated:
+		pcs.OnDemandLocks.RLock()
+		_, ok := pcs.OnDemandPayments[update.Account]
+		pcs.OnDemandLocks.RUnlock()
+		if !ok {
+			return nil
+		}
+		res, err := pcs.tx.GetOnDemandPaymentByAccount(ctx, update.Account)
+		if err != nil {
+			return err
+		}
+		pcs.OnDemandLocks.Lock()
+		pcs.OnDemandPayments[update.Account] = res
+		pcs.OnDemandLocks.Unlock()
+	case eth.PaymentParamsUpdated, eth.QuorumParamsUpdated:
+		paymentVaultParams, err := pcs.GetPaymentVaultParams(ctx)
+		if err != nil {
+			return err
+		}
+		pcs.PaymentVaultParams.Store(paymentVaultParams)
+	}
+	return nil
+}
+
 // GetReservedPaymentByAccount returns a pointer to the active reservation for the given account ID; no writes will be made to the reservation
 func (pcs *OnchainPaymentState) GetReservedPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*core.ReservedPayment, error) {
 	pcs.ReservationsLock.RLock()
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/meterer"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
@@ -29,6 +30,11 @@ func (m *MockOnchainPaymentState) RefreshOnchainPaymentState(ctx context.Context
 	return args.Error(0)
 }
 
+func (m *MockOnchainPaymentState) ApplyOnchainUpdate(ctx context.Context, update eth.OnchainStateUpdate) error {
+	args := m.Called(ctx, update)
+	return args.Error(0)
+}
+
 func (m *MockOnchainPaymentState) GetReservedPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*core.ReservedPayment, error) {
 	args := m.Called(ctx, accountID)
 	var value *core.ReservedPayment
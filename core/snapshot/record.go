@@ -0,0 +1,152 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// Record records the on-chain state at the given block number. The operator state of the given quorums is read from
+// ics, and the chain parameters are read from reader. If no quorums are given, all the quorums registered at the block
+// are recorded.
+func Record(
+	ctx context.Context,
+	reader core.Reader,
+	ics core.IndexedChainState,
+	blockNumber uint,
+	quorums []core.QuorumID,
+) (*Snapshot, error) {
+	block := uint32(blockNumber)
+
+	quorumCount, err := reader.GetQuorumCount(ctx, block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum count: %w", err)
+	}
+	if len(quorums) == 0 {
+		for quorum := core.QuorumID(0); quorum < quorumCount; quorum++ {
+			quorums = append(quorums, quorum)
+		}
+	}
+	requiredQuorums, err := reader.GetRequiredQuorumNumbers(ctx, block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required quorums: %w", err)
+	}
+	securityParams, err := reader.GetQuorumSecurityParams(ctx, block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum security params: %w", err)
+	}
+	blockStaleMeasure, err := reader.GetBlockStaleMeasure(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block stale measure: %w", err)
+	}
+	storeDurationBlocks, err := reader.GetStoreDurationBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store duration blocks: %w", err)
+	}
+	numBlobVersions, err := reader.GetNumBlobVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get number of blob versions: %w", err)
+	}
+	blobVersionParams, err := reader.GetAllVersionedBlobParams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob version params: %w", err)
+	}
+	relayURLs, err := reader.GetRelayURLs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relay URLs: %w", err)
+	}
+
+	state, err := ics.GetIndexedOperatorState(ctx, blockNumber, quorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", blockNumber, err)
+	}
+
+	snapshot := &Snapshot{
+		BlockNumber:         blockNumber,
+		QuorumCount:         quorumCount,
+		SecurityParams:      securityParams,
+		BlockStaleMeasure:   blockStaleMeasure,
+		StoreDurationBlocks: storeDurationBlocks,
+		NumBlobVersions:     numBlobVersions,
+		BlobVersionParams:   blobVersionParams,
+		RelayURLs:           relayURLs,
+	}
+	for _, quorum := range requiredQuorums {
+		snapshot.RequiredQuorums = append(snapshot.RequiredQuorums, uint32(quorum))
+	}
+	snapshot.Quorums, snapshot.Operators = encodeState(state)
+	return snapshot, nil
+}
+
+// NewSnapshot creates a snapshot of the given operator state, without any chain parameters. It is meant for tests
+// that only need a core.IndexedChainState.
+func NewSnapshot(state *core.IndexedOperatorState) *Snapshot {
+	snapshot := &Snapshot{BlockNumber: state.BlockNumber}
+	snapshot.Quorums, snapshot.Operators = encodeState(state)
+	return snapshot
+}
+
+// encodeState encodes the operator state, sorted by quorum and operator ID so that the same state always produces
+// the same snapshot.
+func encodeState(state *core.IndexedOperatorState) ([]*QuorumSnapshot, []*OperatorSnapshot) {
+	quorumIDs := make([]core.QuorumID, 0, len(state.Operators))
+	for quorum := range state.Operators {
+		quorumIDs = append(quorumIDs, quorum)
+	}
+	slices.Sort(quorumIDs)
+
+	quorums := make([]*QuorumSnapshot, 0, len(quorumIDs))
+	for _, quorum := range quorumIDs {
+		q := &QuorumSnapshot{
+			QuorumID:   quorum,
+			TotalStake: "0",
+			Operators:  make([]*QuorumOperatorSnapshot, 0, len(state.Operators[quorum])),
+		}
+		if total, ok := state.Totals[quorum]; ok && total != nil && total.Stake != nil {
+			q.TotalStake = total.Stake.String()
+		}
+		if aggKey, ok := state.AggKeys[quorum]; ok && aggKey != nil {
+			q.AggregatePubKey = encodePoint(aggKey.Serialize())
+		}
+		for _, operatorID := range sortedOperatorIDs(state.Operators[quorum]) {
+			info := state.Operators[quorum][operatorID]
+			q.Operators = append(q.Operators, &QuorumOperatorSnapshot{
+				OperatorID: operatorID.Hex(),
+				Stake:      info.Stake.String(),
+				Index:      info.Index,
+			})
+		}
+		quorums = append(quorums, q)
+	}
+
+	operators := make([]*OperatorSnapshot, 0, len(state.IndexedOperators))
+	for _, operatorID := range sortedOperatorIDs(state.IndexedOperators) {
+		info := state.IndexedOperators[operatorID]
+		o := &OperatorSnapshot{
+			OperatorID: operatorID.Hex(),
+			Socket:     info.Socket,
+		}
+		if info.PubkeyG1 != nil {
+			o.PubkeyG1 = encodePoint(info.PubkeyG1.Serialize())
+		}
+		if info.PubkeyG2 != nil {
+			o.PubkeyG2 = encodePoint(info.PubkeyG2.Serialize())
+		}
+		operators = append(operators, o)
+	}
+
+	return quorums, operators
+}
+
+func sortedOperatorIDs[V any](operators map[core.OperatorID]V) []core.OperatorID {
+	ids := make([]core.OperatorID, 0, len(operators))
+	for id := range operators {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b core.OperatorID) int {
+		return slices.Compare(a[:], b[:])
+	})
+	return ids
+}
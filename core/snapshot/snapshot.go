@@ -0,0 +1,179 @@
+// Package snapshot records the on-chain state at a reference block to a JSON file, and serves it back as a
+// core.IndexedChainState and a core.Reader. This makes it possible to reproduce an incident, such as a bad
+// assignment or a failed aggregation, in a unit test without access to the chain or the subgraph.
+package snapshot
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// Snapshot is the on-chain state at a reference block.
+type Snapshot struct {
+	BlockNumber uint `json:"blockNumber"`
+
+	// Quorums holds the operators registered in each recorded quorum
+	Quorums []*QuorumSnapshot `json:"quorums"`
+	// Operators holds the indexed info of every operator registered in a recorded quorum
+	Operators []*OperatorSnapshot `json:"operators"`
+
+	QuorumCount         uint8                                  `json:"quorumCount"`
+	RequiredQuorums     []uint32                               `json:"requiredQuorums"`
+	SecurityParams      []core.SecurityParam                   `json:"securityParams"`
+	BlockStaleMeasure   uint32                                 `json:"blockStaleMeasure"`
+	StoreDurationBlocks uint32                                 `json:"storeDurationBlocks"`
+	NumBlobVersions     uint16                                 `json:"numBlobVersions"`
+	BlobVersionParams   map[uint16]*core.BlobVersionParameters `json:"blobVersionParams"`
+	RelayURLs           map[uint32]string                      `json:"relayURLs"`
+}
+
+// QuorumSnapshot is the operator set of a quorum.
+type QuorumSnapshot struct {
+	QuorumID core.QuorumID `json:"quorumId"`
+	// AggregatePubKey is the hex encoded aggregate G1 public key of the quorum
+	AggregatePubKey string `json:"aggregatePubKey"`
+	// TotalStake is the decimal total stake of the quorum
+	TotalStake string                    `json:"totalStake"`
+	Operators  []*QuorumOperatorSnapshot `json:"operators"`
+}
+
+// QuorumOperatorSnapshot is the stake of an operator in a quorum.
+type QuorumOperatorSnapshot struct {
+	// OperatorID is the hex encoded operator ID
+	OperatorID string `json:"operatorId"`
+	// Stake is the decimal stake of the operator in the quorum
+	Stake string `json:"stake"`
+	// Index is the index of the operator within the quorum
+	Index uint `json:"index"`
+}
+
+// OperatorSnapshot is the indexed info of an operator.
+type OperatorSnapshot struct {
+	// OperatorID is the hex encoded operator ID
+	OperatorID string `json:"operatorId"`
+	// PubkeyG1 and PubkeyG2 are the hex encoded public keys of the operator
+	PubkeyG1 string `json:"pubkeyG1"`
+	PubkeyG2 string `json:"pubkeyG2"`
+	Socket   string `json:"socket"`
+}
+
+// Load reads a snapshot from a JSON file.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// Save writes the snapshot to a JSON file.
+func (s *Snapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// decoded is a snapshot decoded into the core types.
+type decoded struct {
+	operators map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo
+	totals    map[core.QuorumID]*core.OperatorInfo
+	aggKeys   map[core.QuorumID]*core.G1Point
+	indexed   map[core.OperatorID]*core.IndexedOperatorInfo
+}
+
+func (s *Snapshot) decode() (*decoded, error) {
+	d := &decoded{
+		operators: make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo),
+		totals:    make(map[core.QuorumID]*core.OperatorInfo),
+		aggKeys:   make(map[core.QuorumID]*core.G1Point),
+		indexed:   make(map[core.OperatorID]*core.IndexedOperatorInfo),
+	}
+
+	for _, quorum := range s.Quorums {
+		totalStake, ok := new(big.Int).SetString(quorum.TotalStake, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid total stake %q in quorum %d", quorum.TotalStake, quorum.QuorumID)
+		}
+		d.totals[quorum.QuorumID] = &core.OperatorInfo{
+			Stake: totalStake,
+			Index: core.OperatorIndex(len(quorum.Operators)),
+		}
+
+		if quorum.AggregatePubKey != "" {
+			aggKey, err := decodeG1Point(quorum.AggregatePubKey)
+			if err != nil {
+				return nil, fmt.Errorf("invalid aggregate public key in quorum %d: %w", quorum.QuorumID, err)
+			}
+			d.aggKeys[quorum.QuorumID] = aggKey
+		}
+
+		operators := make(map[core.OperatorID]*core.OperatorInfo, len(quorum.Operators))
+		for _, operator := range quorum.Operators {
+			id, err := core.OperatorIDFromHex(operator.OperatorID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid operator ID %q in quorum %d: %w", operator.OperatorID, quorum.QuorumID, err)
+			}
+			stake, ok := new(big.Int).SetString(operator.Stake, 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid stake %q of operator %s", operator.Stake, operator.OperatorID)
+			}
+			operators[id] = &core.OperatorInfo{Stake: stake, Index: operator.Index}
+		}
+		d.operators[quorum.QuorumID] = operators
+	}
+
+	for _, operator := range s.Operators {
+		id, err := core.OperatorIDFromHex(operator.OperatorID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operator ID %q: %w", operator.OperatorID, err)
+		}
+		info := &core.IndexedOperatorInfo{Socket: operator.Socket}
+		if operator.PubkeyG1 != "" {
+			if info.PubkeyG1, err = decodeG1Point(operator.PubkeyG1); err != nil {
+				return nil, fmt.Errorf("invalid G1 public key of operator %s: %w", operator.OperatorID, err)
+			}
+		}
+		if operator.PubkeyG2 != "" {
+			if info.PubkeyG2, err = decodeG2Point(operator.PubkeyG2); err != nil {
+				return nil, fmt.Errorf("invalid G2 public key of operator %s: %w", operator.OperatorID, err)
+			}
+		}
+		d.indexed[id] = info
+	}
+
+	return d, nil
+}
+
+func encodePoint(serialized []byte) string {
+	return hex.EncodeToString(serialized)
+}
+
+func decodeG1Point(s string) (*core.G1Point, error) {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(core.G1Point).Deserialize(data)
+}
+
+func decodeG2Point(s string) (*core.G2Point, error) {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(core.G2Point).Deserialize(data)
+}
@@ -0,0 +1,108 @@
+package snapshot_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/core/snapshot"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainStateRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dat, err := coremock.MakeChainDataMock(map[core.QuorumID]int{
+		0: 4,
+		1: 6,
+	})
+	require.NoError(t, err)
+
+	blockNumber := uint(100)
+	quorums := []core.QuorumID{0, 1}
+	expected, err := dat.GetIndexedOperatorState(ctx, blockNumber, quorums)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, snapshot.NewSnapshot(expected).Save(path))
+	cs, err := snapshot.LoadChainState(path)
+	require.NoError(t, err)
+
+	state, err := cs.GetIndexedOperatorState(ctx, blockNumber, quorums)
+	require.NoError(t, err)
+	require.Equal(t, expected, state)
+
+	// quorums that were not recorded are ignored
+	state, err = cs.GetIndexedOperatorState(ctx, blockNumber, []core.QuorumID{1, 2})
+	require.NoError(t, err)
+	require.Len(t, state.Operators, 1)
+	require.Len(t, state.IndexedOperators, 6)
+
+	for operatorID, info := range expected.IndexedOperators {
+		socket, err := cs.GetOperatorSocket(ctx, blockNumber, operatorID)
+		require.NoError(t, err)
+		require.Equal(t, info.Socket, socket)
+
+		operatorState, err := cs.GetOperatorStateByOperator(ctx, blockNumber, operatorID)
+		require.NoError(t, err)
+		for quorum := range operatorState.Operators {
+			require.Contains(t, operatorState.Operators[quorum], operatorID)
+		}
+	}
+
+	_, err = cs.GetOperatorState(ctx, blockNumber+1, quorums)
+	require.Error(t, err)
+}
+
+func TestReaderRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := &snapshot.Snapshot{
+		BlockNumber:     100,
+		QuorumCount:     2,
+		RequiredQuorums: []uint32{0, 1},
+		SecurityParams: []core.SecurityParam{
+			{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		},
+		BlockStaleMeasure:   150,
+		StoreDurationBlocks: 201600,
+		NumBlobVersions:     2,
+		BlobVersionParams: map[uint16]*core.BlobVersionParameters{
+			0: {CodingRate: 8, MaxNumOperators: 3537, NumChunks: 8192},
+		},
+		RelayURLs: map[uint32]string{0: "relay-0:32011"},
+	}
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, s.Save(path))
+	loaded, err := snapshot.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, s, loaded)
+
+	reader, err := snapshot.NewReader(loaded, nil)
+	require.NoError(t, err)
+
+	blockNumber, err := reader.GetCurrentBlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), blockNumber)
+	required, err := reader.GetRequiredQuorumNumbers(ctx, blockNumber)
+	require.NoError(t, err)
+	require.Equal(t, []core.QuorumID{0, 1}, required)
+	params, err := reader.GetVersionedBlobParams(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(8192), params.NumChunks)
+	_, err = reader.GetVersionedBlobParams(ctx, 1)
+	require.Error(t, err)
+	numBlobVersions, err := reader.GetNumBlobVersions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint16(2), numBlobVersions)
+	url, err := reader.GetRelayURL(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, "relay-0:32011", url)
+
+	// Without a base reader, the methods that are not recorded fail instead of panicking.
+	_, err = reader.GetOperatorSetParams(ctx, 0)
+	require.ErrorIs(t, err, snapshot.ErrNotInSnapshot)
+	_, err = reader.GetReservedPaymentByAccount(ctx, gethcommon.Address{})
+	require.ErrorIs(t, err, snapshot.ErrNotInSnapshot)
+}
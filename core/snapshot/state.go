@@ -0,0 +1,408 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/core"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// ChainState is a core.IndexedChainState that serves the operator state recorded in a snapshot. Only the state at the
+// block number of the snapshot can be queried.
+type ChainState struct {
+	snapshot *Snapshot
+	state    *decoded
+}
+
+var _ core.IndexedChainState = (*ChainState)(nil)
+
+// NewChainState creates a ChainState that serves the given snapshot.
+func NewChainState(snapshot *Snapshot) (*ChainState, error) {
+	if snapshot == nil {
+		return nil, errors.New("snapshot is required")
+	}
+	state, err := snapshot.decode()
+	if err != nil {
+		return nil, err
+	}
+	return &ChainState{
+		snapshot: snapshot,
+		state:    state,
+	}, nil
+}
+
+// LoadChainState creates a ChainState that serves the snapshot stored in the given file.
+func LoadChainState(path string) (*ChainState, error) {
+	snapshot, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewChainState(snapshot)
+}
+
+func (cs *ChainState) GetCurrentBlockNumber() (uint, error) {
+	return cs.snapshot.BlockNumber, nil
+}
+
+func (cs *ChainState) GetOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.OperatorState, error) {
+	if err := cs.checkBlockNumber(blockNumber); err != nil {
+		return nil, err
+	}
+
+	state := &core.OperatorState{
+		Operators:   make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo),
+		Totals:      make(map[core.QuorumID]*core.OperatorInfo),
+		BlockNumber: blockNumber,
+	}
+	for _, quorum := range quorums {
+		operators, ok := cs.state.operators[quorum]
+		if !ok {
+			continue
+		}
+		state.Operators[quorum] = copyOperators(operators)
+		state.Totals[quorum] = copyOperatorInfo(cs.state.totals[quorum])
+	}
+	return state, nil
+}
+
+func (cs *ChainState) GetOperatorStateByOperator(ctx context.Context, blockNumber uint, operator core.OperatorID) (*core.OperatorState, error) {
+	if err := cs.checkBlockNumber(blockNumber); err != nil {
+		return nil, err
+	}
+
+	quorums := make([]core.QuorumID, 0)
+	for quorum, operators := range cs.state.operators {
+		if _, ok := operators[operator]; ok {
+			quorums = append(quorums, quorum)
+		}
+	}
+	if len(quorums) == 0 {
+		return nil, fmt.Errorf("operator %s is not registered in any quorum at block %d", operator.Hex(), blockNumber)
+	}
+	return cs.GetOperatorState(ctx, blockNumber, quorums)
+}
+
+func (cs *ChainState) GetOperatorSocket(ctx context.Context, blockNumber uint, operator core.OperatorID) (string, error) {
+	if err := cs.checkBlockNumber(blockNumber); err != nil {
+		return "", err
+	}
+	info, ok := cs.state.indexed[operator]
+	if !ok {
+		return "", fmt.Errorf("no socket found for operator %s", operator.Hex())
+	}
+	return info.Socket, nil
+}
+
+func (cs *ChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	operatorState, err := cs.GetOperatorState(ctx, blockNumber, quorums)
+	if err != nil {
+		return nil, err
+	}
+
+	aggKeys := make(map[core.QuorumID]*core.G1Point)
+	indexedOperators := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	for quorum, operators := range operatorState.Operators {
+		if aggKey, ok := cs.state.aggKeys[quorum]; ok {
+			aggKeys[quorum] = aggKey.Clone()
+		}
+		for operatorID := range operators {
+			info, ok := cs.state.indexed[operatorID]
+			if !ok {
+				return nil, fmt.Errorf("operator %s not found in indexed state", operatorID.Hex())
+			}
+			indexedOperators[operatorID] = copyIndexedOperatorInfo(info)
+		}
+	}
+
+	return &core.IndexedOperatorState{
+		OperatorState:    operatorState,
+		IndexedOperators: indexedOperators,
+		AggKeys:          aggKeys,
+	}, nil
+}
+
+func (cs *ChainState) GetIndexedOperators(ctx context.Context, blockNumber uint) (map[core.OperatorID]*core.IndexedOperatorInfo, error) {
+	if err := cs.checkBlockNumber(blockNumber); err != nil {
+		return nil, err
+	}
+	indexedOperators := make(map[core.OperatorID]*core.IndexedOperatorInfo, len(cs.state.indexed))
+	for operatorID, info := range cs.state.indexed {
+		indexedOperators[operatorID] = copyIndexedOperatorInfo(info)
+	}
+	return indexedOperators, nil
+}
+
+func (cs *ChainState) Start(ctx context.Context) error {
+	return nil
+}
+
+func (cs *ChainState) checkBlockNumber(blockNumber uint) error {
+	if blockNumber != cs.snapshot.BlockNumber {
+		return fmt.Errorf("snapshot was recorded at block %d, cannot serve block %d", cs.snapshot.BlockNumber, blockNumber)
+	}
+	return nil
+}
+
+// ErrNotInSnapshot is returned by a Reader without a base reader for the methods whose results are not recorded in a
+// snapshot.
+var ErrNotInSnapshot = errors.New("not in snapshot")
+
+// Reader is a core.Reader that serves the chain parameters recorded in a snapshot. The methods that are not recorded
+// are delegated to the base reader, or fail with ErrNotInSnapshot if there is none.
+type Reader struct {
+	snapshot *Snapshot
+	base     core.Reader
+}
+
+var _ core.Reader = (*Reader)(nil)
+
+// NewReader creates a Reader that serves the given snapshot, and delegates the methods that are not recorded to base.
+// base may be nil if those methods are not used.
+func NewReader(snapshot *Snapshot, base core.Reader) (*Reader, error) {
+	if snapshot == nil {
+		return nil, errors.New("snapshot is required")
+	}
+	return &Reader{
+		snapshot: snapshot,
+		base:     base,
+	}, nil
+}
+
+func (r *Reader) GetCurrentBlockNumber(ctx context.Context) (uint32, error) {
+	return uint32(r.snapshot.BlockNumber), nil
+}
+
+func (r *Reader) GetQuorumCount(ctx context.Context, blockNumber uint32) (uint8, error) {
+	return r.snapshot.QuorumCount, nil
+}
+
+func (r *Reader) GetQuorumSecurityParams(ctx context.Context, blockNumber uint32) ([]core.SecurityParam, error) {
+	params := make([]core.SecurityParam, len(r.snapshot.SecurityParams))
+	copy(params, r.snapshot.SecurityParams)
+	return params, nil
+}
+
+func (r *Reader) GetRequiredQuorumNumbers(ctx context.Context, blockNumber uint32) ([]core.QuorumID, error) {
+	quorums := make([]core.QuorumID, len(r.snapshot.RequiredQuorums))
+	for i, quorum := range r.snapshot.RequiredQuorums {
+		quorums[i] = core.QuorumID(quorum)
+	}
+	return quorums, nil
+}
+
+func (r *Reader) GetBlockStaleMeasure(ctx context.Context) (uint32, error) {
+	return r.snapshot.BlockStaleMeasure, nil
+}
+
+func (r *Reader) GetStoreDurationBlocks(ctx context.Context) (uint32, error) {
+	return r.snapshot.StoreDurationBlocks, nil
+}
+
+func (r *Reader) GetNumBlobVersions(ctx context.Context) (uint16, error) {
+	return r.snapshot.NumBlobVersions, nil
+}
+
+func (r *Reader) GetVersionedBlobParams(ctx context.Context, blobVersion uint16) (*core.BlobVersionParameters, error) {
+	params, ok := r.snapshot.BlobVersionParams[blobVersion]
+	if !ok {
+		return nil, fmt.Errorf("blob version %d not found in snapshot", blobVersion)
+	}
+	p := *params
+	return &p, nil
+}
+
+func (r *Reader) GetAllVersionedBlobParams(ctx context.Context) (map[uint16]*core.BlobVersionParameters, error) {
+	all := make(map[uint16]*core.BlobVersionParameters, len(r.snapshot.BlobVersionParams))
+	for version, params := range r.snapshot.BlobVersionParams {
+		p := *params
+		all[version] = &p
+	}
+	return all, nil
+}
+
+func (r *Reader) GetNumRelays(ctx context.Context) (uint32, error) {
+	return uint32(len(r.snapshot.RelayURLs)), nil
+}
+
+func (r *Reader) GetRelayURL(ctx context.Context, key uint32) (string, error) {
+	url, ok := r.snapshot.RelayURLs[key]
+	if !ok {
+		return "", fmt.Errorf("relay %d not found in snapshot", key)
+	}
+	return url, nil
+}
+
+func (r *Reader) GetRelayURLs(ctx context.Context) (map[uint32]string, error) {
+	return maps.Clone(r.snapshot.RelayURLs), nil
+}
+
+// The methods below are not recorded in the snapshot.
+
+func (r *Reader) GetRegisteredQuorumIdsForOperator(ctx context.Context, operatorID core.OperatorID) ([]core.QuorumID, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("GetRegisteredQuorumIdsForOperator")
+	}
+	return r.base.GetRegisteredQuorumIdsForOperator(ctx, operatorID)
+}
+
+func (r *Reader) GetOperatorStakes(ctx context.Context, operatorID core.OperatorID, blockNumber uint32) (core.OperatorStakes, []core.QuorumID, error) {
+	if r.base == nil {
+		return nil, nil, notInSnapshot("GetOperatorStakes")
+	}
+	return r.base.GetOperatorStakes(ctx, operatorID, blockNumber)
+}
+
+func (r *Reader) GetOperatorStakesForQuorums(ctx context.Context, quorums []core.QuorumID, blockNumber uint32) (core.OperatorStakes, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("GetOperatorStakesForQuorums")
+	}
+	return r.base.GetOperatorStakesForQuorums(ctx, quorums, blockNumber)
+}
+
+func (r *Reader) StakeRegistry(ctx context.Context) (gethcommon.Address, error) {
+	if r.base == nil {
+		return gethcommon.Address{}, notInSnapshot("StakeRegistry")
+	}
+	return r.base.StakeRegistry(ctx)
+}
+
+func (r *Reader) OperatorIDToAddress(ctx context.Context, operatorId core.OperatorID) (gethcommon.Address, error) {
+	if r.base == nil {
+		return gethcommon.Address{}, notInSnapshot("OperatorIDToAddress")
+	}
+	return r.base.OperatorIDToAddress(ctx, operatorId)
+}
+
+func (r *Reader) OperatorAddressToID(ctx context.Context, operatorAddress gethcommon.Address) (core.OperatorID, error) {
+	if r.base == nil {
+		return core.OperatorID{}, notInSnapshot("OperatorAddressToID")
+	}
+	return r.base.OperatorAddressToID(ctx, operatorAddress)
+}
+
+func (r *Reader) BatchOperatorIDToAddress(ctx context.Context, operatorIds []core.OperatorID) ([]gethcommon.Address, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("BatchOperatorIDToAddress")
+	}
+	return r.base.BatchOperatorIDToAddress(ctx, operatorIds)
+}
+
+func (r *Reader) GetCurrentQuorumBitmapByOperatorId(ctx context.Context, operatorId core.OperatorID) (*big.Int, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("GetCurrentQuorumBitmapByOperatorId")
+	}
+	return r.base.GetCurrentQuorumBitmapByOperatorId(ctx, operatorId)
+}
+
+func (r *Reader) GetQuorumBitmapForOperatorsAtBlockNumber(ctx context.Context, operatorIds []core.OperatorID, blockNumber uint32) ([]*big.Int, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("GetQuorumBitmapForOperatorsAtBlockNumber")
+	}
+	return r.base.GetQuorumBitmapForOperatorsAtBlockNumber(ctx, operatorIds, blockNumber)
+}
+
+func (r *Reader) GetOperatorSetParams(ctx context.Context, quorumID core.QuorumID) (*core.OperatorSetParam, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("GetOperatorSetParams")
+	}
+	return r.base.GetOperatorSetParams(ctx, quorumID)
+}
+
+func (r *Reader) GetOperatorSocket(ctx context.Context, operatorID core.OperatorID) (string, error) {
+	if r.base == nil {
+		return "", notInSnapshot("GetOperatorSocket")
+	}
+	return r.base.GetOperatorSocket(ctx, operatorID)
+}
+
+func (r *Reader) GetNumberOfRegisteredOperatorForQuorum(ctx context.Context, quorumID core.QuorumID) (uint32, error) {
+	if r.base == nil {
+		return 0, notInSnapshot("GetNumberOfRegisteredOperatorForQuorum")
+	}
+	return r.base.GetNumberOfRegisteredOperatorForQuorum(ctx, quorumID)
+}
+
+func (r *Reader) WeightOfOperatorForQuorum(ctx context.Context, quorumID core.QuorumID, operator gethcommon.Address) (*big.Int, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("WeightOfOperatorForQuorum")
+	}
+	return r.base.WeightOfOperatorForQuorum(ctx, quorumID, operator)
+}
+
+func (r *Reader) CalculateOperatorChurnApprovalDigestHash(
+	ctx context.Context,
+	operatorAddress gethcommon.Address,
+	operatorId core.OperatorID,
+	operatorsToChurn []core.OperatorToChurn,
+	salt [32]byte,
+	expiry *big.Int,
+) ([32]byte, error) {
+	if r.base == nil {
+		return [32]byte{}, notInSnapshot("CalculateOperatorChurnApprovalDigestHash")
+	}
+	return r.base.CalculateOperatorChurnApprovalDigestHash(ctx, operatorAddress, operatorId, operatorsToChurn, salt, expiry)
+}
+
+func (r *Reader) GetReservedPayments(ctx context.Context, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.ReservedPayment, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("GetReservedPayments")
+	}
+	return r.base.GetReservedPayments(ctx, accountIDs)
+}
+
+func (r *Reader) GetReservedPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*core.ReservedPayment, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("GetReservedPaymentByAccount")
+	}
+	return r.base.GetReservedPaymentByAccount(ctx, accountID)
+}
+
+func (r *Reader) GetOnDemandPayments(ctx context.Context, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.OnDemandPayment, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("GetOnDemandPayments")
+	}
+	return r.base.GetOnDemandPayments(ctx, accountIDs)
+}
+
+func (r *Reader) GetOnDemandPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*core.OnDemandPayment, error) {
+	if r.base == nil {
+		return nil, notInSnapshot("GetOnDemandPaymentByAccount")
+	}
+	return r.base.GetOnDemandPaymentByAccount(ctx, accountID)
+}
+
+func notInSnapshot(method string) error {
+	return fmt.Errorf("%s: %w", method, ErrNotInSnapshot)
+}
+
+// The snapshot is shared by all callers, so the returned states are copies that callers are free to modify.
+
+func copyOperators(operators map[core.OperatorID]*core.OperatorInfo) map[core.OperatorID]*core.OperatorInfo {
+	copied := make(map[core.OperatorID]*core.OperatorInfo, len(operators))
+	for operatorID, info := range operators {
+		copied[operatorID] = copyOperatorInfo(info)
+	}
+	return copied
+}
+
+func copyOperatorInfo(info *core.OperatorInfo) *core.OperatorInfo {
+	return &core.OperatorInfo{
+		Stake: new(big.Int).Set(info.Stake),
+		Index: info.Index,
+	}
+}
+
+func copyIndexedOperatorInfo(info *core.IndexedOperatorInfo) *core.IndexedOperatorInfo {
+	copied := &core.IndexedOperatorInfo{Socket: info.Socket}
+	if info.PubkeyG1 != nil {
+		copied.PubkeyG1 = info.PubkeyG1.Clone()
+	}
+	if info.PubkeyG2 != nil {
+		copied.PubkeyG2 = info.PubkeyG2.Clone()
+	}
+	return copied
+}
@@ -0,0 +1,80 @@
+package statecache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// cacheEntry is a cached value and its weight.
+type cacheEntry struct {
+	key    string
+	value  any
+	weight uint64
+}
+
+// weightedCache is a least recently used cache whose capacity is the total weight of its values. It is safe for
+// concurrent use.
+type weightedCache struct {
+	maxWeight uint64
+
+	mu      sync.Mutex
+	weight  uint64
+	entries map[string]*list.Element
+	// order holds the entries from the most to the least recently used
+	order *list.List
+}
+
+func newWeightedCache(maxWeight uint64) *weightedCache {
+	return &weightedCache{
+		maxWeight: maxWeight,
+		entries:   make(map[string]*list.Element),
+		order:     list.New(),
+	}
+}
+
+func (c *weightedCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).value, true
+}
+
+// put adds a value to the cache, evicting the least recently used values until the total weight fits. Values
+// heavier than the whole cache are not cached.
+func (c *weightedCache) put(key string, value any, weight uint64) {
+	if weight > c.maxWeight {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, weight: weight})
+	c.weight += weight
+
+	for c.weight > c.maxWeight {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove removes an entry from the cache. Must be called with c.mu held.
+func (c *weightedCache) remove(element *list.Element) {
+	entry := c.order.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.weight -= entry.weight
+}
+
+// stats returns the number of cached values and their total weight.
+func (c *weightedCache) stats() (int, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries), c.weight
+}
@@ -0,0 +1,58 @@
+package statecache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	hitResult  = "hit"
+	missResult = "miss"
+)
+
+type metrics struct {
+	lookups *prometheus.CounterVec
+	size    prometheus.Gauge
+	weight  prometheus.Gauge
+}
+
+// newMetrics creates the cache metrics. If registry is nil, the metrics are not registered.
+func newMetrics(registry *prometheus.Registry, namespace string) *metrics {
+	var registerer prometheus.Registerer
+	if registry != nil {
+		registerer = registry
+	}
+	return &metrics{
+		lookups: promauto.With(registerer).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "chain_state_cache_lookup_count",
+				Help:      "Number of chain state lookups, by method and by whether they were served from the cache",
+			},
+			[]string{"method", "result"},
+		),
+		size: promauto.With(registerer).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "chain_state_cache_size",
+				Help:      "Number of chain states in the cache",
+			},
+		),
+		weight: promauto.With(registerer).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "chain_state_cache_weight",
+				Help:      "Total weight of the chain states in the cache",
+			},
+		),
+	}
+}
+
+func (m *metrics) reportLookup(method string, result string) {
+	m.lookups.WithLabelValues(method, result).Inc()
+}
+
+func (m *metrics) reportCacheStats(size int, weight uint64) {
+	m.size.Set(float64(size))
+	m.weight.Set(float64(weight))
+}
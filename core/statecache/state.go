@@ -0,0 +1,242 @@
+// Package statecache caches the operator state read from the chain or the subgraph, keyed by reference block number.
+// The operator state at a finalized block never changes, so components that look up the state of the same reference
+// block many times can share a single lookup.
+package statecache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultFetchTimeout is the default timeout of a lookup that is shared by concurrent callers.
+const DefaultFetchTimeout = 30 * time.Second
+
+type Config struct {
+	// MaxWeight is the maximum total weight of the cached states. The weight of a state is the number of operator
+	// entries it holds, plus one.
+	MaxWeight uint64
+	// FetchTimeout is the timeout of a lookup. Lookups are shared by the concurrent callers of the same method with
+	// the same arguments, so they are not canceled when one of the callers gives up.
+	FetchTimeout time.Duration
+}
+
+// ChainState is a core.ChainState that caches the states returned by another core.ChainState. The reference blocks
+// queried through it must be final, since the state of a block is cached regardless of reorgs. The cached states
+// are shared between callers and must not be modified.
+type ChainState struct {
+	base core.ChainState
+
+	config  Config
+	cache   *weightedCache
+	group   singleflight.Group
+	metrics *metrics
+}
+
+var _ core.ChainState = (*ChainState)(nil)
+
+// NewChainState creates a ChainState that caches the states returned by base. The metrics are registered with the
+// registry under the given namespace.
+func NewChainState(
+	base core.ChainState,
+	config Config,
+	registry *prometheus.Registry,
+	namespace string,
+) (*ChainState, error) {
+	if base == nil {
+		return nil, errors.New("base chain state is required")
+	}
+	if config.MaxWeight == 0 {
+		return nil, errors.New("max weight must be positive")
+	}
+	if config.FetchTimeout <= 0 {
+		config.FetchTimeout = DefaultFetchTimeout
+	}
+
+	return &ChainState{
+		base:    base,
+		config:  config,
+		cache:   newWeightedCache(config.MaxWeight),
+		metrics: newMetrics(registry, namespace),
+	}, nil
+}
+
+func (cs *ChainState) GetCurrentBlockNumber() (uint, error) {
+	return cs.base.GetCurrentBlockNumber()
+}
+
+func (cs *ChainState) GetOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.OperatorState, error) {
+	key := fmt.Sprintf("GetOperatorState/%d/%s", blockNumber, quorumsKey(quorums))
+	value, err := cs.lookup(ctx, "GetOperatorState", key, func(ctx context.Context) (any, uint64, error) {
+		state, err := cs.base.GetOperatorState(ctx, blockNumber, quorums)
+		if err != nil {
+			return nil, 0, err
+		}
+		return state, operatorStateWeight(state), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*core.OperatorState), nil
+}
+
+func (cs *ChainState) GetOperatorStateByOperator(ctx context.Context, blockNumber uint, operator core.OperatorID) (*core.OperatorState, error) {
+	key := fmt.Sprintf("GetOperatorStateByOperator/%d/%s", blockNumber, operator.Hex())
+	value, err := cs.lookup(ctx, "GetOperatorStateByOperator", key, func(ctx context.Context) (any, uint64, error) {
+		state, err := cs.base.GetOperatorStateByOperator(ctx, blockNumber, operator)
+		if err != nil {
+			return nil, 0, err
+		}
+		return state, operatorStateWeight(state), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*core.OperatorState), nil
+}
+
+func (cs *ChainState) GetOperatorSocket(ctx context.Context, blockNumber uint, operator core.OperatorID) (string, error) {
+	key := fmt.Sprintf("GetOperatorSocket/%d/%s", blockNumber, operator.Hex())
+	value, err := cs.lookup(ctx, "GetOperatorSocket", key, func(ctx context.Context) (any, uint64, error) {
+		socket, err := cs.base.GetOperatorSocket(ctx, blockNumber, operator)
+		if err != nil {
+			return nil, 0, err
+		}
+		return socket, 1, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+// lookup returns the cached value for the key, or fetches it. Concurrent lookups of the same key share a single
+// fetch; each caller stops waiting when its own context is done.
+func (cs *ChainState) lookup(
+	ctx context.Context,
+	method string,
+	key string,
+	fetch func(ctx context.Context) (any, uint64, error),
+) (any, error) {
+	if value, ok := cs.cache.get(key); ok {
+		cs.metrics.reportLookup(method, hitResult)
+		return value, nil
+	}
+	cs.metrics.reportLookup(method, missResult)
+
+	result := cs.group.DoChan(key, func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cs.config.FetchTimeout)
+		defer cancel()
+
+		value, weight, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+		cs.cache.put(key, value, weight)
+		cs.metrics.reportCacheStats(cs.cache.stats())
+		return value, nil
+	})
+
+	select {
+	case r := <-result:
+		return r.Val, r.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// IndexedChainState is a core.IndexedChainState that caches the states returned by another
+// core.IndexedChainState. See ChainState.
+type IndexedChainState struct {
+	*ChainState
+	base core.IndexedChainState
+}
+
+var _ core.IndexedChainState = (*IndexedChainState)(nil)
+
+// NewIndexedChainState creates an IndexedChainState that caches the states returned by base. The metrics are
+// registered with the registry under the given namespace.
+func NewIndexedChainState(
+	base core.IndexedChainState,
+	config Config,
+	registry *prometheus.Registry,
+	namespace string,
+) (*IndexedChainState, error) {
+	if base == nil {
+		return nil, errors.New("base chain state is required")
+	}
+	cs, err := NewChainState(base, config, registry, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &IndexedChainState{
+		ChainState: cs,
+		base:       base,
+	}, nil
+}
+
+func (ics *IndexedChainState) Start(ctx context.Context) error {
+	return ics.base.Start(ctx)
+}
+
+func (ics *IndexedChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	key := fmt.Sprintf("GetIndexedOperatorState/%d/%s", blockNumber, quorumsKey(quorums))
+	value, err := ics.lookup(ctx, "GetIndexedOperatorState", key, func(ctx context.Context) (any, uint64, error) {
+		state, err := ics.base.GetIndexedOperatorState(ctx, blockNumber, quorums)
+		if err != nil {
+			return nil, 0, err
+		}
+		weight := uint64(len(state.IndexedOperators))
+		if state.OperatorState != nil {
+			weight += operatorStateWeight(state.OperatorState)
+		}
+		return state, weight, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*core.IndexedOperatorState), nil
+}
+
+func (ics *IndexedChainState) GetIndexedOperators(ctx context.Context, blockNumber uint) (map[core.OperatorID]*core.IndexedOperatorInfo, error) {
+	key := fmt.Sprintf("GetIndexedOperators/%d", blockNumber)
+	value, err := ics.lookup(ctx, "GetIndexedOperators", key, func(ctx context.Context) (any, uint64, error) {
+		operators, err := ics.base.GetIndexedOperators(ctx, blockNumber)
+		if err != nil {
+			return nil, 0, err
+		}
+		return operators, uint64(len(operators)) + 1, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(map[core.OperatorID]*core.IndexedOperatorInfo), nil
+}
+
+// quorumsKey returns a key that is the same for any order of the quorums.
+func quorumsKey(quorums []core.QuorumID) string {
+	sorted := slices.Clone(quorums)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	ids := make([]string, len(sorted))
+	for i, quorum := range sorted {
+		ids[i] = fmt.Sprint(quorum)
+	}
+	return strings.Join(ids, ",")
+}
+
+func operatorStateWeight(state *core.OperatorState) uint64 {
+	weight := uint64(1)
+	for _, operators := range state.Operators {
+		weight += uint64(len(operators))
+	}
+	return weight
+}
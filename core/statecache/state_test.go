@@ -0,0 +1,202 @@
+package statecache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/statecache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// countingChainState returns a state with numOperators operators per quorum, and counts the lookups.
+type countingChainState struct {
+	numOperators int
+	lookups      atomic.Int32
+	// release, if set, blocks lookups until it is closed
+	release chan struct{}
+	err     error
+}
+
+var _ core.IndexedChainState = (*countingChainState)(nil)
+
+func (cs *countingChainState) state(blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	cs.lookups.Add(1)
+	if cs.release != nil {
+		<-cs.release
+	}
+	if cs.err != nil {
+		return nil, cs.err
+	}
+
+	operators := make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo)
+	indexed := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	for _, quorum := range quorums {
+		operators[quorum] = make(map[core.OperatorID]*core.OperatorInfo)
+		for i := 0; i < cs.numOperators; i++ {
+			id := core.OperatorID{byte(quorum), byte(i)}
+			operators[quorum][id] = &core.OperatorInfo{Index: core.OperatorIndex(i)}
+			indexed[id] = &core.IndexedOperatorInfo{}
+		}
+	}
+	return &core.IndexedOperatorState{
+		OperatorState: &core.OperatorState{
+			Operators:   operators,
+			BlockNumber: blockNumber,
+		},
+		IndexedOperators: indexed,
+	}, nil
+}
+
+func (cs *countingChainState) GetCurrentBlockNumber() (uint, error) {
+	return 0, nil
+}
+
+func (cs *countingChainState) GetOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.OperatorState, error) {
+	state, err := cs.state(blockNumber, quorums)
+	if err != nil {
+		return nil, err
+	}
+	return state.OperatorState, nil
+}
+
+func (cs *countingChainState) GetOperatorStateByOperator(ctx context.Context, blockNumber uint, operator core.OperatorID) (*core.OperatorState, error) {
+	return cs.GetOperatorState(ctx, blockNumber, []core.QuorumID{0})
+}
+
+func (cs *countingChainState) GetOperatorSocket(ctx context.Context, blockNumber uint, operator core.OperatorID) (string, error) {
+	cs.lookups.Add(1)
+	return "localhost:32005;32006", nil
+}
+
+func (cs *countingChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	return cs.state(blockNumber, quorums)
+}
+
+func (cs *countingChainState) GetIndexedOperators(ctx context.Context, blockNumber uint) (map[core.OperatorID]*core.IndexedOperatorInfo, error) {
+	state, err := cs.state(blockNumber, []core.QuorumID{0})
+	if err != nil {
+		return nil, err
+	}
+	return state.IndexedOperators, nil
+}
+
+func (cs *countingChainState) Start(ctx context.Context) error {
+	return nil
+}
+
+func newTestCache(t *testing.T, base *countingChainState, maxWeight uint64) *statecache.IndexedChainState {
+	ics, err := statecache.NewIndexedChainState(
+		base, statecache.Config{MaxWeight: maxWeight}, prometheus.NewRegistry(), "test")
+	require.NoError(t, err)
+	return ics
+}
+
+func TestCacheHit(t *testing.T) {
+	ctx := context.Background()
+	base := &countingChainState{numOperators: 3}
+	ics := newTestCache(t, base, 1000)
+
+	state, err := ics.GetIndexedOperatorState(ctx, 10, []core.QuorumID{0, 1})
+	require.NoError(t, err)
+	require.Len(t, state.IndexedOperators, 6)
+
+	// the order of the quorums does not matter
+	cached, err := ics.GetIndexedOperatorState(ctx, 10, []core.QuorumID{1, 0})
+	require.NoError(t, err)
+	require.Same(t, state, cached)
+	require.Equal(t, int32(1), base.lookups.Load())
+
+	// other blocks and methods are looked up separately
+	_, err = ics.GetIndexedOperatorState(ctx, 11, []core.QuorumID{0, 1})
+	require.NoError(t, err)
+	_, err = ics.GetOperatorState(ctx, 10, []core.QuorumID{0, 1})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), base.lookups.Load())
+
+	for i := 0; i < 2; i++ {
+		_, err = ics.GetOperatorSocket(ctx, 10, core.OperatorID{1})
+		require.NoError(t, err)
+		_, err = ics.GetIndexedOperators(ctx, 10)
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(5), base.lookups.Load())
+}
+
+func TestCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	base := &countingChainState{numOperators: 10}
+	// each state weighs 1 + 10 operators
+	ics, err := statecache.NewChainState(base, statecache.Config{MaxWeight: 25}, nil, "test")
+	require.NoError(t, err)
+
+	for _, block := range []uint{1, 2, 1, 3} {
+		_, err := ics.GetOperatorState(ctx, block, []core.QuorumID{0})
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(3), base.lookups.Load())
+
+	// block 2 was the least recently used, so it was evicted to make room for block 3
+	_, err = ics.GetOperatorState(ctx, 1, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), base.lookups.Load())
+	_, err = ics.GetOperatorState(ctx, 2, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), base.lookups.Load())
+}
+
+func TestCacheErrorsAreNotCached(t *testing.T) {
+	ctx := context.Background()
+	base := &countingChainState{numOperators: 1, err: errors.New("unavailable")}
+	ics := newTestCache(t, base, 1000)
+
+	_, err := ics.GetIndexedOperatorState(ctx, 10, []core.QuorumID{0})
+	require.Error(t, err)
+
+	base.err = nil
+	_, err = ics.GetIndexedOperatorState(ctx, 10, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), base.lookups.Load())
+}
+
+func TestConcurrentLookupsAreCoalesced(t *testing.T) {
+	base := &countingChainState{numOperators: 1, release: make(chan struct{})}
+	ics := newTestCache(t, base, 1000)
+
+	// a caller that gives up does not cancel the lookup for the others
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := ics.GetIndexedOperatorState(canceledCtx, 10, []core.QuorumID{0})
+		canceled <- err
+	}()
+	require.Eventually(t, func() bool { return base.lookups.Load() == 1 }, time.Second, time.Millisecond)
+
+	const numCallers = 10
+	states := make([]*core.IndexedOperatorState, numCallers)
+	var wg sync.WaitGroup
+	for i := 0; i < numCallers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			state, err := ics.GetIndexedOperatorState(context.Background(), 10, []core.QuorumID{0})
+			require.NoError(t, err)
+			states[i] = state
+		}(i)
+	}
+
+	cancel()
+	require.ErrorIs(t, <-canceled, context.Canceled)
+
+	close(base.release)
+	wg.Wait()
+	require.Equal(t, int32(1), base.lookups.Load())
+	for _, state := range states {
+		require.Same(t, states[0], state)
+	}
+}
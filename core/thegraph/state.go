@@ -123,6 +123,13 @@ func (ics *indexedChainState) Start(ctx context.Context) error {
 	}
 }
 
+// CheckHealth checks that the subgraph answers queries.
+func (ics *indexedChainState) CheckHealth(ctx context.Context) error {
+	return ics.querier.Query(ctx, &queryFirstOperatorGql{}, map[string]any{
+		"first": graphql.Int(1),
+	})
+}
+
 func (ics *indexedChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
 	operatorState, err := ics.ChainState.GetOperatorState(ctx, blockNumber, quorums)
 	if err != nil {
@@ -2,11 +2,13 @@ package v2
 
 type BlobRequestAuthenticator interface {
 	AuthenticateBlobRequest(header *BlobHeader) error
-	AuthenticatePaymentStateRequest(signature []byte, accountId string) error
+	AuthenticatePaymentStateRequest(signature []byte, accountId string, timestamp uint64, nonce []byte) error
+	AuthenticateGetChunksRequest(signature []byte, accountId string, blobKey []byte, quorumID uint32, timestamp uint64) error
 }
 
 type BlobRequestSigner interface {
 	SignBlobRequest(header *BlobHeader) ([]byte, error)
-	SignPaymentStateRequest() ([]byte, error)
+	SignPaymentStateRequest(timestamp uint64, nonce []byte) ([]byte, error)
+	SignGetChunksRequest(blobKey []byte, quorumID uint32, timestamp uint64) ([]byte, error)
 	GetAccountID() (string, error)
 }
@@ -0,0 +1,76 @@
+package v2
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetentionTier selects how long the network holds a blob. Every tier holds a blob for a multiple of the standard
+// retention period, and is billed as if the blob were that many times larger.
+type RetentionTier uint8
+
+const (
+	// RetentionTierStandard holds a blob for the standard retention period.
+	RetentionTierStandard RetentionTier = iota
+	// RetentionTierExtended holds a blob for twice the standard retention period.
+	RetentionTierExtended
+	// RetentionTierArchival holds a blob for four times the standard retention period, e.g. to outlast fraud proof
+	// windows.
+	RetentionTierArchival
+
+	// MaxRetentionTier is the highest valid retention tier.
+	MaxRetentionTier = RetentionTierArchival
+)
+
+var retentionTierMultipliers = map[RetentionTier]uint{
+	RetentionTierStandard: 1,
+	RetentionTierExtended: 2,
+	RetentionTierArchival: 4,
+}
+
+var retentionTierNames = map[RetentionTier]string{
+	RetentionTierStandard: "standard",
+	RetentionTierExtended: "extended",
+	RetentionTierArchival: "archival",
+}
+
+// RetentionTierFromString parses the name of a retention tier.
+func RetentionTierFromString(name string) (RetentionTier, error) {
+	for tier, tierName := range retentionTierNames {
+		if tierName == name {
+			return tier, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown retention tier %q", name)
+}
+
+// Validate returns an error if the tier is not a known retention tier.
+func (t RetentionTier) Validate() error {
+	if t > MaxRetentionTier {
+		return fmt.Errorf("invalid retention tier %d; maximum is %d", t, MaxRetentionTier)
+	}
+	return nil
+}
+
+func (t RetentionTier) String() string {
+	if name, ok := retentionTierNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(t))
+}
+
+// Multiplier returns the multiple of the standard retention period the tier holds a blob for. It is 0 for an
+// invalid tier.
+func (t RetentionTier) Multiplier() uint {
+	return retentionTierMultipliers[t]
+}
+
+// Retention returns how long the tier holds a blob, given the standard retention period.
+func (t RetentionTier) Retention(standard time.Duration) time.Duration {
+	return standard * time.Duration(t.Multiplier())
+}
+
+// BilledSymbols returns the number of symbols a blob of numSymbols symbols is metered as.
+func (t RetentionTier) BilledSymbols(numSymbols uint) uint {
+	return numSymbols * t.Multiplier()
+}
@@ -0,0 +1,31 @@
+package v2_test
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionTier(t *testing.T) {
+	standard := 14 * 24 * time.Hour
+
+	assert.Equal(t, standard, v2.RetentionTierStandard.Retention(standard))
+	assert.Equal(t, 2*standard, v2.RetentionTierExtended.Retention(standard))
+	assert.Equal(t, 4*standard, v2.RetentionTierArchival.Retention(standard))
+
+	assert.Equal(t, uint(4096), v2.RetentionTierStandard.BilledSymbols(4096))
+	assert.Equal(t, uint(4*4096), v2.RetentionTierArchival.BilledSymbols(4096))
+
+	for tier := v2.RetentionTierStandard; tier <= v2.MaxRetentionTier; tier++ {
+		require.NoError(t, tier.Validate())
+		parsed, err := v2.RetentionTierFromString(tier.String())
+		require.NoError(t, err)
+		assert.Equal(t, tier, parsed)
+	}
+	assert.Error(t, (v2.MaxRetentionTier + 1).Validate())
+	_, err := v2.RetentionTierFromString("forever")
+	assert.Error(t, err)
+}
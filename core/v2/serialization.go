@@ -99,7 +99,7 @@ func (b *BlobHeader) BlobKey() (BlobKey, error) {
 		},
 	}
 
-	packedBytes, err := arguments.Pack(
+	values := []interface{}{
 		b.BlobVersion,
 		b.QuorumNumbers,
 		abiBlobCommitments{
@@ -129,7 +129,8 @@ func (b *BlobHeader) BlobKey() (BlobKey, error) {
 			},
 			DataLength: uint32(b.BlobCommitments.Length),
 		},
-	)
+	}
+	packedBytes, err := arguments.Pack(values...)
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -159,7 +160,7 @@ func (b *BlobHeader) BlobKey() (BlobKey, error) {
 		},
 	}
 
-	paymentMetadataHash, err := b.PaymentMetadata.Hash()
+	paymentMetadataHash, err := b.PaymentHeaderHash()
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -185,6 +186,17 @@ func (b *BlobHeader) BlobKey() (BlobKey, error) {
 	return blobKey, nil
 }
 
+// PaymentHeaderHash returns the payment header hash of the blob header, as carried by the BlobHeaderV2 of certs.
+// The retention tier is part of the payment header, since the EigenDA contracts hash the payment header hash without
+// interpreting it. Certs of blobs of every tier therefore verify on chain, and the payment header hash of standard
+// blobs is unchanged.
+func (b *BlobHeader) PaymentHeaderHash() ([32]byte, error) {
+	if b.RetentionTier == RetentionTierStandard {
+		return b.PaymentMetadata.Hash()
+	}
+	return b.PaymentMetadata.HashWithRetentionTier(uint8(b.RetentionTier))
+}
+
 func (c *BlobCertificate) Hash() ([32]byte, error) {
 	if c.BlobHeader == nil {
 		return [32]byte{}, fmt.Errorf("blob header is nil")
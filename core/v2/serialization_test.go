@@ -58,6 +58,55 @@ func TestBlobKeyFromHeader(t *testing.T) {
 	assert.Equal(t, "22c9e31c3d79c7c4085b564113f488019cbae18198c9a4fc4ecd70a5742e8638", blobKey.Hex())
 }
 
+func TestBlobKeyRetentionTier(t *testing.T) {
+	data := codec.ConvertByPaddingEmptyByte(GETTYSBURG_ADDRESS_BYTES)
+	commitments, err := p.GetCommitmentsForPaddedLength(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bh := v2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0, 1},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         "0x123",
+			ReservationPeriod: 5,
+			CumulativePayment: big.NewInt(100),
+			Salt:              42,
+		},
+		Signature:     []byte{1, 2, 3},
+		RetentionTier: v2.RetentionTierStandard,
+	}
+	standardKey, err := bh.BlobKey()
+	assert.NoError(t, err)
+	// the key of a standard blob does not depend on the retention tier
+	assert.Equal(t, "22c9e31c3d79c7c4085b564113f488019cbae18198c9a4fc4ecd70a5742e8638", standardKey.Hex())
+
+	bh.RetentionTier = v2.RetentionTierExtended
+	extendedKey, err := bh.BlobKey()
+	assert.NoError(t, err)
+	bh.RetentionTier = v2.RetentionTierArchival
+	archivalKey, err := bh.BlobKey()
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, standardKey, extendedKey)
+	assert.NotEqual(t, standardKey, archivalKey)
+	assert.NotEqual(t, extendedKey, archivalKey)
+
+	// the tier is carried by the payment header hash, which the contracts hash without interpreting
+	bh.RetentionTier = v2.RetentionTierStandard
+	standardPaymentHash, err := bh.PaymentHeaderHash()
+	assert.NoError(t, err)
+	paymentMetadataHash, err := bh.PaymentMetadata.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, paymentMetadataHash, standardPaymentHash)
+	bh.RetentionTier = v2.RetentionTierArchival
+	archivalPaymentHash, err := bh.PaymentHeaderHash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, standardPaymentHash, archivalPaymentHash)
+}
+
 func TestBatchHeaderHash(t *testing.T) {
 	batchRoot := [32]byte{}
 	copy(batchRoot[:], []byte("1"))
@@ -72,6 +72,9 @@ type BlobHeader struct {
 
 	// Signature is the signature of the blob header by the account ID
 	Signature []byte
+
+	// RetentionTier selects how long the network holds the blob
+	RetentionTier RetentionTier
 }
 
 func BlobHeaderFromProtobuf(proto *commonpb.BlobHeader) (*BlobHeader, error) {
@@ -113,6 +116,10 @@ func BlobHeaderFromProtobuf(proto *commonpb.BlobHeader) (*BlobHeader, error) {
 		return nil, errors.New("payment metadata is nil")
 	}
 
+	if proto.GetRetentionTier() > uint32(MaxRetentionTier) {
+		return nil, fmt.Errorf("invalid retention tier %d; maximum is %d", proto.GetRetentionTier(), MaxRetentionTier)
+	}
+
 	return &BlobHeader{
 		BlobVersion: BlobVersion(proto.GetVersion()),
 		BlobCommitments: encoding.BlobCommitments{
@@ -124,6 +131,7 @@ func BlobHeaderFromProtobuf(proto *commonpb.BlobHeader) (*BlobHeader, error) {
 		QuorumNumbers:   quorumNumbers,
 		PaymentMetadata: *paymentMetadata,
 		Signature:       proto.GetSignature(),
+		RetentionTier:   RetentionTier(proto.GetRetentionTier()),
 	}, nil
 }
 
@@ -144,6 +152,7 @@ func (b *BlobHeader) ToProtobuf() (*commonpb.BlobHeader, error) {
 		Commitment:    commitments,
 		PaymentHeader: b.PaymentMetadata.ToProtobuf(),
 		Signature:     b.Signature,
+		RetentionTier: uint32(b.RetentionTier),
 	}, nil
 }
 
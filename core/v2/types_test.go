@@ -82,7 +82,8 @@ func TestConvertBlobHeaderToFromProtobuf(t *testing.T) {
 			ReservationPeriod: 5,
 			CumulativePayment: big.NewInt(100),
 		},
-		Signature: []byte{1, 2, 3},
+		Signature:     []byte{1, 2, 3},
+		RetentionTier: v2.RetentionTierExtended,
 	}
 
 	pb, err := bh.ToProtobuf()
@@ -92,6 +93,10 @@ func TestConvertBlobHeaderToFromProtobuf(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, bh, newBH)
+
+	pb.RetentionTier = uint32(v2.MaxRetentionTier) + 1
+	_, err = v2.BlobHeaderFromProtobuf(pb)
+	assert.Error(t, err)
 }
 
 func TestConvertBlobCertToFromProtobuf(t *testing.T) {
@@ -20,6 +20,54 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// EncodingPriority is used by the encoder to order queued requests. Requests backed by a reservation are
+// scheduled before on-demand requests.
+type EncodingPriority int32
+
+const (
+	EncodingPriority_ON_DEMAND   EncodingPriority = 0
+	EncodingPriority_RESERVATION EncodingPriority = 1
+)
+
+// Enum value maps for EncodingPriority.
+var (
+	EncodingPriority_name = map[int32]string{
+		0: "ON_DEMAND",
+		1: "RESERVATION",
+	}
+	EncodingPriority_value = map[string]int32{
+		"ON_DEMAND":   0,
+		"RESERVATION": 1,
+	}
+)
+
+func (x EncodingPriority) Enum() *EncodingPriority {
+	p := new(EncodingPriority)
+	*p = x
+	return p
+}
+
+func (x EncodingPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EncodingPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_encoder_encoder_proto_enumTypes[0].Descriptor()
+}
+
+func (EncodingPriority) Type() protoreflect.EnumType {
+	return &file_encoder_encoder_proto_enumTypes[0]
+}
+
+func (x EncodingPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EncodingPriority.Descriptor instead.
+func (EncodingPriority) EnumDescriptor() ([]byte, []int) {
+	return file_encoder_encoder_proto_rawDescGZIP(), []int{0}
+}
+
 type ChunkEncodingFormat int32
 
 const (
@@ -53,11 +101,11 @@ func (x ChunkEncodingFormat) String() string {
 }
 
 func (ChunkEncodingFormat) Descriptor() protoreflect.EnumDescriptor {
-	return file_encoder_encoder_proto_enumTypes[0].Descriptor()
+	return file_encoder_encoder_proto_enumTypes[1].Descriptor()
 }
 
 func (ChunkEncodingFormat) Type() protoreflect.EnumType {
-	return &file_encoder_encoder_proto_enumTypes[0]
+	return &file_encoder_encoder_proto_enumTypes[1]
 }
 
 func (x ChunkEncodingFormat) Number() protoreflect.EnumNumber {
@@ -66,7 +114,7 @@ func (x ChunkEncodingFormat) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ChunkEncodingFormat.Descriptor instead.
 func (ChunkEncodingFormat) EnumDescriptor() ([]byte, []int) {
-	return file_encoder_encoder_proto_rawDescGZIP(), []int{0}
+	return file_encoder_encoder_proto_rawDescGZIP(), []int{1}
 }
 
 // BlobCommitments contains the blob's commitment, degree proof, and the actual degree
@@ -206,6 +254,8 @@ type EncodeBlobRequest struct {
 
 	Data           []byte          `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	EncodingParams *EncodingParams `protobuf:"bytes,2,opt,name=encoding_params,json=encodingParams,proto3" json:"encoding_params,omitempty"`
+	// A hint of how urgently the blob should be encoded, relative to other requests of the same size class.
+	Priority EncodingPriority `protobuf:"varint,3,opt,name=priority,proto3,enum=encoder.EncodingPriority" json:"priority,omitempty"`
 }
 
 func (x *EncodeBlobRequest) Reset() {
@@ -254,6 +304,13 @@ func (x *EncodeBlobRequest) GetEncodingParams() *EncodingParams {
 	return nil
 }
 
+func (x *EncodeBlobRequest) GetPriority() EncodingPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return EncodingPriority_ON_DEMAND
+}
+
 // EncodeBlobReply returns all encoded chunks along with BlobCommitment for the same,
 // where Chunk is the smallest unit that is distributed to DA nodes
 type EncodeBlobReply struct {
@@ -340,37 +397,43 @@ var file_encoder_encoder_proto_rawDesc = []byte{
 	0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
 	0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x22,
-	0x69, 0x0a, 0x11, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x6f,
-	0x64, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x63, 0x6f,
-	0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x0e, 0x65, 0x6e, 0x63, 0x6f,
-	0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x0f, 0x45,
-	0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37,
-	0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f,
-	0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12,
-	0x50, 0x0a, 0x15, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e,
-	0x67, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c,
-	0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e,
-	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x13, 0x63, 0x68,
-	0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61,
-	0x74, 0x2a, 0x36, 0x0a, 0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69,
-	0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
-	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x4e, 0x41, 0x52, 0x4b, 0x10, 0x01,
-	0x12, 0x07, 0x0a, 0x03, 0x47, 0x4f, 0x42, 0x10, 0x02, 0x32, 0x4f, 0x0a, 0x07, 0x45, 0x6e, 0x63,
-	0x6f, 0x64, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0a, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c,
-	0x6f, 0x62, 0x12, 0x1a, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x63,
-	0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0xa0, 0x01, 0x0a, 0x11, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0f, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x0e, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x70,
+	0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e,
+	0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x22, 0xb4, 0x01, 0x0a, 0x0f, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f,
+	0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x50, 0x0a, 0x15, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x52, 0x13, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x2a, 0x32, 0x0a, 0x10, 0x45, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x0d, 0x0a,
+	0x09, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x4d, 0x41, 0x4e, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b,
+	0x52, 0x45, 0x53, 0x45, 0x52, 0x56, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x2a, 0x36, 0x0a,
+	0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x4e, 0x41, 0x52, 0x4b, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
+	0x47, 0x4f, 0x42, 0x10, 0x02, 0x32, 0x4f, 0x0a, 0x07, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x12, 0x44, 0x0a, 0x0a, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x1a,
 	0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42,
-	0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61,
-	0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67,
-	0x72, 0x70, 0x63, 0x2f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65,
+	0x69, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f,
+	0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -385,26 +448,28 @@ func file_encoder_encoder_proto_rawDescGZIP() []byte {
 	return file_encoder_encoder_proto_rawDescData
 }
 
-var file_encoder_encoder_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_encoder_encoder_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
 var file_encoder_encoder_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_encoder_encoder_proto_goTypes = []interface{}{
-	(ChunkEncodingFormat)(0),  // 0: encoder.ChunkEncodingFormat
-	(*BlobCommitment)(nil),    // 1: encoder.BlobCommitment
-	(*EncodingParams)(nil),    // 2: encoder.EncodingParams
-	(*EncodeBlobRequest)(nil), // 3: encoder.EncodeBlobRequest
-	(*EncodeBlobReply)(nil),   // 4: encoder.EncodeBlobReply
+	(EncodingPriority)(0),     // 0: encoder.EncodingPriority
+	(ChunkEncodingFormat)(0),  // 1: encoder.ChunkEncodingFormat
+	(*BlobCommitment)(nil),    // 2: encoder.BlobCommitment
+	(*EncodingParams)(nil),    // 3: encoder.EncodingParams
+	(*EncodeBlobRequest)(nil), // 4: encoder.EncodeBlobRequest
+	(*EncodeBlobReply)(nil),   // 5: encoder.EncodeBlobReply
 }
 var file_encoder_encoder_proto_depIdxs = []int32{
-	2, // 0: encoder.EncodeBlobRequest.encoding_params:type_name -> encoder.EncodingParams
-	1, // 1: encoder.EncodeBlobReply.commitment:type_name -> encoder.BlobCommitment
-	0, // 2: encoder.EncodeBlobReply.chunk_encoding_format:type_name -> encoder.ChunkEncodingFormat
-	3, // 3: encoder.Encoder.EncodeBlob:input_type -> encoder.EncodeBlobRequest
-	4, // 4: encoder.Encoder.EncodeBlob:output_type -> encoder.EncodeBlobReply
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 0: encoder.EncodeBlobRequest.encoding_params:type_name -> encoder.EncodingParams
+	0, // 1: encoder.EncodeBlobRequest.priority:type_name -> encoder.EncodingPriority
+	2, // 2: encoder.EncodeBlobReply.commitment:type_name -> encoder.BlobCommitment
+	1, // 3: encoder.EncodeBlobReply.chunk_encoding_format:type_name -> encoder.ChunkEncodingFormat
+	4, // 4: encoder.Encoder.EncodeBlob:input_type -> encoder.EncodeBlobRequest
+	5, // 5: encoder.Encoder.EncodeBlob:output_type -> encoder.EncodeBlobReply
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_encoder_encoder_proto_init() }
@@ -467,7 +532,7 @@ func file_encoder_encoder_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_encoder_encoder_proto_rawDesc,
-			NumEnums:      1,
+			NumEnums:      2,
 			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
@@ -2,6 +2,7 @@ package apiserver
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -12,15 +13,19 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 )
 
-func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (*pb.DisperseBlobReply, error) {
+func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (reply *pb.DisperseBlobReply, err error) {
+	// authenticated is set once the signature of the blob header is verified, including for requests rejected later.
+	authenticated := false
 	start := time.Now()
 	defer func() {
 		s.metrics.reportDisperseBlobLatency(time.Since(start))
+		s.auditDispersal(req, authenticated, reply, err)
 	}()
 
 	onchainState := s.onchainState.Load()
@@ -28,7 +33,8 @@ func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBl
 		return nil, api.NewErrorInternal("onchain state is nil")
 	}
 
-	if err := s.validateDispersalRequest(ctx, req, onchainState); err != nil {
+	authenticated, err = s.validateDispersalRequest(ctx, req, onchainState)
+	if err != nil {
 		return nil, err
 	}
 
@@ -44,7 +50,9 @@ func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBl
 	}
 	s.logger.Debug("received a new blob dispersal request", "blobSizeBytes", len(data), "quorums", req.GetBlobHeader().GetQuorumNumbers())
 
-	blobKey, err := s.StoreBlob(ctx, data, blobHeader, time.Now(), onchainState.TTL)
+	// the blob is held for a multiple of the standard TTL determined by its retention tier
+	ttl := blobHeader.RetentionTier.Retention(onchainState.TTL)
+	blobKey, err := s.StoreBlob(ctx, data, blobHeader, time.Now(), ttl)
 	if err != nil {
 		return nil, err
 	}
@@ -57,13 +65,50 @@ func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBl
 	}, nil
 }
 
+// auditDispersal records the decision on a dispersal request in the audit log. The request is only attributed to
+// the account of its payment header if its signature was verified. The blob key of a rejected request is recorded if
+// its blob header is well formed.
+func (s *DispersalServerV2) auditDispersal(req *pb.DisperseBlobRequest, authenticated bool, reply *pb.DisperseBlobReply, err error) {
+	if s.auditLog == nil {
+		return
+	}
+
+	paymentHeader := req.GetBlobHeader().GetPaymentHeader()
+	record := &audit.Record{
+		APIVersion: "v2",
+		Method:     "DisperseBlob",
+		BlobSize:   len(req.GetData()),
+		Quorums:    req.GetBlobHeader().GetQuorumNumbers(),
+	}
+	record.SetAccount(paymentHeader.GetAccountId(), authenticated)
+	if paymentHeader != nil {
+		if new(big.Int).SetBytes(paymentHeader.GetCumulativePayment()).Sign() == 0 {
+			record.PaymentMode = audit.PaymentModeReservation
+		} else {
+			record.PaymentMode = audit.PaymentModeOnDemand
+		}
+	}
+	if reply != nil {
+		record.BlobKey = hex.EncodeToString(reply.GetBlobKey())
+	} else if req.GetBlobHeader().GetCommitment() != nil {
+		if blobHeader, headerErr := corev2.BlobHeaderFromProtobuf(req.GetBlobHeader()); headerErr == nil {
+			if blobKey, keyErr := blobHeader.BlobKey(); keyErr == nil {
+				record.BlobKey = blobKey.Hex()
+			}
+		}
+	}
+	record.SetOutcome(err)
+	s.auditLog.Record(record)
+}
+
 func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHeader *corev2.BlobHeader, requestedAt time.Time, ttl time.Duration) (corev2.BlobKey, error) {
 	blobKey, err := blobHeader.BlobKey()
 	if err != nil {
 		return corev2.BlobKey{}, api.NewErrorInvalidArg(fmt.Sprintf("failed to get blob key: %v", err))
 	}
 
-	if err := s.blobStore.StoreBlob(ctx, blobKey, data); err != nil {
+	deduplicated, err := s.blobStore.StoreBlobWithRetention(ctx, blobKey, data, blobHeader.RetentionTier)
+	if err != nil {
 		s.logger.Warn("failed to store blob", "err", err, "blobKey", blobKey.Hex())
 		if errors.Is(err, common.ErrAlreadyExists) {
 			return corev2.BlobKey{}, api.NewErrorAlreadyExists(fmt.Sprintf("blob already exists: %s", blobKey.Hex()))
@@ -71,6 +116,9 @@ func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHead
 
 		return corev2.BlobKey{}, api.NewErrorInternal(fmt.Sprintf("failed to store blob: %v", err))
 	}
+	if deduplicated {
+		s.metrics.reportDeduplicatedBlob(len(data))
+	}
 
 	blobMetadata := &dispv2.BlobMetadata{
 		BlobHeader:  blobHeader,
@@ -93,46 +141,48 @@ func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHead
 	return blobKey, err
 }
 
-func (s *DispersalServerV2) validateDispersalRequest(ctx context.Context, req *pb.DisperseBlobRequest, onchainState *OnchainState) error {
+// validateDispersalRequest validates a dispersal request, and meters it. It returns whether the signature of the
+// blob header was verified, which holds even if the request is rejected afterwards.
+func (s *DispersalServerV2) validateDispersalRequest(ctx context.Context, req *pb.DisperseBlobRequest, onchainState *OnchainState) (bool, error) {
 	data := req.GetData()
 	blobSize := len(data)
 	if blobSize == 0 {
-		return api.NewErrorInvalidArg("blob size must be greater than 0")
+		return false, api.NewErrorInvalidArg("blob size must be greater than 0")
 	}
 	blobLength := encoding.GetBlobLengthPowerOf2(uint(blobSize))
 	if blobLength > uint(s.maxNumSymbolsPerBlob) {
-		return api.NewErrorInvalidArg("blob size too big")
+		return false, api.NewErrorInvalidArg("blob size too big")
 	}
 
 	blobHeaderProto := req.GetBlobHeader()
 	if blobHeaderProto.GetCommitment() == nil {
-		return api.NewErrorInvalidArg("blob header must contain commitments")
+		return false, api.NewErrorInvalidArg("blob header must contain commitments")
 	}
 
 	blobHeader, err := corev2.BlobHeaderFromProtobuf(blobHeaderProto)
 	if err != nil {
-		return api.NewErrorInvalidArg(fmt.Sprintf("invalid blob header: %s", err.Error()))
+		return false, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob header: %s", err.Error()))
 	}
 
 	if blobHeader.PaymentMetadata == (core.PaymentMetadata{}) {
-		return api.NewErrorInvalidArg("payment metadata is required")
+		return false, api.NewErrorInvalidArg("payment metadata is required")
 	}
 
 	if len(blobHeader.PaymentMetadata.AccountID) == 0 || (blobHeader.PaymentMetadata.ReservationPeriod == 0 && blobHeader.PaymentMetadata.CumulativePayment.Cmp(big.NewInt(0)) == 0) {
-		return api.NewErrorInvalidArg("invalid payment metadata")
+		return false, api.NewErrorInvalidArg("invalid payment metadata")
 	}
 
 	if len(blobHeaderProto.GetQuorumNumbers()) == 0 {
-		return api.NewErrorInvalidArg("blob header must contain at least one quorum number")
+		return false, api.NewErrorInvalidArg("blob header must contain at least one quorum number")
 	}
 
 	if len(blobHeaderProto.GetQuorumNumbers()) > int(onchainState.QuorumCount) {
-		return api.NewErrorInvalidArg(fmt.Sprintf("too many quorum numbers specified: maximum is %d", onchainState.QuorumCount))
+		return false, api.NewErrorInvalidArg(fmt.Sprintf("too many quorum numbers specified: maximum is %d", onchainState.QuorumCount))
 	}
 
 	for _, quorum := range blobHeaderProto.GetQuorumNumbers() {
 		if quorum > corev2.MaxQuorumID || uint8(quorum) >= onchainState.QuorumCount {
-			return api.NewErrorInvalidArg(fmt.Sprintf("invalid quorum number %d; maximum is %d", quorum, onchainState.QuorumCount))
+			return false, api.NewErrorInvalidArg(fmt.Sprintf("invalid quorum number %d; maximum is %d", quorum, onchainState.QuorumCount))
 		}
 	}
 
@@ -140,15 +190,15 @@ func (s *DispersalServerV2) validateDispersalRequest(ctx context.Context, req *p
 	_, err = rs.ToFrArray(data)
 	if err != nil {
 		s.logger.Error("failed to convert a 32bytes as a field element", "err", err)
-		return api.NewErrorInvalidArg("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617")
+		return false, api.NewErrorInvalidArg("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617")
 	}
 
 	if _, ok := onchainState.BlobVersionParameters.Get(corev2.BlobVersion(blobHeaderProto.GetVersion())); !ok {
-		return api.NewErrorInvalidArg(fmt.Sprintf("invalid blob version %d; valid blob versions are: %v", blobHeaderProto.GetVersion(), onchainState.BlobVersionParameters.Keys()))
+		return false, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob version %d; valid blob versions are: %v", blobHeaderProto.GetVersion(), onchainState.BlobVersionParameters.Keys()))
 	}
 
 	if err = s.authenticator.AuthenticateBlobRequest(blobHeader); err != nil {
-		return api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
+		return false, api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
 	}
 
 	// handle payments and check rate limits
@@ -162,18 +212,19 @@ func (s *DispersalServerV2) validateDispersalRequest(ctx context.Context, req *p
 		CumulativePayment: cumulativePayment,
 	}
 
-	err = s.meterer.MeterRequest(ctx, paymentHeader, blobLength, blobHeader.QuorumNumbers)
+	// longer retention tiers are billed as proportionally larger blobs
+	err = s.meterer.MeterRequest(ctx, paymentHeader, blobHeader.RetentionTier.BilledSymbols(blobLength), blobHeader.QuorumNumbers)
 	if err != nil {
-		return api.NewErrorResourceExhausted(err.Error())
+		return true, api.NewErrorPaymentRejected(err.Error())
 	}
 
 	commitments, err := s.prover.GetCommitmentsForPaddedLength(data)
 	if err != nil {
-		return api.NewErrorInternal(fmt.Sprintf("failed to get commitments: %v", err))
+		return true, api.NewErrorInternal(fmt.Sprintf("failed to get commitments: %v", err))
 	}
 	if !commitments.Equal(&blobHeader.BlobCommitments) {
-		return api.NewErrorInvalidArg("invalid blob commitment")
+		return true, api.NewErrorInvalidArg("invalid blob commitment")
 	}
 
-	return nil
+	return true, nil
 }
@@ -0,0 +1,135 @@
+package apiserver
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/gateway"
+	pbcommonv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+// GatewayHandler returns the HTTP/JSON gateway of the v2 disperser API, whose requests are served by this server:
+//
+//	POST /disperser/v2/blobs                  DisperseBlob
+//	GET  /disperser/v2/blobs/:blob_key/status GetBlobStatus
+//	POST /disperser/v2/blob-commitment        GetBlobCommitment
+//	POST /disperser/v2/payment-state          GetPaymentState
+//
+// Blobs are dispersed either as a JSON DisperseBlobRequest, or as the raw bytes of the blob, with the signed blob
+// header in the gateway.BlobHeaderHeader HTTP header. The blob commitment is requested with the raw bytes of the blob.
+func (s *DispersalServerV2) GatewayHandler(config gateway.Config) http.Handler {
+	router := gateway.NewRouter(config, gateway.DisperserV2OpenAPI)
+	group := router.Group("/disperser/v2")
+	{
+		group.POST("/blobs", func(c *gin.Context) { s.gatewayDisperseBlob(c, config.MaxRequestSize) })
+		group.GET("/blobs/:blob_key/status", s.gatewayGetBlobStatus)
+		group.POST("/blob-commitment", func(c *gin.Context) { s.gatewayGetBlobCommitment(c, config.MaxRequestSize) })
+		group.POST("/payment-state", func(c *gin.Context) { s.gatewayGetPaymentState(c, config.MaxRequestSize) })
+	}
+	return router
+}
+
+func (s *DispersalServerV2) gatewayDisperseBlob(c *gin.Context, maxRequestSize int64) {
+	req := &pb.DisperseBlobRequest{}
+	if isJSONRequest(c) {
+		if err := gateway.ReadJSON(c, maxRequestSize, req); err != nil {
+			gateway.WriteError(c, err)
+			return
+		}
+	} else {
+		data, err := gateway.ReadBody(c, maxRequestSize)
+		if err != nil {
+			gateway.WriteError(c, err)
+			return
+		}
+		blobHeader, err := parseBlobHeaderHeader(c.GetHeader(gateway.BlobHeaderHeader))
+		if err != nil {
+			gateway.WriteError(c, err)
+			return
+		}
+		req.Data = data
+		req.BlobHeader = blobHeader
+	}
+
+	reply, err := gateway.Invoke(gateway.IncomingContext(c), s.metrics.grpcServerInterceptor, s,
+		pb.Disperser_DisperseBlob_FullMethodName, req, s.DisperseBlob)
+	if err != nil {
+		gateway.WriteError(c, err)
+		return
+	}
+	gateway.WriteJSON(c, reply)
+}
+
+func (s *DispersalServerV2) gatewayGetBlobStatus(c *gin.Context) {
+	blobKey, err := hex.DecodeString(strings.TrimPrefix(c.Param("blob_key"), "0x"))
+	if err != nil {
+		gateway.WriteError(c, api.NewErrorInvalidArg("invalid blob key"))
+		return
+	}
+
+	reply, err := gateway.Invoke(gateway.IncomingContext(c), s.metrics.grpcServerInterceptor, s,
+		pb.Disperser_GetBlobStatus_FullMethodName, &pb.BlobStatusRequest{BlobKey: blobKey}, s.GetBlobStatus)
+	if err != nil {
+		gateway.WriteError(c, err)
+		return
+	}
+	gateway.WriteJSON(c, reply)
+}
+
+func (s *DispersalServerV2) gatewayGetBlobCommitment(c *gin.Context, maxRequestSize int64) {
+	data, err := gateway.ReadBody(c, maxRequestSize)
+	if err != nil {
+		gateway.WriteError(c, err)
+		return
+	}
+
+	reply, err := gateway.Invoke(gateway.IncomingContext(c), s.metrics.grpcServerInterceptor, s,
+		pb.Disperser_GetBlobCommitment_FullMethodName, &pb.BlobCommitmentRequest{Data: data}, s.GetBlobCommitment)
+	if err != nil {
+		gateway.WriteError(c, err)
+		return
+	}
+	gateway.WriteJSON(c, reply)
+}
+
+func (s *DispersalServerV2) gatewayGetPaymentState(c *gin.Context, maxRequestSize int64) {
+	req := &pb.GetPaymentStateRequest{}
+	if err := gateway.ReadJSON(c, maxRequestSize, req); err != nil {
+		gateway.WriteError(c, err)
+		return
+	}
+
+	reply, err := gateway.Invoke(gateway.IncomingContext(c), s.metrics.grpcServerInterceptor, s,
+		pb.Disperser_GetPaymentState_FullMethodName, req, s.GetPaymentState)
+	if err != nil {
+		gateway.WriteError(c, err)
+		return
+	}
+	gateway.WriteJSON(c, reply)
+}
+
+// parseBlobHeaderHeader parses the base64 encoded, protobuf serialized blob header of a raw-bytes dispersal.
+func parseBlobHeaderHeader(value string) (*pbcommonv2.BlobHeader, error) {
+	if value == "" {
+		return nil, api.NewErrorInvalidArg("missing " + gateway.BlobHeaderHeader + " header")
+	}
+	serialized, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, api.NewErrorInvalidArg("invalid " + gateway.BlobHeaderHeader + " header: not base64 encoded")
+	}
+	blobHeader := &pbcommonv2.BlobHeader{}
+	if err := proto.Unmarshal(serialized, blobHeader); err != nil {
+		return nil, api.NewErrorInvalidArg("invalid " + gateway.BlobHeaderHeader + " header: not a serialized blob header")
+	}
+	return blobHeader, nil
+}
+
+func isJSONRequest(c *gin.Context) bool {
+	return c.ContentType() == gateway.ContentTypeJSON
+}
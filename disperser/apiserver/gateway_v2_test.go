@@ -0,0 +1,140 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/gateway"
+	pbcommon "github.com/Layr-Labs/eigenda/api/grpc/common"
+	pbcommonv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestV2GatewayDisperseBlob(t *testing.T) {
+	c := newTestServerV2(t)
+	handler := c.DispersalServerV2.GatewayHandler(gateway.Config{MaxRequestSize: 1024 * 1024})
+
+	data := make([]byte, 50)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitmentsForPaddedLength(data)
+	require.NoError(t, err)
+	accountID, err := c.Signer.GetAccountID()
+	require.NoError(t, err)
+	commitmentProto, err := commitments.ToProtobuf()
+	require.NoError(t, err)
+	blobHeaderProto := &pbcommonv2.BlobHeader{
+		Version:       0,
+		QuorumNumbers: []uint32{0, 1},
+		Commitment:    commitmentProto,
+		PaymentHeader: &pbcommon.PaymentHeader{
+			AccountId:         accountID,
+			ReservationPeriod: 5,
+			CumulativePayment: big.NewInt(100).Bytes(),
+		},
+	}
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(blobHeaderProto)
+	require.NoError(t, err)
+	sig, err := auth.NewLocalBlobRequestSigner(privateKeyHex).SignBlobRequest(blobHeader)
+	require.NoError(t, err)
+	blobHeaderProto.Signature = sig
+	serializedHeader, err := proto.Marshal(blobHeaderProto)
+	require.NoError(t, err)
+
+	// a raw-bytes dispersal without a blob header is rejected
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/disperser/v2/blobs", bytes.NewReader(data)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	request := httptest.NewRequest(http.MethodPost, "/disperser/v2/blobs", bytes.NewReader(data))
+	request.Header.Set("Content-Type", gateway.ContentTypeOctetStream)
+	request.Header.Set(gateway.BlobHeaderHeader, base64.StdEncoding.EncodeToString(serializedHeader))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	reply := struct {
+		Result  string `json:"result"`
+		BlobKey []byte `json:"blob_key"`
+	}{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &reply))
+	assert.Equal(t, "QUEUED", reply.Result)
+	assert.Equal(t, blobKey[:], reply.BlobKey)
+
+	storedData, err := c.BlobStore.GetBlob(request.Context(), blobKey)
+	require.NoError(t, err)
+	assert.Equal(t, data, storedData)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/disperser/v2/blobs/0x"+blobKey.Hex()+"/status", nil))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.JSONEq(t, `{"status": "QUEUED"}`, recorder.Body.String())
+
+	// dispersing the same blob again fails with the status of the gRPC error
+	request = httptest.NewRequest(http.MethodPost, "/disperser/v2/blobs", bytes.NewReader(data))
+	request.Header.Set(gateway.BlobHeaderHeader, base64.StdEncoding.EncodeToString(serializedHeader))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.NotEqual(t, http.StatusOK, recorder.Code)
+	response := gateway.ErrorResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.NotEmpty(t, response.Error)
+}
+
+func TestV2GatewayGetBlobStatusInvalidKey(t *testing.T) {
+	c := newTestServerV2(t)
+	handler := c.DispersalServerV2.GatewayHandler(gateway.Config{MaxRequestSize: 1024 * 1024})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/disperser/v2/blobs/not-hex/status", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/disperser/v2/blobs/0102/status", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	response := gateway.ErrorResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "InvalidArgument", response.Code)
+}
+
+func TestV2GatewayGetBlobCommitment(t *testing.T) {
+	c := newTestServerV2(t)
+	handler := c.DispersalServerV2.GatewayHandler(gateway.Config{MaxRequestSize: 1024 * 1024})
+
+	data := make([]byte, 50)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commit, err := prover.GetCommitmentsForPaddedLength(data)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/disperser/v2/blob-commitment", bytes.NewReader(data)))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	reply := struct {
+		BlobCommitment struct {
+			Length uint32 `json:"length"`
+		} `json:"blob_commitment"`
+	}{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &reply))
+	assert.Equal(t, uint32(commit.Length), reply.BlobCommitment.Length)
+
+	// empty blobs are rejected
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/disperser/v2/blob-commitment", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
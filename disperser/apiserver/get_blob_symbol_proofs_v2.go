@@ -0,0 +1,117 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+func (s *DispersalServerV2) GetBlobSymbolProofs(ctx context.Context, req *pb.BlobSymbolProofsRequest) (*pb.BlobSymbolProofsReply, error) {
+	start := time.Now()
+	defer func() {
+		s.metrics.reportGetBlobSymbolProofsLatency(time.Since(start))
+	}()
+
+	if s.prover == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+
+	if req.GetBlobKey() == nil || len(req.GetBlobKey()) != 32 {
+		return nil, api.NewErrorInvalidArg("invalid blob key")
+	}
+	blobKey, err := corev2.BytesToBlobKey(req.GetBlobKey())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg("invalid blob key")
+	}
+
+	indices := req.GetSymbolIndices()
+	if len(indices) == 0 {
+		return nil, api.NewErrorInvalidArg("symbol indices are empty")
+	}
+	if err := encoding.CheckSymbolOpeningCount(len(indices), req.GetBatched()); err != nil {
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
+
+	// Every opened symbol costs a polynomial division and an MSM over the whole blob, so openings are metered.
+	origin, err := common.GetClientAddress(ctx, s.serverConfig.ClientIPHeader, 2, true)
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
+	if err := s.symbolOpeningLimiter.AllowOpenings(time.Now(), origin, len(indices)); err != nil {
+		return nil, api.WrapError(api.NewErrorResourceExhausted("rate limited"), err)
+	}
+
+	metadata, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			return nil, api.NewErrorNotFound("no such blob found")
+		}
+		s.logger.Error("failed to get blob metadata", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata: %s", err.Error()))
+	}
+
+	commitments := metadata.BlobHeader.BlobCommitments
+	for _, index := range indices {
+		if index >= encoding.NextPowerOf2(uint64(commitments.Length)) {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("symbol index %d is out of range for a blob of length %d", index, commitments.Length))
+		}
+	}
+
+	data, err := s.blobStore.GetBlob(ctx, blobKey)
+	if err != nil {
+		s.logger.Error("failed to get blob", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob: %s", err.Error()))
+	}
+
+	commitmentProto, err := commitments.ToProtobuf()
+	if err != nil {
+		return nil, api.NewErrorInternal("failed to serialize blob commitment")
+	}
+	reply := &pb.BlobSymbolProofsReply{
+		BlobCommitment: commitmentProto,
+		SymbolProofs:   make([]*pb.SymbolProof, len(indices)),
+	}
+
+	if req.GetBatched() {
+		opening, err := s.prover.OpenSymbolsBatch(data, uint64(commitments.Length), indices)
+		if err != nil {
+			s.logger.Error("failed to open blob symbols", "err", err, "blobKey", blobKey.Hex())
+			return nil, api.NewErrorInternal(fmt.Sprintf("failed to open blob symbols: %s", err.Error()))
+		}
+		for i := range opening.Indices {
+			value := opening.Values[i].Bytes()
+			reply.SymbolProofs[i] = &pb.SymbolProof{
+				Index: opening.Indices[i],
+				Value: value[:],
+			}
+		}
+		proof := opening.Proof.Bytes()
+		reply.BatchedProof = proof[:]
+		return reply, nil
+	}
+
+	openings, err := s.prover.OpenSymbols(data, uint64(commitments.Length), indices)
+	if err != nil {
+		s.logger.Error("failed to open blob symbols", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to open blob symbols: %s", err.Error()))
+	}
+	for i, opening := range openings {
+		value := opening.Value.Bytes()
+		proof := opening.Proof.Bytes()
+		reply.SymbolProofs[i] = &pb.SymbolProof{
+			Index: opening.Index,
+			Value: value[:],
+			Proof: proof[:],
+		}
+	}
+
+	return reply, nil
+}
@@ -13,7 +13,8 @@ const namespace = "eigenda_disperser_api"
 
 // metricsV2 encapsulates the metrics for the v2 API server.
 type metricsV2 struct {
-	grpcServerOption grpc.ServerOption
+	grpcServerOption      grpc.ServerOption
+	grpcServerInterceptor grpc.UnaryServerInterceptor
 
 	getBlobCommitmentLatency        *prometheus.SummaryVec
 	getPaymentStateLatency          *prometheus.SummaryVec
@@ -22,6 +23,9 @@ type metricsV2 struct {
 	validateDispersalRequestLatency *prometheus.SummaryVec
 	storeBlobLatency                *prometheus.SummaryVec
 	getBlobStatusLatency            *prometheus.SummaryVec
+	getBlobSymbolProofsLatency      *prometheus.SummaryVec
+	deduplicatedBlobs               *prometheus.CounterVec
+	deduplicatedBytes               *prometheus.CounterVec
 }
 
 // newAPIServerV2Metrics creates a new metricsV2 instance.
@@ -29,9 +33,8 @@ func newAPIServerV2Metrics(registry *prometheus.Registry) *metricsV2 {
 	grpcMetrics := grpcprom.NewServerMetrics()
 	registry.MustRegister(grpcMetrics)
 
-	grpcServerOption := grpc.UnaryInterceptor(
-		grpcMetrics.UnaryServerInterceptor(),
-	)
+	grpcServerInterceptor := grpcMetrics.UnaryServerInterceptor()
+	grpcServerOption := grpc.UnaryInterceptor(grpcServerInterceptor)
 
 	objectives := map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
 
@@ -104,8 +107,37 @@ func newAPIServerV2Metrics(registry *prometheus.Registry) *metricsV2 {
 		[]string{},
 	)
 
+	getBlobSymbolProofsLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "get_blob_symbol_proofs_latency_ms",
+			Help:       "The time required to open symbols of a blob.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
+	deduplicatedBlobs := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "deduplicated_blobs_total",
+			Help:      "The number of blobs whose bytes were already stored for another blob.",
+		},
+		[]string{},
+	)
+
+	deduplicatedBytes := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "deduplicated_bytes_total",
+			Help:      "The number of blob bytes not stored again because they were already stored for another blob.",
+		},
+		[]string{},
+	)
+
 	return &metricsV2{
 		grpcServerOption:                grpcServerOption,
+		grpcServerInterceptor:           grpcServerInterceptor,
 		getBlobCommitmentLatency:        getBlobCommitmentLatency,
 		getPaymentStateLatency:          getPaymentStateLatency,
 		disperseBlobLatency:             disperseBlobLatency,
@@ -113,6 +145,9 @@ func newAPIServerV2Metrics(registry *prometheus.Registry) *metricsV2 {
 		validateDispersalRequestLatency: validateDispersalRequestLatency,
 		storeBlobLatency:                storeBlobLatency,
 		getBlobStatusLatency:            getBlobStatusLatency,
+		getBlobSymbolProofsLatency:      getBlobSymbolProofsLatency,
+		deduplicatedBlobs:               deduplicatedBlobs,
+		deduplicatedBytes:               deduplicatedBytes,
 	}
 }
 
@@ -141,6 +176,15 @@ func (m *metricsV2) reportStoreBlobLatency(duration time.Duration) {
 	m.storeBlobLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
 
+func (m *metricsV2) reportDeduplicatedBlob(size int) {
+	m.deduplicatedBlobs.WithLabelValues().Inc()
+	m.deduplicatedBytes.WithLabelValues().Add(float64(size))
+}
+
 func (m *metricsV2) reportGetBlobStatusLatency(duration time.Duration) {
 	m.getBlobStatusLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
+
+func (m *metricsV2) reportGetBlobSymbolProofsLatency(duration time.Duration) {
+	m.getBlobSymbolProofsLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
@@ -0,0 +1,68 @@
+package apiserver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+)
+
+const (
+	minPaymentStateNonceLength = 8
+	maxPaymentStateNonceLength = 64
+)
+
+// authenticatePaymentStateRequest checks that the request was signed by the account, that it was signed recently,
+// and that it has not been received before. Requests from legacy clients, which sign over the account ID only, are
+// accepted until the configured deadline.
+func (s *DispersalServerV2) authenticatePaymentStateRequest(req *pb.GetPaymentStateRequest, now time.Time) error {
+	timestamp := req.GetTimestamp()
+	nonce := req.GetNonce()
+
+	if auth.IsLegacyPaymentStateRequest(timestamp, nonce) {
+		if !now.Before(s.serverConfig.LegacyPaymentStateAuthDeadline) {
+			return api.NewErrorInvalidArg("payment state request must be signed over a timestamp and nonce")
+		}
+		err := s.authenticator.AuthenticatePaymentStateRequest(req.GetSignature(), req.GetAccountId(), timestamp, nonce)
+		if err != nil {
+			return api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
+		}
+		return nil
+	}
+
+	if len(nonce) < minPaymentStateNonceLength || len(nonce) > maxPaymentStateNonceLength {
+		return api.NewErrorInvalidArg(fmt.Sprintf("nonce length must be between %d and %d bytes, got %d",
+			minPaymentStateNonceLength, maxPaymentStateNonceLength, len(nonce)))
+	}
+
+	maxAge := s.serverConfig.PaymentStateRequestMaxAge
+	requestTime := time.Unix(0, int64(timestamp))
+	if requestTime.Before(now.Add(-maxAge)) || requestTime.After(now.Add(maxAge)) {
+		return api.NewErrorInvalidArg(fmt.Sprintf("request timestamp %d is more than %s away from the server time",
+			timestamp, maxAge))
+	}
+
+	err := s.authenticator.AuthenticatePaymentStateRequest(req.GetSignature(), req.GetAccountId(), timestamp, nonce)
+	if err != nil {
+		return api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
+	}
+
+	// Only authenticated requests are recorded, so that requests cannot count against the quota of
+	// another account in the replay cache
+	hash := auth.PaymentStateRequestHash(req.GetAccountId(), timestamp, nonce)
+	err = s.paymentStateReplayCache.add(req.GetAccountId(), hash, requestTime.Add(maxAge), now)
+	if errors.Is(err, errRequestReplayed) {
+		return api.NewErrorInvalidArg("payment state request has already been received")
+	}
+	if errors.Is(err, errRequestTooOld) {
+		return api.NewErrorInvalidArg("payment state request is too old, sign a new request with the current time")
+	}
+	if err != nil {
+		return api.NewErrorResourceExhausted(err.Error())
+	}
+
+	return nil
+}
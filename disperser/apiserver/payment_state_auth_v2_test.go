@@ -0,0 +1,110 @@
+package apiserver
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const paymentStateTestPrivateKey = "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func newPaymentStateAuthServer(config disperser.ServerConfig) *DispersalServerV2 {
+	replayCache := newReplayCache(config.PaymentStateReplayCacheSize, config.PaymentStateReplayCacheSizePerAccount)
+	return &DispersalServerV2{
+		serverConfig:            config,
+		authenticator:           auth.NewAuthenticator(),
+		paymentStateReplayCache: replayCache,
+	}
+}
+
+func signPaymentStateRequest(t *testing.T, timestamp uint64, nonce []byte) *pb.GetPaymentStateRequest {
+	signer := auth.NewLocalBlobRequestSigner(paymentStateTestPrivateKey)
+	accountID, err := signer.GetAccountID()
+	require.NoError(t, err)
+	signature, err := signer.SignPaymentStateRequest(timestamp, nonce)
+	require.NoError(t, err)
+	return &pb.GetPaymentStateRequest{
+		AccountId: accountID,
+		Signature: signature,
+		Timestamp: timestamp,
+		Nonce:     nonce,
+	}
+}
+
+func TestAuthenticatePaymentStateRequest(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := newPaymentStateAuthServer(disperser.ServerConfig{
+		PaymentStateRequestMaxAge:             time.Minute,
+		PaymentStateReplayCacheSize:           10,
+		PaymentStateReplayCacheSizePerAccount: 2,
+	})
+
+	nonce := []byte("0123456789abcdef")
+	req := signPaymentStateRequest(t, uint64(now.UnixNano()), nonce)
+	require.NoError(t, s.authenticatePaymentStateRequest(req, now))
+
+	// the same request cannot be replayed
+	err := s.authenticatePaymentStateRequest(req, now.Add(time.Second))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// requests outside of the freshness window are rejected
+	stale := signPaymentStateRequest(t, uint64(now.Add(-2*time.Minute).UnixNano()), nonce)
+	err = s.authenticatePaymentStateRequest(stale, now)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	future := signPaymentStateRequest(t, uint64(now.Add(2*time.Minute).UnixNano()), nonce)
+	err = s.authenticatePaymentStateRequest(future, now)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the nonce must be long enough to make requests unique
+	err = s.authenticatePaymentStateRequest(signPaymentStateRequest(t, uint64(now.UnixNano()), []byte{1}), now)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// a tampered timestamp invalidates the signature
+	tampered := signPaymentStateRequest(t, uint64(now.UnixNano()), []byte("fedcba9876543210"))
+	tampered.Timestamp++
+	err = s.authenticatePaymentStateRequest(tampered, now)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// an account cannot have more unexpired requests in the cache than its quota
+	req2 := signPaymentStateRequest(t, uint64(now.UnixNano()), []byte("fedcba9876543210"))
+	require.NoError(t, s.authenticatePaymentStateRequest(req2, now))
+	req3 := signPaymentStateRequest(t, uint64(now.UnixNano()), []byte("0000000000000000"))
+	err = s.authenticatePaymentStateRequest(req3, now)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// once the earlier requests expire, there is room again
+	later := now.Add(2*time.Minute + time.Second)
+	req3 = signPaymentStateRequest(t, uint64(later.UnixNano()), []byte("0000000000000000"))
+	require.NoError(t, s.authenticatePaymentStateRequest(req3, later))
+}
+
+func TestAuthenticateLegacyPaymentStateRequest(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	req := signPaymentStateRequest(t, 0, nil)
+
+	// legacy requests are rejected by default
+	s := newPaymentStateAuthServer(disperser.ServerConfig{
+		PaymentStateRequestMaxAge:             time.Minute,
+		PaymentStateReplayCacheSize:           10,
+		PaymentStateReplayCacheSizePerAccount: 10,
+	})
+	err := s.authenticatePaymentStateRequest(req, now)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// and accepted until the deadline
+	s = newPaymentStateAuthServer(disperser.ServerConfig{
+		PaymentStateRequestMaxAge:             time.Minute,
+		PaymentStateReplayCacheSize:           10,
+		PaymentStateReplayCacheSizePerAccount: 10,
+		LegacyPaymentStateAuthDeadline:        now.Add(time.Hour),
+	})
+	require.NoError(t, s.authenticatePaymentStateRequest(req, now))
+	err = s.authenticatePaymentStateRequest(req, now.Add(time.Hour))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
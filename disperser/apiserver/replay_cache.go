@@ -0,0 +1,131 @@
+package apiserver
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// errRequestReplayed is returned when a request has already been seen.
+	errRequestReplayed = errors.New("request has already been processed")
+	// errRequestTooOld is returned when a request is older than requests the cache has already forgotten, so that
+	// the cache cannot tell whether it is a replay.
+	errRequestTooOld = errors.New("request is too old to be checked for replays")
+	// errAccountReplayCacheFull is returned when an account has too many unexpired requests in the cache.
+	errAccountReplayCacheFull = errors.New("too many recent requests from the account")
+)
+
+// replayCache remembers the hashes of signed requests until they expire, so that a captured request cannot be
+// submitted again while its timestamp is still fresh. Expired hashes are dropped, since the freshness check rejects
+// those requests anyway.
+//
+// Each account may have at most accountCapacity unexpired hashes in the cache, so that a single account cannot fill
+// it. Since accounts cost nothing to create, the cache may still fill up with the requests of many accounts. It then
+// evicts the hash closest to expiry, and rejects every request expiring no later than the evicted hash from then on,
+// since it can no longer tell whether those are replays. A flood of requests therefore shortens the window in which
+// requests are accepted, instead of locking every account out.
+type replayCache struct {
+	capacity        int
+	accountCapacity int
+
+	mu          sync.Mutex
+	expirations map[[32]byte]time.Time
+	counts      map[string]int
+	queue       expirationQueue
+	// horizon is the latest expiry of an evicted hash. Requests expiring no later than it are rejected.
+	horizon time.Time
+}
+
+func newReplayCache(capacity int, accountCapacity int) *replayCache {
+	return &replayCache{
+		capacity:        capacity,
+		accountCapacity: accountCapacity,
+		expirations:     make(map[[32]byte]time.Time),
+		counts:          make(map[string]int),
+	}
+}
+
+// add records the hash of a request from an account until its expiry. It returns errRequestReplayed if the hash is
+// already recorded, errRequestTooOld if the cache has evicted hashes expiring later than the request, and
+// errAccountReplayCacheFull if the account has too many unexpired requests.
+func (c *replayCache) add(accountID string, hash [32]byte, expiry time.Time, now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prune(now)
+
+	if _, ok := c.expirations[hash]; ok {
+		return errRequestReplayed
+	}
+	if !expiry.After(c.horizon) {
+		return errRequestTooOld
+	}
+	if c.counts[accountID] >= c.accountCapacity {
+		return errAccountReplayCacheFull
+	}
+	if len(c.expirations) >= c.capacity {
+		if !expiry.After(c.queue[0].expiry) {
+			// the request would be the first to be evicted
+			return errRequestTooOld
+		}
+		c.evict()
+	}
+
+	c.expirations[hash] = expiry
+	c.counts[accountID]++
+	heap.Push(&c.queue, expiringHash{accountID: accountID, hash: hash, expiry: expiry})
+	return nil
+}
+
+// prune drops the hashes that expired before now. Must be called with c.mu held.
+func (c *replayCache) prune(now time.Time) {
+	for c.queue.Len() > 0 && !c.queue[0].expiry.After(now) {
+		c.remove(heap.Pop(&c.queue).(expiringHash))
+	}
+}
+
+// evict drops the hash closest to expiry before it expires, and stops accepting requests expiring no later than it.
+// Must be called with c.mu held.
+func (c *replayCache) evict() {
+	evicted := heap.Pop(&c.queue).(expiringHash)
+	c.remove(evicted)
+	if evicted.expiry.After(c.horizon) {
+		c.horizon = evicted.expiry
+	}
+}
+
+// remove drops a hash popped from the queue. Must be called with c.mu held.
+func (c *replayCache) remove(h expiringHash) {
+	delete(c.expirations, h.hash)
+	c.counts[h.accountID]--
+	if c.counts[h.accountID] == 0 {
+		delete(c.counts, h.accountID)
+	}
+}
+
+type expiringHash struct {
+	accountID string
+	hash      [32]byte
+	expiry    time.Time
+}
+
+// expirationQueue is a min-heap of hashes ordered by expiry.
+type expirationQueue []expiringHash
+
+func (q expirationQueue) Len() int           { return len(q) }
+func (q expirationQueue) Less(i, j int) bool { return q[i].expiry.Before(q[j].expiry) }
+func (q expirationQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *expirationQueue) Push(x any) {
+	*q = append(*q, x.(expiringHash))
+}
+
+func (q *expirationQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,52 @@
+package apiserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayCacheAccountCapacity(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c := newReplayCache(10, 2)
+
+	require.NoError(t, c.add("alice", [32]byte{1}, now.Add(time.Minute), now))
+	require.ErrorIs(t, c.add("alice", [32]byte{1}, now.Add(time.Minute), now), errRequestReplayed)
+	require.NoError(t, c.add("alice", [32]byte{2}, now.Add(2*time.Minute), now))
+	require.ErrorIs(t, c.add("alice", [32]byte{3}, now.Add(time.Minute), now), errAccountReplayCacheFull)
+
+	// other accounts are not affected
+	require.NoError(t, c.add("bob", [32]byte{3}, now.Add(time.Minute), now))
+
+	// the account has room again once its requests expire
+	later := now.Add(time.Minute)
+	require.NoError(t, c.add("alice", [32]byte{4}, later.Add(time.Minute), later))
+	require.ErrorIs(t, c.add("alice", [32]byte{5}, later.Add(time.Minute), later), errAccountReplayCacheFull)
+}
+
+func TestReplayCacheEvictsClosestToExpiry(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c := newReplayCache(2, 2)
+
+	require.NoError(t, c.add("alice", [32]byte{1}, now.Add(time.Minute), now))
+	require.NoError(t, c.add("bob", [32]byte{2}, now.Add(2*time.Minute), now))
+
+	// a full cache evicts the request closest to expiry instead of rejecting other accounts
+	require.NoError(t, c.add("carol", [32]byte{3}, now.Add(3*time.Minute), now))
+
+	// the evicted request cannot be replayed, and neither can any request expiring before it
+	require.ErrorIs(t, c.add("alice", [32]byte{1}, now.Add(time.Minute), now), errRequestTooOld)
+	require.ErrorIs(t, c.add("dave", [32]byte{4}, now.Add(30*time.Second), now), errRequestTooOld)
+
+	// a request that would be evicted first is rejected rather than evicting a later one
+	require.ErrorIs(t, c.add("dave", [32]byte{4}, now.Add(90*time.Second), now), errRequestTooOld)
+
+	// the remaining requests are still rejected as replays
+	require.ErrorIs(t, c.add("bob", [32]byte{2}, now.Add(2*time.Minute), now), errRequestReplayed)
+	require.ErrorIs(t, c.add("carol", [32]byte{3}, now.Add(3*time.Minute), now), errRequestReplayed)
+
+	// eviction frees the quota of the evicted account
+	require.NoError(t, c.add("alice", [32]byte{5}, now.Add(4*time.Minute), now))
+	require.NoError(t, c.add("alice", [32]byte{6}, now.Add(5*time.Minute), now))
+}
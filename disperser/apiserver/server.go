@@ -23,6 +23,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core/meterer"
 	"github.com/Layr-Labs/eigenda/disperser"
 	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -57,6 +58,9 @@ type DispersalServer struct {
 
 	maxBlobSize int
 
+	// auditLog records the decision on every dispersal request. It may be nil.
+	auditLog *audit.Log
+
 	logger logging.Logger
 }
 
@@ -80,6 +84,7 @@ func NewDispersalServer(
 	ratelimiter common.RateLimiter,
 	rateConfig RateConfig,
 	maxBlobSize int,
+	auditLog *audit.Log,
 ) *DispersalServer {
 	logger := _logger.With("component", "DispersalServer")
 	for account, rateInfoByQuorum := range rateConfig.Allowlist {
@@ -105,10 +110,24 @@ func NewDispersalServer(
 		mu:            &sync.RWMutex{},
 		quorumConfig:  QuorumConfig{},
 		maxBlobSize:   maxBlobSize,
+		auditLog:      auditLog,
 	}
 }
 
-func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_DisperseBlobAuthenticatedServer) error {
+func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_DisperseBlobAuthenticatedServer) (err error) {
+	var (
+		disperseRequest      *pb.DisperseBlobRequest
+		blob                 *core.Blob
+		authenticatedAddress string
+		// authenticated is set once the client answers the challenge for the key of authenticatedAddress
+		authenticated bool
+		reply         *pb.DisperseBlobReply
+	)
+	defer func() {
+		if disperseRequest != nil {
+			s.auditDispersal("DisperseBlobAuthenticated", disperseRequest, blob, authenticatedAddress, authenticated, reply, err)
+		}
+	}()
 
 	// This uses the existing deadline of stream.Context() if it is earlier.
 	ctx, cancel := context.WithTimeout(stream.Context(), s.serverConfig.GrpcTimeout)
@@ -129,7 +148,8 @@ func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_Disperse
 		return api.NewErrorInvalidArg("missing DisperseBlobRequest")
 	}
 
-	blob, err := s.validateRequestAndGetBlob(ctx, request.DisperseRequest)
+	disperseRequest = request.DisperseRequest
+	blob, err = s.validateRequestAndGetBlob(ctx, request.DisperseRequest)
 	if err != nil {
 		for _, quorumID := range request.DisperseRequest.CustomQuorumNumbers {
 			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(request.DisperseRequest.GetData()), "DisperseBlobAuthenticated")
@@ -154,7 +174,7 @@ func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_Disperse
 		return api.NewErrorInvalidArg(fmt.Sprintf("failed to decode public key (%v): %v", hexutil.Encode(publicKeyBytes), err))
 	}
 
-	authenticatedAddress := crypto.PubkeyToAddress(*pubKey).String()
+	authenticatedAddress = crypto.PubkeyToAddress(*pubKey).String()
 
 	// Send back challenge to client
 	challengeBytes := make([]byte, 32)
@@ -217,9 +237,10 @@ func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_Disperse
 		s.metrics.HandleInvalidArgRequest("DisperseBlobAuthenticated")
 		return api.NewErrorInvalidArg(fmt.Sprintf("failed to authenticate blob request: %v", err))
 	}
+	authenticated = true
 
 	// Disperse the blob
-	reply, err := s.disperseBlob(ctx, blob, authenticatedAddress, "DisperseBlobAuthenticated", nil)
+	reply, err = s.disperseBlob(ctx, blob, authenticatedAddress, "DisperseBlobAuthenticated", nil)
 	if err != nil {
 		// Note the disperseBlob already updated metrics for this error.
 		s.logger.Info("failed to disperse blob", "err", err)
@@ -241,8 +262,14 @@ func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_Disperse
 
 }
 
-func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (*pb.DisperseBlobReply, error) {
-	blob, err := s.validateRequestAndGetBlob(ctx, req)
+func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (reply *pb.DisperseBlobReply, err error) {
+	var blob *core.Blob
+	defer func() {
+		// the account ID of an unauthenticated request is not verified
+		s.auditDispersal("DisperseBlob", req, blob, req.GetAccountId(), false, reply, err)
+	}()
+
+	blob, err = s.validateRequestAndGetBlob(ctx, req)
 	if err != nil {
 		for _, quorumID := range req.CustomQuorumNumbers {
 			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(req.GetData()), "DisperseBlob")
@@ -251,7 +278,7 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 		return nil, api.NewErrorInvalidArg(err.Error())
 	}
 
-	reply, err := s.disperseBlob(ctx, blob, "", "DisperseBlob", nil)
+	reply, err = s.disperseBlob(ctx, blob, "", "DisperseBlob", nil)
 	if err != nil {
 		// Note the disperseBlob already updated metrics for this error.
 		s.logger.Info("failed to disperse blob", "err", err)
@@ -329,6 +356,45 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 	}, nil
 }
 
+// auditDispersal records the decision on a dispersal request in the audit log. The blob is nil if the request
+// failed validation. The request is only attributed to the account if it was authenticated.
+func (s *DispersalServer) auditDispersal(
+	method string,
+	req *pb.DisperseBlobRequest,
+	blob *core.Blob,
+	accountID string,
+	authenticated bool,
+	reply *pb.DisperseBlobReply,
+	err error) {
+
+	if s.auditLog == nil {
+		return
+	}
+
+	record := &audit.Record{
+		APIVersion: "v1",
+		Method:     method,
+		BlobSize:   len(req.GetData()),
+		Quorums:    req.GetCustomQuorumNumbers(),
+	}
+	record.SetAccount(accountID, authenticated)
+	if blob != nil {
+		quorums := blob.GetQuorumNumbers()
+		record.Quorums = make([]uint32, len(quorums))
+		for i, quorum := range quorums {
+			record.Quorums[i] = uint32(quorum)
+		}
+	}
+	if s.ratelimiter != nil {
+		record.PaymentMode = audit.PaymentModeRateLimit
+	}
+	if reply != nil {
+		record.BlobKey = string(reply.GetRequestId())
+	}
+	record.SetOutcome(err)
+	s.auditLog.Record(record)
+}
+
 func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, quorumID core.QuorumID) (*PerUserRateInfo, string, error) {
 	unauthRates, ok := s.rateConfig.QuorumRateInfos[quorumID]
 	if !ok {
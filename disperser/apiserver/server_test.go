@@ -797,7 +797,7 @@ func newTestServer(transactor core.Writer, testName string) *apiserver.Dispersal
 		teardown()
 		panic("failed to create offchain store")
 	}
-	mt := meterer.NewMeterer(meterer.Config{}, mockState, store, logger)
+	mt := meterer.NewMeterer(meterer.Config{}, mockState, store, nil, logger)
 	err = mt.ChainPaymentState.RefreshOnchainPaymentState(context.Background())
 	if err != nil {
 		panic("failed to make initial query to the on-chain state")
@@ -858,7 +858,7 @@ func newTestServer(transactor core.Writer, testName string) *apiserver.Dispersal
 	return apiserver.NewDispersalServer(disperser.ServerConfig{
 		GrpcPort:    "51001",
 		GrpcTimeout: 1 * time.Second,
-	}, queue, transactor, logger, disperser.NewMetrics(prometheus.NewRegistry(), "9001", logger), grpcprom.NewServerMetrics(), mt, ratelimiter, rateConfig, testMaxBlobSize)
+	}, queue, transactor, logger, disperser.NewMetrics(prometheus.NewRegistry(), "9001", logger), grpcprom.NewServerMetrics(), mt, ratelimiter, rateConfig, testMaxBlobSize, nil)
 }
 
 func disperseBlob(t *testing.T, server *apiserver.DispersalServer, data []byte) (pb.BlobStatus, uint, []byte) {
@@ -15,11 +15,14 @@ import (
 	pbv1 "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/meterer"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -57,6 +60,23 @@ type DispersalServerV2 struct {
 	onchainState                atomic.Pointer[OnchainState]
 	maxNumSymbolsPerBlob        uint64
 	onchainStateRefreshInterval time.Duration
+	// onchainStateUpdates receives the on-chain events that trigger a refresh before the next interval. It is nil
+	// if no watcher is configured.
+	onchainStateUpdates <-chan eth.OnchainStateUpdate
+	// onchainStateRefreshed records the last successful refresh of the onchain state.
+	onchainStateRefreshed healthcheck.Heartbeat
+
+	// healthRegistry aggregates the health of the dependencies of the server. It may be nil.
+	healthRegistry *healthcheck.Registry
+
+	// auditLog records the decision on every dispersal request. It may be nil.
+	auditLog *audit.Log
+
+	// recently seen GetPaymentState requests
+	paymentStateReplayCache *replayCache
+
+	// symbolOpeningLimiter meters the symbols opened by GetBlobSymbolProofs per client IP.
+	symbolOpeningLimiter *ratelimit.SymbolOpeningLimiter
 
 	metrics *metricsV2
 }
@@ -72,6 +92,9 @@ func NewDispersalServerV2(
 	prover encoding.Prover,
 	maxNumSymbolsPerBlob uint64,
 	onchainStateRefreshInterval time.Duration,
+	onchainStateWatcher *eth.OnchainStateWatcher,
+	healthRegistry *healthcheck.Registry,
+	auditLog *audit.Log,
 	_logger logging.Logger,
 	registry *prometheus.Registry,
 ) (*DispersalServerV2, error) {
@@ -102,7 +125,22 @@ func NewDispersalServerV2(
 
 	logger := _logger.With("component", "DispersalServerV2")
 
-	return &DispersalServerV2{
+	if serverConfig.PaymentStateRequestMaxAge == 0 {
+		serverConfig.PaymentStateRequestMaxAge = disperser.DefaultPaymentStateRequestMaxAge
+	}
+	if serverConfig.PaymentStateReplayCacheSize == 0 {
+		serverConfig.PaymentStateReplayCacheSize = disperser.DefaultPaymentStateReplayCacheSize
+	}
+	if serverConfig.PaymentStateReplayCacheSizePerAccount == 0 {
+		serverConfig.PaymentStateReplayCacheSizePerAccount = disperser.DefaultPaymentStateReplayCacheSizePerAccount
+	}
+
+	symbolOpeningLimiter, err := ratelimit.NewSymbolOpeningLimiter(serverConfig.SymbolOpeningLimits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create symbol opening limiter: %w", err)
+	}
+
+	server := &DispersalServerV2{
 		serverConfig:      serverConfig,
 		blobStore:         blobStore,
 		blobMetadataStore: blobMetadataStore,
@@ -115,9 +153,29 @@ func NewDispersalServerV2(
 
 		maxNumSymbolsPerBlob:        maxNumSymbolsPerBlob,
 		onchainStateRefreshInterval: onchainStateRefreshInterval,
+		onchainStateUpdates:         onchainStateWatcher.Subscribe(eth.BlobVersionAdded, eth.QuorumParamsUpdated),
+
+		paymentStateReplayCache: newReplayCache(
+			serverConfig.PaymentStateReplayCacheSize, serverConfig.PaymentStateReplayCacheSizePerAccount),
+		symbolOpeningLimiter: symbolOpeningLimiter,
+
+		healthRegistry: healthRegistry,
+		auditLog:       auditLog,
 
 		metrics: newAPIServerV2Metrics(registry),
-	}, nil
+	}
+
+	if healthRegistry != nil {
+		healthRegistry.Register("BlobMetadataStore", healthcheck.Readiness, blobMetadataStore.CheckHealth)
+		// The refresh loop retries on every interval, so a few missed refreshes are tolerated. An eth RPC outage
+		// is not fixed by restarting the server, so stale state only takes the server out of rotation.
+		healthRegistry.Register(
+			"OnchainStateRefresh",
+			healthcheck.Readiness,
+			server.onchainStateRefreshed.Probe(3*onchainStateRefreshInterval))
+	}
+
+	return server, nil
 }
 
 func (s *DispersalServerV2) Start(ctx context.Context) error {
@@ -139,7 +197,7 @@ func (s *DispersalServerV2) Start(ctx context.Context) error {
 
 	// Register Server for Health Checks
 	name := pb.Disperser_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	s.healthRegistry.RegisterHealthServer(name, gs)
 
 	if err := s.RefreshOnchainState(ctx); err != nil {
 		return fmt.Errorf("failed to refresh onchain quorum state: %w", err)
@@ -155,6 +213,11 @@ func (s *DispersalServerV2) Start(ctx context.Context) error {
 				if err := s.RefreshOnchainState(ctx); err != nil {
 					s.logger.Error("failed to refresh onchain quorum state", "err", err)
 				}
+			case update := <-s.onchainStateUpdates:
+				s.logger.Info("refreshing onchain quorum state on update", "kind", update.Kind, "block", update.BlockNumber)
+				if err := s.RefreshOnchainState(ctx); err != nil {
+					s.logger.Error("failed to refresh onchain quorum state", "err", err)
+				}
 			case <-ctx.Done():
 				return
 			}
@@ -252,6 +315,7 @@ func (s *DispersalServerV2) RefreshOnchainState(ctx context.Context) error {
 	}
 
 	s.onchainState.Store(onchainState)
+	s.onchainStateRefreshed.Beat()
 
 	return nil
 }
@@ -267,10 +331,9 @@ func (s *DispersalServerV2) GetPaymentState(ctx context.Context, req *pb.GetPaym
 
 	accountID := gethcommon.HexToAddress(req.AccountId)
 
-	// validate the signature
-	if err := s.authenticator.AuthenticatePaymentStateRequest(req.GetSignature(), req.GetAccountId()); err != nil {
-		s.logger.Debug("failed to validate signature", "err", err, "accountID", accountID)
-		return nil, api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
+	if err := s.authenticatePaymentStateRequest(req, time.Now()); err != nil {
+		s.logger.Debug("failed to authenticate payment state request", "err", err, "accountID", accountID)
+		return nil, err
 	}
 	// on-chain global payment parameters
 	globalSymbolsPerSecond := s.meterer.ChainPaymentState.GetGlobalSymbolsPerSecond()
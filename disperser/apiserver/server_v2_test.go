@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/core"
 	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
 	"github.com/Layr-Labs/eigenda/core/meterer"
@@ -21,13 +23,16 @@ import (
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	pbcommon "github.com/Layr-Labs/eigenda/api/grpc/common"
 	pbcommonv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
@@ -45,6 +50,8 @@ type testComponents struct {
 	ChainReader       *mock.MockWriter
 	Signer            *auth.LocalBlobRequestSigner
 	Peer              *peer.Peer
+	AuditLog          *audit.Log
+	AuditSink         audit.Sink
 }
 
 func TestV2DisperseBlob(t *testing.T) {
@@ -115,6 +122,109 @@ func TestV2DisperseBlob(t *testing.T) {
 	})
 	assert.Nil(t, reply)
 	assert.ErrorContains(t, err, "payment already exists")
+
+	// A request claiming the account without its signature is not attributed to the account
+	forgedHeaderProto := &pbcommonv2.BlobHeader{
+		Version:       0,
+		QuorumNumbers: []uint32{0, 1},
+		Commitment:    commitmentProto,
+		PaymentHeader: &pbcommon.PaymentHeader{
+			AccountId:         accountID,
+			ReservationPeriod: 5,
+			CumulativePayment: big.NewInt(200).Bytes(),
+		},
+		Signature: sig,
+	}
+	reply, err = c.DispersalServerV2.DisperseBlob(ctx, &pbv2.DisperseBlobRequest{
+		Data:       data,
+		BlobHeader: forgedHeaderProto,
+	})
+	assert.Nil(t, reply)
+	assert.ErrorContains(t, err, "authentication failed")
+
+	// The authenticated decisions are in the audit log of the account, newest first
+	c.AuditLog.Close()
+	records, err := c.AuditSink.QueryByAccount(ctx, accountID, now, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, audit.Rejected, records[0].Decision)
+	assert.Equal(t, codes.ResourceExhausted.String(), records[0].Code)
+	assert.Contains(t, records[0].Reason, "payment already exists")
+	assert.Equal(t, audit.Accepted, records[1].Decision)
+	assert.Equal(t, blobKey.Hex(), records[1].BlobKey)
+	assert.Equal(t, len(data), records[1].BlobSize)
+	assert.Equal(t, []uint32{0, 1}, records[1].Quorums)
+	assert.Equal(t, audit.PaymentModeOnDemand, records[1].PaymentMode)
+	assert.Equal(t, "v2", records[1].APIVersion)
+	assert.True(t, records[1].Authenticated)
+
+	records, err = c.AuditSink.QueryByAccount(ctx, audit.UnknownAccount, now, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, accountID, records[0].ClaimedAccountID)
+	assert.False(t, records[0].Authenticated)
+	assert.Equal(t, codes.InvalidArgument.String(), records[0].Code)
+}
+
+func TestV2DisperseBlobRetentionTier(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+	data := make([]byte, 50)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitmentsForPaddedLength(data)
+	assert.NoError(t, err)
+	accountID, err := c.Signer.GetAccountID()
+	assert.NoError(t, err)
+	commitmentProto, err := commitments.ToProtobuf()
+	assert.NoError(t, err)
+	blobHeaderProto := &pbcommonv2.BlobHeader{
+		Version:       0,
+		QuorumNumbers: []uint32{0, 1},
+		Commitment:    commitmentProto,
+		PaymentHeader: &pbcommon.PaymentHeader{
+			AccountId:         accountID,
+			ReservationPeriod: 5,
+			CumulativePayment: big.NewInt(100).Bytes(),
+		},
+		RetentionTier: uint32(corev2.RetentionTierArchival),
+	}
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(blobHeaderProto)
+	assert.NoError(t, err)
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	sig, err := signer.SignBlobRequest(blobHeader)
+	assert.NoError(t, err)
+	blobHeader.Signature = sig
+	blobHeaderProto.Signature = sig
+
+	now := time.Now()
+	reply, err := c.DispersalServerV2.DisperseBlob(ctx, &pbv2.DisperseBlobRequest{
+		Data:       data,
+		BlobHeader: blobHeaderProto,
+	})
+	require.NoError(t, err)
+
+	blobKey, err := blobHeader.BlobKey()
+	assert.NoError(t, err)
+	assert.Equal(t, blobKey[:], reply.BlobKey)
+
+	// the blob expires after four times the standard TTL of (100 + 10) blocks
+	blobMetadata, err := c.BlobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	require.NoError(t, err)
+	assert.Equal(t, corev2.RetentionTierArchival, blobMetadata.BlobHeader.RetentionTier)
+	ttl := corev2.RetentionTierArchival.Retention(110 * 12 * time.Second)
+	assert.GreaterOrEqual(t, blobMetadata.Expiry, uint64(now.Add(ttl).Unix()))
+	assert.Less(t, blobMetadata.Expiry, uint64(now.Add(ttl+time.Minute).Unix()))
+
+	// an unknown retention tier is rejected
+	blobHeaderProto.RetentionTier = uint32(corev2.MaxRetentionTier) + 1
+	_, err = c.DispersalServerV2.DisperseBlob(ctx, &pbv2.DisperseBlobRequest{
+		Data:       data,
+		BlobHeader: blobHeaderProto,
+	})
+	assert.ErrorContains(t, err, "invalid retention tier")
 }
 
 func TestV2DisperseBlobRequestValidation(t *testing.T) {
@@ -422,6 +532,90 @@ func TestV2GetBlobCommitment(t *testing.T) {
 	assert.Equal(t, uint32(commit.Length), reply.BlobCommitment.Length)
 }
 
+func TestV2GetBlobSymbolProofs(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+	data := make([]byte, 50)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	commitments, err := prover.GetCommitmentsForPaddedLength(data)
+	require.NoError(t, err)
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         "0x1234",
+			ReservationPeriod: 0,
+			CumulativePayment: big.NewInt(532),
+		},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	err = c.BlobStore.StoreBlob(ctx, blobKey, data)
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: dispv2.Queued,
+		Expiry:     uint64(time.Now().Add(time.Hour).Unix()),
+		UpdatedAt:  uint64(time.Now().UnixNano()),
+	})
+	require.NoError(t, err)
+
+	indices := []uint64{0, 1}
+	expected, err := prover.OpenSymbols(data, uint64(commitments.Length), indices)
+	require.NoError(t, err)
+	reply, err := c.DispersalServerV2.GetBlobSymbolProofs(ctx, &pbv2.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: indices,
+	})
+	require.NoError(t, err)
+	require.Len(t, reply.GetSymbolProofs(), len(indices))
+	require.Empty(t, reply.GetBatchedProof())
+	for i, symbolProof := range reply.GetSymbolProofs() {
+		value := expected[i].Value.Bytes()
+		proof := expected[i].Proof.Bytes()
+		require.Equal(t, indices[i], symbolProof.GetIndex())
+		require.Equal(t, value[:], symbolProof.GetValue())
+		require.Equal(t, proof[:], symbolProof.GetProof())
+	}
+
+	reply, err = c.DispersalServerV2.GetBlobSymbolProofs(ctx, &pbv2.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: indices,
+		Batched:       true,
+	})
+	require.NoError(t, err)
+	require.Len(t, reply.GetSymbolProofs(), len(indices))
+	require.NotEmpty(t, reply.GetBatchedProof())
+
+	_, err = c.DispersalServerV2.GetBlobSymbolProofs(ctx, &pbv2.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: []uint64{uint64(commitments.Length)},
+	})
+	require.ErrorContains(t, err, "out of range")
+
+	tooMany := make([]uint64, encoding.MaxBatchedSymbolOpenings+1)
+	for i := range tooMany {
+		tooMany[i] = uint64(i)
+	}
+	_, err = c.DispersalServerV2.GetBlobSymbolProofs(ctx, &pbv2.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: tooMany,
+		Batched:       true,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Every opened symbol is charged to the client, which has spent 5 of its 8 openings.
+	_, err = c.DispersalServerV2.GetBlobSymbolProofs(ctx, &pbv2.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: []uint64{0, 1, 0, 1},
+	})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
 func newTestServerV2(t *testing.T) *testComponents {
 	logger := logging.NewNoopLogger()
 	// logger, err := common.NewLogger(common.DefaultLoggerConfig())
@@ -488,7 +682,7 @@ func newTestServerV2(t *testing.T) *testComponents {
 		teardown()
 		panic("failed to create offchain store")
 	}
-	meterer := meterer.NewMeterer(meterer.Config{}, mockState, store, logger)
+	meterer := meterer.NewMeterer(meterer.Config{}, mockState, store, nil, logger)
 
 	chainReader.On("GetCurrentBlockNumber").Return(uint32(100), nil)
 	chainReader.On("GetQuorumCount").Return(uint8(2), nil)
@@ -503,10 +697,18 @@ func newTestServerV2(t *testing.T) *testComponents {
 		},
 	}, nil)
 
+	auditSink, err := audit.NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	auditLog := audit.NewLog(auditSink, 100, logger)
+
 	s, err := apiserver.NewDispersalServerV2(
 		disperser.ServerConfig{
 			GrpcPort:    "51002",
 			GrpcTimeout: 1 * time.Second,
+			SymbolOpeningLimits: ratelimit.SymbolOpeningLimitConfig{
+				MaxSymbolOpeningsPerSecondClient: 0.001,
+				SymbolOpeningsBurstinessClient:   8,
+			},
 		},
 		blobStore,
 		blobMetadataStore,
@@ -516,6 +718,9 @@ func newTestServerV2(t *testing.T) *testComponents {
 		prover,
 		10,
 		time.Hour,
+		nil,
+		nil,
+		auditLog,
 		logger,
 		prometheus.NewRegistry())
 	assert.NoError(t, err)
@@ -537,5 +742,7 @@ func newTestServerV2(t *testing.T) *testComponents {
 		ChainReader:       chainReader,
 		Signer:            signer,
 		Peer:              p,
+		AuditLog:          auditLog,
+		AuditSink:         auditSink,
 	}
 }
@@ -4,13 +4,17 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/gateway"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
@@ -35,7 +39,7 @@ type Config struct {
 	EncodingConfig              kzg.KzgConfig
 	EnableRatelimiter           bool
 	EnablePaymentMeterer        bool
-	UpdateInterval              int
+	UpdateInterval              time.Duration
 	ChainReadTimeout            int
 	ReservationsTableName       string
 	OnDemandTableName           string
@@ -46,6 +50,12 @@ type Config struct {
 	MaxBlobSize                 int
 	MaxNumSymbolsPerBlob        uint
 	OnchainStateRefreshInterval time.Duration
+	OnchainStateWatcherConfig   eth.OnchainStateWatcherConfig
+	HealthConfig                healthcheck.Config
+	// GatewayConfig configures the HTTP/JSON gateway of the v2 API.
+	GatewayConfig gateway.Config
+	// AuditConfig configures the audit log of dispersal decisions.
+	AuditConfig audit.Config
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -99,6 +109,18 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			GrpcTimeout:   ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
 			PprofHttpPort: ctx.GlobalString(flags.PprofHttpPort.Name),
 			EnablePprof:   ctx.GlobalBool(flags.EnablePprof.Name),
+
+			PaymentStateRequestMaxAge:   ctx.GlobalDuration(flags.PaymentStateRequestMaxAgeFlag.Name),
+			PaymentStateReplayCacheSize: ctx.GlobalInt(flags.PaymentStateReplayCacheSizeFlag.Name),
+			PaymentStateReplayCacheSizePerAccount: ctx.GlobalInt(
+				flags.PaymentStateReplayCacheSizePerAccountFlag.Name),
+			ClientIPHeader: rateConfig.ClientIPHeader,
+			SymbolOpeningLimits: ratelimit.SymbolOpeningLimitConfig{
+				MaxSymbolOpeningsPerSecond:       ctx.GlobalFloat64(flags.MaxSymbolOpeningsPerSecondFlag.Name),
+				SymbolOpeningsBurstiness:         ctx.GlobalInt(flags.SymbolOpeningsBurstinessFlag.Name),
+				MaxSymbolOpeningsPerSecondClient: ctx.GlobalFloat64(flags.MaxSymbolOpeningsPerSecondClientFlag.Name),
+				SymbolOpeningsBurstinessClient:   ctx.GlobalInt(flags.SymbolOpeningsBurstinessClientFlag.Name),
+			},
 		},
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
@@ -119,15 +141,24 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		GlobalRateTableName:         ctx.GlobalString(flags.GlobalRateTableName.Name),
 		BucketTableName:             ctx.GlobalString(flags.BucketTableName.Name),
 		BucketStoreSize:             ctx.GlobalInt(flags.BucketStoreSize.Name),
-		UpdateInterval:              ctx.GlobalInt(flags.UpdateInterval.Name),
+		UpdateInterval:              ctx.GlobalDuration(flags.UpdateInterval.Name),
 		ChainReadTimeout:            ctx.GlobalInt(flags.ChainReadTimeout.Name),
 		EthClientConfig:             geth.ReadEthClientConfigRPCOnly(ctx),
 		MaxBlobSize:                 ctx.GlobalInt(flags.MaxBlobSize.Name),
 		MaxNumSymbolsPerBlob:        ctx.GlobalUint(flags.MaxNumSymbolsPerBlob.Name),
 		OnchainStateRefreshInterval: ctx.GlobalDuration(flags.OnchainStateRefreshInterval.Name),
+		OnchainStateWatcherConfig:   eth.ReadOnchainStateWatcherCLIConfig(ctx),
+		HealthConfig:                healthcheck.ReadCLIConfig(ctx),
+		GatewayConfig:               gateway.ReadCLIConfig(ctx),
+		AuditConfig:                 audit.ReadCLIConfig(ctx),
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 	}
+	if deadline := ctx.GlobalInt64(flags.LegacyPaymentStateAuthDeadlineFlag.Name); deadline > 0 {
+		config.ServerConfig.LegacyPaymentStateAuthDeadline = time.Unix(deadline, 0)
+	} else if window := ctx.GlobalDuration(flags.LegacyPaymentStateAuthWindowFlag.Name); window > 0 {
+		config.ServerConfig.LegacyPaymentStateAuthDeadline = time.Now().Add(window)
+	}
 	return config, nil
 }
@@ -4,11 +4,16 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/gateway"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
@@ -109,8 +114,8 @@ var (
 	}
 	UpdateInterval = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "update-interval"),
-		Usage:    "update interval for refreshing the on-chain state",
-		Value:    1 * time.Second,
+		Usage:    "interval at which the payment meterer polls the on-chain payment state; 0 disables polling",
+		Value:    0,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "UPDATE_INTERVAL"),
 		Required: false,
 	}
@@ -155,6 +160,69 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_NUM_SYMBOLS_PER_BLOB"),
 		Required: false,
 	}
+	PaymentStateRequestMaxAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "payment-state-request-max-age"),
+		Usage:    "maximum difference between the timestamp of a GetPaymentState request and the server time. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PAYMENT_STATE_REQUEST_MAX_AGE"),
+		Value:    disperser.DefaultPaymentStateRequestMaxAge,
+	}
+	PaymentStateReplayCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "payment-state-replay-cache-size"),
+		Usage:    "maximum number of recent GetPaymentState requests remembered to reject replays. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PAYMENT_STATE_REPLAY_CACHE_SIZE"),
+		Value:    disperser.DefaultPaymentStateReplayCacheSize,
+	}
+	PaymentStateReplayCacheSizePerAccountFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "payment-state-replay-cache-size-per-account"),
+		Usage:    "maximum number of recent GetPaymentState requests of a single account remembered to reject replays. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PAYMENT_STATE_REPLAY_CACHE_SIZE_PER_ACCOUNT"),
+		Value:    disperser.DefaultPaymentStateReplayCacheSizePerAccount,
+	}
+	LegacyPaymentStateAuthDeadlineFlag = cli.Int64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "legacy-payment-state-auth-deadline"),
+		Usage:    "unix timestamp in seconds until which GetPaymentState requests signed over the account ID only are accepted. Takes precedence over legacy-payment-state-auth-window. After the deadline, clients must sign over a timestamp and nonce. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LEGACY_PAYMENT_STATE_AUTH_DEADLINE"),
+		Value:    0,
+	}
+	MaxSymbolOpeningsPerSecondFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-symbol-openings-per-second"),
+		Usage:    "maximum number of symbols opened per second by GetBlobSymbolProofs over all clients. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_SYMBOL_OPENINGS_PER_SECOND"),
+		Value:    ratelimit.DefaultMaxSymbolOpeningsPerSecond,
+	}
+	SymbolOpeningsBurstinessFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "symbol-openings-burstiness"),
+		Usage:    "maximum number of symbols opened at once by GetBlobSymbolProofs over all clients. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SYMBOL_OPENINGS_BURSTINESS"),
+		Value:    ratelimit.DefaultSymbolOpeningsBurstiness,
+	}
+	MaxSymbolOpeningsPerSecondClientFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-symbol-openings-per-second-client"),
+		Usage:    "maximum number of symbols opened per second by GetBlobSymbolProofs for a single client. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_SYMBOL_OPENINGS_PER_SECOND_CLIENT"),
+		Value:    ratelimit.DefaultMaxSymbolOpeningsPerSecondClient,
+	}
+	SymbolOpeningsBurstinessClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "symbol-openings-burstiness-client"),
+		Usage:    "maximum number of symbols opened at once by GetBlobSymbolProofs for a single client. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SYMBOL_OPENINGS_BURSTINESS_CLIENT"),
+		Value:    ratelimit.DefaultSymbolOpeningsBurstinessClient,
+	}
+	LegacyPaymentStateAuthWindowFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "legacy-payment-state-auth-window"),
+		Usage:    "how long after startup GetPaymentState requests signed over the account ID only are accepted, if no legacy-payment-state-auth-deadline is set. Every restart starts a new window, so set a deadline or 0 to cut old clients over. If 0, such requests are rejected. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LEGACY_PAYMENT_STATE_AUTH_WINDOW"),
+		Value:    disperser.DefaultLegacyPaymentStateAuthWindow,
+	}
 	PprofHttpPort = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "pprof-http-port"),
 		Usage:    "the http port which the pprof server is listening",
@@ -260,6 +328,15 @@ var optionalFlags = []cli.Flag{
 	GlobalRateTableName,
 	OnchainStateRefreshInterval,
 	MaxNumSymbolsPerBlob,
+	PaymentStateRequestMaxAgeFlag,
+	PaymentStateReplayCacheSizeFlag,
+	PaymentStateReplayCacheSizePerAccountFlag,
+	LegacyPaymentStateAuthDeadlineFlag,
+	LegacyPaymentStateAuthWindowFlag,
+	MaxSymbolOpeningsPerSecondFlag,
+	SymbolOpeningsBurstinessFlag,
+	MaxSymbolOpeningsPerSecondClientFlag,
+	SymbolOpeningsBurstinessClientFlag,
 	PprofHttpPort,
 	EnablePprof,
 }
@@ -270,6 +347,10 @@ var Flags []cli.Flag
 func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
+	Flags = append(Flags, eth.OnchainStateWatcherCLIFlags(envVarPrefix)...)
+	Flags = append(Flags, healthcheck.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, gateway.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, audit.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, ratelimit.RatelimiterCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
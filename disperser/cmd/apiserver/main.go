@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/gateway"
 	"github.com/Layr-Labs/eigenda/common"
 	mt "github.com/Layr-Labs/eigenda/core/meterer"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
@@ -19,12 +20,14 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
 	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/urfave/cli"
@@ -65,6 +68,10 @@ func RunDisperserServer(ctx *cli.Context) error {
 		return err
 	}
 
+	// serverCtx bounds the background loops started below, so that they stop when the server stops serving.
+	serverCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	client, err := geth.NewMultiHomingClient(config.EthClientConfig, gethcommon.Address{}, logger)
 	if err != nil {
 		logger.Error("Cannot create chain.Client", "err", err)
@@ -84,6 +91,17 @@ func RunDisperserServer(ctx *cli.Context) error {
 		return fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
 	}
 
+	var onchainStateWatcher *eth.OnchainStateWatcher
+	if config.OnchainStateWatcherConfig.Enabled {
+		onchainStateWatcher, err = eth.NewOnchainStateWatcher(logger, client, transactor, config.OnchainStateWatcherConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create onchain state watcher: %w", err)
+		}
+		if err := onchainStateWatcher.Start(serverCtx); err != nil {
+			return fmt.Errorf("failed to start onchain state watcher: %w", err)
+		}
+	}
+
 	s3Client, err := s3.NewClient(context.Background(), config.AwsClientConfig, logger)
 	if err != nil {
 		return err
@@ -95,12 +113,13 @@ func RunDisperserServer(ctx *cli.Context) error {
 	}
 
 	reg := prometheus.NewRegistry()
+	client.RegisterMetrics(reg)
 
 	var meterer *mt.Meterer
 	if config.EnablePaymentMeterer {
 		mtConfig := mt.Config{
 			ChainReadTimeout: time.Duration(config.ChainReadTimeout) * time.Second,
-			UpdateInterval:   time.Duration(config.UpdateInterval) * time.Second,
+			UpdateInterval:   config.UpdateInterval,
 		}
 
 		paymentChainState, err := mt.NewOnchainPaymentState(context.Background(), transactor)
@@ -126,9 +145,14 @@ func RunDisperserServer(ctx *cli.Context) error {
 			mtConfig,
 			paymentChainState,
 			offchainStore,
+			onchainStateWatcher,
 			logger,
 			// metrics.NewNoopMetrics(),
 		)
+		if mtConfig.UpdateInterval > 0 {
+			logger.Info("Payment meterer will poll the on-chain payment state", "interval", mtConfig.UpdateInterval)
+		}
+		meterer.Start(serverCtx)
 	}
 
 	var ratelimiter common.RateLimiter
@@ -159,6 +183,12 @@ func RunDisperserServer(ctx *cli.Context) error {
 		return fmt.Errorf("configured max blob size must be power of 2 %v", config.MaxBlobSize)
 	}
 
+	auditLog, err := audit.NewLogFromConfig(config.AuditConfig, dynamoClient, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+	defer auditLog.Close()
+
 	bucketName := config.BlobstoreConfig.BucketName
 	logger.Info("Blob store", "bucket", bucketName)
 	if config.DisperserVersion == V2 {
@@ -170,6 +200,9 @@ func RunDisperserServer(ctx *cli.Context) error {
 		blobMetadataStore := blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName)
 		blobStore := blobstorev2.NewBlobStore(bucketName, s3Client, logger)
 
+		healthRegistry := healthcheck.NewRegistry(logger, config.HealthConfig.CheckInterval, config.HealthConfig.ProbeTimeout)
+		healthRegistry.Register("EthClient", healthcheck.Readiness, healthcheck.BlockFreshnessProbe(client, config.HealthConfig.MaxBlockAge))
+
 		server, err := apiserver.NewDispersalServerV2(
 			config.ServerConfig,
 			blobStore,
@@ -180,13 +213,19 @@ func RunDisperserServer(ctx *cli.Context) error {
 			prover,
 			uint64(config.MaxNumSymbolsPerBlob),
 			config.OnchainStateRefreshInterval,
+			onchainStateWatcher,
+			healthRegistry,
+			auditLog,
 			logger,
 			reg,
 		)
 		if err != nil {
 			return err
 		}
-		return server.Start(context.Background())
+		healthRegistry.Start(serverCtx)
+		healthRegistry.StartHTTPServer(serverCtx, config.HealthConfig.HTTPPort)
+		gateway.Start(serverCtx, logger, config.GatewayConfig, server.GatewayHandler(config.GatewayConfig))
+		return server.Start(serverCtx)
 	}
 
 	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
@@ -205,6 +244,7 @@ func RunDisperserServer(ctx *cli.Context) error {
 		ratelimiter,
 		config.RateConfig,
 		config.MaxBlobSize,
+		auditLog,
 	)
 
 	reg.MustRegister(grpcMetrics)
@@ -213,9 +253,9 @@ func RunDisperserServer(ctx *cli.Context) error {
 	if config.MetricsConfig.EnableMetrics {
 		httpSocket := fmt.Sprintf(":%s", config.MetricsConfig.HTTPPort)
 		// TODO(cody-littley): once we deprecate v1, move all remaining metrics functionality to metrics_v2.go
-		metrics.Start(context.Background())
+		metrics.Start(serverCtx)
 		logger.Info("Enabled metrics for Disperser", "socket", httpSocket)
 	}
 
-	return server.Start(context.Background())
+	return server.Start(serverCtx)
 }
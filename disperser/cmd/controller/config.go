@@ -6,6 +6,9 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/statecache"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
@@ -19,6 +22,7 @@ const MaxUint16 = ^uint16(0)
 type Config struct {
 	EncodingManagerConfig          controller.EncodingManagerConfig
 	DispatcherConfig               controller.DispatcherConfig
+	BlobLifecycleManagerConfig     controller.BlobLifecycleManagerConfig
 	NumConcurrentEncodingRequests  int
 	NumConcurrentDispersalRequests int
 	NodeClientCacheSize            int
@@ -32,10 +36,14 @@ type Config struct {
 	ChainStateConfig thegraph.Config
 	UseGraph         bool
 
+	OnchainStateWatcherConfig eth.OnchainStateWatcherConfig
+	ChainStateCacheConfig     statecache.Config
+
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 
-	MetricsPort int
+	MetricsPort  int
+	HealthConfig healthcheck.Config
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -59,6 +67,15 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		}
 		relays[i] = corev2.RelayKey(relay)
 	}
+	blobBucketName := ctx.GlobalString(flags.S3BucketNameFlag.Name)
+	chunkBucketName := ctx.GlobalString(flags.ChunkStoreBucketNameFlag.Name)
+	if chunkBucketName == "" {
+		chunkBucketName = blobBucketName
+	}
+	collectionInterval := ctx.GlobalDuration(flags.BlobCollectionIntervalFlag.Name)
+	if collectionInterval > 0 && blobBucketName == "" {
+		return Config{}, fmt.Errorf("the blob bucket name is required to delete expired blobs")
+	}
 	config := Config{
 		DynamoDBTableName: ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
 		EthClientConfig:   ethClientConfig,
@@ -72,6 +89,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			NumRelayAssignment:          uint16(numRelayAssignments),
 			AvailableRelays:             relays,
 			EncoderAddress:              ctx.GlobalString(flags.EncoderAddressFlag.Name),
+			FallbackEncoderAddresses:    ctx.GlobalStringSlice(flags.FallbackEncoderAddressesFlag.Name),
 			MaxNumBlobsPerIteration:     int32(ctx.GlobalInt(flags.MaxNumBlobsPerIterationFlag.Name)),
 			OnchainStateRefreshInterval: ctx.GlobalDuration(flags.OnchainStateRefreshIntervalFlag.Name),
 		},
@@ -82,16 +100,29 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			NumRequestRetries:      ctx.GlobalInt(flags.NumRequestRetriesFlag.Name),
 			MaxBatchSize:           int32(ctx.GlobalInt(flags.MaxBatchSizeFlag.Name)),
 		},
+		BlobLifecycleManagerConfig: controller.BlobLifecycleManagerConfig{
+			CollectionInterval:      collectionInterval,
+			MaxNumBlobsPerIteration: int32(ctx.GlobalInt(flags.MaxNumBlobsPerIterationFlag.Name)),
+			BlobBucketName:          blobBucketName,
+			ChunkBucketName:         chunkBucketName,
+			DryRun:                  ctx.GlobalBool(flags.BlobCollectionDryRunFlag.Name),
+		},
 		NumConcurrentEncodingRequests:  ctx.GlobalInt(flags.NumConcurrentEncodingRequestsFlag.Name),
 		NumConcurrentDispersalRequests: ctx.GlobalInt(flags.NumConcurrentDispersalRequestsFlag.Name),
 		NodeClientCacheSize:            ctx.GlobalInt(flags.NodeClientCacheNumEntriesFlag.Name),
 		IndexerConfig:                  indexer.ReadIndexerConfig(ctx),
 		ChainStateConfig:               thegraph.ReadCLIConfig(ctx),
 		UseGraph:                       ctx.GlobalBool(flags.UseGraphFlag.Name),
+		OnchainStateWatcherConfig:      eth.ReadOnchainStateWatcherCLIConfig(ctx),
+		ChainStateCacheConfig: statecache.Config{
+			MaxWeight:    ctx.GlobalUint64(flags.ChainStateCacheMaxWeightFlag.Name),
+			FetchTimeout: ctx.GlobalDuration(flags.ChainStateCacheFetchTimeoutFlag.Name),
+		},
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		MetricsPort:                   ctx.GlobalInt(flags.MetricsPortFlag.Name),
+		HealthConfig:                  healthcheck.ReadCLIConfig(ctx),
 	}
 	return config, nil
 }
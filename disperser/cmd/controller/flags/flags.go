@@ -6,6 +6,9 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/statecache"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
@@ -67,6 +70,12 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_ADDRESS"),
 	}
+	FallbackEncoderAddressesFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fallback-encoder-addresses"),
+		Usage:    "the ip:port of the encoders that blobs are encoded on when the output of the encoder fails self verification, tried in order",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FALLBACK_ENCODER_ADDRESSES"),
+	}
 	EncodingRequestTimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "encoding-request-timeout"),
 		Usage:    "Timeout for encoding requests",
@@ -178,6 +187,47 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METRICS_PORT"),
 		Value:    9101,
 	}
+	ChainStateCacheMaxWeightFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-state-cache-max-weight"),
+		Usage:    "Maximum number of operator entries held by the operator state cache. 0 disables the cache",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHAIN_STATE_CACHE_MAX_WEIGHT"),
+		Value:    100_000,
+	}
+	ChainStateCacheFetchTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-state-cache-fetch-timeout"),
+		Usage:    "Timeout of an operator state lookup shared by concurrent requests",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHAIN_STATE_CACHE_FETCH_TIMEOUT"),
+		Value:    statecache.DefaultFetchTimeout,
+	}
+
+	// Blob Lifecycle Manager Flags
+	BlobCollectionIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-collection-interval"),
+		Usage:    "Interval at which expired blobs, their chunks, proofs and metadata are deleted. 0 disables the deletion of expired blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_COLLECTION_INTERVAL"),
+		Value:    0,
+	}
+	BlobCollectionDryRunFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-collection-dry-run"),
+		Usage:    "Only log and report the expired blobs and the bytes they hold, without deleting anything",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_COLLECTION_DRY_RUN"),
+	}
+	S3BucketNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-bucket-name"),
+		Usage:    "Name of the bucket storing blobs. Required if expired blobs are deleted",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_BUCKET_NAME"),
+	}
+	ChunkStoreBucketNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-store-bucket-name"),
+		Usage:    "Name of the bucket storing chunks and proofs. Defaults to the bucket storing blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_STORE_BUCKET_NAME"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -196,6 +246,7 @@ var requiredFlags = []cli.Flag{
 
 var optionalFlags = []cli.Flag{
 	IndexerDataDirFlag,
+	FallbackEncoderAddressesFlag,
 	EncodingRequestTimeoutFlag,
 	EncodingStoreTimeoutFlag,
 	NumEncodingRetriesFlag,
@@ -210,6 +261,12 @@ var optionalFlags = []cli.Flag{
 	NodeClientCacheNumEntriesFlag,
 	MaxBatchSizeFlag,
 	MetricsPortFlag,
+	ChainStateCacheMaxWeightFlag,
+	ChainStateCacheFetchTimeoutFlag,
+	BlobCollectionIntervalFlag,
+	BlobCollectionDryRunFlag,
+	S3BucketNameFlag,
+	ChunkStoreBucketNameFlag,
 }
 
 var Flags []cli.Flag
@@ -217,8 +274,10 @@ var Flags []cli.Flag
 func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
+	Flags = append(Flags, eth.OnchainStateWatcherCLIFlags(envVarPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, indexer.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, healthcheck.CLIFlags(envVarPrefix)...)
 }
@@ -13,11 +13,15 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/core/statecache"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
@@ -75,6 +79,17 @@ func RunController(ctx *cli.Context) error {
 		return err
 	}
 
+	var onchainStateWatcher *eth.OnchainStateWatcher
+	if config.OnchainStateWatcherConfig.Enabled {
+		onchainStateWatcher, err = eth.NewOnchainStateWatcher(logger, gethClient, chainReader, config.OnchainStateWatcherConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create onchain state watcher: %w", err)
+		}
+		if err := onchainStateWatcher.Start(context.Background()); err != nil {
+			return fmt.Errorf("failed to start onchain state watcher: %w", err)
+		}
+	}
+
 	blobMetadataStore := blobstore.NewBlobMetadataStore(
 		dynamoClient,
 		logger,
@@ -84,6 +99,7 @@ func RunController(ctx *cli.Context) error {
 	metricsRegistry := prometheus.NewRegistry()
 	metricsRegistry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	metricsRegistry.MustRegister(collectors.NewGoCollector())
+	gethClient.RegisterMetrics(metricsRegistry)
 
 	logger.Infof("Starting metrics server at port %d", config.MetricsPort)
 	addr := fmt.Sprintf(":%d", config.MetricsPort)
@@ -101,13 +117,23 @@ func RunController(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create encoder client: %v", err)
 	}
+	fallbackEncoderClients := make([]disperser.EncoderClientV2, 0, len(config.EncodingManagerConfig.FallbackEncoderAddresses))
+	for _, addr := range config.EncodingManagerConfig.FallbackEncoderAddresses {
+		fallbackEncoderClient, err := encoder.NewEncoderClientV2(addr)
+		if err != nil {
+			return fmt.Errorf("failed to create fallback encoder client: %v", err)
+		}
+		fallbackEncoderClients = append(fallbackEncoderClients, fallbackEncoderClient)
+	}
 	encodingPool := workerpool.New(config.NumConcurrentEncodingRequests)
 	encodingManager, err := controller.NewEncodingManager(
 		&config.EncodingManagerConfig,
 		blobMetadataStore,
 		encodingPool,
 		encoderClient,
+		fallbackEncoderClients,
 		chainReader,
+		onchainStateWatcher,
 		logger,
 		metricsRegistry,
 	)
@@ -148,6 +174,12 @@ func RunController(ctx *cli.Context) error {
 			return err
 		}
 	}
+	if config.ChainStateCacheConfig.MaxWeight > 0 {
+		ics, err = statecache.NewIndexedChainState(ics, config.ChainStateCacheConfig, metricsRegistry, "eigenda_dispatcher")
+		if err != nil {
+			return fmt.Errorf("failed to create chain state cache: %v", err)
+		}
+	}
 	nodeClientManager, err := controller.NewNodeClientManager(config.NodeClientCacheSize, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create node client manager: %v", err)
@@ -177,6 +209,34 @@ func RunController(ctx *cli.Context) error {
 		return fmt.Errorf("failed to start dispatcher: %v", err)
 	}
 
+	if config.BlobLifecycleManagerConfig.CollectionInterval > 0 {
+		s3Client, err := s3.NewClient(c, config.AwsClientConfig, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create s3 client: %v", err)
+		}
+		blobLifecycleManager, err := controller.NewBlobLifecycleManager(
+			&config.BlobLifecycleManagerConfig,
+			blobMetadataStore,
+			s3Client,
+			logger,
+			metricsRegistry,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create blob lifecycle manager: %v", err)
+		}
+		err = blobLifecycleManager.Start(c)
+		if err != nil {
+			return fmt.Errorf("failed to start blob lifecycle manager: %v", err)
+		}
+	}
+
+	healthRegistry := healthcheck.NewRegistry(logger, config.HealthConfig.CheckInterval, config.HealthConfig.ProbeTimeout)
+	healthRegistry.Register("BlobMetadataStore", healthcheck.Readiness, blobMetadataStore.CheckHealth)
+	healthRegistry.Register("EthClient", healthcheck.Readiness, healthcheck.BlockFreshnessProbe(gethClient, config.HealthConfig.MaxBlockAge))
+	healthRegistry.Register("Encoder", healthcheck.Readiness, healthcheck.GRPCReachabilityProbe(config.EncodingManagerConfig.EncoderAddress))
+	healthRegistry.Start(c)
+	healthRegistry.StartHTTPServer(c, config.HealthConfig.HTTPPort)
+
 	go func() {
 		err := metricsServer.ListenAndServe()
 		if err != nil && !strings.Contains(err.Error(), "http: Server closed") {
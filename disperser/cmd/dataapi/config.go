@@ -8,6 +8,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
@@ -23,6 +24,8 @@ type Config struct {
 	PrometheusConfig prometheus.Config
 	MetricsConfig    dataapi.MetricsConfig
 	ChainStateConfig thegraph.Config
+	// AuditConfig configures the sink of the dispersal audit records served by the v2 API.
+	AuditConfig audit.Config
 
 	SocketAddr                   string
 	PrometheusApiAddr            string
@@ -81,6 +84,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		ChurnerHostname:    ctx.GlobalString(flags.ChurnerHostnameFlag.Name),
 		BatcherHealthEndpt: ctx.GlobalString(flags.BatcherHealthEndptFlag.Name),
 		ChainStateConfig:   thegraph.ReadCLIConfig(ctx),
+		AuditConfig:        audit.ReadCLIConfig(ctx),
 	}
 	return config, nil
 }
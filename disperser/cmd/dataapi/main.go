@@ -15,6 +15,7 @@ import (
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
@@ -132,6 +133,10 @@ func RunDataApi(ctx *cli.Context) error {
 
 	if config.ServerVersion == 2 {
 		blobMetadataStorev2 := blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName)
+		auditSink, err := audit.NewSink(config.AuditConfig, dynamoClient, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create audit sink: %w", err)
+		}
 		serverv2 := serverv2.NewServerV2(
 			dataapi.Config{
 				ServerMode:         config.ServerMode,
@@ -149,6 +154,7 @@ func RunDataApi(ctx *cli.Context) error {
 			indexedChainState,
 			logger,
 			metrics,
+			auditSink,
 		)
 		return runServer(serverv2, logger)
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/encoder/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
@@ -29,6 +30,7 @@ type Config struct {
 	LoggerConfig     common.LoggerConfig
 	ServerConfig     *encoder.ServerConfig
 	MetricsConfig    *encoder.MetricsConfig
+	HealthConfig     healthcheck.Config
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -53,20 +55,29 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		EncoderConfig: kzg.ReadCLIConfig(ctx),
 		LoggerConfig:  *loggerConfig,
 		ServerConfig: &encoder.ServerConfig{
-			GrpcPort:                 ctx.GlobalString(flags.GrpcPortFlag.Name),
-			MaxConcurrentRequests:    ctx.GlobalInt(flags.MaxConcurrentRequestsFlag.Name),
-			RequestPoolSize:          ctx.GlobalInt(flags.RequestPoolSizeFlag.Name),
-			EnableGnarkChunkEncoding: ctx.Bool(flags.EnableGnarkChunkEncodingFlag.Name),
-			PreventReencoding:        ctx.Bool(flags.PreventReencodingFlag.Name),
-			Backend:                  ctx.String(flags.BackendFlag.Name),
-			GPUEnable:                ctx.Bool(flags.GPUEnableFlag.Name),
-			PprofHttpPort:            ctx.GlobalString(flags.PprofHttpPort.Name),
-			EnablePprof:              ctx.GlobalBool(flags.EnablePprof.Name),
+			GrpcPort:                   ctx.GlobalString(flags.GrpcPortFlag.Name),
+			MaxConcurrentRequests:      ctx.GlobalInt(flags.MaxConcurrentRequestsFlag.Name),
+			RequestPoolSize:            ctx.GlobalInt(flags.RequestPoolSizeFlag.Name),
+			SmallBlobConcurrencyLimit:  ctx.GlobalInt(flags.SmallBlobConcurrencyLimitFlag.Name),
+			MediumBlobConcurrencyLimit: ctx.GlobalInt(flags.MediumBlobConcurrencyLimitFlag.Name),
+			LargeBlobConcurrencyLimit:  ctx.GlobalInt(flags.LargeBlobConcurrencyLimitFlag.Name),
+			EnableGnarkChunkEncoding:   ctx.Bool(flags.EnableGnarkChunkEncodingFlag.Name),
+			PreventReencoding:          ctx.Bool(flags.PreventReencodingFlag.Name),
+			Backend:                    ctx.String(flags.BackendFlag.Name),
+			GPUEnable:                  ctx.Bool(flags.GPUEnableFlag.Name),
+			PprofHttpPort:              ctx.GlobalString(flags.PprofHttpPort.Name),
+			EnablePprof:                ctx.GlobalBool(flags.EnablePprof.Name),
+			EnableStreamingEncoding:    ctx.GlobalBool(flags.EnableStreamingEncodingFlag.Name),
+			StreamingEncodingBatchSize: ctx.GlobalUint64(flags.StreamingEncodingBatchSizeFlag.Name),
+			EnableSelfVerification:     ctx.GlobalBool(flags.EnableSelfVerificationFlag.Name),
+			SelfVerificationSampleSize: ctx.GlobalUint64(flags.SelfVerificationSampleSizeFlag.Name),
+			EnableChunkReuse:           ctx.GlobalBool(flags.EnableChunkReuseFlag.Name),
 		},
 		MetricsConfig: &encoder.MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
 		},
+		HealthConfig: healthcheck.ReadCLIConfig(ctx),
 	}
 	return config, nil
 }
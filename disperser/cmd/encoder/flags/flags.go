@@ -3,6 +3,7 @@ package flags
 import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
@@ -62,6 +63,27 @@ var (
 		Value:    32,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REQUEST_POOL_SIZE"),
 	}
+	SmallBlobConcurrencyLimitFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "small-blob-concurrency-limit"),
+		Usage:    "maximum number of concurrent requests for blobs of up to 128KiB. If 0, only max-concurrent-requests applies",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SMALL_BLOB_CONCURRENCY_LIMIT"),
+	}
+	MediumBlobConcurrencyLimitFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "medium-blob-concurrency-limit"),
+		Usage:    "maximum number of concurrent requests for blobs of up to 2MiB. If 0, only max-concurrent-requests applies",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MEDIUM_BLOB_CONCURRENCY_LIMIT"),
+	}
+	LargeBlobConcurrencyLimitFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "large-blob-concurrency-limit"),
+		Usage:    "maximum number of concurrent requests for blobs larger than 2MiB. If 0, only max-concurrent-requests applies",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LARGE_BLOB_CONCURRENCY_LIMIT"),
+	}
 	EnableGnarkChunkEncodingFlag = cli.BoolFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "enable-gnark-chunk-encoding"),
 		Usage:    "if true, will produce chunks in Gnark, instead of Gob",
@@ -87,6 +109,38 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PREVENT_REENCODING"),
 	}
+	EnableStreamingEncodingFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-streaming-encoding"),
+		Usage:    "if true, the v2 encoder computes and uploads chunk coefficients in batches of frames, so that coefficient memory scales with the batch size instead of the encoded blob size. The blob and the proofs of every chunk are still held in memory",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_STREAMING_ENCODING"),
+	}
+	StreamingEncodingBatchSizeFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "streaming-encoding-batch-size"),
+		Usage:    "number of chunks computed at a time when streaming encoding is enabled",
+		Required: false,
+		Value:    64,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STREAMING_ENCODING_BATCH_SIZE"),
+	}
+	EnableSelfVerificationFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-self-verification"),
+		Usage:    "if true, the v2 encoder verifies the encoded chunks against the blob commitment before storing them. Requires the G2 SRS points",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_SELF_VERIFICATION"),
+	}
+	SelfVerificationSampleSizeFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "self-verification-sample-size"),
+		Usage:    "number of randomly chosen chunks verified per blob when self verification is enabled. If 0, every chunk is verified",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SELF_VERIFICATION_SAMPLE_SIZE"),
+	}
+	EnableChunkReuseFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-chunk-reuse"),
+		Usage:    "if true, the v2 encoder copies the chunks of a previously encoded blob with the same content, commitment and encoding params instead of encoding again. Disabled by default",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_CHUNK_REUSE"),
+	}
 	PprofHttpPort = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "pprof-http-port"),
 		Usage:    "the http port which the pprof server is listening",
@@ -111,12 +165,20 @@ var optionalFlags = []cli.Flag{
 	EnableMetrics,
 	MaxConcurrentRequestsFlag,
 	RequestPoolSizeFlag,
+	SmallBlobConcurrencyLimitFlag,
+	MediumBlobConcurrencyLimitFlag,
+	LargeBlobConcurrencyLimitFlag,
 	EnableGnarkChunkEncodingFlag,
 	EncoderVersionFlag,
 	S3BucketNameFlag,
 	GPUEnableFlag,
 	BackendFlag,
 	PreventReencodingFlag,
+	EnableStreamingEncodingFlag,
+	StreamingEncodingBatchSizeFlag,
+	EnableSelfVerificationFlag,
+	SelfVerificationSampleSizeFlag,
+	EnableChunkReuseFlag,
 	PprofHttpPort,
 	EnablePprof,
 }
@@ -129,4 +191,5 @@ func init() {
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, kzg.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, healthcheck.CLIFlags(envVarPrefix)...)
 }
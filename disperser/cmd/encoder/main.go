@@ -8,11 +8,13 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/encoder/flags"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
@@ -104,19 +106,34 @@ func RunEncoderServer(ctx *cli.Context) error {
 		blobStore := blobstorev2.NewBlobStore(blobStoreBucketName, s3Client, logger)
 		logger.Info("Blob store", "bucket", blobStoreBucketName)
 
+		// The verifier only needs the G2 points at the chunk lengths it verifies, which are read from G2Path on
+		// demand, so it does not load G2 points either.
+		var frameVerifier encoding.Verifier
+		if config.ServerConfig.EnableSelfVerification {
+			frameVerifier, err = verifier.NewVerifier(&config.EncoderConfig, encodingConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create verifier: %w", err)
+			}
+		}
+
 		chunkStoreBucketName := config.ChunkStoreConfig.BucketName
 		chunkWriter := chunkstore.NewChunkWriter(logger, s3Client, chunkStoreBucketName, DefaultFragmentSizeBytes)
 		logger.Info("Chunk store writer", "bucket", blobStoreBucketName)
 
+		healthRegistry := healthcheck.NewRegistry(logger, config.HealthConfig.CheckInterval, config.HealthConfig.ProbeTimeout)
 		server := encoder.NewEncoderServerV2(
 			*config.ServerConfig,
 			blobStore,
 			chunkWriter,
 			logger,
 			prover,
+			frameVerifier,
 			metrics,
+			healthRegistry,
 		)
 
+		healthRegistry.Start(context.Background())
+		healthRegistry.StartHTTPServer(context.Background(), config.HealthConfig.HTTPPort)
 		return server.Start()
 	}
 
@@ -0,0 +1,196 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Decision is the outcome of a dispersal request.
+type Decision string
+
+const (
+	Accepted Decision = "accepted"
+	Rejected Decision = "rejected"
+)
+
+// PaymentMode is how a dispersal request is paid for.
+type PaymentMode string
+
+const (
+	// PaymentModeReservation is a v2 request paid for by the reservation of the account.
+	PaymentModeReservation PaymentMode = "reservation"
+	// PaymentModeOnDemand is a v2 request paid for by the on-demand deposit of the account.
+	PaymentModeOnDemand PaymentMode = "on-demand"
+	// PaymentModeRateLimit is a v1 request admitted by the free tier or allowlist rate limits.
+	PaymentModeRateLimit PaymentMode = "rate-limit"
+	// PaymentModeNone is a request whose payment could not be determined, e.g. because it was malformed.
+	PaymentModeNone PaymentMode = ""
+)
+
+// UnknownAccount is the account ID recorded for requests that did not prove which account they come from, either
+// because they are too malformed to carry one or because they failed authentication.
+const UnknownAccount = "unknown"
+
+// NormalizeAccountID returns the checksummed form of an account ID that is an Ethereum address, so that the records
+// of an account are stored and queried under a single ID whatever the case of the address. Other account IDs, such
+// as the public keys of v1 requests, are returned unchanged.
+func NormalizeAccountID(accountID string) string {
+	if gethcommon.IsHexAddress(accountID) {
+		return gethcommon.HexToAddress(accountID).Hex()
+	}
+	return accountID
+}
+
+// Record is the audit record of a single dispersal request.
+type Record struct {
+	// RequestID uniquely identifies the record. It is assigned by the Log.
+	RequestID string `json:"request_id"`
+	// Timestamp is the time the decision was made. It is assigned by the Log if not set.
+	Timestamp time.Time `json:"timestamp"`
+	// APIVersion is the version of the disperser API serving the request, "v1" or "v2".
+	APIVersion string `json:"api_version"`
+	// Method is the gRPC method serving the request.
+	Method string `json:"method"`
+	// AccountID is the account the request was authenticated as, or UnknownAccount if the request was not
+	// authenticated, so that the records of an account only hold requests the account actually signed.
+	AccountID string `json:"account_id"`
+	// ClaimedAccountID is the account the request claims to come from, whether or not the claim was verified.
+	ClaimedAccountID string `json:"claimed_account_id,omitempty"`
+	// Authenticated is whether the request proved that it comes from AccountID.
+	Authenticated bool `json:"authenticated"`
+	// BlobKey is the hex encoded blob key of a v2 request, or the request ID of a v1 request. It is empty if the
+	// request was rejected before the key could be computed.
+	BlobKey     string      `json:"blob_key,omitempty"`
+	BlobSize    int         `json:"blob_size"`
+	Quorums     []uint32    `json:"quorums"`
+	PaymentMode PaymentMode `json:"payment_mode,omitempty"`
+	Decision    Decision    `json:"decision"`
+	// Code is the gRPC status code of the reply.
+	Code string `json:"code"`
+	// Reason is the error returned to the client for a rejected request.
+	Reason string `json:"reason,omitempty"`
+}
+
+// SetAccount sets the account of the record. The claimed account is only attributed the request if it was
+// authenticated.
+func (r *Record) SetAccount(claimedAccountID string, authenticated bool) {
+	r.ClaimedAccountID = claimedAccountID
+	r.Authenticated = authenticated
+	r.AccountID = ""
+	if authenticated {
+		r.AccountID = claimedAccountID
+	}
+}
+
+// SetOutcome sets the decision, code and reason of the record from the error returned for the request.
+func (r *Record) SetOutcome(err error) {
+	if err == nil {
+		r.Decision = Accepted
+		r.Code = codes.OK.String()
+		r.Reason = ""
+		return
+	}
+	s := status.Convert(err)
+	r.Decision = Rejected
+	r.Code = s.Code().String()
+	r.Reason = s.Message()
+}
+
+// Sink durably appends audit records and queries them by account. Implementations must be thread safe.
+type Sink interface {
+	// Append appends a record. Records are never updated or deleted.
+	Append(ctx context.Context, record *Record) error
+	// QueryByAccount returns the records of an account with a timestamp in [start, end], newest first.
+	// At most limit records are returned.
+	QueryByAccount(ctx context.Context, accountID string, start time.Time, end time.Time, limit int) ([]*Record, error)
+}
+
+// Log writes audit records to a sink in the background, so that a slow sink does not add latency to dispersals.
+// Records are dropped, with a warning, when the buffer is full. A nil Log discards all records.
+type Log struct {
+	sink    Sink
+	logger  logging.Logger
+	records chan *Record
+	wg      sync.WaitGroup
+
+	// mu guards closed, so that records arriving during shutdown are dropped instead of sent on a closed channel.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewLog creates a Log that buffers up to bufferSize records and starts writing them to the sink.
+func NewLog(sink Sink, bufferSize int, logger logging.Logger) *Log {
+	l := &Log{
+		sink:    sink,
+		logger:  logger.With("component", "AuditLog"),
+		records: make(chan *Record, bufferSize),
+	}
+	l.wg.Add(1)
+	go l.run()
+	return l
+}
+
+// Record queues a record to be written to the sink. It never blocks.
+func (l *Log) Record(record *Record) {
+	if l == nil {
+		return
+	}
+	record.RequestID = newRequestID()
+	record.AccountID = NormalizeAccountID(record.AccountID)
+	record.ClaimedAccountID = NormalizeAccountID(record.ClaimedAccountID)
+	if record.AccountID == "" {
+		record.AccountID = UnknownAccount
+	}
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		return
+	}
+	select {
+	case l.records <- record:
+	default:
+		l.logger.Warn("audit log buffer is full, dropping record",
+			"accountID", record.AccountID, "blobKey", record.BlobKey, "decision", record.Decision)
+	}
+}
+
+// Close writes the buffered records to the sink and stops the Log. Records recorded after Close are dropped.
+func (l *Log) Close() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.records)
+	}
+	l.mu.Unlock()
+	l.wg.Wait()
+}
+
+func (l *Log) run() {
+	defer l.wg.Done()
+	for record := range l.records {
+		if err := l.sink.Append(context.Background(), record); err != nil {
+			l.logger.Error("failed to append audit record", "requestID", record.RequestID, "err", err)
+		}
+	}
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
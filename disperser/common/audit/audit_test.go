@@ -0,0 +1,137 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSinks(t *testing.T) map[string]audit.Sink {
+	fileSink, err := audit.NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	return map[string]audit.Sink{
+		"file": fileSink,
+		"kv":   audit.NewKVSink(mapstore.NewStore()),
+	}
+}
+
+func TestSinkQueryByAccount(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+
+	for name, sink := range testSinks(t) {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 5; i++ {
+				require.NoError(t, sink.Append(ctx, &audit.Record{
+					RequestID: fmt.Sprintf("a-%d", i),
+					Timestamp: now.Add(time.Duration(i) * time.Second),
+					AccountID: "alice",
+					Quorums:   []uint32{0, 1},
+				}))
+			}
+			require.NoError(t, sink.Append(ctx, &audit.Record{
+				RequestID: "b-0",
+				Timestamp: now,
+				AccountID: "bob",
+			}))
+
+			records, err := sink.QueryByAccount(ctx, "alice", now, now.Add(time.Hour), 0)
+			require.NoError(t, err)
+			require.Len(t, records, 5)
+			for i, record := range records {
+				assert.Equal(t, fmt.Sprintf("a-%d", 4-i), record.RequestID)
+				assert.Equal(t, []uint32{0, 1}, record.Quorums)
+			}
+
+			// the range is inclusive on both ends
+			records, err = sink.QueryByAccount(ctx, "alice", now.Add(time.Second), now.Add(3*time.Second), 0)
+			require.NoError(t, err)
+			require.Len(t, records, 3)
+			assert.Equal(t, "a-3", records[0].RequestID)
+			assert.Equal(t, "a-1", records[2].RequestID)
+
+			records, err = sink.QueryByAccount(ctx, "alice", now, now.Add(time.Hour), 2)
+			require.NoError(t, err)
+			require.Len(t, records, 2)
+			assert.Equal(t, "a-4", records[0].RequestID)
+
+			records, err = sink.QueryByAccount(ctx, "bob", now, now.Add(time.Hour), 0)
+			require.NoError(t, err)
+			require.Len(t, records, 1)
+
+			records, err = sink.QueryByAccount(ctx, "carol", now, now.Add(time.Hour), 0)
+			require.NoError(t, err)
+			assert.Empty(t, records)
+		})
+	}
+}
+
+func TestLog(t *testing.T) {
+	sink := audit.NewKVSink(mapstore.NewStore())
+	log := audit.NewLog(sink, 100, logging.NewNoopLogger())
+
+	accepted := &audit.Record{AccountID: "alice", BlobKey: "key"}
+	accepted.SetOutcome(nil)
+	log.Record(accepted)
+
+	rejected := &audit.Record{}
+	rejected.SetAccount("alice", false)
+	rejected.SetOutcome(api.NewErrorInvalidArg("blob size is 0"))
+	log.Record(rejected)
+
+	// Close flushes the buffered records
+	log.Close()
+	log.Record(&audit.Record{AccountID: "alice"})
+
+	records, err := sink.QueryByAccount(context.Background(), "alice", time.Time{}, time.Now(), 0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.NotEmpty(t, records[0].RequestID)
+	assert.Equal(t, audit.Accepted, records[0].Decision)
+	assert.Equal(t, "OK", records[0].Code)
+
+	records, err = sink.QueryByAccount(context.Background(), audit.UnknownAccount, time.Time{}, time.Now(), 0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, audit.Rejected, records[0].Decision)
+	assert.Equal(t, "InvalidArgument", records[0].Code)
+	assert.Equal(t, "blob size is 0", records[0].Reason)
+	// the unauthenticated request claimed to come from alice, but is not attributed to her
+	assert.Equal(t, "alice", records[0].ClaimedAccountID)
+	assert.False(t, records[0].Authenticated)
+
+	// a nil Log discards records
+	var nilLog *audit.Log
+	nilLog.Record(&audit.Record{})
+	nilLog.Close()
+}
+
+func TestLogNormalizesAccountIDs(t *testing.T) {
+	sink := audit.NewKVSink(mapstore.NewStore())
+	log := audit.NewLog(sink, 100, logging.NewNoopLogger())
+
+	account := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	record := &audit.Record{}
+	record.SetAccount("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true)
+	log.Record(record)
+	log.Close()
+
+	// the records of an address are stored under its checksummed form, whatever the case it was sent in
+	records, err := sink.QueryByAccount(context.Background(), account, time.Time{}, time.Now(), 0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, account, records[0].AccountID)
+	assert.Equal(t, account, audit.NormalizeAccountID("0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED"))
+
+	// other account IDs are kept as they are
+	assert.Equal(t, "alice", audit.NormalizeAccountID("alice"))
+}
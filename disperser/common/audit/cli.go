@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli"
+)
+
+const (
+	SinkTypeNone     = ""
+	SinkTypeFile     = "file"
+	SinkTypeLevelDB  = "leveldb"
+	SinkTypeDynamoDB = "dynamodb"
+)
+
+var (
+	sinkFlagName          = "audit.sink"
+	filePathFlagName      = "audit.file-path"
+	levelDBPathFlagName   = "audit.leveldb-path"
+	dynamoDBTableFlagName = "audit.dynamodb-table-name"
+	bufferSizeFlagName    = "audit.buffer-size"
+)
+
+type Config struct {
+	// SinkType is the sink of the audit records: file, leveldb or dynamodb. No records are kept if empty.
+	SinkType string
+	// FilePath is the JSON lines file of the file sink.
+	FilePath string
+	// LevelDBPath is the database directory of the leveldb sink.
+	LevelDBPath string
+	// DynamoDBTableName is the table of the dynamodb sink.
+	DynamoDBTableName string
+	// BufferSize is the number of records buffered before records are dropped.
+	BufferSize int
+}
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     sinkFlagName,
+			Usage:    "Sink of the dispersal audit records: file, leveldb or dynamodb. No records are kept if empty",
+			Required: false,
+			Value:    SinkTypeNone,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AUDIT_SINK"),
+		},
+		cli.StringFlag{
+			Name:     filePathFlagName,
+			Usage:    "Path of the JSON lines file of the file audit sink",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AUDIT_FILE_PATH"),
+		},
+		cli.StringFlag{
+			Name:     levelDBPathFlagName,
+			Usage:    "Path of the database of the leveldb audit sink. The database can only be opened by one process at a time",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AUDIT_LEVELDB_PATH"),
+		},
+		cli.StringFlag{
+			Name:     dynamoDBTableFlagName,
+			Usage:    "Name of the DynamoDB table of the dynamodb audit sink",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AUDIT_DYNAMODB_TABLE_NAME"),
+		},
+		cli.IntFlag{
+			Name:     bufferSizeFlagName,
+			Usage:    "Number of audit records buffered before records are dropped",
+			Required: false,
+			Value:    10000,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AUDIT_BUFFER_SIZE"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context) Config {
+	return Config{
+		SinkType:          ctx.GlobalString(sinkFlagName),
+		FilePath:          ctx.GlobalString(filePathFlagName),
+		LevelDBPath:       ctx.GlobalString(levelDBPathFlagName),
+		DynamoDBTableName: ctx.GlobalString(dynamoDBTableFlagName),
+		BufferSize:        ctx.GlobalInt(bufferSizeFlagName),
+	}
+}
+
+// NewSink creates the sink of the config, or returns nil if no sink is configured. The DynamoDB client is only
+// used by the dynamodb sink.
+func NewSink(config Config, dynamoDBClient commondynamodb.Client, logger logging.Logger) (Sink, error) {
+	switch config.SinkType {
+	case SinkTypeNone:
+		return nil, nil
+	case SinkTypeFile:
+		if config.FilePath == "" {
+			return nil, fmt.Errorf("%s is required by the file audit sink", filePathFlagName)
+		}
+		return NewFileSink(config.FilePath)
+	case SinkTypeLevelDB:
+		if config.LevelDBPath == "" {
+			return nil, fmt.Errorf("%s is required by the leveldb audit sink", levelDBPathFlagName)
+		}
+		store, err := leveldb.NewStore(logger, config.LevelDBPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit database: %w", err)
+		}
+		return NewKVSink(store), nil
+	case SinkTypeDynamoDB:
+		if config.DynamoDBTableName == "" {
+			return nil, fmt.Errorf("%s is required by the dynamodb audit sink", dynamoDBTableFlagName)
+		}
+		if dynamoDBClient == nil {
+			return nil, errors.New("the dynamodb audit sink requires a DynamoDB client")
+		}
+		return NewDynamoSink(dynamoDBClient, config.DynamoDBTableName), nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink %q", config.SinkType)
+	}
+}
+
+// NewLogFromConfig creates the Log of the config, or returns nil, which discards all records, if no sink is
+// configured.
+func NewLogFromConfig(config Config, dynamoDBClient commondynamodb.Client, logger logging.Logger) (*Log, error) {
+	sink, err := NewSink(config, dynamoDBClient, logger)
+	if err != nil {
+		return nil, err
+	}
+	if sink == nil {
+		return nil, nil
+	}
+	return NewLog(sink, config.BufferSize, logger), nil
+}
@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type dynamoSink struct {
+	dynamoDBClient commondynamodb.Client
+	tableName      string
+}
+
+var _ Sink = (*dynamoSink)(nil)
+
+// NewDynamoSink creates a Sink that keeps records in a DynamoDB table with the schema of GenerateTableSchema.
+func NewDynamoSink(dynamoDBClient commondynamodb.Client, tableName string) Sink {
+	return &dynamoSink{
+		dynamoDBClient: dynamoDBClient,
+		tableName:      tableName,
+	}
+}
+
+// sortKey orders the records of an account by timestamp. The request ID keeps the keys of records with the same
+// timestamp distinct.
+func sortKey(timestamp time.Time, requestID string) string {
+	return fmt.Sprintf("%020d#%s", timestamp.UnixNano(), requestID)
+}
+
+func (s *dynamoSink) Append(ctx context.Context, record *Record) error {
+	item, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit record: %w", err)
+	}
+	item["SK"] = &types.AttributeValueMemberS{Value: sortKey(record.Timestamp, record.RequestID)}
+	if err = s.dynamoDBClient.PutItem(ctx, s.tableName, item); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+func (s *dynamoSink) QueryByAccount(
+	ctx context.Context,
+	accountID string,
+	start time.Time,
+	end time.Time,
+	limit int) ([]*Record, error) {
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(s.tableName),
+		KeyConditionExpression: aws.String("AccountID = :accountID AND SK BETWEEN :start AND :end"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":accountID": &types.AttributeValueMemberS{Value: accountID},
+			":start":     &types.AttributeValueMemberS{Value: sortKey(start, "")},
+			// "~" sorts after the hex request IDs, so that records at exactly end are included
+			":end": &types.AttributeValueMemberS{Value: sortKey(end, "~")},
+		},
+		// newest first
+		ScanIndexForward: aws.Bool(false),
+	}
+	if limit > 0 {
+		input.Limit = aws.Int32(int32(limit))
+	}
+	items, err := s.dynamoDBClient.QueryWithInput(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit records: %w", err)
+	}
+
+	records := make([]*Record, 0, len(items))
+	for _, item := range items {
+		record := &Record{}
+		if err := attributevalue.UnmarshalMap(item, record); err != nil {
+			return nil, fmt.Errorf("failed to parse audit record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// GenerateTableSchema returns the schema of the DynamoDB table of audit records, which is partitioned by account
+// and sorted by timestamp.
+func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("AccountID"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("SK"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("AccountID"),
+				KeyType:       types.KeyTypeHash,
+			},
+			{
+				AttributeName: aws.String("SK"),
+				KeyType:       types.KeyTypeRange,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
@@ -0,0 +1,200 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+)
+
+// selectRecords returns the records of an account with a timestamp in [start, end], newest first, truncated to
+// limit records.
+func selectRecords(records []*Record, accountID string, start time.Time, end time.Time, limit int) []*Record {
+	selected := make([]*Record, 0)
+	for _, record := range records {
+		if record.AccountID != accountID || record.Timestamp.Before(start) || record.Timestamp.After(end) {
+			continue
+		}
+		selected = append(selected, record)
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].Timestamp.After(selected[j].Timestamp)
+	})
+	if limit > 0 && len(selected) > limit {
+		selected = selected[:limit]
+	}
+	return selected
+}
+
+type fileSink struct {
+	path string
+	// mu serializes appends, so that concurrent records are never interleaved within a line.
+	mu sync.Mutex
+}
+
+var _ Sink = (*fileSink)(nil)
+
+// NewFileSink creates a Sink that appends records as JSON lines to the file at path. Queries scan the whole file,
+// so it is meant for low volume deployments and local testing.
+func NewFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close audit log file: %w", err)
+	}
+	return &fileSink{path: path}, nil
+}
+
+func (s *fileSink) Append(_ context.Context, record *Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	if _, err = file.Write(line); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return file.Close()
+}
+
+func (s *fileSink) QueryByAccount(
+	_ context.Context,
+	accountID string,
+	start time.Time,
+	end time.Time,
+	limit int) ([]*Record, error) {
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	records := make([]*Record, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		record := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			// a partially written last line is skipped
+			continue
+		}
+		if record.AccountID == accountID {
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log file: %w", err)
+	}
+	return selectRecords(records, accountID, start, end, limit), nil
+}
+
+// auditKeyPrefix prefixes the keys of audit records in a kvstore.
+const auditKeyPrefix = "audit/"
+
+// kvTimestampDigits is the number of digits of the zero padded timestamps in the keys of audit records.
+const kvTimestampDigits = 20
+
+type kvSink struct {
+	store kvstore.Store[[]byte]
+}
+
+var _ Sink = (*kvSink)(nil)
+
+// NewKVSink creates a Sink that keeps records in a kvstore, keyed by account and timestamp so that the records
+// of an account are read with a prefix scan.
+func NewKVSink(store kvstore.Store[[]byte]) Sink {
+	return &kvSink{store: store}
+}
+
+func accountKeyPrefix(accountID string) []byte {
+	return []byte(auditKeyPrefix + accountID + "/")
+}
+
+func (s *kvSink) Append(_ context.Context, record *Record) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit record: %w", err)
+	}
+	// the zero padded timestamp keeps the keys of an account in chronological order
+	key := fmt.Sprintf("%s%0*d/%s",
+		accountKeyPrefix(record.AccountID), kvTimestampDigits, record.Timestamp.UnixNano(), record.RequestID)
+	if err = s.store.Put([]byte(key), value); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+func (s *kvSink) QueryByAccount(
+	_ context.Context,
+	accountID string,
+	start time.Time,
+	end time.Time,
+	limit int) ([]*Record, error) {
+
+	prefix := accountKeyPrefix(accountID)
+	iterator, err := s.store.NewIterator(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over audit records: %w", err)
+	}
+	defer iterator.Release()
+
+	// The keys of an account are in chronological order, so the records are read newest first from the end of the
+	// account's keys. Records after end are skipped by their key alone, and the scan stops at the first record
+	// before start, or once limit records are read.
+	records := make([]*Record, 0)
+	for ok := iterator.Last(); ok && (limit <= 0 || len(records) < limit); ok = iterator.Prev() {
+		timestamp, err := keyTimestamp(iterator.Key(), prefix)
+		if err != nil {
+			return nil, err
+		}
+		if timestamp.After(end) {
+			continue
+		}
+		if timestamp.Before(start) {
+			break
+		}
+
+		record := &Record{}
+		if err := json.Unmarshal(iterator.Value(), record); err != nil {
+			return nil, fmt.Errorf("failed to parse audit record %s: %w", string(iterator.Key()), err)
+		}
+		records = append(records, record)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over audit records: %w", err)
+	}
+	return records, nil
+}
+
+// keyTimestamp returns the timestamp of the record stored under a key of an account with the given key prefix.
+func keyTimestamp(key []byte, prefix []byte) (time.Time, error) {
+	if len(key) < len(prefix)+kvTimestampDigits {
+		return time.Time{}, fmt.Errorf("malformed audit record key %s", string(key))
+	}
+	nanos, err := strconv.ParseInt(string(key[len(prefix):len(prefix)+kvTimestampDigits]), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("malformed audit record key %s: %w", string(key), err)
+	}
+	return time.Unix(0, nanos), nil
+}
@@ -26,6 +26,7 @@ import (
 
 const (
 	StatusIndexName            = "StatusIndex"
+	ExpiryIndexName            = "ExpiryIndex"
 	OperatorDispersalIndexName = "OperatorDispersalIndex"
 	OperatorResponseIndexName  = "OperatorResponseIndex"
 
@@ -56,6 +57,12 @@ type StatusIndexCursor struct {
 	UpdatedAt uint64
 }
 
+// ExpiryIndexCursor is the position of a query on the expiry index.
+type ExpiryIndexCursor struct {
+	BlobKey corev2.BlobKey
+	Expiry  uint64
+}
+
 // BlobMetadataStore is a blob metadata storage backed by DynamoDB
 type BlobMetadataStore struct {
 	dynamoDBClient commondynamodb.Client
@@ -72,6 +79,11 @@ func NewBlobMetadataStore(dynamoDBClient commondynamodb.Client, logger logging.L
 	}
 }
 
+// CheckHealth checks that the metadata table is reachable.
+func (s *BlobMetadataStore) CheckHealth(ctx context.Context) error {
+	return s.dynamoDBClient.TableExists(ctx, s.tableName)
+}
+
 func (s *BlobMetadataStore) PutBlobMetadata(ctx context.Context, blobMetadata *v2.BlobMetadata) error {
 	item, err := MarshalBlobMetadata(blobMetadata)
 	if err != nil {
@@ -116,10 +128,9 @@ func (s *BlobMetadataStore) UpdateBlobStatus(ctx context.Context, blobKey corev2
 	if errors.Is(err, commondynamodb.ErrConditionFailed) {
 		blob, err := s.GetBlobMetadata(ctx, blobKey)
 		if err != nil {
+			// the blob may have been collected after it expired
 			s.logger.Errorf("failed to get blob metadata for key %s: %v", blobKey.Hex(), err)
-		}
-
-		if blob.BlobStatus == status {
+		} else if blob.BlobStatus == status {
 			return fmt.Errorf("%w: blob already in status %s", common.ErrAlreadyExists, status.String())
 		}
 
@@ -298,6 +309,73 @@ func (s *BlobMetadataStore) GetBlobMetadataByStatusPaginated(
 	return metadata, &newCursor, nil
 }
 
+// GetExpiredBlobMetadataByStatus returns up to limit metadata with the given status that expired at or before expiry,
+// starting after the given cursor. Results are ordered by expiry in ascending order. The returned cursor is nil once
+// there are no more results.
+func (s *BlobMetadataStore) GetExpiredBlobMetadataByStatus(
+	ctx context.Context,
+	status v2.BlobStatus,
+	expiry uint64,
+	exclusiveStartKey *ExpiryIndexCursor,
+	limit int32,
+) ([]*v2.BlobMetadata, *ExpiryIndexCursor, error) {
+	var cursor map[string]types.AttributeValue
+	if exclusiveStartKey != nil {
+		cursor = map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{
+				Value: blobKeyPrefix + exclusiveStartKey.BlobKey.Hex(),
+			},
+			"SK": &types.AttributeValueMemberS{
+				Value: blobMetadataSK,
+			},
+			"Expiry": &types.AttributeValueMemberN{
+				Value: strconv.FormatUint(exclusiveStartKey.Expiry, 10),
+			},
+			"BlobStatus": &types.AttributeValueMemberN{
+				Value: strconv.Itoa(int(status)),
+			},
+		}
+	}
+	res, err := s.dynamoDBClient.QueryIndexWithPagination(ctx, s.tableName, ExpiryIndexName, "BlobStatus = :status AND Expiry <= :expiry", commondynamodb.ExpressionValues{
+		":status": &types.AttributeValueMemberN{
+			Value: strconv.Itoa(int(status)),
+		},
+		":expiry": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(expiry, 10),
+		},
+	}, limit, cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := make([]*v2.BlobMetadata, 0, len(res.Items))
+	for _, item := range res.Items {
+		m, err := UnmarshalBlobMetadata(item)
+		// Skip invalid/corrupt items
+		if err != nil {
+			s.logger.Errorf("failed to unmarshal blob metadata: %v", err)
+			continue
+		}
+		metadata = append(metadata, m)
+	}
+
+	if res.LastEvaluatedKey == nil {
+		return metadata, nil, nil
+	}
+
+	newCursor := ExpiryIndexCursor{}
+	err = attributevalue.UnmarshalMap(res.LastEvaluatedKey, &newCursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	newCursor.BlobKey, err = UnmarshalBlobKey(res.LastEvaluatedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return metadata, &newCursor, nil
+}
+
 // GetBlobMetadataCountByStatus returns the count of all the metadata with the given status
 // Because this function scans the entire index, it should only be used for status with a limited number of items.
 func (s *BlobMetadataStore) GetBlobMetadataCountByStatus(ctx context.Context, status v2.BlobStatus) (int32, error) {
@@ -674,6 +752,42 @@ func (s *BlobMetadataStore) GetBlobVerificationInfos(ctx context.Context, blobKe
 	return responses, nil
 }
 
+// DeleteBlobVerificationInfos deletes the verification infos of a blob in every batch it was dispersed in.
+// It is not an error if the blob has no verification infos.
+func (s *BlobMetadataStore) DeleteBlobVerificationInfos(ctx context.Context, blobKey corev2.BlobKey) error {
+	items, err := s.dynamoDBClient.Query(ctx, s.tableName, "PK = :pk AND begins_with(SK, :prefix)", commondynamodb.ExpressionValues{
+		":pk": &types.AttributeValueMemberS{
+			Value: blobKeyPrefix + blobKey.Hex(),
+		},
+		":prefix": &types.AttributeValueMemberS{
+			Value: batchHeaderKeyPrefix,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	keys := make([]commondynamodb.Key, len(items))
+	for i, item := range items {
+		keys[i] = commondynamodb.Key{
+			"PK": item["PK"],
+			"SK": item["SK"],
+		}
+	}
+	failedKeys, err := s.dynamoDBClient.DeleteItems(ctx, s.tableName, keys)
+	if err != nil {
+		return err
+	}
+	if len(failedKeys) > 0 {
+		return fmt.Errorf("failed to delete %d verification infos for key %s", len(failedKeys), blobKey.Hex())
+	}
+
+	return nil
+}
+
 func (s *BlobMetadataStore) GetSignedBatch(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, *corev2.Attestation, error) {
 	items, err := s.dynamoDBClient.Query(ctx, s.tableName, "PK = :pk", commondynamodb.ExpressionValues{
 		":pk": &types.AttributeValueMemberS{
@@ -737,6 +851,10 @@ func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacit
 				AttributeName: aws.String("UpdatedAt"),
 				AttributeType: types.ScalarAttributeTypeN,
 			},
+			{
+				AttributeName: aws.String("Expiry"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
 			{
 				AttributeName: aws.String("OperatorID"),
 				AttributeType: types.ScalarAttributeTypeS,
@@ -782,6 +900,26 @@ func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacit
 					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
 				},
 			},
+			{
+				IndexName: aws.String(ExpiryIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("BlobStatus"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("Expiry"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
 			{
 				IndexName: aws.String(OperatorDispersalIndexName),
 				KeySchema: []types.KeySchemaElement{
@@ -167,6 +167,58 @@ func TestBlobMetadataStoreGetBlobMetadataByStatusPaginated(t *testing.T) {
 	deleteItems(t, dynamoKeys)
 }
 
+func TestBlobMetadataStoreGetExpiredBlobMetadataByStatus(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	numExpired := 5
+	pageSize := 2
+	dynamoKeys := make([]commondynamodb.Key, 0)
+	putBlob := func(status v2.BlobStatus, expiry time.Time) corev2.BlobKey {
+		blobKey, blobHeader := newBlob(t)
+		err := blobMetadataStore.PutBlobMetadata(ctx, &v2.BlobMetadata{
+			BlobHeader: blobHeader,
+			BlobStatus: status,
+			Expiry:     uint64(expiry.Unix()),
+			UpdatedAt:  uint64(now.UnixNano()),
+		})
+		require.NoError(t, err)
+		dynamoKeys = append(dynamoKeys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+		})
+		return blobKey
+	}
+
+	expired := make([]corev2.BlobKey, numExpired)
+	for i := 0; i < numExpired; i++ {
+		expired[i] = putBlob(v2.Failed, now.Add(-time.Duration(numExpired-i)*time.Minute))
+	}
+	putBlob(v2.Failed, now.Add(time.Hour))
+	putBlob(v2.Certified, now.Add(-time.Hour))
+
+	// expired blobs are returned in order of expiry, and the live blobs and the blobs in other statuses are not read
+	found := make([]corev2.BlobKey, 0)
+	var cursor *blobstore.ExpiryIndexCursor
+	for {
+		metadata, nextCursor, err := blobMetadataStore.GetExpiredBlobMetadataByStatus(
+			ctx, v2.Failed, uint64(now.Unix()), cursor, int32(pageSize))
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(metadata), pageSize)
+		for _, m := range metadata {
+			blobKey, err := m.BlobHeader.BlobKey()
+			require.NoError(t, err)
+			found = append(found, blobKey)
+		}
+		if nextCursor == nil {
+			break
+		}
+		cursor = nextCursor
+	}
+	require.Equal(t, expired, found)
+
+	deleteItems(t, dynamoKeys)
+}
+
 func TestBlobMetadataStoreCerts(t *testing.T) {
 	ctx := context.Background()
 	blobKey, blobHeader := newBlob(t)
@@ -418,6 +470,19 @@ func TestBlobMetadataStoreVerificationInfo(t *testing.T) {
 	err = mockedBlobMetadataStore.PutBlobVerificationInfos(ctx, []*corev2.BlobVerificationInfo{verificationInfo1, verificationInfo2})
 	assert.NoError(t, err)
 	mockDynamoClient.AssertNumberOfCalls(t, "PutItems", 3)
+
+	// delete the verification infos of a single blob
+	err = blobMetadataStore.DeleteBlobVerificationInfos(ctx, blobKey)
+	assert.NoError(t, err)
+	_, err = blobMetadataStore.GetBlobVerificationInfos(ctx, blobKey)
+	assert.ErrorIs(t, err, common.ErrMetadataNotFound)
+	fetchedInfos, err := blobMetadataStore.GetBlobVerificationInfos(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Len(t, fetchedInfos, 1)
+
+	// deleting again is a no-op
+	err = blobMetadataStore.DeleteBlobVerificationInfos(ctx, blobKey)
+	assert.NoError(t, err)
 }
 
 func TestBlobMetadataStoreBatchAttestation(t *testing.T) {
@@ -2,6 +2,9 @@ package blobstore
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
@@ -10,6 +13,20 @@ import (
 	"github.com/pkg/errors"
 )
 
+// BlobStore stores the bytes of blobs in S3. The bytes are content addressed: blobs with the same bytes and retention
+// tier, which have different blob keys if their payment metadata or salt differ, share a single content object. For
+// each blob, the store keeps
+//   - a blob reference (s3.ScopedBlobReferenceKey), holding the content hash of the blob, and
+//   - a content reference (s3.ScopedContentReferenceKey), marking the content as in use by the blob.
+//
+// The content references of a content act as its reference count. The content is deleted by DeleteBlob once the
+// last blob referencing it is deleted. Blobs stored before contents were deduplicated are stored whole under
+// s3.ScopedBlobKey, and are still read and deleted.
+//
+// All three objects are tagged with the retention tier. The store does not configure its bucket, so the objects are
+// only removed by DeleteBlob, unless the bucket is configured with the rules of s3.RetentionLifecycleRules. With those
+// rules, the references of a blob are created together, so they expire together, and storing a blob restarts the
+// lifetime of its content, so that the content never expires before a blob referencing it.
 type BlobStore struct {
 	bucketName string
 	s3Client   s3.Client
@@ -24,27 +41,180 @@ func NewBlobStore(s3BucketName string, s3Client s3.Client, logger logging.Logger
 	}
 }
 
-// StoreBlob adds a blob to the blob store
+// StoreBlob adds a blob with the standard retention tier to the blob store
 func (b *BlobStore) StoreBlob(ctx context.Context, key corev2.BlobKey, data []byte) error {
-	_, err := b.s3Client.HeadObject(ctx, b.bucketName, s3.ScopedBlobKey(key))
-	if err == nil {
-		b.logger.Warnf("blob already exists in bucket %s: %s", b.bucketName, key)
-		return common.ErrAlreadyExists
+	_, err := b.StoreBlobWithRetention(ctx, key, data, corev2.RetentionTierStandard)
+	return err
+}
+
+// StoreBlobWithRetention adds a blob to the blob store, and returns whether its bytes were already stored for
+// another blob of the same retention tier.
+func (b *BlobStore) StoreBlobWithRetention(
+	ctx context.Context,
+	key corev2.BlobKey,
+	data []byte,
+	tier corev2.RetentionTier) (bool, error) {
+
+	for _, objectKey := range []string{s3.ScopedBlobReferenceKey(key), s3.ScopedBlobKey(key)} {
+		_, err := b.s3Client.HeadObject(ctx, b.bucketName, objectKey)
+		if err == nil {
+			b.logger.Warnf("blob already exists in bucket %s: %s", b.bucketName, key)
+			return false, common.ErrAlreadyExists
+		}
+	}
+
+	// The content reference is stored before the content is looked up. If DeleteBlob deletes the content
+	// concurrently, it either sees this reference and restores the content, or deletes the content before it is
+	// looked up below, in which case it is uploaded again.
+	contentHash := ContentHash(data, tier)
+	tags := s3.RetentionTierTags(tier)
+	err := b.s3Client.UploadObject(ctx, b.bucketName, s3.ScopedContentReferenceKey(contentHash, key), []byte{}, tags...)
+	if err != nil {
+		b.logger.Errorf("failed to upload content reference in bucket %s: %v", b.bucketName, err)
+		return false, err
+	}
+
+	// copying the content onto itself restarts its lifetime without uploading it again
+	deduplicated := true
+	contentKey := s3.ScopedContentKey(contentHash)
+	err = b.s3Client.CopyObject(ctx, b.bucketName, contentKey, contentKey, tags...)
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		deduplicated = false
+		err = b.s3Client.UploadObject(ctx, b.bucketName, contentKey, data, tags...)
+	}
+	if err != nil {
+		b.logger.Errorf("failed to upload blob content in bucket %s: %v", b.bucketName, err)
+		return false, err
 	}
 
-	err = b.s3Client.UploadObject(ctx, b.bucketName, s3.ScopedBlobKey(key), data)
+	// the blob reference is stored last, so that a blob is never visible before its content
+	err = b.s3Client.UploadObject(ctx, b.bucketName, s3.ScopedBlobReferenceKey(key), []byte(contentHash), tags...)
 	if err != nil {
 		b.logger.Errorf("failed to upload blob in bucket %s: %v", b.bucketName, err)
-		return err
+		return false, err
 	}
-	return nil
+	return deduplicated, nil
 }
 
 // GetBlob retrieves a blob from the blob store
 func (b *BlobStore) GetBlob(ctx context.Context, key corev2.BlobKey) ([]byte, error) {
-	data, err := b.s3Client.DownloadObject(ctx, b.bucketName, s3.ScopedBlobKey(key))
+	contentHash, err := b.GetBlobContentHash(ctx, key)
+	if errors.Is(err, common.ErrBlobNotFound) {
+		// the blob may have been stored before contents were deduplicated
+		return b.download(ctx, s3.ScopedBlobKey(key))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b.download(ctx, s3.ScopedContentKey(contentHash))
+}
+
+// GetBlobContentHash returns the content hash of a blob, which is the same for every blob with the same bytes and
+// retention tier. Returns common.ErrBlobNotFound if the blob does not exist, or was stored before contents were
+// deduplicated.
+func (b *BlobStore) GetBlobContentHash(ctx context.Context, key corev2.BlobKey) (string, error) {
+	contentHash, err := b.s3Client.DownloadObject(ctx, b.bucketName, s3.ScopedBlobReferenceKey(key))
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return "", common.ErrBlobNotFound
+	}
+	if err != nil {
+		b.logger.Errorf("failed to download blob reference from bucket %s: %v", b.bucketName, err)
+		return "", err
+	}
+	return string(contentHash), nil
+}
+
+// BlobSize returns the number of bytes deleting a blob would reclaim: the size of its content if no other blob
+// references it, and zero otherwise.
+func (b *BlobStore) BlobSize(ctx context.Context, key corev2.BlobKey) (uint64, error) {
+	contentHash, err := b.GetBlobContentHash(ctx, key)
+	if errors.Is(err, common.ErrBlobNotFound) {
+		return b.objectSize(ctx, s3.ScopedBlobKey(key))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	references, err := b.s3Client.ListObjects(ctx, b.bucketName, s3.ScopedContentReferencePrefix(contentHash))
+	if err != nil {
+		return 0, err
+	}
+	for _, reference := range references {
+		if reference.Key != s3.ScopedContentReferenceKey(contentHash, key) {
+			return 0, nil
+		}
+	}
+	return b.objectSize(ctx, s3.ScopedContentKey(contentHash))
+}
+
+// DeleteBlob deletes a blob from the blob store, along with its content if no other blob references it, and returns
+// the number of bytes of content deleted. Deleting a blob that does not exist, or that has expired along with its
+// content reference if the bucket has lifecycle rules, succeeds. A failed deletion can be retried.
+func (b *BlobStore) DeleteBlob(ctx context.Context, key corev2.BlobKey) (uint64, error) {
+	contentHash, err := b.GetBlobContentHash(ctx, key)
+	if errors.Is(err, common.ErrBlobNotFound) {
+		size, err := b.objectSize(ctx, s3.ScopedBlobKey(key))
+		if err != nil {
+			return 0, err
+		}
+		return size, b.s3Client.DeleteObject(ctx, b.bucketName, s3.ScopedBlobKey(key))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// The blob reference is deleted last, so that a retry finds the content again.
+	if err := b.s3Client.DeleteObject(ctx, b.bucketName, s3.ScopedContentReferenceKey(contentHash, key)); err != nil {
+		return 0, err
+	}
+	size, err := b.deleteUnreferencedContent(ctx, contentHash)
+	if err != nil {
+		return 0, err
+	}
+	return size, b.s3Client.DeleteObject(ctx, b.bucketName, s3.ScopedBlobReferenceKey(key))
+}
+
+// deleteUnreferencedContent deletes a content if no blob references it, and returns the number of bytes deleted.
+// A blob storing the same content concurrently stores its reference before it looks up the content, so the
+// references are listed again once the content is deleted, and the content is restored if a reference appeared.
+func (b *BlobStore) deleteUnreferencedContent(ctx context.Context, contentHash string) (uint64, error) {
+	references, err := b.s3Client.ListObjects(ctx, b.bucketName, s3.ScopedContentReferencePrefix(contentHash))
+	if err != nil {
+		return 0, err
+	}
+	if len(references) > 0 {
+		return 0, nil
+	}
+
+	data, err := b.s3Client.DownloadObject(ctx, b.bucketName, s3.ScopedContentKey(contentHash))
 	if errors.Is(err, s3.ErrObjectNotFound) {
-		b.logger.Warnf("blob not found in bucket %s: %s", b.bucketName, key)
+		// deleted by a previous attempt
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := b.s3Client.DeleteObject(ctx, b.bucketName, s3.ScopedContentKey(contentHash)); err != nil {
+		return 0, err
+	}
+
+	references, err = b.s3Client.ListObjects(ctx, b.bucketName, s3.ScopedContentReferencePrefix(contentHash))
+	if err == nil && len(references) == 0 {
+		return uint64(len(data)), nil
+	}
+	b.logger.Info("restoring blob content referenced while it was deleted", "contentHash", contentHash)
+	tags := s3.RetentionTierTags(contentTier(contentHash))
+	if err := b.s3Client.UploadObject(ctx, b.bucketName, s3.ScopedContentKey(contentHash), data, tags...); err != nil {
+		b.logger.Errorf("failed to restore blob content in bucket %s: %v", b.bucketName, err)
+		return 0, err
+	}
+	return 0, nil
+}
+
+func (b *BlobStore) download(ctx context.Context, objectKey string) ([]byte, error) {
+	data, err := b.s3Client.DownloadObject(ctx, b.bucketName, objectKey)
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		b.logger.Warnf("blob not found in bucket %s: %s", b.bucketName, objectKey)
 		return nil, common.ErrBlobNotFound
 	}
 
@@ -54,3 +224,41 @@ func (b *BlobStore) GetBlob(ctx context.Context, key corev2.BlobKey) ([]byte, er
 	}
 	return data, nil
 }
+
+func (b *BlobStore) objectSize(ctx context.Context, objectKey string) (uint64, error) {
+	size, err := b.s3Client.HeadObject(ctx, b.bucketName, objectKey)
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return uint64(*size), nil
+}
+
+// ContentHash returns the hash that the bytes of a blob with the given retention tier are stored under. Contents are
+// only shared by blobs of the same tier, so that lifecycle rules expire a content with the blobs referencing it.
+func ContentHash(data []byte, tier corev2.RetentionTier) string {
+	hash := sha256.Sum256(data)
+	contentHash := hex.EncodeToString(hash[:])
+	if tier != corev2.RetentionTierStandard {
+		contentHash += contentTierSeparator + tier.String()
+	}
+	return contentHash
+}
+
+// contentTierSeparator separates the hash of the bytes of a content from its retention tier.
+const contentTierSeparator = "-"
+
+// contentTier returns the retention tier of the blobs referencing a content.
+func contentTier(contentHash string) corev2.RetentionTier {
+	i := strings.LastIndex(contentHash, contentTierSeparator)
+	if i < 0 {
+		return corev2.RetentionTierStandard
+	}
+	tier, err := corev2.RetentionTierFromString(contentHash[i+1:])
+	if err != nil {
+		return corev2.RetentionTierStandard
+	}
+	return tier
+}
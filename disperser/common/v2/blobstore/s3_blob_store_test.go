@@ -4,10 +4,15 @@ import (
 	"context"
 	"testing"
 
+	awsmock "github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStoreGetBlob(t *testing.T) {
@@ -25,3 +30,151 @@ func TestGetBlobNotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, data)
 }
+
+func TestStoreBlobWithRetention(t *testing.T) {
+	ctx := context.Background()
+	s3Client := awsmock.NewS3Client()
+	store := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
+
+	// every object of a blob is tagged with its tier, so that the lifecycle rules of the bucket expire them together
+	data := []byte("retention")
+	for _, tier := range []corev2.RetentionTier{corev2.RetentionTierStandard, corev2.RetentionTierArchival} {
+		key := corev2.BlobKey(tu.RandomBytes(32))
+		deduplicated, err := store.StoreBlobWithRetention(ctx, key, data, tier)
+		require.NoError(t, err)
+		// contents are not shared across tiers
+		assert.False(t, deduplicated)
+
+		contentHash := blobstore.ContentHash(data, tier)
+		tags := s3.RetentionTierTags(tier)
+		assert.Equal(t, tags, s3Client.GetTags(s3.ScopedBlobReferenceKey(key)))
+		assert.Equal(t, tags, s3Client.GetTags(s3.ScopedContentReferenceKey(contentHash, key)))
+		assert.Equal(t, tags, s3Client.GetTags(s3.ScopedContentKey(contentHash)))
+	}
+	assert.NotEqual(t,
+		blobstore.ContentHash(data, corev2.RetentionTierStandard),
+		blobstore.ContentHash(data, corev2.RetentionTierArchival))
+}
+
+func TestDeleteBlobExpiredByLifecycleRules(t *testing.T) {
+	ctx := context.Background()
+	s3Client := awsmock.NewS3Client()
+	store := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
+
+	data := []byte("expired")
+	contentHash := blobstore.ContentHash(data, corev2.RetentionTierStandard)
+	key1 := corev2.BlobKey(tu.RandomBytes(32))
+	key2 := corev2.BlobKey(tu.RandomBytes(32))
+	_, err := store.StoreBlobWithRetention(ctx, key1, data, corev2.RetentionTierStandard)
+	require.NoError(t, err)
+
+	// storing the second blob restarts the lifetime of the content, rather than uploading it again
+	uploads := s3Client.Called["UploadObject"]
+	copies := s3Client.Called["CopyObject"]
+	deduplicated, err := store.StoreBlobWithRetention(ctx, key2, data, corev2.RetentionTierStandard)
+	require.NoError(t, err)
+	assert.True(t, deduplicated)
+	assert.Equal(t, uploads+2, s3Client.Called["UploadObject"])
+	assert.Equal(t, copies+1, s3Client.Called["CopyObject"])
+
+	// the references of the first blob were created together, so the lifecycle rules expire them together
+	require.NoError(t, s3Client.DeleteObject(ctx, s3BucketName, s3.ScopedBlobReferenceKey(key1)))
+	require.NoError(t, s3Client.DeleteObject(ctx, s3BucketName, s3.ScopedContentReferenceKey(contentHash, key1)))
+	size, err := store.DeleteBlob(ctx, key1)
+	require.NoError(t, err)
+	assert.Zero(t, size)
+
+	// the content is then deleted with the last blob referencing it
+	size, err = store.DeleteBlob(ctx, key2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(data)), size)
+	objects, err := s3Client.ListObjects(ctx, s3BucketName, "")
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func TestStoreBlobDeduplication(t *testing.T) {
+	ctx := context.Background()
+	s3Client := awsmock.NewS3Client()
+	store := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
+
+	data := []byte("deduplicated")
+	contentHash := blobstore.ContentHash(data, corev2.RetentionTierExtended)
+	key1 := corev2.BlobKey(tu.RandomBytes(32))
+	key2 := corev2.BlobKey(tu.RandomBytes(32))
+
+	deduplicated, err := store.StoreBlobWithRetention(ctx, key1, data, corev2.RetentionTierExtended)
+	require.NoError(t, err)
+	assert.False(t, deduplicated)
+	deduplicated, err = store.StoreBlobWithRetention(ctx, key2, data, corev2.RetentionTierExtended)
+	require.NoError(t, err)
+	assert.True(t, deduplicated)
+	_, err = store.StoreBlobWithRetention(ctx, key2, data, corev2.RetentionTierExtended)
+	assert.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	// both blobs read the single stored content
+	for _, key := range []corev2.BlobKey{key1, key2} {
+		stored, err := store.GetBlob(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, data, stored)
+		hash, err := store.GetBlobContentHash(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, contentHash, hash)
+	}
+	contents, err := s3Client.ListObjects(ctx, s3BucketName, s3.ScopedContentKey(contentHash))
+	require.NoError(t, err)
+	assert.Len(t, contents, 1)
+
+	// the content is kept while another blob references it
+	size, err := store.BlobSize(ctx, key1)
+	require.NoError(t, err)
+	assert.Zero(t, size)
+	size, err = store.DeleteBlob(ctx, key1)
+	require.NoError(t, err)
+	assert.Zero(t, size)
+	_, err = store.GetBlob(ctx, key1)
+	assert.ErrorIs(t, err, common.ErrBlobNotFound)
+	stored, err := store.GetBlob(ctx, key2)
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+
+	// and is deleted with the last blob referencing it
+	size, err = store.BlobSize(ctx, key2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(data)), size)
+	size, err = store.DeleteBlob(ctx, key2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(data)), size)
+	_, err = store.GetBlob(ctx, key2)
+	assert.ErrorIs(t, err, common.ErrBlobNotFound)
+	objects, err := s3Client.ListObjects(ctx, s3BucketName, "")
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+
+	// deleting is idempotent
+	size, err = store.DeleteBlob(ctx, key2)
+	require.NoError(t, err)
+	assert.Zero(t, size)
+}
+
+func TestGetBlobStoredWithoutDeduplication(t *testing.T) {
+	ctx := context.Background()
+	s3Client := awsmock.NewS3Client()
+	store := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
+
+	// blobs stored before contents were deduplicated are stored whole under their blob key
+	key := corev2.BlobKey(tu.RandomBytes(32))
+	require.NoError(t, s3Client.UploadObject(ctx, s3BucketName, s3.ScopedBlobKey(key), []byte("legacy")))
+
+	stored, err := store.GetBlob(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("legacy"), stored)
+	err = store.StoreBlob(ctx, key, []byte("legacy"))
+	assert.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	size, err := store.DeleteBlob(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len("legacy")), size)
+	_, err = store.GetBlob(ctx, key)
+	assert.ErrorIs(t, err, common.ErrBlobNotFound)
+}
@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectableStatuses are the statuses of the blobs the lifecycle manager collects once they expire. Blobs that are
+// still Queued or Encoded at their expiry are stuck: their retention has run out before they were certified, so they
+// are collected along with the blobs in a terminal status.
+var collectableStatuses = []v2.BlobStatus{
+	v2.Certified,
+	v2.Failed,
+	v2.InsufficientSignatures,
+	v2.Queued,
+	v2.Encoded,
+}
+
+type BlobLifecycleManagerConfig struct {
+	// CollectionInterval is the interval at which expired blobs are collected
+	CollectionInterval time.Duration
+	// MaxNumBlobsPerIteration is the maximum number of blob metadata read from the metadata store at once
+	MaxNumBlobsPerIteration int32
+	// BlobBucketName is the name of the S3 bucket holding the blobs
+	BlobBucketName string
+	// ChunkBucketName is the name of the S3 bucket holding the chunks and proofs of the blobs
+	ChunkBucketName string
+	// DryRun only logs and reports the expired blobs and the bytes they hold, without deleting anything
+	DryRun bool
+}
+
+// CollectionResult summarizes a pass of the blob lifecycle manager over the expired blobs.
+type CollectionResult struct {
+	// NumBlobs is the number of expired blobs collected
+	NumBlobs int
+	// NumFailed is the number of expired blobs that could not be collected. They are retried on the next pass.
+	NumFailed int
+	// ReclaimedBytes is the number of bytes of blobs, chunks and proofs deleted from S3. The bytes of a blob are
+	// only counted when the last blob with the same content is collected.
+	ReclaimedBytes uint64
+}
+
+// BlobLifecycleManager periodically deletes the blobs whose expiry has passed, along with their chunks, proofs,
+// encoding records and metadata. Objects are deleted in an order that keeps every step retryable: the S3 objects go
+// first, starting with the encoding record that lets other blobs copy the chunks and the proofs that mark a blob as
+// encoded, and the blob metadata the expired blobs are found by goes last. A failure
+// at any step leaves the blob to be collected again on the next pass. The bytes of a blob are shared with the other
+// blobs with the same content, and are only deleted by the blob store once no blob references them.
+type BlobLifecycleManager struct {
+	*BlobLifecycleManagerConfig
+
+	blobMetadataStore *blobstore.BlobMetadataStore
+	blobStore         *blobstore.BlobStore
+	chunkWriter       chunkstore.ChunkWriter
+	s3Client          s3.Client
+	logger            logging.Logger
+
+	metrics *blobLifecycleManagerMetrics
+}
+
+func NewBlobLifecycleManager(
+	config *BlobLifecycleManagerConfig,
+	blobMetadataStore *blobstore.BlobMetadataStore,
+	s3Client s3.Client,
+	logger logging.Logger,
+	registry *prometheus.Registry,
+) (*BlobLifecycleManager, error) {
+	if config.CollectionInterval <= 0 || config.MaxNumBlobsPerIteration < 1 {
+		return nil, fmt.Errorf("invalid blob lifecycle manager config")
+	}
+	if config.BlobBucketName == "" || config.ChunkBucketName == "" {
+		return nil, fmt.Errorf("blob and chunk bucket names must be provided")
+	}
+	return &BlobLifecycleManager{
+		BlobLifecycleManagerConfig: config,
+		blobMetadataStore:          blobMetadataStore,
+		blobStore:                  blobstore.NewBlobStore(config.BlobBucketName, s3Client, logger),
+		// the chunk writer only deletes encoding records, so its fragment size is never used
+		chunkWriter: chunkstore.NewChunkWriter(logger, s3Client, config.ChunkBucketName, 0),
+		s3Client:    s3Client,
+		logger:      logger.With("component", "BlobLifecycleManager"),
+		metrics:     newBlobLifecycleManagerMetrics(registry),
+	}, nil
+}
+
+func (m *BlobLifecycleManager) Start(ctx context.Context) error {
+	if m.DryRun {
+		m.logger.Info("blob lifecycle manager running in dry run, expired blobs are not deleted")
+	}
+
+	go func() {
+		ticker := time.NewTicker(m.CollectionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := m.CollectExpiredBlobs(ctx, time.Now())
+				if err != nil {
+					m.logger.Error("failed to collect expired blobs", "err", err)
+				}
+				m.logger.Info("collected expired blobs",
+					"numBlobs", result.NumBlobs,
+					"numFailed", result.NumFailed,
+					"reclaimedBytes", result.ReclaimedBytes,
+					"dryRun", m.DryRun)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// CollectExpiredBlobs makes a single pass over the blobs that expired at or before now, and collects them. The blobs
+// are read from the expiry index, so a pass only reads the expired blobs, and the blobs that failed to be collected
+// on earlier passes. The result covers the blobs collected before an error, if any.
+func (m *BlobLifecycleManager) CollectExpiredBlobs(ctx context.Context, now time.Time) (*CollectionResult, error) {
+	start := time.Now()
+	defer func() {
+		m.metrics.reportCollectionLatency(time.Since(start))
+	}()
+
+	result := &CollectionResult{}
+	for _, status := range collectableStatuses {
+		var cursor *blobstore.ExpiryIndexCursor
+		for {
+			var (
+				blobMetadatas []*v2.BlobMetadata
+				err           error
+			)
+			blobMetadatas, cursor, err = m.blobMetadataStore.GetExpiredBlobMetadataByStatus(
+				ctx, status, uint64(now.Unix()), cursor, m.MaxNumBlobsPerIteration)
+			if err != nil {
+				return result, fmt.Errorf("failed to get expired blob metadata with status %s: %w", status, err)
+			}
+
+			for _, metadata := range blobMetadatas {
+				reclaimedBytes, err := m.collectBlob(ctx, metadata)
+				if err != nil {
+					if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+						return result, err
+					}
+					m.logger.Warn("failed to collect expired blob", "err", err, "expiry", metadata.Expiry)
+					m.metrics.reportFailedCollection()
+					result.NumFailed++
+					continue
+				}
+				m.metrics.reportCollectedBlob(status, reclaimedBytes, m.DryRun)
+				result.NumBlobs++
+				result.ReclaimedBytes += reclaimedBytes
+			}
+
+			if cursor == nil {
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// collectBlob deletes the S3 objects and metadata of an expired blob, and returns the number of bytes deleted
+// from S3. In dry run, nothing is deleted.
+func (m *BlobLifecycleManager) collectBlob(ctx context.Context, metadata *v2.BlobMetadata) (uint64, error) {
+	blobKey, err := metadata.BlobHeader.BlobKey()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get blob key: %w", err)
+	}
+
+	// Once the encoding record is deleted, encoders no longer copy the chunks of the blob to blobs with the same
+	// encoding. A copy that started before fails on the deleted proofs, and the encoder encodes the blob instead.
+	if !m.DryRun {
+		if err := m.chunkWriter.DeleteEncoding(ctx, blobKey); err != nil {
+			return 0, fmt.Errorf("failed to delete encoding of blob %s: %w", blobKey.Hex(), err)
+		}
+	}
+
+	// The encoder stores proofs after coefficients, and both it and the relays consider a blob encoded once its
+	// proofs exist. Deleting the proofs first never leaves coefficients that look like a complete encoding.
+	var reclaimedBytes uint64
+	for _, prefix := range []string{s3.ScopedProofKey(blobKey), s3.ScopedChunkKey(blobKey)} {
+		size, err := m.deleteObjects(ctx, m.ChunkBucketName, prefix)
+		reclaimedBytes += size
+		if err != nil {
+			return reclaimedBytes, fmt.Errorf("failed to delete objects of blob %s: %w", blobKey.Hex(), err)
+		}
+	}
+
+	var size uint64
+	if m.DryRun {
+		size, err = m.blobStore.BlobSize(ctx, blobKey)
+	} else {
+		size, err = m.blobStore.DeleteBlob(ctx, blobKey)
+	}
+	reclaimedBytes += size
+	if err != nil {
+		return reclaimedBytes, fmt.Errorf("failed to delete blob %s: %w", blobKey.Hex(), err)
+	}
+
+	m.logger.Debug("collecting expired blob", "blobKey", blobKey.Hex(), "status", metadata.BlobStatus.String(),
+		"expiry", metadata.Expiry, "reclaimedBytes", reclaimedBytes, "dryRun", m.DryRun)
+	if m.DryRun {
+		return reclaimedBytes, nil
+	}
+
+	if err := m.blobMetadataStore.DeleteBlobVerificationInfos(ctx, blobKey); err != nil {
+		return reclaimedBytes, fmt.Errorf("failed to delete verification infos of blob %s: %w", blobKey.Hex(), err)
+	}
+	if err := m.blobMetadataStore.DeleteBlobCertificate(ctx, blobKey); err != nil {
+		return reclaimedBytes, fmt.Errorf("failed to delete certificate of blob %s: %w", blobKey.Hex(), err)
+	}
+	if err := m.blobMetadataStore.DeleteBlobMetadata(ctx, blobKey); err != nil {
+		return reclaimedBytes, fmt.Errorf("failed to delete metadata of blob %s: %w", blobKey.Hex(), err)
+	}
+
+	return reclaimedBytes, nil
+}
+
+// deleteObjects deletes the objects with the given prefix, including every fragment of a fragmented object, and
+// returns their total size. In dry run, the objects are only listed.
+func (m *BlobLifecycleManager) deleteObjects(ctx context.Context, bucket string, prefix string) (uint64, error) {
+	objects, err := m.s3Client.ListObjects(ctx, bucket, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list objects with prefix %s: %w", prefix, err)
+	}
+
+	var size uint64
+	for _, object := range objects {
+		if !m.DryRun {
+			if err := m.s3Client.DeleteObject(ctx, bucket, object.Key); err != nil {
+				return size, fmt.Errorf("failed to delete object %s: %w", object.Key, err)
+			}
+		}
+		size += uint64(object.Size)
+	}
+	return size, nil
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const blobLifecycleManagerNamespace = "eigenda_blob_lifecycle_manager"
+
+// blobLifecycleManagerMetrics is a struct that holds the metrics for the blob lifecycle manager.
+type blobLifecycleManagerMetrics struct {
+	collectionLatency *prometheus.SummaryVec
+	collectedBlobs    *prometheus.CounterVec
+	reclaimedBytes    *prometheus.CounterVec
+	failedCollections *prometheus.CounterVec
+}
+
+// newBlobLifecycleManagerMetrics sets up metrics for the blob lifecycle manager.
+func newBlobLifecycleManagerMetrics(registry *prometheus.Registry) *blobLifecycleManagerMetrics {
+	collectionLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  blobLifecycleManagerNamespace,
+			Name:       "collection_latency_ms",
+			Help:       "The time required to collect the expired blobs in a single pass.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{},
+	)
+
+	collectedBlobs := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: blobLifecycleManagerNamespace,
+			Name:      "collected_blobs_total",
+			Help:      "The number of expired blobs collected, by the status they expired in. In dry run, the blobs are only counted.",
+		},
+		[]string{"status", "dry_run"},
+	)
+
+	reclaimedBytes := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: blobLifecycleManagerNamespace,
+			Name:      "reclaimed_bytes_total",
+			Help:      "The number of bytes of blobs, chunks and proofs deleted from S3. In dry run, the bytes are only counted.",
+		},
+		[]string{"dry_run"},
+	)
+
+	failedCollections := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: blobLifecycleManagerNamespace,
+			Name:      "failed_collections_total",
+			Help:      "The number of expired blobs that could not be collected.",
+		},
+		[]string{},
+	)
+
+	return &blobLifecycleManagerMetrics{
+		collectionLatency: collectionLatency,
+		collectedBlobs:    collectedBlobs,
+		reclaimedBytes:    reclaimedBytes,
+		failedCollections: failedCollections,
+	}
+}
+
+func (m *blobLifecycleManagerMetrics) reportCollectionLatency(duration time.Duration) {
+	m.collectionLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
+
+func (m *blobLifecycleManagerMetrics) reportCollectedBlob(status v2.BlobStatus, reclaimedBytes uint64, dryRun bool) {
+	m.collectedBlobs.WithLabelValues(status.String(), strconv.FormatBool(dryRun)).Inc()
+	m.reclaimedBytes.WithLabelValues(strconv.FormatBool(dryRun)).Add(float64(reclaimedBytes))
+}
+
+func (m *blobLifecycleManagerMetrics) reportFailedCollection() {
+	m.failedCollections.WithLabelValues().Inc()
+}
@@ -0,0 +1,154 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awsmock "github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobLifecycleManagerCollectExpiredBlobs(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	mockS3Client := awsmock.NewS3Client()
+	chunkWriter := chunkstore.NewChunkWriter(logger, mockS3Client, s3BucketName, 128)
+
+	// putBlob stores a blob with its chunks, proofs, encoding, certificate and verification info
+	putBlob := func(status commonv2.BlobStatus, expiry time.Time) corev2.BlobKey {
+		blobKey, blobHeader := newBlob(t, []core.QuorumID{0, 1})
+		require.NoError(t, blobMetadataStore.PutBlobMetadata(ctx, &commonv2.BlobMetadata{
+			BlobHeader:  blobHeader,
+			BlobStatus:  status,
+			Expiry:      uint64(expiry.Unix()),
+			BlobSize:    100,
+			RequestedAt: uint64(now.UnixNano()),
+			UpdatedAt:   uint64(now.UnixNano()),
+		}))
+		require.NoError(t, blobMetadataStore.PutBlobCertificate(ctx, &corev2.BlobCertificate{
+			BlobHeader: blobHeader,
+			RelayKeys:  []corev2.RelayKey{0},
+		}, &encoding.FragmentInfo{TotalChunkSizeBytes: 300, FragmentSizeBytes: 128}))
+		require.NoError(t, blobMetadataStore.PutBlobVerificationInfo(ctx, &corev2.BlobVerificationInfo{
+			BatchHeader:    &corev2.BatchHeader{BatchRoot: [32]byte{1}, ReferenceBlockNumber: 100},
+			BlobKey:        blobKey,
+			BlobIndex:      0,
+			InclusionProof: []byte("proof"),
+		}))
+
+		require.NoError(t, mockS3Client.UploadObject(ctx, s3BucketName, s3.ScopedBlobKey(blobKey), make([]byte, 100)))
+		require.NoError(t, mockS3Client.UploadObject(ctx, s3BucketName, s3.ScopedProofKey(blobKey), make([]byte, 50)))
+		require.NoError(t, mockS3Client.FragmentedUploadObject(ctx, s3BucketName, s3.ScopedChunkKey(blobKey), make([]byte, 300), 128))
+		require.NoError(t, chunkWriter.PutEncoding(ctx, blobKey.Hex(), blobKey, &encoding.FragmentInfo{TotalChunkSizeBytes: 300, FragmentSizeBytes: 128}))
+		return blobKey
+	}
+	objectsOf := func(blobKey corev2.BlobKey) int {
+		count := 0
+		prefixes := []string{
+			s3.ScopedBlobKey(blobKey),
+			s3.ScopedProofKey(blobKey),
+			s3.ScopedChunkKey(blobKey),
+			s3.ScopedEncodingKey(blobKey.Hex()),
+			s3.ScopedEncodingReferenceKey(blobKey),
+		}
+		for _, prefix := range prefixes {
+			objects, err := mockS3Client.ListObjects(ctx, s3BucketName, prefix)
+			require.NoError(t, err)
+			count += len(objects)
+		}
+		return count
+	}
+
+	expired := putBlob(commonv2.Certified, now.Add(-time.Hour))
+	expiredFailed := putBlob(commonv2.Failed, now.Add(-time.Minute))
+	live := putBlob(commonv2.Certified, now.Add(time.Hour))
+	// a blob that is still queued when it expires is stuck, and is collected as well
+	stuckQueued := putBlob(commonv2.Queued, now.Add(-time.Hour))
+	liveQueued := putBlob(commonv2.Queued, now.Add(time.Hour))
+
+	config := &controller.BlobLifecycleManagerConfig{
+		CollectionInterval:      time.Minute,
+		MaxNumBlobsPerIteration: 1,
+		BlobBucketName:          s3BucketName,
+		ChunkBucketName:         s3BucketName,
+		DryRun:                  true,
+	}
+	manager, err := controller.NewBlobLifecycleManager(config, blobMetadataStore, mockS3Client, logger, prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	// a dry run reports the bytes of the expired blobs without deleting them
+	result, err := manager.CollectExpiredBlobs(ctx, now)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.NumBlobs, 3)
+	assert.GreaterOrEqual(t, result.ReclaimedBytes, uint64(3*(100+50+300)))
+	for _, blobKey := range []corev2.BlobKey{expired, expiredFailed, stuckQueued} {
+		assert.Equal(t, 1+1+3+2, objectsOf(blobKey))
+		_, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+		assert.NoError(t, err)
+	}
+
+	config.DryRun = false
+	result, err = manager.CollectExpiredBlobs(ctx, now)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.NumBlobs, 3)
+	assert.Equal(t, 0, result.NumFailed)
+	assert.GreaterOrEqual(t, result.ReclaimedBytes, uint64(3*(100+50+300)))
+	for _, blobKey := range []corev2.BlobKey{expired, expiredFailed, stuckQueued} {
+		assert.Equal(t, 0, objectsOf(blobKey))
+		_, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+		assert.Error(t, err)
+		_, _, err = blobMetadataStore.GetBlobCertificate(ctx, blobKey)
+		assert.Error(t, err)
+		_, err = blobMetadataStore.GetBlobVerificationInfos(ctx, blobKey)
+		assert.Error(t, err)
+	}
+	for _, blobKey := range []corev2.BlobKey{live, liveQueued} {
+		assert.Equal(t, 1+1+3+2, objectsOf(blobKey))
+		_, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+		assert.NoError(t, err)
+	}
+
+	// a second pass has nothing left to collect from these blobs
+	result, err = manager.CollectExpiredBlobs(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.NumFailed)
+
+	// the bytes of a blob are kept while a live blob with the same content references them
+	blobStore := blobstore.NewBlobStore(s3BucketName, mockS3Client, logger)
+	content := []byte("shared content")
+	expiredShared := putBlob(commonv2.Certified, now.Add(-time.Hour))
+	liveShared := putBlob(commonv2.Certified, now.Add(time.Hour))
+	for _, blobKey := range []corev2.BlobKey{expiredShared, liveShared} {
+		require.NoError(t, mockS3Client.DeleteObject(ctx, s3BucketName, s3.ScopedBlobKey(blobKey)))
+		require.NoError(t, blobStore.StoreBlob(ctx, blobKey, content))
+	}
+	result, err = manager.CollectExpiredBlobs(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.NumFailed)
+	_, err = blobStore.GetBlob(ctx, expiredShared)
+	assert.ErrorIs(t, err, common.ErrBlobNotFound)
+	stored, err := blobStore.GetBlob(ctx, liveShared)
+	require.NoError(t, err)
+	assert.Equal(t, content, stored)
+	size, err := blobStore.DeleteBlob(ctx, liveShared)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(content)), size)
+
+	for _, blobKey := range []corev2.BlobKey{live, liveQueued, liveShared} {
+		require.NoError(t, blobMetadataStore.DeleteBlobVerificationInfos(ctx, blobKey))
+		require.NoError(t, blobMetadataStore.DeleteBlobCertificate(ctx, blobKey))
+		require.NoError(t, blobMetadataStore.DeleteBlobMetadata(ctx, blobKey))
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser"
 	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
@@ -37,6 +38,9 @@ type EncodingManagerConfig struct {
 	AvailableRelays []corev2.RelayKey
 	// EncoderAddress is the address of the encoder
 	EncoderAddress string
+	// FallbackEncoderAddresses are the addresses of the encoders that a blob is encoded on when the output of the
+	// encoder fails self verification. Each of them is tried at most once per blob, in order.
+	FallbackEncoderAddresses []string
 	// MaxNumBlobsPerIteration is the maximum number of blobs to encode per iteration
 	MaxNumBlobsPerIteration int32
 	// OnchainStateRefreshInterval is the interval at which the onchain state is refreshed
@@ -53,12 +57,17 @@ type EncodingManager struct {
 	blobMetadataStore *blobstore.BlobMetadataStore
 	pool              common.WorkerPool
 	encodingClient    disperser.EncoderClientV2
-	chainReader       core.Reader
-	logger            logging.Logger
+	// fallbackEncodingClients are the clients of the encoders at FallbackEncoderAddresses
+	fallbackEncodingClients []disperser.EncoderClientV2
+	chainReader             core.Reader
+	logger                  logging.Logger
 
 	// state
 	cursor                *blobstore.StatusIndexCursor
 	blobVersionParameters atomic.Pointer[corev2.BlobVersionParameterMap]
+	// onchainStateUpdates receives the blob version events that trigger a refresh before the next interval. It is
+	// nil if no watcher is configured.
+	onchainStateUpdates <-chan eth.OnchainStateUpdate
 
 	metrics *encodingManagerMetrics
 }
@@ -68,7 +77,9 @@ func NewEncodingManager(
 	blobMetadataStore *blobstore.BlobMetadataStore,
 	pool common.WorkerPool,
 	encodingClient disperser.EncoderClientV2,
+	fallbackEncodingClients []disperser.EncoderClientV2,
 	chainReader core.Reader,
+	onchainStateWatcher *eth.OnchainStateWatcher,
 	logger logging.Logger,
 	registry *prometheus.Registry,
 ) (*EncodingManager, error) {
@@ -81,14 +92,16 @@ func NewEncodingManager(
 		return nil, fmt.Errorf("NumRelayAssignment (%d) cannot be greater than NumRelays (%d)", config.NumRelayAssignment, len(config.AvailableRelays))
 	}
 	return &EncodingManager{
-		EncodingManagerConfig: config,
-		blobMetadataStore:     blobMetadataStore,
-		pool:                  pool,
-		encodingClient:        encodingClient,
-		chainReader:           chainReader,
-		logger:                logger.With("component", "EncodingManager"),
-		cursor:                nil,
-		metrics:               newEncodingManagerMetrics(registry),
+		EncodingManagerConfig:   config,
+		blobMetadataStore:       blobMetadataStore,
+		pool:                    pool,
+		encodingClient:          encodingClient,
+		fallbackEncodingClients: fallbackEncodingClients,
+		chainReader:             chainReader,
+		logger:                  logger.With("component", "EncodingManager"),
+		cursor:                  nil,
+		onchainStateUpdates:     onchainStateWatcher.Subscribe(eth.BlobVersionAdded),
+		metrics:                 newEncodingManagerMetrics(registry),
 	}, nil
 }
 
@@ -110,6 +123,11 @@ func (e *EncodingManager) Start(ctx context.Context) error {
 				if err := e.refreshBlobVersionParams(ctx); err != nil {
 					e.logger.Error("failed to refresh blob version params", "err", err)
 				}
+			case update := <-e.onchainStateUpdates:
+				e.logger.Info("refreshing blob version params on update", "blobVersion", update.BlobVersion, "block", update.BlockNumber)
+				if err := e.refreshBlobVersionParams(ctx); err != nil {
+					e.logger.Error("failed to refresh blob version params", "err", err)
+				}
 			case <-ctx.Done():
 				return
 			}
@@ -190,10 +208,27 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 			var finishedUpdateBlobStatusTime time.Time
 			var success bool
 
-			for i = 0; i < e.NumEncodingRetries+1; i++ {
+			encodingClient := e.encodingClient
+			fallbackEncodingClients := e.fallbackEncodingClients
+			attempts := e.NumEncodingRetries + 1
+			for i = 0; i < attempts; i++ {
 				encodingCtx, cancel := context.WithTimeout(ctx, e.EncodingRequestTimeout)
-				fragmentInfo, err := e.encodeBlob(encodingCtx, blobKey, blob, blobParams)
+				fragmentInfo, err := e.encodeBlob(encodingCtx, encodingClient, blobKey, blob, blobParams)
 				cancel()
+				if errors.Is(err, disperser.ErrEncodingSelfVerificationFailed) {
+					e.metrics.reportEncodingVerificationFailure()
+					// The encoder would likely produce the same output again, so the blob is only retried on a
+					// different encoder, without using up one of the encoding retries.
+					if len(fallbackEncodingClients) == 0 {
+						e.logger.Error("encoded chunks failed self verification and no fallback encoder is left", "blobKey", blobKey.Hex(), "err", err)
+						break
+					}
+					e.logger.Warn("encoded chunks failed self verification, retrying on a fallback encoder", "blobKey", blobKey.Hex(), "err", err)
+					encodingClient = fallbackEncodingClients[0]
+					fallbackEncodingClients = fallbackEncodingClients[1:]
+					attempts++
+					continue
+				}
 				if err != nil {
 					e.logger.Error("failed to encode blob", "blobKey", blobKey.Hex(), "err", err)
 					continue
@@ -268,12 +303,22 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 	return nil
 }
 
-func (e *EncodingManager) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, blob *v2.BlobMetadata, blobParams *core.BlobVersionParameters) (*encoding.FragmentInfo, error) {
+func (e *EncodingManager) encodeBlob(ctx context.Context, encodingClient disperser.EncoderClientV2, blobKey corev2.BlobKey, blob *v2.BlobMetadata, blobParams *core.BlobVersionParameters) (*encoding.FragmentInfo, error) {
 	encodingParams, err := blob.BlobHeader.GetEncodingParams(blobParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get encoding params: %w", err)
 	}
-	return e.encodingClient.EncodeBlob(ctx, blobKey, encodingParams)
+	return encodingClient.EncodeBlob(ctx, blobKey, encodingParams, &blob.BlobHeader.BlobCommitments, getEncodingPriority(blob), blob.BlobHeader.RetentionTier)
+}
+
+// getEncodingPriority returns the priority of the blob at the encoder. Blobs with no cumulative payment are dispersed
+// under a reservation, and are encoded before on-demand blobs.
+func getEncodingPriority(blob *v2.BlobMetadata) disperser.EncodingPriority {
+	payment := blob.BlobHeader.PaymentMetadata.CumulativePayment
+	if payment == nil || payment.Sign() == 0 {
+		return disperser.ReservationEncodingPriority
+	}
+	return disperser.OnDemandEncodingPriority
 }
 
 func (e *EncodingManager) refreshBlobVersionParams(ctx context.Context) error {
@@ -20,6 +20,7 @@ type encodingManagerMetrics struct {
 	batchDataSize           *prometheus.GaugeVec
 	batchRetryCount         *prometheus.GaugeVec
 	failedSubmissionCount   *prometheus.CounterVec
+	verificationFailures    *prometheus.CounterVec
 }
 
 // NewEncodingManagerMetrics sets up metrics for the encoding manager.
@@ -110,6 +111,15 @@ func newEncodingManagerMetrics(registry *prometheus.Registry) *encodingManagerMe
 		[]string{},
 	)
 
+	verificationFailures := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: encodingManagerNamespace,
+			Name:      "encoding_verification_failure_count",
+			Help:      "The number of encoding attempts whose output failed self verification at the encoder.",
+		},
+		[]string{},
+	)
+
 	return &encodingManagerMetrics{
 		batchSubmissionLatency:  batchSubmissionLatency,
 		blobHandleLatency:       blobHandleLatency,
@@ -120,6 +130,7 @@ func newEncodingManagerMetrics(registry *prometheus.Registry) *encodingManagerMe
 		batchDataSize:           batchDataSize,
 		batchRetryCount:         batchRetryCount,
 		failedSubmissionCount:   failSubmissionCount,
+		verificationFailures:    verificationFailures,
 	}
 }
 
@@ -158,3 +169,7 @@ func (m *encodingManagerMetrics) reportBatchRetryCount(count int) {
 func (m *encodingManagerMetrics) reportFailedSubmission() {
 	m.failedSubmissionCount.WithLabelValues().Inc()
 }
+
+func (m *encodingManagerMetrics) reportEncodingVerificationFailure() {
+	m.verificationFailures.WithLabelValues().Inc()
+}
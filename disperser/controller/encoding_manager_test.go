@@ -2,6 +2,7 @@ package controller_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser"
 	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
@@ -32,8 +34,10 @@ type testComponents struct {
 	EncodingManager *controller.EncodingManager
 	Pool            common.WorkerPool
 	EncodingClient  *dispmock.MockEncoderClientV2
-	ChainReader     *coremock.MockWriter
-	MockPool        *commonmock.MockWorkerpool
+	// FallbackEncodingClient is used when the output of EncodingClient fails self verification
+	FallbackEncodingClient *dispmock.MockEncoderClientV2
+	ChainReader            *coremock.MockWriter
+	MockPool               *commonmock.MockWorkerpool
 }
 
 func TestGetRelayKeys(t *testing.T) {
@@ -282,6 +286,63 @@ func TestEncodingManagerHandleBatchRetryFailure(t *testing.T) {
 	deleteBlobs(t, blobMetadataStore, []corev2.BlobKey{blobKey1}, nil)
 }
 
+func TestEncodingManagerHandleBatchSelfVerificationFailure(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newQueuedBlob := func() (corev2.BlobKey, *commonv2.BlobMetadata) {
+		blobKey, blobHeader := newBlob(t, []core.QuorumID{0, 1})
+		metadata := &commonv2.BlobMetadata{
+			BlobHeader: blobHeader,
+			BlobStatus: commonv2.Queued,
+			Expiry:     uint64(now.Add(time.Hour).Unix()),
+			NumRetries: 0,
+			UpdatedAt:  uint64(now.UnixNano()),
+		}
+		err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+		require.NoError(t, err)
+		return blobKey, metadata
+	}
+	verificationErr := fmt.Errorf("%w: commitment mismatch", disperser.ErrEncodingSelfVerificationFailed)
+
+	// the blob is encoded on the fallback encoder
+	blobKey1, _ := newQueuedBlob()
+	c := newTestComponents(t, false)
+	c.EncodingClient.On("EncodeBlob", mock.Anything, mock.Anything, mock.Anything).Return(nil, verificationErr)
+	c.FallbackEncodingClient.On("EncodeBlob", mock.Anything, mock.Anything, mock.Anything).Return(&encoding.FragmentInfo{
+		TotalChunkSizeBytes: 100,
+		FragmentSizeBytes:   1024 * 1024 * 4,
+	}, nil)
+
+	err := c.EncodingManager.HandleBatch(ctx)
+	require.NoError(t, err)
+	c.Pool.StopWait()
+
+	fetchedMetadata, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey1)
+	require.NoError(t, err)
+	require.Equal(t, commonv2.Encoded, fetchedMetadata.BlobStatus)
+	c.EncodingClient.AssertNumberOfCalls(t, "EncodeBlob", 1)
+	c.FallbackEncodingClient.AssertNumberOfCalls(t, "EncodeBlob", 1)
+	deleteBlobs(t, blobMetadataStore, []corev2.BlobKey{blobKey1}, nil)
+
+	// the blob fails once no fallback encoder is left, without retrying on the same encoder
+	blobKey2, metadata2 := newQueuedBlob()
+	c = newTestComponents(t, false)
+	c.EncodingClient.On("EncodeBlob", mock.Anything, mock.Anything, mock.Anything).Return(nil, verificationErr)
+	c.FallbackEncodingClient.On("EncodeBlob", mock.Anything, mock.Anything, mock.Anything).Return(nil, verificationErr)
+
+	err = c.EncodingManager.HandleBatch(ctx)
+	require.NoError(t, err)
+	c.Pool.StopWait()
+
+	fetchedMetadata, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey2)
+	require.NoError(t, err)
+	require.Equal(t, commonv2.Failed, fetchedMetadata.BlobStatus)
+	require.Greater(t, fetchedMetadata.UpdatedAt, metadata2.UpdatedAt)
+	c.EncodingClient.AssertNumberOfCalls(t, "EncodeBlob", 1)
+	c.FallbackEncodingClient.AssertNumberOfCalls(t, "EncodeBlob", 1)
+	deleteBlobs(t, blobMetadataStore, []corev2.BlobKey{blobKey2}, nil)
+}
+
 func newTestComponents(t *testing.T, mockPool bool) *testComponents {
 	logger := logging.NewNoopLogger()
 	// logger, err := common.NewLogger(common.DefaultLoggerConfig())
@@ -295,6 +356,7 @@ func newTestComponents(t *testing.T, mockPool bool) *testComponents {
 		pool = workerpool.New(5)
 	}
 	encodingClient := dispmock.NewMockEncoderClientV2()
+	fallbackEncodingClient := dispmock.NewMockEncoderClientV2()
 	chainReader := &coremock.MockWriter{}
 	chainReader.On("GetCurrentBlockNumber").Return(blockNumber, nil)
 	chainReader.On("GetAllVersionedBlobParams", mock.Anything).Return(map[v2.BlobVersion]*core.BlobVersionParameters{
@@ -315,7 +377,7 @@ func newTestComponents(t *testing.T, mockPool bool) *testComponents {
 		AvailableRelays:             []corev2.RelayKey{0, 1, 2, 3},
 		MaxNumBlobsPerIteration:     5,
 		OnchainStateRefreshInterval: onchainRefreshInterval,
-	}, blobMetadataStore, pool, encodingClient, chainReader, logger, prometheus.NewRegistry())
+	}, blobMetadataStore, pool, encodingClient, []disperser.EncoderClientV2{fallbackEncodingClient}, chainReader, nil, logger, prometheus.NewRegistry())
 	assert.NoError(t, err)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*onchainRefreshInterval)
@@ -323,10 +385,11 @@ func newTestComponents(t *testing.T, mockPool bool) *testComponents {
 	// Start the encoding manager to fetch the onchain state
 	_ = em.Start(ctx)
 	return &testComponents{
-		EncodingManager: em,
-		Pool:            pool,
-		EncodingClient:  encodingClient,
-		ChainReader:     chainReader,
-		MockPool:        mockP,
+		EncodingManager:        em,
+		Pool:                   pool,
+		EncodingClient:         encodingClient,
+		FallbackEncodingClient: fallbackEncodingClient,
+		ChainReader:            chainReader,
+		MockPool:               mockP,
 	}
 }
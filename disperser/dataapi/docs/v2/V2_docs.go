@@ -15,6 +15,64 @@ const docTemplateV2 = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/accounts/{account_id}/dispersals": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Fetch the audit records of the dispersal requests of an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp [default: 1 day ago]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of records, newest first [default: 100, max: 1000]",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.AccountDispersalsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/batches/{batch_header_hash}": {
             "get": {
                 "produces": [
@@ -480,6 +538,92 @@ const docTemplateV2 = `{
         }
     },
     "definitions": {
+        "audit.Decision": {
+            "type": "string",
+            "enum": [
+                "accepted",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "Accepted",
+                "Rejected"
+            ]
+        },
+        "audit.PaymentMode": {
+            "type": "string",
+            "enum": [
+                "reservation",
+                "on-demand",
+                "rate-limit",
+                ""
+            ],
+            "x-enum-varnames": [
+                "PaymentModeReservation",
+                "PaymentModeOnDemand",
+                "PaymentModeRateLimit",
+                "PaymentModeNone"
+            ]
+        },
+        "audit.Record": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "description": "AccountID is the account the request was authenticated as, or UnknownAccount if the request was not\nauthenticated, so that the records of an account only hold requests the account actually signed.",
+                    "type": "string"
+                },
+                "api_version": {
+                    "description": "APIVersion is the version of the disperser API serving the request, \"v1\" or \"v2\".",
+                    "type": "string"
+                },
+                "authenticated": {
+                    "description": "Authenticated is whether the request proved that it comes from AccountID.",
+                    "type": "boolean"
+                },
+                "blob_key": {
+                    "description": "BlobKey is the hex encoded blob key of a v2 request, or the request ID of a v1 request. It is empty if the\nrequest was rejected before the key could be computed.",
+                    "type": "string"
+                },
+                "blob_size": {
+                    "type": "integer"
+                },
+                "claimed_account_id": {
+                    "description": "ClaimedAccountID is the account the request claims to come from, whether or not the claim was verified.",
+                    "type": "string"
+                },
+                "code": {
+                    "description": "Code is the gRPC status code of the reply.",
+                    "type": "string"
+                },
+                "decision": {
+                    "$ref": "#/definitions/audit.Decision"
+                },
+                "method": {
+                    "description": "Method is the gRPC method serving the request.",
+                    "type": "string"
+                },
+                "payment_mode": {
+                    "$ref": "#/definitions/audit.PaymentMode"
+                },
+                "quorums": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reason": {
+                    "description": "Reason is the error returned to the client for a rejected request.",
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "RequestID uniquely identifies the record. It is assigned by the Log.",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Timestamp is the time the decision was made. It is assigned by the Log if not set.",
+                    "type": "string"
+                }
+            }
+        },
         "big.Int": {
             "type": "object"
         },
@@ -701,6 +845,14 @@ const docTemplateV2 = `{
                         "type": "integer"
                     }
                 },
+                "retentionTier": {
+                    "description": "RetentionTier selects how long the network holds the blob",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v2.RetentionTier"
+                        }
+                    ]
+                },
                 "signature": {
                     "description": "Signature is the signature of the blob header by the account ID",
                     "type": "array",
@@ -786,6 +938,20 @@ const docTemplateV2 = `{
                 }
             }
         },
+        "v2.AccountDispersalsResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "dispersals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/audit.Record"
+                    }
+                }
+            }
+        },
         "v2.BatchResponse": {
             "type": "object",
             "properties": {
@@ -976,6 +1142,21 @@ const docTemplateV2 = `{
                 }
             }
         },
+        "v2.RetentionTier": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                2
+            ],
+            "x-enum-varnames": [
+                "RetentionTierStandard",
+                "RetentionTierExtended",
+                "RetentionTierArchival",
+                "MaxRetentionTier"
+            ]
+        },
         "v2.SemverReportResponse": {
             "type": "object",
             "properties": {
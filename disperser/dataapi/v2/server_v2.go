@@ -15,6 +15,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	"github.com/Layr-Labs/eigenda/disperser/common/semver"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
@@ -38,6 +39,9 @@ const (
 	maxOperatorPortCheckAge = 60
 	maxMetricAge            = 10
 	maxThroughputAge        = 10
+
+	defaultAccountDispersalsLimit = 100
+	maxAccountDispersalsLimit     = 1000
 )
 
 type (
@@ -114,6 +118,11 @@ type (
 		Throughput float64 `json:"throughput"`
 		Timestamp  uint64  `json:"timestamp"`
 	}
+
+	AccountDispersalsResponse struct {
+		AccountID  string          `json:"account_id"`
+		Dispersals []*audit.Record `json:"dispersals"`
+	}
 )
 
 type ServerV2 struct {
@@ -132,6 +141,9 @@ type ServerV2 struct {
 
 	operatorHandler *dataapi.OperatorHandler
 	metricsHandler  *dataapi.MetricsHandler
+
+	// auditSink holds the audit records of dispersals. The accounts endpoints are not served if it is nil.
+	auditSink audit.Sink
 }
 
 func NewServerV2(
@@ -144,6 +156,7 @@ func NewServerV2(
 	indexedChainState core.IndexedChainState,
 	logger logging.Logger,
 	metrics *dataapi.Metrics,
+	auditSink audit.Sink,
 ) *ServerV2 {
 	l := logger.With("component", "DataAPIServerV2")
 	return &ServerV2{
@@ -160,6 +173,7 @@ func NewServerV2(
 		metrics:           metrics,
 		operatorHandler:   dataapi.NewOperatorHandler(l, metrics, chainReader, chainState, indexedChainState, subgraphClient),
 		metricsHandler:    dataapi.NewMetricsHandler(promClient),
+		auditSink:         auditSink,
 	}
 }
 
@@ -201,6 +215,12 @@ func (s *ServerV2) Start() error {
 			metrics.GET("/summary", s.FetchMetricsSummaryHandler)
 			metrics.GET("/timeseries/throughput", s.FetchMetricsThroughputTimeseriesHandler)
 		}
+		if s.auditSink != nil {
+			accounts := v2.Group("/accounts")
+			{
+				accounts.GET("/:account_id/dispersals", s.FetchAccountDispersalsHandler)
+			}
+		}
 		swagger := v2.Group("/swagger")
 		{
 			swagger.GET("/*any", ginswagger.WrapHandler(swaggerfiles.Handler, ginswagger.InstanceName("V2"), ginswagger.URL("/api/v2/swagger/doc.json")))
@@ -682,3 +702,59 @@ func (s *ServerV2) FetchMetricsThroughputTimeseriesHandler(c *gin.Context) {
 	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxThroughputAge))
 	c.JSON(http.StatusOK, ths)
 }
+
+// FetchAccountDispersalsHandler godoc
+//
+//	@Summary	Fetch the audit records of the dispersal requests of an account
+//	@Tags		Accounts
+//	@Produce	json
+//	@Param		account_id	path		string	true	"The account ID"
+//	@Param		start		query		int		false	"Start unix timestamp [default: 1 day ago]"
+//	@Param		end			query		int		false	"End unix timestamp [default: unix time now]"
+//	@Param		limit		query		int		false	"Maximum number of records, newest first [default: 100, max: 1000]"
+//	@Success	200			{object}	AccountDispersalsResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/accounts/{account_id}/dispersals [get]
+func (s *ServerV2) FetchAccountDispersalsHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchAccountDispersals", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	// records are stored under the checksummed form of an address
+	accountID := audit.NormalizeAccountID(c.Param("account_id"))
+
+	now := time.Now()
+	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
+	if err != nil || start == 0 {
+		start = now.Add(-24 * time.Hour).Unix()
+	}
+	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
+	if err != nil || end == 0 {
+		end = now.Unix()
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultAccountDispersalsLimit)))
+	if err != nil || limit <= 0 || limit > maxAccountDispersalsLimit {
+		s.metrics.IncrementInvalidArgRequestNum("FetchAccountDispersals")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: fmt.Sprintf("invalid limit parameter, must be between 1 and %d", maxAccountDispersalsLimit),
+		})
+		return
+	}
+
+	// end is inclusive up to the last nanosecond of its second
+	records, err := s.auditSink.QueryByAccount(
+		c.Request.Context(), accountID, time.Unix(start, 0), time.Unix(end+1, 0).Add(-time.Nanosecond), limit)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountDispersals")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchAccountDispersals")
+	c.JSON(http.StatusOK, &AccountDispersalsResponse{
+		AccountID:  accountID,
+		Dispersals: records,
+	})
+}
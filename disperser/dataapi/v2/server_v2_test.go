@@ -18,9 +18,11 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/audit"
 	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
 	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
@@ -55,6 +57,7 @@ var (
 	mockPrometheusRespAvgThroughput string
 
 	blobMetadataStore   *blobstorev2.BlobMetadataStore
+	auditSink           = audit.NewKVSink(mapstore.NewStore())
 	testDataApiServerV2 *serverv2.ServerV2
 
 	logger = logging.NewNoopLogger()
@@ -194,7 +197,7 @@ func setup(m *testing.M) {
 		panic("failed to create dynamodb client: " + err.Error())
 	}
 	blobMetadataStore = blobstorev2.NewBlobMetadataStore(dynamoClient, logger, metadataTableName)
-	testDataApiServerV2 = serverv2.NewServerV2(config, blobMetadataStore, prometheusClient, subgraphClient, mockTx, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger))
+	testDataApiServerV2 = serverv2.NewServerV2(config, blobMetadataStore, prometheusClient, subgraphClient, mockTx, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), auditSink)
 }
 
 // makeCommitment returns a test hardcoded BlobCommitments
@@ -648,3 +651,55 @@ func TestFetchMetricsThroughputTimeseriesHandler(t *testing.T) {
 	assert.Equal(t, uint64(1701292920), response[0].Timestamp)
 	assert.Equal(t, float64(3.503022666666651e+07), totalThroughput)
 }
+
+func TestFetchAccountDispersalsHandler(t *testing.T) {
+	r := setUpRouter()
+	r.GET("/v2/accounts/:account_id/dispersals", testDataApiServerV2.FetchAccountDispersalsHandler)
+
+	accountID := "0x1aa8226f6d354380dDE75eE6B634875c4203e522"
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		record := &audit.Record{
+			RequestID:  fmt.Sprintf("request-%d", i),
+			Timestamp:  now.Add(time.Duration(i-3) * time.Minute),
+			APIVersion: "v2",
+			Method:     "DisperseBlob",
+			AccountID:  accountID,
+			BlobSize:   100 * (i + 1),
+			Quorums:    []uint32{0, 1},
+		}
+		record.SetOutcome(nil)
+		require.NoError(t, auditSink.Append(context.Background(), record))
+	}
+	// a record of another account
+	require.NoError(t, auditSink.Append(context.Background(), &audit.Record{
+		RequestID: "request-other",
+		Timestamp: now.Add(-time.Minute),
+		AccountID: "0x0000000000000000000000000000000000000001",
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/accounts/"+accountID+"/dispersals?limit=2", nil)
+	r.ServeHTTP(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var response serverv2.AccountDispersalsResponse
+	require.NoError(t, json.Unmarshal(data, &response))
+	assert.Equal(t, accountID, response.AccountID)
+	require.Equal(t, 2, len(response.Dispersals))
+	// newest first
+	assert.Equal(t, "request-2", response.Dispersals[0].RequestID)
+	assert.Equal(t, "request-1", response.Dispersals[1].RequestID)
+	assert.Equal(t, audit.Accepted, response.Dispersals[0].Decision)
+	assert.Equal(t, 300, response.Dispersals[0].BlobSize)
+
+	// limit over the maximum
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v2/accounts/"+accountID+"/dispersals?limit=100000", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
@@ -9,7 +9,9 @@ import (
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 type clientV2 struct {
@@ -22,7 +24,7 @@ func NewEncoderClientV2(addr string) (disperser.EncoderClientV2, error) {
 	}, nil
 }
 
-func (c *clientV2) EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error) {
+func (c *clientV2) EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams, blobCommitments *encoding.BlobCommitments, priority disperser.EncodingPriority, retentionTier corev2.RetentionTier) (*encoding.FragmentInfo, error) {
 	// Establish connection
 	conn, err := grpc.NewClient(
 		c.addr,
@@ -43,10 +45,21 @@ func (c *clientV2) EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encod
 			ChunkLength: encodingParams.ChunkLength,
 			NumChunks:   encodingParams.NumChunks,
 		},
+		Priority:      pb.EncodingPriority(priority),
+		RetentionTier: uint32(retentionTier),
+	}
+	if blobCommitments != nil {
+		req.BlobCommitment, err = blobCommitments.ToProtobuf()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert blob commitments to protobuf: %w", err)
+		}
 	}
 
 	// Make the RPC call
 	reply, err := client.EncodeBlob(ctx, req)
+	if status.Code(err) == codes.DataLoss {
+		return nil, fmt.Errorf("%w: %s", disperser.ErrEncodingSelfVerificationFailed, status.Convert(err).Message())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode blob: %w", err)
 	}
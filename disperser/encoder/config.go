@@ -14,4 +14,23 @@ type ServerConfig struct {
 	GPUEnable                bool
 	PprofHttpPort            string
 	EnablePprof              bool
+	// EnableStreamingEncoding makes the v2 encoder compute and upload frame coefficients in batches of frames, instead
+	// of holding the coefficients of every frame in memory before uploading. The blob and the proofs of every frame
+	// are still held in memory.
+	EnableStreamingEncoding bool
+	// StreamingEncodingBatchSize is the number of frames computed at a time when streaming encoding is enabled.
+	StreamingEncodingBatchSize uint64
+	// EnableSelfVerification makes the v2 encoder verify its output against the blob commitment before storing it.
+	EnableSelfVerification bool
+	// SelfVerificationSampleSize is the number of randomly chosen frames verified per blob. If 0, every frame is
+	// verified.
+	SelfVerificationSampleSize uint64
+	// EnableChunkReuse makes the v2 encoder copy the chunks of a previously encoded blob with the same content,
+	// commitment and encoding params, instead of encoding the blob again.
+	EnableChunkReuse bool
+	// SmallBlobConcurrencyLimit, MediumBlobConcurrencyLimit and LargeBlobConcurrencyLimit limit the number of
+	// requests of each blob size class that are encoded at a time. If 0, only MaxConcurrentRequests applies.
+	SmallBlobConcurrencyLimit  int
+	MediumBlobConcurrencyLimit int
+	LargeBlobConcurrencyLimit  int
 }
@@ -18,6 +18,14 @@ type MetricsConfig struct {
 	EnableMetrics bool
 }
 
+// QueueDepthKey identifies the requests of one size class and priority that are in the same state, either queued or
+// running.
+type QueueDepthKey struct {
+	SizeClass string
+	Priority  string
+	State     string
+}
+
 type Metrics struct {
 	logger   logging.Logger
 	registry *prometheus.Registry
@@ -27,8 +35,11 @@ type Metrics struct {
 	BlobSizeTotal         *prometheus.CounterVec
 	Latency               *prometheus.SummaryVec
 	BlobQueue             *prometheus.GaugeVec
+	QueueDepth            *prometheus.GaugeVec
 	QueueCapacity         prometheus.Gauge
 	QueueUtilization      prometheus.Gauge
+	NumReusedEncodings    prometheus.Counter
+	ReusedChunkSizeTotal  prometheus.Counter
 }
 
 func NewMetrics(reg *prometheus.Registry, httpPort string, logger logging.Logger) *Metrics {
@@ -45,7 +56,7 @@ func NewMetrics(reg *prometheus.Registry, httpPort string, logger logging.Logger
 				Name:      "request_total",
 				Help:      "the number of total encode blob request at server side per state",
 			},
-			[]string{"state"}, // state is either success, ratelimited, canceled, or failure
+			[]string{"state"}, // state is either success, ratelimited, canceled, failed_verification, or failure
 		),
 		BlobSizeTotal: promauto.With(reg).NewCounterVec(
 			prometheus.CounterOpts{
@@ -72,6 +83,14 @@ func NewMetrics(reg *prometheus.Registry, httpPort string, logger logging.Logger
 			},
 			[]string{"size_bucket"},
 		),
+		QueueDepth: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "eigenda_encoder",
+				Name:      "queue_depth",
+				Help:      "the number of requests per size class and priority that are queued or running",
+			},
+			[]string{"size_class", "priority", "state"}, // state is either queued or running
+		),
 		QueueCapacity: promauto.With(reg).NewGauge(
 			prometheus.GaugeOpts{
 				Namespace: "eigenda_encoder",
@@ -86,6 +105,20 @@ func NewMetrics(reg *prometheus.Registry, httpPort string, logger logging.Logger
 				Help:      "Current utilization of request pool (total across all buckets)",
 			},
 		),
+		NumReusedEncodings: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: "eigenda_encoder",
+				Name:      "reused_encoding_total",
+				Help:      "the number of blobs whose chunks were copied from another blob with the same encoding instead of encoded",
+			},
+		),
+		ReusedChunkSizeTotal: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: "eigenda_encoder",
+				Name:      "reused_chunk_size_total",
+				Help:      "the size in bytes of the chunk coefficients copied from another blob with the same encoding",
+			},
+		),
 	}
 }
 
@@ -103,6 +136,13 @@ func (m *Metrics) IncrementFailedBlobRequestNum(blobSize int) {
 	m.BlobSizeTotal.WithLabelValues("failed").Add(float64(blobSize))
 }
 
+// IncrementFailedVerificationBlobRequestNum increments the number of requests whose output failed self verification
+// this counter incrementation is atomic
+func (m *Metrics) IncrementFailedVerificationBlobRequestNum(blobSize int) {
+	m.NumEncodeBlobRequests.WithLabelValues("failed_verification").Inc()
+	m.BlobSizeTotal.WithLabelValues("failed_verification").Add(float64(blobSize))
+}
+
 // IncrementRateLimitedBlobRequestNum increments the number of rate limited requests
 // this counter incrementation is atomic
 func (m *Metrics) IncrementRateLimitedBlobRequestNum(blobSize int) {
@@ -117,17 +157,32 @@ func (m *Metrics) IncrementCanceledBlobRequestNum(blobSize int) {
 	m.BlobSizeTotal.WithLabelValues("canceled").Add(float64(blobSize))
 }
 
+// IncrementReusedEncodingNum increments the number of blobs whose chunks were copied from another blob
+// this counter incrementation is atomic
+func (m *Metrics) IncrementReusedEncodingNum(chunkSize int) {
+	m.NumReusedEncodings.Inc()
+	m.ReusedChunkSizeTotal.Add(float64(chunkSize))
+}
+
 func (m *Metrics) ObserveLatency(stage string, duration time.Duration) {
 	m.Latency.WithLabelValues(stage).Observe(float64(duration.Milliseconds()))
 }
 
-func (m *Metrics) ObserveQueue(queueStats map[string]int) {
+func (m *Metrics) ObserveQueue(queueStats map[string]int, queueDepths map[QueueDepthKey]int) {
 	total := 0
 	for bucket, num := range queueStats {
 		m.BlobQueue.With(prometheus.Labels{"size_bucket": bucket}).Set(float64(num))
 		total += num
 	}
 	m.QueueUtilization.Set(float64(total))
+
+	for key, num := range queueDepths {
+		m.QueueDepth.With(prometheus.Labels{
+			"size_class": key.SizeClass,
+			"priority":   key.Priority,
+			"state":      key.State,
+		}).Set(float64(num))
+	}
 }
 
 func (m *Metrics) SetQueueCapacity(capacity int) {
@@ -0,0 +1,238 @@
+package encoder
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+)
+
+// errRequestPoolFull is returned when the scheduler already holds RequestPoolSize requests.
+var errRequestPoolFull = errors.New("request pool is full")
+
+// sizeClass groups blobs of similar size, so that a burst of large blobs cannot occupy every encoding slot.
+type sizeClass int
+
+const (
+	smallSizeClass sizeClass = iota
+	mediumSizeClass
+	largeSizeClass
+	numSizeClasses
+)
+
+const (
+	// maxSmallBlobSize is the largest blob size, in bytes, in the small size class
+	maxSmallBlobSize = 128 * 1024
+	// maxMediumBlobSize is the largest blob size, in bytes, in the medium size class
+	maxMediumBlobSize = 2 * 1024 * 1024
+)
+
+func getSizeClass(blobSize int) sizeClass {
+	switch {
+	case blobSize <= maxSmallBlobSize:
+		return smallSizeClass
+	case blobSize <= maxMediumBlobSize:
+		return mediumSizeClass
+	default:
+		return largeSizeClass
+	}
+}
+
+func (c sizeClass) String() string {
+	switch c {
+	case smallSizeClass:
+		return "small"
+	case mediumSizeClass:
+		return "medium"
+	default:
+		return "large"
+	}
+}
+
+// scheduledRequest is a request waiting for an encoding slot.
+type scheduledRequest struct {
+	priority   disperser.EncodingPriority
+	class      sizeClass
+	sizeBucket string
+	// seq is the arrival order of the request, used to break ties
+	seq uint64
+	// ready is closed once the request has been given an encoding slot
+	ready chan struct{}
+}
+
+// before returns true if r should be scheduled before other. Higher priority requests go first, then requests for
+// smaller blobs, then requests that arrived earlier.
+func (r *scheduledRequest) before(other *scheduledRequest) bool {
+	if r.priority != other.priority {
+		return r.priority > other.priority
+	}
+	if r.class != other.class {
+		return r.class < other.class
+	}
+	return r.seq < other.seq
+}
+
+// requestScheduler limits the number of requests held by the encoder server, and decides which of the queued
+// requests is encoded next. At most maxRunning requests are encoded at a time, and at most classLimits[c] of them
+// may belong to size class c, so that slots remain available for the other classes.
+type requestScheduler struct {
+	maxPooled   int
+	maxRunning  int
+	classLimits [numSizeClasses]int
+	metrics     *Metrics
+
+	mu         sync.Mutex
+	waiting    []*scheduledRequest
+	numRunning int
+	running    [numSizeClasses]int
+	nextSeq    uint64
+	// number of requests queued or running, per blob size bucket
+	queueStats map[string]int
+	// number of requests per size class, priority and state
+	queueDepths map[QueueDepthKey]int
+}
+
+func newRequestScheduler(config ServerConfig, metrics *Metrics) *requestScheduler {
+	classLimits := [numSizeClasses]int{
+		smallSizeClass:  config.SmallBlobConcurrencyLimit,
+		mediumSizeClass: config.MediumBlobConcurrencyLimit,
+		largeSizeClass:  config.LargeBlobConcurrencyLimit,
+	}
+	for i := range classLimits {
+		if classLimits[i] <= 0 || classLimits[i] > config.MaxConcurrentRequests {
+			classLimits[i] = config.MaxConcurrentRequests
+		}
+	}
+
+	return &requestScheduler{
+		maxPooled:   config.RequestPoolSize,
+		maxRunning:  config.MaxConcurrentRequests,
+		classLimits: classLimits,
+		metrics:     metrics,
+		queueStats:  make(map[string]int),
+		queueDepths: make(map[QueueDepthKey]int),
+	}
+}
+
+// acquire waits until the request may be encoded. It returns errRequestPoolFull immediately if the scheduler holds
+// too many requests, or the context error if the context is done before an encoding slot is available. On success,
+// release must be called once the request is done.
+func (s *requestScheduler) acquire(
+	ctx context.Context,
+	priority disperser.EncodingPriority,
+	blobSize int) (release func(), err error) {
+
+	s.mu.Lock()
+	if len(s.waiting)+s.numRunning >= s.maxPooled {
+		s.mu.Unlock()
+		return nil, errRequestPoolFull
+	}
+
+	request := &scheduledRequest{
+		priority:   priority,
+		class:      getSizeClass(blobSize),
+		sizeBucket: common.BlobSizeBucket(blobSize),
+		seq:        s.nextSeq,
+		ready:      make(chan struct{}),
+	}
+	s.nextSeq++
+	s.waiting = append(s.waiting, request)
+	s.queueStats[request.sizeBucket]++
+	s.queueDepths[request.depthKey("queued")]++
+	s.dispatch()
+	s.observe()
+	s.mu.Unlock()
+
+	select {
+	case <-request.ready:
+		return func() { s.release(request) }, nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-request.ready:
+		// the request was given a slot while the context was being canceled
+		s.finish(request)
+	default:
+		s.remove(request)
+		s.queueStats[request.sizeBucket]--
+		s.queueDepths[request.depthKey("queued")]--
+	}
+	s.observe()
+	return nil, ctx.Err()
+}
+
+// usage returns the number of requests held by the scheduler, and the most it may hold.
+func (s *requestScheduler) usage() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.waiting) + s.numRunning, s.maxPooled
+}
+
+func (s *requestScheduler) release(request *scheduledRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finish(request)
+	s.observe()
+}
+
+// finish frees the slot of a running request and hands it to the next request. Must be called with s.mu held.
+func (s *requestScheduler) finish(request *scheduledRequest) {
+	s.numRunning--
+	s.running[request.class]--
+	s.queueStats[request.sizeBucket]--
+	s.queueDepths[request.depthKey("running")]--
+	s.dispatch()
+}
+
+// dispatch starts as many waiting requests as the limits allow. Must be called with s.mu held.
+func (s *requestScheduler) dispatch() {
+	for s.numRunning < s.maxRunning {
+		var next *scheduledRequest
+		for _, request := range s.waiting {
+			if s.running[request.class] >= s.classLimits[request.class] {
+				continue
+			}
+			if next == nil || request.before(next) {
+				next = request
+			}
+		}
+		if next == nil {
+			return
+		}
+
+		s.remove(next)
+		s.numRunning++
+		s.running[next.class]++
+		s.queueDepths[next.depthKey("queued")]--
+		s.queueDepths[next.depthKey("running")]++
+		close(next.ready)
+	}
+}
+
+// remove removes a request from the waiting list. Must be called with s.mu held.
+func (s *requestScheduler) remove(request *scheduledRequest) {
+	for i, r := range s.waiting {
+		if r == request {
+			s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+			return
+		}
+	}
+}
+
+// observe reports the queue depths. Must be called with s.mu held.
+func (s *requestScheduler) observe() {
+	s.metrics.ObserveQueue(s.queueStats, s.queueDepths)
+}
+
+func (r *scheduledRequest) depthKey(state string) QueueDepthKey {
+	return QueueDepthKey{
+		SizeClass: r.class.String(),
+		Priority:  r.priority.String(),
+		State:     state,
+	}
+}
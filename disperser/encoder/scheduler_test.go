@@ -0,0 +1,129 @@
+package encoder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	smallBlobSize = 1024
+	largeBlobSize = 16 * 1024 * 1024
+)
+
+func newTestScheduler(config ServerConfig) *requestScheduler {
+	return newRequestScheduler(config, NewMetrics(prometheus.NewRegistry(), "9000", logger))
+}
+
+// acquireAsync requests a slot in the background, and waits until the request is queued.
+func acquireAsync(
+	t *testing.T,
+	s *requestScheduler,
+	priority disperser.EncodingPriority,
+	blobSize int,
+	started chan<- int,
+	id int) {
+
+	s.mu.Lock()
+	queued := len(s.waiting)
+	s.mu.Unlock()
+
+	go func() {
+		release, err := s.acquire(context.Background(), priority, blobSize)
+		if err != nil {
+			return
+		}
+		started <- id
+		// hold the slot until the next request is expected to start
+		time.Sleep(10 * time.Millisecond)
+		release()
+	}()
+
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.waiting) == queued+1
+	}, time.Second, time.Millisecond)
+}
+
+func TestSchedulerRequestPoolFull(t *testing.T) {
+	s := newTestScheduler(ServerConfig{MaxConcurrentRequests: 1, RequestPoolSize: 1})
+
+	release, err := s.acquire(context.Background(), disperser.OnDemandEncodingPriority, smallBlobSize)
+	require.NoError(t, err)
+
+	_, err = s.acquire(context.Background(), disperser.ReservationEncodingPriority, smallBlobSize)
+	require.ErrorIs(t, err, errRequestPoolFull)
+
+	release()
+	release, err = s.acquire(context.Background(), disperser.OnDemandEncodingPriority, smallBlobSize)
+	require.NoError(t, err)
+	release()
+}
+
+func TestSchedulerOrder(t *testing.T) {
+	s := newTestScheduler(ServerConfig{MaxConcurrentRequests: 1, RequestPoolSize: 10})
+
+	release, err := s.acquire(context.Background(), disperser.OnDemandEncodingPriority, smallBlobSize)
+	require.NoError(t, err)
+
+	started := make(chan int, 4)
+	acquireAsync(t, s, disperser.OnDemandEncodingPriority, largeBlobSize, started, 0)
+	acquireAsync(t, s, disperser.OnDemandEncodingPriority, smallBlobSize, started, 1)
+	acquireAsync(t, s, disperser.ReservationEncodingPriority, largeBlobSize, started, 2)
+	acquireAsync(t, s, disperser.ReservationEncodingPriority, smallBlobSize, started, 3)
+	release()
+
+	// reservations first, then smaller blobs first
+	for _, expected := range []int{3, 2, 1, 0} {
+		require.Equal(t, expected, <-started)
+	}
+}
+
+func TestSchedulerClassLimits(t *testing.T) {
+	s := newTestScheduler(ServerConfig{MaxConcurrentRequests: 2, RequestPoolSize: 10, LargeBlobConcurrencyLimit: 1})
+
+	release, err := s.acquire(context.Background(), disperser.ReservationEncodingPriority, largeBlobSize)
+	require.NoError(t, err)
+	defer release()
+
+	// the second large blob waits even though a slot is free
+	started := make(chan int, 2)
+	acquireAsync(t, s, disperser.ReservationEncodingPriority, largeBlobSize, started, 0)
+	select {
+	case <-started:
+		require.Fail(t, "large blob exceeded its concurrency limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// a small blob can still use the free slot
+	release2, err := s.acquire(context.Background(), disperser.OnDemandEncodingPriority, smallBlobSize)
+	require.NoError(t, err)
+	release2()
+}
+
+func TestSchedulerCanceledWhileQueued(t *testing.T) {
+	s := newTestScheduler(ServerConfig{MaxConcurrentRequests: 1, RequestPoolSize: 2})
+
+	release, err := s.acquire(context.Background(), disperser.OnDemandEncodingPriority, smallBlobSize)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.acquire(ctx, disperser.OnDemandEncodingPriority, smallBlobSize)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the canceled request no longer takes space in the pool
+	s.mu.Lock()
+	require.Empty(t, s.waiting)
+	s.mu.Unlock()
+	release()
+
+	release, err = s.acquire(context.Background(), disperser.OnDemandEncodingPriority, smallBlobSize)
+	require.NoError(t, err)
+	release()
+}
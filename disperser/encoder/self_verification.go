@@ -0,0 +1,100 @@
+package encoder
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	pbcommon "github.com/Layr-Labs/eigenda/api/grpc/common"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// selfVerificationFailedCode is the status code returned when the output of the encoder fails its own verification.
+// It is distinct from the codes of other failures so that callers can tell a faulty encoding apart from an
+// unavailable dependency.
+const selfVerificationFailedCode = codes.DataLoss
+
+func newSelfVerificationError(format string, args ...any) error {
+	return status.Errorf(selfVerificationFailedCode, "self verification failed: "+format, args...)
+}
+
+func isSelfVerificationError(err error) bool {
+	return status.Code(err) == selfVerificationFailedCode
+}
+
+// selfVerificationEnabled returns true if the encoder verifies its output before storing it.
+func (s *EncoderServerV2) selfVerificationEnabled() bool {
+	return s.config.EnableSelfVerification && s.verifier != nil
+}
+
+// sampleFrameIndices returns the sorted indices of the frames to verify. A sample size of 0 selects every frame.
+func (s *EncoderServerV2) sampleFrameIndices(numChunks uint64) []encoding.ChunkNumber {
+	sampleSize := s.config.SelfVerificationSampleSize
+	if sampleSize == 0 || sampleSize > numChunks {
+		sampleSize = numChunks
+	}
+
+	indices := make([]encoding.ChunkNumber, sampleSize)
+	for i, index := range rand.Perm(int(numChunks))[:sampleSize] {
+		indices[i] = encoding.ChunkNumber(index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	return indices
+}
+
+// getVerificationCommitments computes the commitment of the blob, and checks it against the commitment from the
+// blob header if the request contains one.
+func (s *EncoderServerV2) getVerificationCommitments(
+	data []byte,
+	headerCommitment *pbcommon.BlobCommitment) (encoding.BlobCommitments, error) {
+
+	commitment, err := s.prover.GetCommitment(data)
+	if err != nil {
+		return encoding.BlobCommitments{}, status.Errorf(codes.Internal, "failed to compute commitment: %v", err)
+	}
+
+	commitments := encoding.BlobCommitments{
+		Commitment: commitment,
+		Length:     uint(encoding.GetBlobLength(uint(len(data)))),
+	}
+	if headerCommitment == nil {
+		return commitments, nil
+	}
+
+	expected, err := new(encoding.G1Commitment).Deserialize(headerCommitment.GetCommitment())
+	if err != nil {
+		return encoding.BlobCommitments{}, status.Errorf(codes.InvalidArgument, "invalid blob commitment: %v", err)
+	}
+	if !(*bn254.G1Affine)(commitment).Equal((*bn254.G1Affine)(expected)) {
+		return encoding.BlobCommitments{}, newSelfVerificationError("blob does not match the commitment in the blob header")
+	}
+	if uint(headerCommitment.GetLength()) < commitments.Length {
+		return encoding.BlobCommitments{}, newSelfVerificationError(
+			"blob length %d exceeds the length %d in the blob header", commitments.Length, headerCommitment.GetLength())
+	}
+
+	return commitments, nil
+}
+
+// verifyFrames verifies the sampled frames against the commitment. The frames must be in the order of the indices.
+func (s *EncoderServerV2) verifyFrames(
+	frames []*encoding.Frame,
+	indices []encoding.ChunkNumber,
+	commitments encoding.BlobCommitments,
+	encodingParams encoding.EncodingParams) error {
+
+	if len(frames) != len(indices) {
+		return newSelfVerificationError("expected %d frames to verify, got %d", len(indices), len(frames))
+	}
+
+	err := s.verifier.VerifyFrames(frames, indices, commitments, encodingParams)
+	if err != nil {
+		return newSelfVerificationError("%v", fmt.Errorf("failed to verify %d frames: %w", len(frames), err))
+	}
+
+	return nil
+}
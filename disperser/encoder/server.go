@@ -6,14 +6,12 @@ import (
 	"fmt"
 	"log"
 	"net"
-	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	commonpprof "github.com/Layr-Labs/eigenda/common/pprof"
 	"github.com/Layr-Labs/eigenda/disperser"
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
-	"github.com/Layr-Labs/eigenda/disperser/common"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
@@ -31,15 +29,7 @@ type EncoderServer struct {
 	grpcMetrics *grpcprom.ServerMetrics
 	close       func()
 
-	runningRequests chan struct{}
-	requestPool     chan blobRequest
-
-	queueStats map[string]int
-	queueLock  sync.Mutex
-}
-
-type blobRequest struct {
-	blobSizeByte int
+	scheduler *requestScheduler
 }
 
 func NewEncoderServer(config ServerConfig, logger logging.Logger, prover encoding.Prover, metrics *Metrics, grpcMetrics *grpcprom.ServerMetrics) *EncoderServer {
@@ -53,9 +43,7 @@ func NewEncoderServer(config ServerConfig, logger logging.Logger, prover encodin
 		metrics:     metrics,
 		grpcMetrics: grpcMetrics,
 
-		runningRequests: make(chan struct{}, config.MaxConcurrentRequests),
-		requestPool:     make(chan blobRequest, config.RequestPoolSize),
-		queueStats:      make(map[string]int),
+		scheduler: newRequestScheduler(config, metrics),
 	}
 }
 
@@ -102,22 +90,18 @@ func (s *EncoderServer) Start() error {
 func (s *EncoderServer) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequest) (*pb.EncodeBlobReply, error) {
 	startTime := time.Now()
 	blobSize := len(req.GetData())
-	sizeBucket := common.BlobSizeBucket(blobSize)
-
-	select {
-	case s.requestPool <- blobRequest{blobSizeByte: blobSize}:
-		s.queueLock.Lock()
-		s.queueStats[sizeBucket]++
-		s.metrics.ObserveQueue(s.queueStats)
-		s.queueLock.Unlock()
-	default:
+
+	release, err := s.scheduler.acquire(ctx, disperser.EncodingPriority(req.GetPriority()), blobSize)
+	if errors.Is(err, errRequestPoolFull) {
 		s.metrics.IncrementRateLimitedBlobRequestNum(len(req.GetData()))
 		s.logger.Warn("rate limiting as request pool is full", "requestPoolSize", s.config.RequestPoolSize, "maxConcurrentRequests", s.config.MaxConcurrentRequests)
 		return nil, errors.New("too many requests")
 	}
-
-	s.runningRequests <- struct{}{}
-	defer s.popRequest()
+	if err != nil {
+		s.metrics.IncrementCanceledBlobRequestNum(blobSize)
+		return nil, err
+	}
+	defer release()
 
 	if ctx.Err() != nil {
 		s.metrics.IncrementCanceledBlobRequestNum(blobSize)
@@ -136,15 +120,6 @@ func (s *EncoderServer) EncodeBlob(ctx context.Context, req *pb.EncodeBlobReques
 	return reply, err
 }
 
-func (s *EncoderServer) popRequest() {
-	blobRequest := <-s.requestPool
-	<-s.runningRequests
-	s.queueLock.Lock()
-	s.queueStats[common.BlobSizeBucket(blobRequest.blobSizeByte)]--
-	s.metrics.ObserveQueue(s.queueStats)
-	s.queueLock.Unlock()
-}
-
 func (s *EncoderServer) handleEncoding(ctx context.Context, req *pb.EncodeBlobRequest) (*pb.EncodeBlobReply, error) {
 	begin := time.Now()
 
@@ -2,11 +2,16 @@ package encoder
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"time"
 
+	pbcommon "github.com/Layr-Labs/eigenda/api/grpc/common"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -14,6 +19,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
+	rb "github.com/Layr-Labs/eigenda/encoding/utils/reverseBits"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"google.golang.org/grpc"
@@ -30,25 +36,47 @@ type EncoderServerV2 struct {
 	chunkWriter chunkstore.ChunkWriter
 	logger      logging.Logger
 	prover      encoding.Prover
+	verifier    encoding.Verifier
 	metrics     *Metrics
 	close       func()
 
-	runningRequests chan struct{}
-	requestPool     chan struct{}
+	scheduler *requestScheduler
+
+	// healthRegistry aggregates the health of the server. It may be nil.
+	healthRegistry *healthcheck.Registry
 }
 
-func NewEncoderServerV2(config ServerConfig, blobStore *blobstore.BlobStore, chunkWriter chunkstore.ChunkWriter, logger logging.Logger, prover encoding.Prover, metrics *Metrics) *EncoderServerV2 {
-	return &EncoderServerV2{
+// queueSaturationThreshold is the fraction of the request pool in use past which the health report flags the encoder
+// as saturated. A saturated encoder still queues requests, which the scheduler starts by priority, until the pool is
+// full. Requests arriving at a full pool are rejected whatever their priority.
+const queueSaturationThreshold = 0.9
+
+// NewEncoderServerV2 creates a new EncoderServerV2. The verifier is only used if self verification is enabled, and may
+// be nil otherwise.
+// The health registry may be nil.
+func NewEncoderServerV2(config ServerConfig, blobStore *blobstore.BlobStore, chunkWriter chunkstore.ChunkWriter, logger logging.Logger, prover encoding.Prover, verifier encoding.Verifier, metrics *Metrics, healthRegistry *healthcheck.Registry) *EncoderServerV2 {
+	// Set initial queue capacity metric
+	metrics.SetQueueCapacity(config.RequestPoolSize)
+
+	server := &EncoderServerV2{
 		config:      config,
 		blobStore:   blobStore,
 		chunkWriter: chunkWriter,
 		logger:      logger.With("component", "EncoderServer"),
 		prover:      prover,
+		verifier:    verifier,
 		metrics:     metrics,
 
-		runningRequests: make(chan struct{}, config.MaxConcurrentRequests),
-		requestPool:     make(chan struct{}, config.RequestPoolSize),
+		scheduler:      newRequestScheduler(config, metrics),
+		healthRegistry: healthRegistry,
 	}
+
+	if healthRegistry != nil {
+		healthRegistry.Register("EncoderQueue", healthcheck.Detail,
+			healthcheck.SaturationProbe(server.scheduler.usage, queueSaturationThreshold))
+	}
+
+	return server
 }
 
 func (s *EncoderServerV2) Start() error {
@@ -65,7 +93,7 @@ func (s *EncoderServerV2) Start() error {
 
 	// Register Server for Health Checks
 	name := pb.Encoder_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	s.healthRegistry.RegisterHealthServer(name, gs)
 
 	s.close = func() {
 		err := listener.Close()
@@ -85,19 +113,18 @@ func (s *EncoderServerV2) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequ
 		s.metrics.ObserveLatency("total", time.Since(totalStart))
 	}()
 
-	// Rate limit
-	select {
-	case s.requestPool <- struct{}{}:
-	default:
-		// TODO: Now that we no longer pass the data directly, should we pass in blob size as part of the request?
+	// Rate limit, and wait for the scheduler to pick this request
+	release, err := s.scheduler.acquire(ctx, disperser.EncodingPriority(req.GetPriority()), getBlobSize(req))
+	if errors.Is(err, errRequestPoolFull) {
 		s.metrics.IncrementRateLimitedBlobRequestNum(1)
 		s.logger.Warn("rate limiting as request pool is full", "requestPoolSize", s.config.RequestPoolSize, "maxConcurrentRequests", s.config.MaxConcurrentRequests)
 		return nil, status.Error(codes.ResourceExhausted, "request pool is full")
 	}
-
-	// Limit the number of concurrent requests
-	s.runningRequests <- struct{}{}
-	defer s.popRequest()
+	if err != nil {
+		s.metrics.IncrementCanceledBlobRequestNum(1)
+		return nil, status.Error(codes.Canceled, "request was canceled")
+	}
+	defer release()
 	if ctx.Err() != nil {
 		s.metrics.IncrementCanceledBlobRequestNum(1)
 		return nil, status.Error(codes.Canceled, "request was canceled")
@@ -105,7 +132,10 @@ func (s *EncoderServerV2) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequ
 
 	s.metrics.ObserveLatency("queuing", time.Since(totalStart))
 	reply, err := s.handleEncodingToChunkStore(ctx, req)
-	if err != nil {
+	if isSelfVerificationError(err) {
+		s.logger.Error("encoded blob failed self verification", "error", err)
+		s.metrics.IncrementFailedVerificationBlobRequestNum(1)
+	} else if err != nil {
 		s.metrics.IncrementFailedBlobRequestNum(1)
 	} else {
 		s.metrics.IncrementSuccessfulBlobRequestNum(1)
@@ -120,6 +150,12 @@ func (s *EncoderServerV2) handleEncodingToChunkStore(ctx context.Context, req *p
 	if err != nil {
 		return nil, err
 	}
+	retentionTier := corev2.RetentionTier(req.GetRetentionTier())
+	if err := retentionTier.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid retention tier: %v", err)
+	}
+	// chunks are tagged with the retention tier of the blob, so that they expire with it
+	chunkWriter := s.chunkWriter.WithRetentionTier(retentionTier)
 
 	s.logger.Info("Preparing to encode", "blobKey", blobKey.Hex(), "encodingParams", encodingParams)
 
@@ -137,6 +173,17 @@ func (s *EncoderServerV2) handleEncodingToChunkStore(ctx context.Context, req *p
 		}
 	}
 
+	// Copy the chunks of a blob with the same encoding, if one was encoded before
+	var encodingHash string
+	if s.config.EnableChunkReuse {
+		encodingHash = s.getEncodingHash(ctx, blobKey, req.GetBlobCommitment(), encodingParams)
+		if encodingHash != "" {
+			if reply, ok := s.reuseChunks(ctx, chunkWriter, blobKey, encodingHash); ok {
+				return reply, nil
+			}
+		}
+	}
+
 	// Fetch blob data
 	fetchStart := time.Now()
 	data, err := s.blobStore.GetBlob(ctx, blobKey)
@@ -148,6 +195,37 @@ func (s *EncoderServerV2) handleEncodingToChunkStore(ctx context.Context, req *p
 	}
 	s.logger.Info("fetched blob", "duration", time.Since(fetchStart).String())
 
+	reply, err := s.encodeToChunkStore(ctx, chunkWriter, blobKey, encodingParams, data, req.GetBlobCommitment())
+	if err != nil {
+		return nil, err
+	}
+
+	if encodingHash != "" {
+		fragmentInfo := &encoding.FragmentInfo{
+			TotalChunkSizeBytes: reply.GetFragmentInfo().GetTotalChunkSizeBytes(),
+			FragmentSizeBytes:   reply.GetFragmentInfo().GetFragmentSizeBytes(),
+		}
+		if err := chunkWriter.PutEncoding(ctx, encodingHash, blobKey, fragmentInfo); err != nil {
+			// the blob is encoded, only later blobs with the same encoding miss out on reusing its chunks
+			s.logger.Warn("failed to record encoding for reuse", "blobKey", blobKey.Hex(), "error", err)
+		}
+	}
+	return reply, nil
+}
+
+// encodeToChunkStore encodes the blob data, and stores the chunks in the chunk store.
+func (s *EncoderServerV2) encodeToChunkStore(
+	ctx context.Context,
+	chunkWriter chunkstore.ChunkWriter,
+	blobKey corev2.BlobKey,
+	encodingParams encoding.EncodingParams,
+	data []byte,
+	headerCommitment *pbcommon.BlobCommitment) (*pb.EncodeBlobReply, error) {
+
+	if s.config.EnableStreamingEncoding {
+		return s.streamEncodingToChunkStore(ctx, chunkWriter, blobKey, encodingParams, data, headerCommitment)
+	}
+
 	// Encode the data
 	encodingStart := time.Now()
 	frames, err := s.prover.GetFrames(data, encodingParams)
@@ -157,13 +235,97 @@ func (s *EncoderServerV2) handleEncodingToChunkStore(ctx context.Context, req *p
 	}
 	s.logger.Info("encoding frames", "duration", time.Since(encodingStart).String())
 
+	// Verify the frames before they become visible to relays
+	if s.selfVerificationEnabled() {
+		verificationStart := time.Now()
+		commitments, err := s.getVerificationCommitments(data, headerCommitment)
+		if err != nil {
+			return nil, err
+		}
+		indices := s.sampleFrameIndices(encodingParams.NumChunks)
+		sampledFrames := make([]*encoding.Frame, len(indices))
+		for i, index := range indices {
+			sampledFrames[i] = frames[index]
+		}
+		if err := s.verifyFrames(sampledFrames, indices, commitments, encodingParams); err != nil {
+			return nil, err
+		}
+		s.logger.Info("verified frames", "numFrames", len(indices), "duration", time.Since(verificationStart).String())
+	}
+
 	// Process and store results
-	return s.processAndStoreResults(ctx, blobKey, frames)
+	return s.processAndStoreResults(ctx, chunkWriter, blobKey, frames)
 }
 
-func (s *EncoderServerV2) popRequest() {
-	<-s.requestPool
-	<-s.runningRequests
+// getEncodingHash returns the hash identifying the chunks of a blob. Chunks only depend on the blob bytes and the
+// encoding params, so they are the same for every blob with the same content hash, commitment and encoding params.
+// Returns an empty string if the request has no commitment or the blob has no content hash, as is the case for blobs
+// stored before blob contents were deduplicated, in which case chunks are not reused.
+func (s *EncoderServerV2) getEncodingHash(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	commitment *pbcommon.BlobCommitment,
+	encodingParams encoding.EncodingParams) string {
+
+	if len(commitment.GetCommitment()) == 0 {
+		return ""
+	}
+	contentHash, err := s.blobStore.GetBlobContentHash(ctx, blobKey)
+	if err != nil {
+		return ""
+	}
+
+	params := make([]byte, 4+8+8)
+	binary.BigEndian.PutUint32(params, commitment.GetLength())
+	binary.BigEndian.PutUint64(params[4:], encodingParams.ChunkLength)
+	binary.BigEndian.PutUint64(params[12:], encodingParams.NumChunks)
+
+	hasher := sha256.New()
+	hasher.Write([]byte(contentHash))
+	hasher.Write(commitment.GetCommitment())
+	hasher.Write(params)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// reuseChunks copies the chunks of a previously encoded blob with the same encoding to the blob, and returns false
+// if there is no such blob or its chunks could not be copied.
+func (s *EncoderServerV2) reuseChunks(
+	ctx context.Context,
+	chunkWriter chunkstore.ChunkWriter,
+	blobKey corev2.BlobKey,
+	encodingHash string) (*pb.EncodeBlobReply, bool) {
+
+	source, fragmentInfo, ok := chunkWriter.GetEncoding(ctx, encodingHash)
+	if !ok || source == blobKey {
+		return nil, false
+	}
+
+	copyStart := time.Now()
+	if err := chunkWriter.CopyChunks(ctx, source, blobKey, fragmentInfo); err != nil {
+		// the chunks of the source blob may have expired since they were recorded
+		s.logger.Warn("failed to reuse chunks, encoding instead", "blobKey", blobKey.Hex(), "source", source.Hex(), "error", err)
+		return nil, false
+	}
+	s.metrics.IncrementReusedEncodingNum(int(fragmentInfo.TotalChunkSizeBytes))
+	s.logger.Info("reused chunks", "blobKey", blobKey.Hex(), "source", source.Hex(), "duration", time.Since(copyStart).String())
+
+	return &pb.EncodeBlobReply{
+		FragmentInfo: &pb.FragmentInfo{
+			TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
+			FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+		},
+	}, true
+}
+
+// getBlobSize returns the size of the blob used to schedule the request. The blob data is not part of the request, so
+// the size is taken from the blob commitment. If the request has no commitment, the size of the encoded blob is used
+// as an upper bound.
+func getBlobSize(req *pb.EncodeBlobRequest) int {
+	if length := req.GetBlobCommitment().GetLength(); length > 0 {
+		return int(length) * encoding.BYTES_PER_SYMBOL
+	}
+	params := req.GetEncodingParams()
+	return int(params.GetChunkLength()*params.GetNumChunks()) * encoding.BYTES_PER_SYMBOL
 }
 
 func (s *EncoderServerV2) validateAndParseRequest(req *pb.EncodeBlobRequest) (corev2.BlobKey, encoding.EncodingParams, error) {
@@ -213,19 +375,19 @@ func (s *EncoderServerV2) validateAndParseRequest(req *pb.EncodeBlobRequest) (co
 	return blobKey, params, nil
 }
 
-func (s *EncoderServerV2) processAndStoreResults(ctx context.Context, blobKey corev2.BlobKey, frames []*encoding.Frame) (*pb.EncodeBlobReply, error) {
+func (s *EncoderServerV2) processAndStoreResults(ctx context.Context, chunkWriter chunkstore.ChunkWriter, blobKey corev2.BlobKey, frames []*encoding.Frame) (*pb.EncodeBlobReply, error) {
 	proofs, coeffs := extractProofsAndCoeffs(frames)
 
 	// Store proofs
 	storeStart := time.Now()
-	if err := s.chunkWriter.PutChunkProofs(ctx, blobKey, proofs); err != nil {
+	if err := chunkWriter.PutChunkProofs(ctx, blobKey, proofs); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to upload chunk proofs: %v", err)
 	}
 	s.logger.Info("stored proofs", "duration", time.Since(storeStart).String())
 
 	// Store coefficients
 	coeffStart := time.Now()
-	fragmentInfo, err := s.chunkWriter.PutChunkCoefficients(ctx, blobKey, coeffs)
+	fragmentInfo, err := chunkWriter.PutChunkCoefficients(ctx, blobKey, coeffs)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to upload chunk coefficients: %v", err)
 	}
@@ -239,6 +401,111 @@ func (s *EncoderServerV2) processAndStoreResults(ctx context.Context, blobKey co
 	}, nil
 }
 
+// streamEncodingToChunkStore stores the same chunks as processAndStoreResults, but never holds the coefficients of
+// every frame in memory. Proofs are computed first, and held until the end, since there is only one per frame.
+// Coefficients are then computed in batches of frames, evaluating the blob only over the cosets of the batch, and
+// uploaded in fragments as they are produced. Proofs are stored last, so that a blob whose frames
+// fail self verification is never considered encoded.
+func (s *EncoderServerV2) streamEncodingToChunkStore(
+	ctx context.Context,
+	chunkWriter chunkstore.ChunkWriter,
+	blobKey corev2.BlobKey,
+	encodingParams encoding.EncodingParams,
+	data []byte,
+	headerCommitment *pbcommon.BlobCommitment) (*pb.EncodeBlobReply, error) {
+
+	proofStart := time.Now()
+	multiFrameProofs, err := s.prover.GetMultiFrameProofs(data, encodingParams)
+	if err != nil {
+		s.logger.Error("failed to compute proofs", "error", err)
+		return nil, status.Errorf(codes.Internal, "encoding failed: %v", err)
+	}
+	if uint64(len(multiFrameProofs)) != encodingParams.NumChunks {
+		return nil, status.Errorf(codes.Internal, "expected %d proofs, got %d", encodingParams.NumChunks, len(multiFrameProofs))
+	}
+	// frame i is proven against the bit reversed coset index of i
+	proofs := make([]*encoding.Proof, len(multiFrameProofs))
+	for i := range proofs {
+		proofs[i] = &multiFrameProofs[rb.ReverseBitsLimited(uint32(encodingParams.NumChunks), uint32(i))]
+	}
+	s.logger.Info("computed proofs", "duration", time.Since(proofStart).String())
+
+	// Frames sampled for self verification are collected as their coefficients are produced
+	var (
+		commitments   encoding.BlobCommitments
+		indices       []encoding.ChunkNumber
+		sampledFrames []*encoding.Frame
+	)
+	if s.selfVerificationEnabled() {
+		commitments, err = s.getVerificationCommitments(data, headerCommitment)
+		if err != nil {
+			return nil, err
+		}
+		indices = s.sampleFrameIndices(encodingParams.NumChunks)
+		sampledFrames = make([]*encoding.Frame, 0, len(indices))
+	}
+
+	coeffStart := time.Now()
+	coefficientsWriter, err := chunkWriter.NewCoefficientsWriter(
+		ctx, blobKey, uint32(encodingParams.NumChunks), uint32(encodingParams.ChunkLength))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upload chunk coefficients: %v", err)
+	}
+
+	batchSize := s.config.StreamingEncodingBatchSize
+	if batchSize == 0 {
+		batchSize = encodingParams.NumChunks
+	}
+	framesWritten := 0
+	err = s.prover.StreamFrameCoefficients(data, encodingParams, batchSize, func(coeffs [][]encoding.Symbol) error {
+		frames := make([]*rs.Frame, len(coeffs))
+		for i := range coeffs {
+			frames[i] = &rs.Frame{Coeffs: coeffs[i]}
+		}
+		// indices are sorted, so the sampled frames of this batch are the next ones in the sample
+		for len(sampledFrames) < len(indices) && int(indices[len(sampledFrames)]) < framesWritten+len(coeffs) {
+			index := indices[len(sampledFrames)]
+			sampledFrames = append(sampledFrames, &encoding.Frame{
+				Proof:  *proofs[index],
+				Coeffs: coeffs[int(index)-framesWritten],
+			})
+		}
+		framesWritten += len(coeffs)
+		return coefficientsWriter.WriteFrames(frames)
+	})
+	if err != nil {
+		s.logger.Error("failed to encode and upload frames", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to encode and upload chunk coefficients: %v", err)
+	}
+
+	fragmentInfo, err := coefficientsWriter.Close()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upload chunk coefficients: %v", err)
+	}
+	s.logger.Info("encoded and stored coefficients", "duration", time.Since(coeffStart).String())
+
+	if s.selfVerificationEnabled() {
+		verificationStart := time.Now()
+		if err := s.verifyFrames(sampledFrames, indices, commitments, encodingParams); err != nil {
+			return nil, err
+		}
+		s.logger.Info("verified frames", "numFrames", len(indices), "duration", time.Since(verificationStart).String())
+	}
+
+	storeStart := time.Now()
+	if err := chunkWriter.PutChunkProofs(ctx, blobKey, proofs); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upload chunk proofs: %v", err)
+	}
+	s.logger.Info("stored proofs", "duration", time.Since(storeStart).String())
+
+	return &pb.EncodeBlobReply{
+		FragmentInfo: &pb.FragmentInfo{
+			TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
+			FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+		},
+	}, nil
+}
+
 func extractProofsAndCoeffs(frames []*encoding.Frame) ([]*encoding.Proof, []*rs.Frame) {
 	proofs := make([]*encoding.Proof, len(frames))
 	coeffs := make([]*rs.Frame, len(frames))
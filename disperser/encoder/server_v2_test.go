@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	pbcommon "github.com/Layr-Labs/eigenda/api/grpc/common"
 	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder/v2"
@@ -16,12 +18,15 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var blobParams = &core.BlobVersionParameters{
@@ -32,6 +37,7 @@ var blobParams = &core.BlobVersionParameters{
 
 type testComponents struct {
 	encoderServer    *encoder.EncoderServerV2
+	prover           encoding.Prover
 	blobStore        *blobstore.BlobStore
 	chunkStoreWriter chunkstore.ChunkWriter
 	chunkStoreReader chunkstore.ChunkReader
@@ -56,6 +62,20 @@ func makeTestProver(numPoint uint64) (encoding.Prover, error) {
 	return p, err
 }
 
+func makeTestVerifier() (encoding.Verifier, error) {
+	kzgConfig := &kzg.KzgConfig{
+		G1Path:          "../../inabox/resources/kzg/g1.point.300000",
+		G2Path:          "../../inabox/resources/kzg/g2.point.300000",
+		G2PowerOf2Path:  "../../inabox/resources/kzg/g2.point.300000.powerOf2",
+		CacheDir:        "../../inabox/resources/kzg/SRSTables",
+		SRSOrder:        300000,
+		SRSNumberToLoad: 300000,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+		LoadG2Points:    false,
+	}
+	return verifier.NewVerifier(kzgConfig, nil)
+}
+
 func TestEncodeBlob(t *testing.T) {
 	const (
 		testDataSize   = 16 * 1024
@@ -127,7 +147,8 @@ func TestEncodeBlob(t *testing.T) {
 		},
 	}
 
-	expectedUploadCalls := 1
+	// the blob store uploads the content, a reference to the content, and the reference of the blob to the content
+	expectedUploadCalls := 3
 	expectedFragmentedUploadObjectCalls := 0
 	assert.Equal(t, c.s3Client.Called["UploadObject"], expectedUploadCalls)
 	assert.Equal(t, c.s3Client.Called["FragmentedUploadObject"], expectedFragmentedUploadObjectCalls)
@@ -189,6 +210,227 @@ func TestEncodeBlob(t *testing.T) {
 	})
 }
 
+func TestEncodeBlobStreaming(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	data := make([]byte, 16*1024)
+	_, err := rand.New(rand.NewSource(42)).Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	blobLength := encoding.GetBlobLength(uint(len(data)))
+	chunkLength, err := corev2.GetChunkLength(core.NextPowerOf2(uint32(blobLength)), blobParams)
+	require.NoError(t, err)
+
+	blobKey, err := createTestBlobHeader(t).BlobKey()
+	require.NoError(t, err)
+	req := &pb.EncodeBlobRequest{
+		BlobKey: blobKey[:],
+		EncodingParams: &pb.EncodingParams{
+			ChunkLength: uint64(chunkLength),
+			NumChunks:   uint64(blobParams.NumChunks),
+		},
+	}
+
+	config := defaultTestServerConfig()
+	c := createTestComponentsWithConfig(t, config)
+	config.EnableStreamingEncoding = true
+	config.StreamingEncodingBatchSize = 100
+	streaming := createTestComponentsWithConfig(t, config)
+
+	require.NoError(t, c.blobStore.StoreBlob(ctx, blobKey, data))
+	require.NoError(t, streaming.blobStore.StoreBlob(ctx, blobKey, data))
+
+	expectedResp, err := c.encoderServer.EncodeBlob(ctx, req)
+	require.NoError(t, err)
+	resp, err := streaming.encoderServer.EncodeBlob(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, expectedResp.FragmentInfo.TotalChunkSizeBytes, resp.FragmentInfo.TotalChunkSizeBytes)
+	require.Equal(t, expectedResp.FragmentInfo.FragmentSizeBytes, resp.FragmentInfo.FragmentSizeBytes)
+
+	// coefficients are uploaded fragment by fragment rather than in a single fragmented upload
+	require.Equal(t, 0, streaming.s3Client.Called["FragmentedUploadObject"])
+
+	expectedProofs, err := c.chunkStoreReader.GetChunkProofs(ctx, blobKey)
+	require.NoError(t, err)
+	proofs, err := streaming.chunkStoreReader.GetChunkProofs(ctx, blobKey)
+	require.NoError(t, err)
+	require.Equal(t, expectedProofs, proofs)
+
+	fragmentInfo := &encoding.FragmentInfo{
+		TotalChunkSizeBytes: resp.FragmentInfo.TotalChunkSizeBytes,
+		FragmentSizeBytes:   resp.FragmentInfo.FragmentSizeBytes,
+	}
+	expectedCoefficients, err := c.chunkStoreReader.GetChunkCoefficients(ctx, blobKey, fragmentInfo)
+	require.NoError(t, err)
+	coefficients, err := streaming.chunkStoreReader.GetChunkCoefficients(ctx, blobKey, fragmentInfo)
+	require.NoError(t, err)
+	require.Equal(t, expectedCoefficients, coefficients)
+}
+
+func TestEncodeBlobSelfVerification(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	data := make([]byte, 16*1024)
+	_, err := rand.New(rand.NewSource(42)).Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	blobLength := encoding.GetBlobLength(uint(len(data)))
+	chunkLength, err := corev2.GetChunkLength(core.NextPowerOf2(uint32(blobLength)), blobParams)
+	require.NoError(t, err)
+
+	blobKey, err := createTestBlobHeader(t).BlobKey()
+	require.NoError(t, err)
+
+	for _, streaming := range []bool{false, true} {
+		config := defaultTestServerConfig()
+		config.EnableStreamingEncoding = streaming
+		config.StreamingEncodingBatchSize = 100
+		config.EnableSelfVerification = true
+		config.SelfVerificationSampleSize = 16
+		c := createTestComponentsWithConfig(t, config)
+		require.NoError(t, c.blobStore.StoreBlob(ctx, blobKey, data))
+
+		commitment, err := c.prover.GetCommitment(data)
+		require.NoError(t, err)
+		commitmentBytes, err := commitment.Serialize()
+		require.NoError(t, err)
+
+		// a commitment that does not match the blob fails verification and nothing is stored
+		wrongCommitment, err := (&encoding.G1Commitment{}).Serialize()
+		require.NoError(t, err)
+		_, err = c.encoderServer.EncodeBlob(ctx, &pb.EncodeBlobRequest{
+			BlobKey: blobKey[:],
+			EncodingParams: &pb.EncodingParams{
+				ChunkLength: uint64(chunkLength),
+				NumChunks:   uint64(blobParams.NumChunks),
+			},
+			BlobCommitment: &pbcommon.BlobCommitment{
+				Commitment: wrongCommitment,
+				Length:     uint32(blobLength),
+			},
+		})
+		require.Error(t, err)
+		require.Equal(t, codes.DataLoss, status.Code(err))
+		require.False(t, c.chunkStoreWriter.ProofExists(ctx, blobKey))
+
+		// the matching commitment verifies
+		resp, err := c.encoderServer.EncodeBlob(ctx, &pb.EncodeBlobRequest{
+			BlobKey: blobKey[:],
+			EncodingParams: &pb.EncodingParams{
+				ChunkLength: uint64(chunkLength),
+				NumChunks:   uint64(blobParams.NumChunks),
+			},
+			BlobCommitment: &pbcommon.BlobCommitment{
+				Commitment: commitmentBytes,
+				Length:     uint32(blobLength),
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, c.chunkStoreWriter.ProofExists(ctx, blobKey))
+		require.NotZero(t, resp.FragmentInfo.TotalChunkSizeBytes)
+	}
+}
+
+func TestEncodeBlobReusesChunks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	data := make([]byte, 16*1024)
+	_, err := rand.New(rand.NewSource(42)).Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	blobLength := encoding.GetBlobLength(uint(len(data)))
+	chunkLength, err := corev2.GetChunkLength(core.NextPowerOf2(uint32(blobLength)), blobParams)
+	require.NoError(t, err)
+
+	config := defaultTestServerConfig()
+	config.EnableChunkReuse = true
+	c := createTestComponentsWithConfig(t, config)
+
+	commitment, err := c.prover.GetCommitment(data)
+	require.NoError(t, err)
+	commitmentBytes, err := commitment.Serialize()
+	require.NoError(t, err)
+	newRequest := func(blobKey corev2.BlobKey) *pb.EncodeBlobRequest {
+		return &pb.EncodeBlobRequest{
+			BlobKey: blobKey[:],
+			EncodingParams: &pb.EncodingParams{
+				ChunkLength: uint64(chunkLength),
+				NumChunks:   uint64(blobParams.NumChunks),
+			},
+			BlobCommitment: &pbcommon.BlobCommitment{
+				Commitment: commitmentBytes,
+				Length:     uint32(blobLength),
+			},
+		}
+	}
+
+	// the same payload dispersed twice has two blob keys
+	blobHeader := createTestBlobHeader(t)
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	blobHeader.PaymentMetadata.CumulativePayment = big.NewInt(533)
+	otherBlobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	require.NotEqual(t, blobKey, otherBlobKey)
+	require.NoError(t, c.blobStore.StoreBlob(ctx, blobKey, data))
+	require.NoError(t, c.blobStore.StoreBlob(ctx, otherBlobKey, data))
+
+	expectedResp, err := c.encoderServer.EncodeBlob(ctx, newRequest(blobKey))
+	require.NoError(t, err)
+	require.Equal(t, 1, c.s3Client.Called["FragmentedUploadObject"])
+
+	// the chunks of the first blob are copied by S3 rather than encoded again
+	copies := c.s3Client.Called["CopyObject"]
+	resp, err := c.encoderServer.EncodeBlob(ctx, newRequest(otherBlobKey))
+	require.NoError(t, err)
+	require.Equal(t, 1, c.s3Client.Called["FragmentedUploadObject"])
+	require.Zero(t, c.s3Client.Called["FragmentedDownloadObject"])
+	require.Greater(t, c.s3Client.Called["CopyObject"], copies)
+	require.Equal(t, expectedResp.FragmentInfo.TotalChunkSizeBytes, resp.FragmentInfo.TotalChunkSizeBytes)
+	require.Equal(t, expectedResp.FragmentInfo.FragmentSizeBytes, resp.FragmentInfo.FragmentSizeBytes)
+
+	expectedProofs, err := c.chunkStoreReader.GetChunkProofs(ctx, blobKey)
+	require.NoError(t, err)
+	proofs, err := c.chunkStoreReader.GetChunkProofs(ctx, otherBlobKey)
+	require.NoError(t, err)
+	require.Equal(t, expectedProofs, proofs)
+
+	fragmentInfo := &encoding.FragmentInfo{
+		TotalChunkSizeBytes: resp.FragmentInfo.TotalChunkSizeBytes,
+		FragmentSizeBytes:   resp.FragmentInfo.FragmentSizeBytes,
+	}
+	expectedCoefficients, err := c.chunkStoreReader.GetChunkCoefficients(ctx, blobKey, fragmentInfo)
+	require.NoError(t, err)
+	coefficients, err := c.chunkStoreReader.GetChunkCoefficients(ctx, otherBlobKey, fragmentInfo)
+	require.NoError(t, err)
+	require.Equal(t, expectedCoefficients, coefficients)
+
+	// if the chunks of the first blob are gone, a third blob with the same payload is encoded again
+	for _, prefix := range []string{s3.ScopedProofKey(blobKey), s3.ScopedChunkKey(blobKey)} {
+		objects, err := c.s3Client.ListObjects(ctx, s3BucketName, prefix)
+		require.NoError(t, err)
+		for _, object := range objects {
+			require.NoError(t, c.s3Client.DeleteObject(ctx, s3BucketName, object.Key))
+		}
+	}
+	blobHeader.PaymentMetadata.CumulativePayment = big.NewInt(534)
+	thirdBlobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	require.NoError(t, c.blobStore.StoreBlob(ctx, thirdBlobKey, data))
+	_, err = c.encoderServer.EncodeBlob(ctx, newRequest(thirdBlobKey))
+	require.NoError(t, err)
+	require.Equal(t, 2, c.s3Client.Called["FragmentedUploadObject"])
+	proofs, err = c.chunkStoreReader.GetChunkProofs(ctx, thirdBlobKey)
+	require.NoError(t, err)
+	require.Equal(t, expectedProofs, proofs)
+}
+
 // Helper function to create test blob header
 func createTestBlobHeader(t *testing.T) *corev2.BlobHeader {
 	t.Helper()
@@ -204,8 +446,22 @@ func createTestBlobHeader(t *testing.T) *corev2.BlobHeader {
 	}
 }
 
+func defaultTestServerConfig() encoder.ServerConfig {
+	return encoder.ServerConfig{
+		GrpcPort:              "8080",
+		MaxConcurrentRequests: 10,
+		RequestPoolSize:       5,
+		PreventReencoding:     true,
+	}
+}
+
 // Helper function to initialize encoder
 func createTestComponents(t *testing.T) *testComponents {
+	t.Helper()
+	return createTestComponentsWithConfig(t, defaultTestServerConfig())
+}
+
+func createTestComponentsWithConfig(t *testing.T, config encoder.ServerConfig) *testComponents {
 	t.Helper()
 	prover, err := makeTestProver(300000)
 	require.NoError(t, err, "Failed to create prover")
@@ -215,15 +471,16 @@ func createTestComponents(t *testing.T) *testComponents {
 	blobStore := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
 	chunkStoreWriter := chunkstore.NewChunkWriter(logger, s3Client, s3BucketName, 512*1024)
 	chunkStoreReader := chunkstore.NewChunkReader(logger, s3Client, s3BucketName)
-	encoderServer := encoder.NewEncoderServerV2(encoder.ServerConfig{
-		GrpcPort:              "8080",
-		MaxConcurrentRequests: 10,
-		RequestPoolSize:       5,
-		PreventReencoding:     true,
-	}, blobStore, chunkStoreWriter, logger, prover, metrics)
+	var v encoding.Verifier
+	if config.EnableSelfVerification {
+		v, err = makeTestVerifier()
+		require.NoError(t, err, "Failed to create verifier")
+	}
+	encoderServer := encoder.NewEncoderServerV2(config, blobStore, chunkStoreWriter, logger, prover, v, metrics, nil)
 
 	return &testComponents{
 		encoderServer:    encoderServer,
+		prover:           prover,
 		blobStore:        blobStore,
 		chunkStoreWriter: chunkStoreWriter,
 		chunkStoreReader: chunkStoreReader,
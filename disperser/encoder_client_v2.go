@@ -2,11 +2,41 @@ package disperser
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 )
 
+// ErrEncodingSelfVerificationFailed is returned when the encoder produced chunks that do not verify against the blob
+// commitment. The chunks are not stored, so the request can be retried, ideally on another encoder.
+var ErrEncodingSelfVerificationFailed = errors.New("encoded chunks failed self verification")
+
+// EncodingPriority is a hint used by the encoder to order queued requests. Higher priorities are encoded first.
+type EncodingPriority uint8
+
+const (
+	// OnDemandEncodingPriority is the priority of blobs paid for on demand
+	OnDemandEncodingPriority EncodingPriority = iota
+	// ReservationEncodingPriority is the priority of blobs dispersed under a reservation
+	ReservationEncodingPriority
+)
+
+func (p EncodingPriority) String() string {
+	switch p {
+	case OnDemandEncodingPriority:
+		return "on_demand"
+	case ReservationEncodingPriority:
+		return "reservation"
+	default:
+		return fmt.Sprintf("%d", p)
+	}
+}
+
 type EncoderClientV2 interface {
-	EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error)
+	// EncodeBlob encodes the blob and stores its chunks. If blobCommitments is not nil, the encoder checks its
+	// output against them before storing it. The priority determines the order in which queued requests are encoded.
+	// The chunks are tagged with the retention tier of the blob.
+	EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams, blobCommitments *encoding.BlobCommitments, priority EncodingPriority, retentionTier corev2.RetentionTier) (*encoding.FragmentInfo, error)
 }
@@ -19,7 +19,7 @@ func NewMockEncoderClientV2() *MockEncoderClientV2 {
 	return &MockEncoderClientV2{}
 }
 
-func (m *MockEncoderClientV2) EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error) {
+func (m *MockEncoderClientV2) EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams, blobCommitments *encoding.BlobCommitments, priority disperser.EncodingPriority, retentionTier corev2.RetentionTier) (*encoding.FragmentInfo, error) {
 	args := m.Called()
 	var fragmentInfo *encoding.FragmentInfo
 	if args.Get(0) != nil {
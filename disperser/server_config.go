@@ -1,9 +1,20 @@
 package disperser
 
-import "time"
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+)
 
 const (
 	Localhost = "0.0.0.0"
+
+	DefaultPaymentStateRequestMaxAge             = time.Minute
+	DefaultPaymentStateReplayCacheSize           = 100_000
+	DefaultPaymentStateReplayCacheSizePerAccount = 100
+	// DefaultLegacyPaymentStateAuthWindow is how long after startup GetPaymentState requests signed over the account
+	// ID only are accepted, unless an explicit deadline is configured.
+	DefaultLegacyPaymentStateAuthWindow = 30 * 24 * time.Hour
 )
 
 type ServerConfig struct {
@@ -12,4 +23,24 @@ type ServerConfig struct {
 
 	PprofHttpPort string
 	EnablePprof   bool
+
+	// PaymentStateRequestMaxAge is the maximum difference between the timestamp of a GetPaymentState request and the
+	// time it is received. If 0, DefaultPaymentStateRequestMaxAge is used.
+	PaymentStateRequestMaxAge time.Duration
+	// PaymentStateReplayCacheSize is the maximum number of recent GetPaymentState requests remembered to reject
+	// replays. If 0, DefaultPaymentStateReplayCacheSize is used.
+	PaymentStateReplayCacheSize int
+	// PaymentStateReplayCacheSizePerAccount is the maximum number of recent GetPaymentState requests of a single
+	// account remembered to reject replays. Further requests of the account are rejected until earlier ones expire.
+	// If 0, DefaultPaymentStateReplayCacheSizePerAccount is used.
+	PaymentStateReplayCacheSizePerAccount int
+	// LegacyPaymentStateAuthDeadline is the time until which GetPaymentState requests signed over the account ID
+	// only are accepted. If zero, such requests are rejected.
+	LegacyPaymentStateAuthDeadline time.Time
+
+	// ClientIPHeader is the header that holds the client IP when the server is behind a proxy. Symbol openings are
+	// metered per client IP.
+	ClientIPHeader string
+	// SymbolOpeningLimits bounds the symbols opened by GetBlobSymbolProofs, over all clients and per client.
+	SymbolOpeningLimits ratelimit.SymbolOpeningLimitConfig
 }
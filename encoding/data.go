@@ -2,6 +2,7 @@ package encoding
 
 import (
 	"bytes"
+	"fmt"
 
 	pbcommon "github.com/Layr-Labs/eigenda/api/grpc/common"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -159,6 +160,43 @@ type SubBatch struct {
 
 type ChunkNumber = uint
 
+// SymbolOpening is a kzg proof that a blob polynomial evaluates to Value at the Index-th root of unity of the
+// smallest power-of-2 evaluation domain that contains the blob. When the payload is converted to coefficient form
+// with an IFFT before dispersal, Value is the symbol that sits at offset Index of the payload.
+type SymbolOpening struct {
+	Index uint64
+	Value Symbol
+	Proof Proof
+}
+
+// MaxSymbolOpenings is the maximum number of symbols that can be opened with separate proofs at once. Every separate
+// proof divides the blob polynomial and computes an MSM over the whole blob, so servers should also meter the opened
+// symbols of each client.
+const MaxSymbolOpenings = 128
+
+// MaxBatchedSymbolOpenings is the maximum number of symbols that can be opened with a single batched proof. Computing
+// the batched proof divides the blob polynomial by the vanishing polynomial of the opened points, which takes time
+// proportional to the number of blob symbols times the number of opened symbols.
+const MaxBatchedSymbolOpenings = 16
+
+// CheckSymbolOpeningCount returns an error if more symbols are opened at once than the limits above allow.
+func CheckSymbolOpeningCount(numIndices int, batched bool) error {
+	if batched && numIndices > MaxBatchedSymbolOpenings {
+		return fmt.Errorf("cannot open more than %d symbols with a batched proof", MaxBatchedSymbolOpenings)
+	}
+	if numIndices > MaxSymbolOpenings {
+		return fmt.Errorf("cannot open more than %d symbols at once", MaxSymbolOpenings)
+	}
+	return nil
+}
+
+// MultiSymbolOpening is a single kzg proof that opens a blob polynomial at several symbol indices at once.
+type MultiSymbolOpening struct {
+	Indices []uint64
+	Values  []Symbol
+	Proof   Proof
+}
+
 // FragmentInfo contains metadata about how chunk coefficients file is stored.
 type FragmentInfo struct {
 	// TotalChunkSizeBytes is the total size of the file containing all chunk coefficients for the blob.
@@ -5,6 +5,9 @@ type Decoder interface {
 	Decode(chunks []*Frame, indices []ChunkNumber, params EncodingParams, inputSize uint64) ([]byte, error)
 }
 
+// FrameCoeffsHandler receives the coefficients of consecutive frames, in frame order.
+type FrameCoeffsHandler func(coeffs [][]Symbol) error
+
 type Prover interface {
 	Decoder
 	// Encode takes in a blob and returns the commitments and encoded chunks. The encoding will satisfy the property that
@@ -14,10 +17,28 @@ type Prover interface {
 
 	GetCommitmentsForPaddedLength(data []byte) (BlobCommitments, error)
 
+	// GetCommitment computes only the G1 commitment of the blob. Unlike GetCommitmentsForPaddedLength, it does not
+	// require G2 points to be loaded.
+	GetCommitment(data []byte) (*G1Commitment, error)
+
 	GetFrames(data []byte, params EncodingParams) ([]*Frame, error)
 
 	GetMultiFrameProofs(data []byte, params EncodingParams) ([]Proof, error)
 
+	// StreamFrameCoefficients computes the same frame coefficients as GetFrames, without the proofs. The coefficients
+	// are passed to the handler in batches of at most batchSize frames, in frame order, so that the caller does not
+	// need to hold the coefficients of every frame in memory at once.
+	StreamFrameCoefficients(data []byte, params EncodingParams, batchSize uint64, handler FrameCoeffsHandler) error
+
+	// OpenSymbols computes a separate opening proof for each of the given symbol indices of the blob. The evaluation
+	// domain is the smallest power of 2 that is at least blobLength symbols. At most MaxSymbolOpenings indices can be
+	// opened at once.
+	OpenSymbols(data []byte, blobLength uint64, indices []uint64) ([]SymbolOpening, error)
+
+	// OpenSymbolsBatch computes a single opening proof for all of the given symbol indices of the blob. At most
+	// MaxBatchedSymbolOpenings indices can be opened at once.
+	OpenSymbolsBatch(data []byte, blobLength uint64, indices []uint64) (*MultiSymbolOpening, error)
+
 	GetSRSOrder() uint64
 }
 
@@ -35,4 +56,10 @@ type Verifier interface {
 
 	// VerifyCommitEquivalence takes in a list of commitments and returns an error if the commitment of G1 and G2 are inconsistent
 	VerifyCommitEquivalenceBatch(commitments []BlobCommitments) error
+
+	// VerifySymbolOpenings takes in the commitments and a list of symbol openings and returns an error if any opening is invalid.
+	VerifySymbolOpenings(commitments BlobCommitments, openings []SymbolOpening) error
+
+	// VerifyMultiSymbolOpening takes in the commitments and a batched symbol opening and returns an error if it is invalid.
+	VerifyMultiSymbolOpening(commitments BlobCommitments, opening *MultiSymbolOpening) error
 }
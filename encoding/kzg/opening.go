@@ -0,0 +1,143 @@
+package kzg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/fft"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// SymbolDomain returns the roots of unity of the smallest power-of-2 evaluation domain that holds both
+// numSymbols and blobLength symbols. The i-th root is the point at which the blob polynomial is opened
+// to prove the symbol at index i.
+func SymbolDomain(numSymbols, blobLength uint64) ([]fr.Element, error) {
+	if numSymbols == 0 && blobLength == 0 {
+		return nil, errors.New("blob is empty")
+	}
+
+	size := numSymbols
+	if blobLength > size {
+		size = blobLength
+	}
+	size = encoding.NextPowerOf2(size)
+
+	fs := fft.NewFFTSettings(uint8(math.Log2(float64(size))))
+	return fs.ExpandedRootsOfUnity[:size], nil
+}
+
+// EvaluatePoly evaluates the polynomial with the given coefficients at z using Horner's rule.
+func EvaluatePoly(coeffs []fr.Element, z *fr.Element) fr.Element {
+	var result fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result.Mul(&result, z)
+		result.Add(&result, &coeffs[i])
+	}
+	return result
+}
+
+// DividePolyByLinear computes the quotient q(x) = (p(x) - p(z)) / (x - z) with synthetic division.
+// It returns the quotient in coefficient form together with the evaluation p(z).
+func DividePolyByLinear(coeffs []fr.Element, z *fr.Element) ([]fr.Element, fr.Element) {
+	if len(coeffs) == 0 {
+		return []fr.Element{}, fr.Element{}
+	}
+
+	quotient := make([]fr.Element, len(coeffs)-1)
+	var carry fr.Element
+	for i := len(coeffs) - 1; i >= 1; i-- {
+		carry.Mul(&carry, z)
+		carry.Add(&carry, &coeffs[i])
+		quotient[i-1] = carry
+	}
+
+	var value fr.Element
+	value.Mul(&carry, z)
+	value.Add(&value, &coeffs[0])
+	return quotient, value
+}
+
+// VanishingPoly returns the coefficients of the polynomial prod_i (x - points[i]).
+func VanishingPoly(points []fr.Element) []fr.Element {
+	result := make([]fr.Element, 1, len(points)+1)
+	result[0].SetOne()
+
+	for i := range points {
+		next := make([]fr.Element, len(result)+1)
+		var term fr.Element
+		for j := range result {
+			// multiply by x
+			next[j+1].Add(&next[j+1], &result[j])
+			// multiply by -points[i]
+			term.Mul(&result[j], &points[i])
+			next[j].Sub(&next[j], &term)
+		}
+		result = next
+	}
+
+	return result
+}
+
+// DividePoly divides the dividend by the divisor and returns the quotient and the remainder.
+// The divisor must have a non-zero leading coefficient.
+func DividePoly(dividend, divisor []fr.Element) ([]fr.Element, []fr.Element, error) {
+	if len(divisor) == 0 || divisor[len(divisor)-1].IsZero() {
+		return nil, nil, errors.New("divisor must have a non-zero leading coefficient")
+	}
+
+	remainder := make([]fr.Element, len(dividend))
+	copy(remainder, dividend)
+
+	if len(dividend) < len(divisor) {
+		return []fr.Element{}, remainder, nil
+	}
+
+	var leadInv fr.Element
+	leadInv.Inverse(&divisor[len(divisor)-1])
+
+	quotient := make([]fr.Element, len(dividend)-len(divisor)+1)
+	var term fr.Element
+	for i := len(quotient) - 1; i >= 0; i-- {
+		quotient[i].Mul(&remainder[i+len(divisor)-1], &leadInv)
+		for j := range divisor {
+			term.Mul(&quotient[i], &divisor[j])
+			remainder[i+j].Sub(&remainder[i+j], &term)
+		}
+	}
+
+	return quotient, remainder[:len(divisor)-1], nil
+}
+
+// InterpolatePoly returns the coefficients of the unique polynomial of degree less than len(xs)
+// that passes through the points (xs[i], ys[i]). The xs must be distinct.
+func InterpolatePoly(xs, ys []fr.Element) ([]fr.Element, error) {
+	if len(xs) != len(ys) {
+		return nil, fmt.Errorf("inconsistent number of points: %d xs and %d ys", len(xs), len(ys))
+	}
+
+	result := make([]fr.Element, len(xs))
+	vanishing := VanishingPoly(xs)
+
+	for i := range xs {
+		// basis(x) = prod_{j != i} (x - xs[j]) = vanishing(x) / (x - xs[i])
+		basis, _ := DividePolyByLinear(vanishing, &xs[i])
+
+		denominator := EvaluatePoly(basis, &xs[i])
+		if denominator.IsZero() {
+			return nil, fmt.Errorf("duplicate interpolation point at position %d", i)
+		}
+
+		var scale fr.Element
+		scale.Div(&ys[i], &denominator)
+
+		var term fr.Element
+		for j := range basis {
+			term.Mul(&basis[j], &scale)
+			result[j].Add(&result[j], &term)
+		}
+	}
+
+	return result, nil
+}
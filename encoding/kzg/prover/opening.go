@@ -0,0 +1,154 @@
+package prover
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// OpenSymbols computes a kzg opening proof for each of the requested symbol indices. The blob is interpreted as the
+// coefficients of a polynomial p, and the symbol at index i is p evaluated at the i-th root of unity of the smallest
+// power-of-2 domain that holds blobLength symbols. Each proof opens the commitment to the whole blob.
+func (p *Prover) OpenSymbols(data []byte, blobLength uint64, indices []uint64) ([]encoding.SymbolOpening, error) {
+	if err := encoding.CheckSymbolOpeningCount(len(indices), false); err != nil {
+		return nil, err
+	}
+	coeffs, roots, err := p.prepareOpening(data, blobLength, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	openings := make([]encoding.SymbolOpening, len(indices))
+	errs := make([]error, len(indices))
+
+	numWorker := p.KzgConfig.NumWorker
+	if numWorker == 0 {
+		numWorker = 1
+	}
+	jobs := make(chan int, len(indices))
+	for i := range indices {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for w := uint64(0); w < numWorker; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				z := roots[indices[i]]
+				quotient, value := kzg.DividePolyByLinear(coeffs, &z)
+				proof, err := p.commitG1(quotient)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				openings[i] = encoding.SymbolOpening{
+					Index: indices[i],
+					Value: value,
+					Proof: *proof,
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range errs {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to open symbol %d: %w", indices[i], errs[i])
+		}
+	}
+
+	return openings, nil
+}
+
+// OpenSymbolsBatch computes a single kzg multi-point opening proof for the requested symbol indices.
+// With Z(x) the vanishing polynomial of the opened points, the proof is a commitment to the quotient
+// q(x) = (p(x) - I(x)) / Z(x), where I(x) interpolates the opened values.
+func (p *Prover) OpenSymbolsBatch(data []byte, blobLength uint64, indices []uint64) (*encoding.MultiSymbolOpening, error) {
+	if err := encoding.CheckSymbolOpeningCount(len(indices), true); err != nil {
+		return nil, err
+	}
+	coeffs, roots, err := p.prepareOpening(data, blobLength, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint64]struct{}, len(indices))
+	points := make([]fr.Element, len(indices))
+	values := make([]fr.Element, len(indices))
+	for i, index := range indices {
+		if _, ok := seen[index]; ok {
+			return nil, fmt.Errorf("duplicate symbol index %d", index)
+		}
+		seen[index] = struct{}{}
+
+		points[i] = roots[index]
+		values[i] = kzg.EvaluatePoly(coeffs, &points[i])
+	}
+
+	quotient, _, err := kzg.DividePoly(coeffs, kzg.VanishingPoly(points))
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := p.commitG1(quotient)
+	if err != nil {
+		return nil, err
+	}
+
+	openedIndices := make([]uint64, len(indices))
+	copy(openedIndices, indices)
+
+	return &encoding.MultiSymbolOpening{
+		Indices: openedIndices,
+		Values:  values,
+		Proof:   *proof,
+	}, nil
+}
+
+// prepareOpening converts the blob to field elements and returns them together with the roots of unity of the
+// evaluation domain for the blob.
+func (p *Prover) prepareOpening(data []byte, blobLength uint64, indices []uint64) ([]fr.Element, []fr.Element, error) {
+	if len(indices) == 0 {
+		return nil, nil, errors.New("no symbol indices to open")
+	}
+
+	coeffs, err := rs.ToFrArray(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot convert bytes to field elements, %w", err)
+	}
+
+	roots, err := kzg.SymbolDomain(uint64(len(coeffs)), blobLength)
+	if err != nil {
+		return nil, nil, err
+	}
+	if uint64(len(roots)) > uint64(len(p.Srs.G1)) {
+		return nil, nil, fmt.Errorf("blob domain of %d symbols exceeds the %d loaded G1 points", len(roots), len(p.Srs.G1))
+	}
+
+	for _, index := range indices {
+		if index >= uint64(len(roots)) {
+			return nil, nil, fmt.Errorf("symbol index %d is out of range for a domain of %d symbols", index, len(roots))
+		}
+	}
+
+	return coeffs, roots, nil
+}
+
+func (p *Prover) commitG1(coeffs []fr.Element) (*bn254.G1Affine, error) {
+	var commit bn254.G1Affine
+	if len(coeffs) == 0 {
+		return &commit, nil
+	}
+	_, err := commit.MultiExp(p.Srs.G1[:len(coeffs)], coeffs, ecc.MultiExpConfig{})
+	return &commit, err
+}
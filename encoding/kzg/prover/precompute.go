@@ -2,6 +2,9 @@ package prover
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -18,6 +21,13 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 )
 
+// TableChecksumSize is the size of the sha256 checksum appended to the end of every precomputed table file.
+// Table files written before checksums were introduced have no trailer, and are still accepted.
+const TableChecksumSize = sha256.Size
+
+// ErrTableChecksumMismatch is returned when the content of a precomputed table file does not match its checksum.
+var ErrTableChecksumMismatch = errors.New("precomputed table checksum mismatch")
+
 type SubTable struct {
 	FilePath string
 }
@@ -51,24 +61,15 @@ func NewSRSTable(tableDir string, s1 []bn254.G1Affine, numWorker uint64) (*SRSTa
 	tables := make(map[TableParam]SubTable)
 	for _, file := range files {
 		filename := file.Name()
+		if isTempTableFile(filename) {
+			continue
+		}
 
-		tokens := strings.Split(filename, ".")
-
-		dimEValue, err := strconv.Atoi(tokens[0][4:])
+		param, err := ParseTableFileName(filename)
 		if err != nil {
 			log.Println("NEWSRSTABLE.ERR.3", err)
 			return nil, err
 		}
-		cosetSizeValue, err := strconv.Atoi(tokens[1][5:])
-		if err != nil {
-			log.Println("NEWSRSTABLE.ERR.4", err)
-			return nil, err
-		}
-
-		param := TableParam{
-			DimE:      uint64(dimEValue),
-			CosetSize: uint64(cosetSizeValue),
-		}
 
 		filePath := path.Join(tableDir, filename)
 		tables[param] = SubTable{FilePath: filePath}
@@ -82,6 +83,45 @@ func NewSRSTable(tableDir string, s1 []bn254.G1Affine, numWorker uint64) (*SRSTa
 	}, nil
 }
 
+// TableFileName returns the name of the file that stores the table with the given parameters.
+func TableFileName(param TableParam) string {
+	return fmt.Sprintf("dimE%v.coset%v", param.DimE, param.CosetSize)
+}
+
+// ParseTableFileName parses a table file name of the form dimE*.coset&, where * is the number of chunks
+// and & is the length of each chunk.
+func ParseTableFileName(filename string) (TableParam, error) {
+	tokens := strings.Split(filename, ".")
+	if len(tokens) != 2 || !strings.HasPrefix(tokens[0], "dimE") || !strings.HasPrefix(tokens[1], "coset") {
+		return TableParam{}, fmt.Errorf("invalid table file name %v", filename)
+	}
+
+	dimEValue, err := strconv.ParseUint(tokens[0][4:], 10, 64)
+	if err != nil {
+		return TableParam{}, fmt.Errorf("failed to parse dimension of table %v: %w", filename, err)
+	}
+	cosetSizeValue, err := strconv.ParseUint(tokens[1][5:], 10, 64)
+	if err != nil {
+		return TableParam{}, fmt.Errorf("failed to parse coset size of table %v: %w", filename, err)
+	}
+
+	return TableParam{
+		DimE:      dimEValue,
+		CosetSize: cosetSizeValue,
+	}, nil
+}
+
+// isTempTableFile reports whether the file is a temporary file left behind by an interrupted table write.
+func isTempTableFile(filename string) bool {
+	return strings.HasPrefix(filename, ".")
+}
+
+// TableSize returns the size in bytes of the table content, excluding the checksum.
+func TableSize(param TableParam) uint64 {
+	// 2 due to circular FFT  mul, and one delimiter per sub table
+	return (param.DimE*2*kzg.G1PointBytes + 1) * param.CosetSize
+}
+
 func (p *SRSTable) GetSubTables(
 	numChunks uint64,
 	chunkLen uint64,
@@ -98,40 +138,105 @@ func (p *SRSTable) GetSubTables(
 
 	start := time.Now()
 	table, ok := p.Tables[param]
-	if !ok {
-		log.Printf("Table with params: DimE=%v CosetSize=%v does not exist\n", dimE, cosetSize)
-		log.Printf("Generating the table. May take a while\n")
-		log.Printf("... ...\n")
-		filename := fmt.Sprintf("dimE%v.coset%v", dimE, cosetSize)
-		dstFilePath := path.Join(p.TableDir, filename)
-		fftPoints := p.Precompute(dim, dimE, cosetSize, m, dstFilePath, p.NumWorker)
+	if ok {
+		log.Printf("Detected Precomputed FFT sliced G1 table\n")
+		fftPoints, err := p.TableReaderThreads(table.FilePath, dimE, cosetSize, p.NumWorker)
+		if err == nil {
+			elapsed := time.Since(start)
+			log.Printf("    Loading Table uses %v\n", elapsed)
 
-		elapsed := time.Since(start)
-		log.Printf("    Precompute finishes using %v\n", elapsed)
+			return fftPoints, nil
+		}
 
-		return fftPoints, nil
+		// a corrupted cache is regenerated rather than used
+		log.Println("GetSubTables.ERR.0", err)
+		log.Printf("Rejecting precomputed table %v\n", table.FilePath)
 	} else {
-		log.Printf("Detected Precomputed FFT sliced G1 table\n")
-		fftPoints, err := p.TableReaderThreads(table.FilePath, dimE, cosetSize, p.NumWorker)
-		if err != nil {
-			log.Println("GetSubTables.ERR.0", err)
-			return nil, err
+		log.Printf("Table with params: DimE=%v CosetSize=%v does not exist\n", dimE, cosetSize)
+	}
+
+	log.Printf("Generating the table. May take a while\n")
+	log.Printf("... ...\n")
+	dstFilePath := path.Join(p.TableDir, TableFileName(param))
+	fftPoints, err := p.Precompute(dim, dimE, cosetSize, m, dstFilePath, p.NumWorker)
+	if err != nil {
+		return nil, err
+	}
+	p.Tables[param] = SubTable{FilePath: dstFilePath}
+
+	elapsed := time.Since(start)
+	log.Printf("    Precompute finishes using %v\n", elapsed)
+
+	return fftPoints, nil
+}
+
+// VerifyTable loads the table with the given parameters and recomputes the sub tables at the given indices from the
+// G1 points, returning an error if the table is corrupted or was generated from a different SRS. If rows is nil,
+// every sub table is recomputed.
+func (p *SRSTable) VerifyTable(numChunks uint64, chunkLen uint64, rows []uint64) error {
+	cosetSize := chunkLen
+	dimE := numChunks
+	m := numChunks*chunkLen - 1
+	dim := m / cosetSize
+
+	param := TableParam{
+		DimE:      dimE,
+		CosetSize: cosetSize,
+	}
+	table, ok := p.Tables[param]
+	if !ok {
+		return fmt.Errorf("table with params: DimE=%v CosetSize=%v does not exist", dimE, cosetSize)
+	}
+	if uint64(len(p.s1)) <= m {
+		return fmt.Errorf("%v G1 points are loaded, but %v are required to verify the table", len(p.s1), m+1)
+	}
+
+	fftPoints, err := p.TableReaderThreads(table.FilePath, dimE, cosetSize, p.NumWorker)
+	if err != nil {
+		return err
+	}
+
+	if rows == nil {
+		rows = make([]uint64, cosetSize)
+		for j := range rows {
+			rows[j] = uint64(j)
 		}
+	}
 
-		elapsed := time.Since(start)
-		log.Printf("    Loading Table uses %v\n", elapsed)
+	order := dimE * cosetSize
+	if cosetSize == 1 {
+		order = dimE * 2
+	}
+	fs := fft.NewFFTSettings(uint8(math.Log2(float64(order))))
 
-		return fftPoints, nil
+	for _, j := range rows {
+		if j >= cosetSize {
+			return fmt.Errorf("sub table %v is out of range for coset size %v", j, cosetSize)
+		}
+		dr, err := p.PrecomputeSubTable(fs, m, dim, dimE, j, cosetSize)
+		if err != nil {
+			return err
+		}
+		for i := range dr.points {
+			if !dr.points[i].Equal(&fftPoints[j][i]) {
+				return fmt.Errorf("table %v: sub table %v does not match the SRS", table.FilePath, j)
+			}
+		}
 	}
+
+	return nil
 }
 
 type DispatchReturn struct {
 	points []bn254.G1Affine
 	j      uint64
+	err    error
 }
 
+// Precompute computes the table and writes it to filePath. It returns an error if a sub table cannot be computed or
+// the table cannot be written.
 // m = len(poly) - 1, which is deg
-func (p *SRSTable) Precompute(dim, dimE, l, m uint64, filePath string, numWorker uint64) [][]bn254.G1Affine {
+func (p *SRSTable) Precompute(dim, dimE, l, m uint64, filePath string, numWorker uint64) ([][]bn254.G1Affine, error) {
 	order := dimE * l
 	if l == 1 {
 		order = dimE * 2
@@ -156,16 +261,23 @@ func (p *SRSTable) Precompute(dim, dimE, l, m uint64, filePath string, numWorker
 	}
 	close(jobChan)
 
+	var err error
 	for w := uint64(0); w < l; w++ {
 		computeResult := <-results
+		if computeResult.err != nil {
+			err = computeResult.err
+			continue
+		}
 		fftPoints[computeResult.j] = computeResult.points
 	}
-
-	err := p.TableWriter(fftPoints, dimE, filePath)
 	if err != nil {
-		log.Println("Precompute error:", err)
+		return nil, fmt.Errorf("failed to compute table %v: %w", filePath, err)
+	}
+
+	if err := p.TableWriter(fftPoints, dimE, filePath); err != nil {
+		return nil, fmt.Errorf("failed to write table %v: %w", filePath, err)
 	}
-	return fftPoints
+	return fftPoints, nil
 }
 
 func (p *SRSTable) precomputeWorker(fs *fft.FFTSettings, m, dim, dimE uint64, jobChan <-chan uint64, l uint64, results chan DispatchReturn) {
@@ -173,7 +285,8 @@ func (p *SRSTable) precomputeWorker(fs *fft.FFTSettings, m, dim, dimE uint64, jo
 		dr, err := p.PrecomputeSubTable(fs, m, dim, dimE, j, l)
 		if err != nil {
 			log.Println("precomputeWorker.ERR.1", err)
-			return
+			results <- DispatchReturn{j: j, err: err}
+			continue
 		}
 		results <- dr
 	}
@@ -233,6 +346,15 @@ func (p *SRSTable) TableReaderThreads(filePath string, dimE, l uint64, numWorker
 		return nil, err
 	}
 
+	trailer, err := io.ReadAll(reader)
+	if err != nil {
+		log.Println("TableReaderThreads.ERR.2", err, "file path:", filePath)
+		return nil, err
+	}
+	if err := verifyTableChecksum(buf, trailer); err != nil {
+		return nil, fmt.Errorf("table %v: %w", filePath, err)
+	}
+
 	boundaries := make([]Boundary, l)
 	for i := uint64(0); i < uint64(l); i++ {
 		start := (subTableSize + 1) * i
@@ -246,13 +368,14 @@ func (p *SRSTable) TableReaderThreads(filePath string, dimE, l uint64, numWorker
 	}
 
 	fftPoints := make([][]bn254.G1Affine, l)
+	errs := make([]error, numWorker)
 
 	jobChan := make(chan Boundary, l)
 
 	var wg sync.WaitGroup
 	wg.Add(int(numWorker))
 	for i := uint64(0); i < numWorker; i++ {
-		go p.readWorker(buf, fftPoints, jobChan, dimE, &wg)
+		go p.readWorker(buf, fftPoints, jobChan, dimE, &wg, &errs[i])
 	}
 
 	for i := uint64(0); i < l; i++ {
@@ -265,6 +388,12 @@ func (p *SRSTable) TableReaderThreads(filePath string, dimE, l uint64, numWorker
 		return nil, err
 	}
 
+	for _, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("table %v: %w", filePath, err)
+		}
+	}
+
 	return fftPoints, nil
 }
 
@@ -274,8 +403,14 @@ func (p *SRSTable) readWorker(
 	jobChan <-chan Boundary,
 	dimE uint64,
 	wg *sync.WaitGroup,
+	workerErr *error,
 ) {
+	defer wg.Done()
 	for b := range jobChan {
+		// keep draining the channel after an error so that the dispatcher is never blocked
+		if *workerErr != nil {
+			continue
+		}
 		slicePoints := make([]bn254.G1Affine, dimE*2)
 		for i := uint64(0); i < dimE*2; i++ {
 			g1 := buf[b.start+i*kzg.G1PointBytes : b.start+(i+1)*kzg.G1PointBytes]
@@ -284,22 +419,84 @@ func (p *SRSTable) readWorker(
 				log.Printf("Error. From %v to %v. %v", b.start, b.end, err)
 				log.Println()
 				log.Println("readWorker.ERR.0", err)
-				return
+				*workerErr = err
+				break
 			}
 		}
 		fftPoints[b.sliceAt] = slicePoints
 	}
-	wg.Done()
 }
 
+// verifyTableChecksum checks the content of a table against the trailer that follows it in the table file.
+// An empty trailer is accepted, since table files created before checksums were introduced have none.
+func verifyTableChecksum(content []byte, trailer []byte) error {
+	if len(trailer) == 0 {
+		log.Printf("Precomputed table has no checksum\n")
+		return nil
+	}
+	if len(trailer) != TableChecksumSize {
+		return fmt.Errorf("%w: unexpected %v trailing bytes", ErrTableChecksumMismatch, len(trailer))
+	}
+
+	checksum := sha256.Sum256(content)
+	if !bytes.Equal(checksum[:], trailer) {
+		return ErrTableChecksumMismatch
+	}
+	return nil
+}
+
+// TableHasChecksum reports whether the table file ends with a checksum trailer.
+func TableHasChecksum(filePath string, param TableParam) (bool, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	switch uint64(info.Size()) {
+	case TableSize(param):
+		return false, nil
+	case TableSize(param) + TableChecksumSize:
+		return true, nil
+	default:
+		return false, fmt.Errorf("table %v has unexpected size %v", filePath, info.Size())
+	}
+}
+
+// AppendTableChecksum adds a checksum trailer to a table file that was written without one.
+func AppendTableChecksum(filePath string, param TableParam) error {
+	hasChecksum, err := TableHasChecksum(filePath, param)
+	if err != nil {
+		return err
+	}
+	if hasChecksum {
+		return nil
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	checksum := sha256.Sum256(content)
+
+	return writeFileAtomically(filePath, append(content, checksum[:]...))
+}
+
+// TableWriter writes the table followed by its checksum. The table is written to a temporary file first and then
+// renamed, so that an interrupted write never leaves a partial table in the cache directory.
 func (p *SRSTable) TableWriter(fftPoints [][]bn254.G1Affine, dimE uint64, filePath string) error {
-	wf, err := os.Create(filePath)
+	wf, err := os.CreateTemp(path.Dir(filePath), "."+path.Base(filePath)+".tmp*")
 	if err != nil {
 		log.Println("TableWriter.ERR.0", err)
 		return err
 	}
-
-	writer := bufio.NewWriter(wf)
+	tmpPath := wf.Name()
+	defer func() {
+		// no-op once the file has been renamed
+		_ = os.Remove(tmpPath)
+	}()
+
+	hash := sha256.New()
+	writer := bufio.NewWriter(io.MultiWriter(wf, hash))
 	l := uint64(len(fftPoints))
 
 	delimiter := [1]byte{'\n'}
@@ -325,7 +522,35 @@ func (p *SRSTable) TableWriter(fftPoints [][]bn254.G1Affine, dimE uint64, filePa
 		return err
 	}
 
-	err = wf.Close()
+	if _, err := wf.Write(hash.Sum(nil)); err != nil {
+		log.Println("TableWriter.ERR.5", err)
+		return err
+	}
+
+	if err = wf.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, filePath)
+}
+
+func writeFileAtomically(filePath string, data []byte) error {
+	wf, err := os.CreateTemp(path.Dir(filePath), "."+path.Base(filePath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmpPath := wf.Name()
+	defer func() {
+		_ = os.Remove(tmpPath)
+	}()
+
+	if _, err := wf.Write(data); err != nil {
+		_ = wf.Close()
+		return err
+	}
+	if err := wf.Close(); err != nil {
+		return err
+	}
 
-	return err
+	return os.Rename(tmpPath, filePath)
 }
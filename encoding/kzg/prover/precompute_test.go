@@ -1,6 +1,8 @@
 package prover_test
 
 import (
+	"os"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -43,3 +45,64 @@ func TestNewSRSTable_PreComputeWorks(t *testing.T) {
 	// Result of non precomputed GetSubTables should equal precomputed GetSubTables
 	assert.Equal(t, fftPoints1, fftPoints2)
 }
+
+func TestNewSRSTable_CorruptedTableIsRegenerated(t *testing.T) {
+
+	kzgConfig.CacheDir = t.TempDir()
+	params := encoding.ParamsFromSysPar(numSys, numPar, uint64(len(gettysburgAddressBytes)))
+
+	s1, err := kzg.ReadG1Points(kzgConfig.G1Path, kzgConfig.SRSOrder, kzgConfig.NumWorker)
+	require.Nil(t, err)
+
+	subTable1, err := prover.NewSRSTable(kzgConfig.CacheDir, s1, kzgConfig.NumWorker)
+	require.Nil(t, err)
+	fftPoints1, err := subTable1.GetSubTables(params.NumChunks, params.ChunkLength)
+	require.Nil(t, err)
+	require.Nil(t, subTable1.VerifyTable(params.NumChunks, params.ChunkLength, nil))
+
+	param := prover.TableParam{DimE: params.NumChunks, CosetSize: params.ChunkLength}
+	filePath := path.Join(kzgConfig.CacheDir, prover.TableFileName(param))
+	hasChecksum, err := prover.TableHasChecksum(filePath, param)
+	require.Nil(t, err)
+	require.True(t, hasChecksum)
+
+	// flip a byte of the first point
+	content, err := os.ReadFile(filePath)
+	require.Nil(t, err)
+	content[1] ^= 0xff
+	require.Nil(t, os.WriteFile(filePath, content, 0644))
+
+	subTable2, err := prover.NewSRSTable(kzgConfig.CacheDir, s1, kzgConfig.NumWorker)
+	require.Nil(t, err)
+	_, err = subTable2.TableReaderThreads(filePath, param.DimE, param.CosetSize, kzgConfig.NumWorker)
+	require.ErrorIs(t, err, prover.ErrTableChecksumMismatch)
+	require.NotNil(t, subTable2.VerifyTable(params.NumChunks, params.ChunkLength, []uint64{0}))
+
+	fftPoints2, err := subTable2.GetSubTables(params.NumChunks, params.ChunkLength)
+	require.Nil(t, err)
+	assert.Equal(t, fftPoints1, fftPoints2)
+	require.Nil(t, subTable2.VerifyTable(params.NumChunks, params.ChunkLength, nil))
+}
+
+func TestNewSRSTable_WriteFailureIsReturned(t *testing.T) {
+
+	kzgConfig.CacheDir = t.TempDir()
+	params := encoding.ParamsFromSysPar(numSys, numPar, uint64(len(gettysburgAddressBytes)))
+
+	s1, err := kzg.ReadG1Points(kzgConfig.G1Path, kzgConfig.SRSOrder, kzgConfig.NumWorker)
+	require.Nil(t, err)
+
+	subTable, err := prover.NewSRSTable(kzgConfig.CacheDir, s1, kzgConfig.NumWorker)
+	require.Nil(t, err)
+
+	// the table can no longer be written once the cache directory is gone
+	require.Nil(t, os.RemoveAll(kzgConfig.CacheDir))
+
+	fftPoints, err := subTable.GetSubTables(params.NumChunks, params.ChunkLength)
+	require.NotNil(t, err)
+	require.Nil(t, fftPoints)
+
+	param := prover.TableParam{DimE: params.NumChunks, CosetSize: params.ChunkLength}
+	_, ok := subTable.Tables[param]
+	require.False(t, ok)
+}
@@ -8,8 +8,6 @@ import (
 	"math"
 	"os"
 	"runtime"
-	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -212,6 +210,30 @@ func (e *Prover) GetFrames(data []byte, params encoding.EncodingParams) ([]*enco
 	return chunks, nil
 }
 
+func (e *Prover) StreamFrameCoefficients(data []byte, params encoding.EncodingParams, batchSize uint64, handler encoding.FrameCoeffsHandler) error {
+	symbols, err := rs.ToFrArray(data)
+	if err != nil {
+		return err
+	}
+
+	enc, err := e.GetKzgEncoder(params)
+	if err != nil {
+		return err
+	}
+
+	if err := enc.validateInput(symbols); err != nil {
+		return err
+	}
+
+	return enc.Encoder.EncodeStream(symbols, params, batchSize, func(frames []rs.Frame) error {
+		coeffs := make([][]encoding.Symbol, len(frames))
+		for i := range frames {
+			coeffs[i] = frames[i].Coeffs
+		}
+		return handler(coeffs)
+	})
+}
+
 func (e *Prover) GetCommitmentsForPaddedLength(data []byte) (encoding.BlobCommitments, error) {
 	symbols, err := rs.ToFrArray(data)
 	if err != nil {
@@ -245,6 +267,35 @@ func (e *Prover) GetCommitmentsForPaddedLength(data []byte) (encoding.BlobCommit
 	return commitments, nil
 }
 
+func (e *Prover) GetCommitment(data []byte) (*encoding.G1Commitment, error) {
+	symbols, err := rs.ToFrArray(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the commitment does not depend on the encoding params
+	params := encoding.EncodingParams{
+		NumChunks:   2,
+		ChunkLength: 2,
+	}
+
+	enc, err := e.GetKzgEncoder(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := enc.validateInput(symbols); err != nil {
+		return nil, err
+	}
+
+	commit, err := enc.KzgCommitmentsBackend.ComputeCommitment(symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*encoding.G1Commitment)(commit), nil
+}
+
 func (e *Prover) GetMultiFrameProofs(data []byte, params encoding.EncodingParams) ([]encoding.Proof, error) {
 	symbols, err := rs.ToFrArray(data)
 	if err != nil {
@@ -302,23 +353,19 @@ func GetAllPrecomputedSrsMap(tableDir string) ([]encoding.EncodingParams, error)
 	tables := make([]encoding.EncodingParams, 0)
 	for _, file := range files {
 		filename := file.Name()
-
-		tokens := strings.Split(filename, ".")
-
-		dimEValue, err := strconv.Atoi(tokens[0][4:])
-		if err != nil {
-			log.Println("Error to parse Dimension part of the Table", err)
-			return nil, err
+		if isTempTableFile(filename) {
+			continue
 		}
-		cosetSizeValue, err := strconv.Atoi(tokens[1][5:])
+
+		param, err := ParseTableFileName(filename)
 		if err != nil {
-			log.Println("Error to parse Coset size of the Table", err)
+			log.Println("Error to parse SRS Table file name", err)
 			return nil, err
 		}
 
 		params := encoding.EncodingParams{
-			NumChunks:   uint64(cosetSizeValue),
-			ChunkLength: uint64(dimEValue),
+			NumChunks:   param.CosetSize,
+			ChunkLength: param.DimE,
 		}
 		tables = append(tables, params)
 	}
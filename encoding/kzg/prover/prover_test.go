@@ -13,6 +13,7 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	rb "github.com/Layr-Labs/eigenda/encoding/utils/reverseBits"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -115,6 +116,50 @@ func TestEncoder(t *testing.T) {
 	assert.Equal(t, gettysburgAddressBytes, decoded)
 }
 
+func TestGetCommitment(t *testing.T) {
+	p, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+
+	commitments, _, err := p.EncodeAndProve(gettysburgAddressBytes, encoding.ParamsFromMins(5, 5))
+	require.NoError(t, err)
+
+	commitment, err := p.GetCommitment(gettysburgAddressBytes)
+	require.NoError(t, err)
+	require.Equal(t, commitments.Commitment, commitment)
+}
+
+func TestStreamFrameCoefficients(t *testing.T) {
+	p, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+
+	params := encoding.ParamsFromMins(5, 5)
+	frames, err := p.GetFrames(gettysburgAddressBytes, params)
+	require.NoError(t, err)
+
+	for _, batchSize := range []uint64{1, 3, params.NumChunks, params.NumChunks + 1} {
+		coeffs := make([][]encoding.Symbol, 0, len(frames))
+		err = p.StreamFrameCoefficients(gettysburgAddressBytes, params, batchSize, func(batch [][]encoding.Symbol) error {
+			assert.LessOrEqual(t, uint64(len(batch)), batchSize)
+			coeffs = append(coeffs, batch...)
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, len(frames), len(coeffs))
+		for i := range frames {
+			assert.Equal(t, frames[i].Coeffs, coeffs[i])
+		}
+	}
+
+	// frame i is proven against the bit reversed coset index of i
+	proofs, err := p.GetMultiFrameProofs(gettysburgAddressBytes, params)
+	require.NoError(t, err)
+	for i := range frames {
+		j := rb.ReverseBitsLimited(uint32(params.NumChunks), uint32(i))
+		assert.Equal(t, frames[i].Proof, proofs[j])
+	}
+}
+
 // Ballpark number for 400KiB blob encoding
 //
 // goos: darwin
@@ -0,0 +1,154 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// VerifySymbolOpenings verifies that each opening proves the value of the blob polynomial at the root of unity of
+// its symbol index, for the evaluation domain implied by commitments.Length.
+//
+// For an opening of value y at point z with proof pi, it checks
+// e([commitment - y]_1, [1]_2) = e(pi, [s - z]_2)
+func (v *Verifier) VerifySymbolOpenings(commitments encoding.BlobCommitments, openings []encoding.SymbolOpening) error {
+	if commitments.Commitment == nil {
+		return errors.New("commitment is nil")
+	}
+	if len(openings) == 0 {
+		return nil
+	}
+
+	roots, err := kzg.SymbolDomain(0, uint64(commitments.Length))
+	if err != nil {
+		return err
+	}
+
+	g2Powers, err := v.g2PowersOfTau(1)
+	if err != nil {
+		return err
+	}
+	g2Tau := g2Powers[1]
+
+	commitment := (*bn254.G1Affine)(commitments.Commitment)
+	for _, opening := range openings {
+		if opening.Index >= uint64(len(roots)) {
+			return fmt.Errorf("symbol index %d is out of range for a domain of %d symbols", opening.Index, len(roots))
+		}
+
+		var valueBig, zBig big.Int
+
+		// [commitment - y]_1
+		var valueG1, commitMinusValue bn254.G1Affine
+		valueG1.ScalarMultiplication(&kzg.GenG1, opening.Value.BigInt(&valueBig))
+		commitMinusValue.Sub(commitment, &valueG1)
+
+		// [s - z]_2
+		var zG2, tauMinusZ bn254.G2Affine
+		zG2.ScalarMultiplication(&kzg.GenG2, roots[opening.Index].BigInt(&zBig))
+		tauMinusZ.Sub(&g2Tau, &zG2)
+
+		proof := opening.Proof
+		if err := PairingsVerify(&commitMinusValue, &kzg.GenG2, &proof, &tauMinusZ); err != nil {
+			return fmt.Errorf("invalid opening for symbol %d: %w", opening.Index, err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyMultiSymbolOpening verifies a batched opening of the blob polynomial at several symbol indices.
+//
+// With I(x) interpolating the opened values and Z(x) vanishing on the opened points, it checks
+// e([commitment - I(s)]_1, [1]_2) = e(pi, [Z(s)]_2)
+func (v *Verifier) VerifyMultiSymbolOpening(commitments encoding.BlobCommitments, opening *encoding.MultiSymbolOpening) error {
+	if commitments.Commitment == nil {
+		return errors.New("commitment is nil")
+	}
+	if opening == nil || len(opening.Indices) == 0 {
+		return errors.New("opening is empty")
+	}
+	if len(opening.Indices) != len(opening.Values) {
+		return fmt.Errorf("inconsistent opening: %d indices and %d values", len(opening.Indices), len(opening.Values))
+	}
+
+	roots, err := kzg.SymbolDomain(0, uint64(commitments.Length))
+	if err != nil {
+		return err
+	}
+
+	points := make([]fr.Element, len(opening.Indices))
+	for i, index := range opening.Indices {
+		if index >= uint64(len(roots)) {
+			return fmt.Errorf("symbol index %d is out of range for a domain of %d symbols", index, len(roots))
+		}
+		points[i] = roots[index]
+	}
+
+	interpolation, err := kzg.InterpolatePoly(points, opening.Values)
+	if err != nil {
+		return err
+	}
+	vanishing := kzg.VanishingPoly(points)
+
+	if len(interpolation) > len(v.Srs.G1) {
+		return fmt.Errorf("opening of %d symbols exceeds the %d loaded G1 points", len(interpolation), len(v.Srs.G1))
+	}
+	g2Powers, err := v.g2PowersOfTau(uint64(len(vanishing) - 1))
+	if err != nil {
+		return err
+	}
+
+	config := ecc.MultiExpConfig{}
+
+	// [commitment - I(s)]_1
+	var interpolationG1, commitMinusInterpolation bn254.G1Affine
+	if _, err := interpolationG1.MultiExp(v.Srs.G1[:len(interpolation)], interpolation, config); err != nil {
+		return err
+	}
+	commitMinusInterpolation.Sub((*bn254.G1Affine)(commitments.Commitment), &interpolationG1)
+
+	// [Z(s)]_2
+	var vanishingG2 bn254.G2Affine
+	if _, err := vanishingG2.MultiExp(g2Powers[:len(vanishing)], vanishing, config); err != nil {
+		return err
+	}
+
+	proof := opening.Proof
+	if err := PairingsVerify(&commitMinusInterpolation, &kzg.GenG2, &proof, &vanishingG2); err != nil {
+		return fmt.Errorf("invalid multi symbol opening: %w", err)
+	}
+
+	return nil
+}
+
+// g2PowersOfTau returns [1]_2, [s]_2, ..., [s^degree]_2, preferring the points that are already loaded in memory.
+func (v *Verifier) g2PowersOfTau(degree uint64) ([]bn254.G2Affine, error) {
+	if uint64(len(v.Srs.G2)) > degree {
+		return v.Srs.G2[:degree+1], nil
+	}
+
+	if len(v.kzgConfig.G2Path) != 0 {
+		if degree >= v.kzgConfig.SRSOrder {
+			return nil, fmt.Errorf("requested power %v is larger than SRSOrder %v", degree, v.kzgConfig.SRSOrder)
+		}
+		return kzg.ReadG2PointSection(v.kzgConfig.G2Path, 0, degree+1, v.kzgConfig.NumWorker)
+	}
+
+	// [s]_2 is the first entry of the power of 2 file, which is enough for single point openings
+	if degree == 1 && len(v.kzgConfig.G2PowerOf2Path) != 0 {
+		g2Tau, err := kzg.ReadG2PointOnPowerOf2(0, v.kzgConfig.SRSOrder, v.kzgConfig.G2PowerOf2Path)
+		if err != nil {
+			return nil, err
+		}
+		return []bn254.G2Affine{kzg.GenG2, g2Tau}, nil
+	}
+
+	return nil, fmt.Errorf("verifying openings of degree %d requires G2 points, but G2Path is not configured", degree)
+}
@@ -0,0 +1,90 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySymbolOpenings(t *testing.T) {
+	p, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+
+	v, err := verifier.NewVerifier(kzgConfig, nil)
+	require.NoError(t, err)
+
+	// the payload is converted to coefficient form so that its symbols are the evaluations of the blob polynomial
+	payload, err := codecs.IFFT(gettysburgAddressBytes)
+	require.NoError(t, err)
+	payloadFr, err := rs.ToFrArray(gettysburgAddressBytes)
+	require.NoError(t, err)
+
+	commitments, err := p.GetCommitmentsForPaddedLength(payload)
+	require.NoError(t, err)
+
+	indices := []uint64{0, 1, 7, uint64(len(payloadFr) - 1)}
+	openings, err := p.OpenSymbols(payload, uint64(commitments.Length), indices)
+	require.NoError(t, err)
+	require.Len(t, openings, len(indices))
+	for i, opening := range openings {
+		require.Equal(t, indices[i], opening.Index)
+		require.Equal(t, payloadFr[indices[i]], opening.Value)
+	}
+
+	err = v.VerifySymbolOpenings(commitments, openings)
+	require.NoError(t, err)
+
+	// tampering with the value must fail verification
+	openings[2].Value.SetUint64(42)
+	err = v.VerifySymbolOpenings(commitments, openings)
+	require.Error(t, err)
+
+	_, err = p.OpenSymbols(payload, uint64(commitments.Length), []uint64{uint64(commitments.Length)})
+	require.Error(t, err)
+}
+
+func TestVerifyMultiSymbolOpening(t *testing.T) {
+	p, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+
+	v, err := verifier.NewVerifier(kzgConfig, nil)
+	require.NoError(t, err)
+
+	payload, err := codecs.IFFT(gettysburgAddressBytes)
+	require.NoError(t, err)
+	payloadFr, err := rs.ToFrArray(gettysburgAddressBytes)
+	require.NoError(t, err)
+
+	commitments, err := p.GetCommitmentsForPaddedLength(payload)
+	require.NoError(t, err)
+
+	indices := []uint64{3, 0, 12, 5, 20}
+	opening, err := p.OpenSymbolsBatch(payload, uint64(commitments.Length), indices)
+	require.NoError(t, err)
+	require.Equal(t, indices, opening.Indices)
+	for i, index := range indices {
+		require.Equal(t, payloadFr[index], opening.Values[i])
+	}
+
+	err = v.VerifyMultiSymbolOpening(commitments, opening)
+	require.NoError(t, err)
+
+	opening.Values[1].SetUint64(42)
+	err = v.VerifyMultiSymbolOpening(commitments, opening)
+	require.Error(t, err)
+
+	_, err = p.OpenSymbolsBatch(payload, uint64(commitments.Length), []uint64{1, 1})
+	require.Error(t, err)
+
+	tooMany := make([]uint64, encoding.MaxBatchedSymbolOpenings+1)
+	for i := range tooMany {
+		tooMany[i] = uint64(i)
+	}
+	_, err = p.OpenSymbolsBatch(payload, uint64(commitments.Length), tooMany)
+	require.Error(t, err)
+}
@@ -29,6 +29,12 @@ func (e *MockEncoder) GetCommitmentsForPaddedLength(data []byte) (encoding.BlobC
 	return args.Get(0).(encoding.BlobCommitments), args.Error(1)
 }
 
+func (e *MockEncoder) GetCommitment(data []byte) (*encoding.G1Commitment, error) {
+	args := e.Called(data)
+	time.Sleep(e.Delay)
+	return args.Get(0).(*encoding.G1Commitment), args.Error(1)
+}
+
 func (e *MockEncoder) GetFrames(data []byte, params encoding.EncodingParams) ([]*encoding.Frame, error) {
 	args := e.Called(data, params)
 	time.Sleep(e.Delay)
@@ -41,6 +47,24 @@ func (e *MockEncoder) GetMultiFrameProofs(data []byte, params encoding.EncodingP
 	return args.Get(0).([]encoding.Proof), args.Error(1)
 }
 
+func (e *MockEncoder) StreamFrameCoefficients(data []byte, params encoding.EncodingParams, batchSize uint64, handler encoding.FrameCoeffsHandler) error {
+	args := e.Called(data, params, batchSize, handler)
+	time.Sleep(e.Delay)
+	return args.Error(0)
+}
+
+func (e *MockEncoder) OpenSymbols(data []byte, blobLength uint64, indices []uint64) ([]encoding.SymbolOpening, error) {
+	args := e.Called(data, blobLength, indices)
+	time.Sleep(e.Delay)
+	return args.Get(0).([]encoding.SymbolOpening), args.Error(1)
+}
+
+func (e *MockEncoder) OpenSymbolsBatch(data []byte, blobLength uint64, indices []uint64) (*encoding.MultiSymbolOpening, error) {
+	args := e.Called(data, blobLength, indices)
+	time.Sleep(e.Delay)
+	return args.Get(0).(*encoding.MultiSymbolOpening), args.Error(1)
+}
+
 func (e *MockEncoder) GetSRSOrder() uint64 {
 	args := e.Called()
 	return args.Get(0).(uint64)
@@ -70,6 +94,18 @@ func (e *MockEncoder) VerifyBlobLength(commitments encoding.BlobCommitments) err
 	return args.Error(0)
 }
 
+func (e *MockEncoder) VerifySymbolOpenings(commitments encoding.BlobCommitments, openings []encoding.SymbolOpening) error {
+	args := e.Called(commitments, openings)
+	time.Sleep(e.Delay)
+	return args.Error(0)
+}
+
+func (e *MockEncoder) VerifyMultiSymbolOpening(commitments encoding.BlobCommitments, opening *encoding.MultiSymbolOpening) error {
+	args := e.Called(commitments, opening)
+	time.Sleep(e.Delay)
+	return args.Error(0)
+}
+
 func (e *MockEncoder) Decode(chunks []*encoding.Frame, indices []encoding.ChunkNumber, params encoding.EncodingParams, maxInputSize uint64) ([]byte, error) {
 	args := e.Called(chunks, indices, params, maxInputSize)
 	time.Sleep(e.Delay)
@@ -75,3 +75,39 @@ func (g *Encoder) Encode(inputFr []fr.Element, params encoding.EncodingParams) (
 
 	return frames, indices, nil
 }
+
+// EncodeStream produces the same frames as Encode, but instead of returning all of them at once it passes them to the
+// handler in batches of at most batchSize frames, in frame order. The polynomial is never extended as a whole: the
+// evaluations of each batch of frames are computed on their own, so the memory used besides the input scales with the
+// batch size rather than with the number of chunks.
+func (g *Encoder) EncodeStream(
+	inputFr []fr.Element,
+	params encoding.EncodingParams,
+	batchSize uint64,
+	handler func(frames []Frame) error) error {
+
+	start := time.Now()
+
+	encoder, err := g.GetRsEncoder(params)
+	if err != nil {
+		return err
+	}
+
+	if uint64(len(inputFr)) > encoder.NumEvaluations() {
+		return fmt.Errorf("the provided encoding parameters are not sufficient for the size of the data input")
+	}
+
+	err = encoder.MakeFramesStream(inputFr, batchSize, handler)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("RSEncodeStream details",
+		"input_size_bytes", len(inputFr)*encoding.BYTES_PER_SYMBOL,
+		"num_chunks", encoder.NumChunks,
+		"chunk_length", encoder.ChunkLength,
+		"batch_size", batchSize,
+		"total_duration", time.Since(start))
+
+	return nil
+}
@@ -85,3 +85,36 @@ func TestEncodeDecode_ErrorsWhenNotEnoughSampledFrames(t *testing.T) {
 
 	assert.EqualError(t, err, "number of frame must be sufficient")
 }
+
+func TestEncodeStream_MatchesEncode(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	cfg := encoding.DefaultConfig()
+	enc, err := rs.NewEncoder(cfg)
+	assert.Nil(t, err)
+
+	inputFr, err := rs.ToFrArray(GETTYSBURG_ADDRESS_BYTES)
+	assert.Nil(t, err)
+
+	for _, params := range []encoding.EncodingParams{
+		encoding.ParamsFromSysPar(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES))),
+		{NumChunks: 64, ChunkLength: 2},
+		{NumChunks: 4, ChunkLength: 32},
+	} {
+		frames, _, err := enc.Encode(inputFr, params)
+		require.Nil(t, err)
+
+		// batch sizes that are not powers of two are rounded down, and batches never exceed the number of chunks
+		for _, batchSize := range []uint64{1, 3, 8, 1000} {
+			streamed := make([]rs.Frame, 0, len(frames))
+			err := enc.EncodeStream(inputFr, params, batchSize, func(batch []rs.Frame) error {
+				assert.LessOrEqual(t, uint64(len(batch)), batchSize)
+				streamed = append(streamed, batch...)
+				return nil
+			})
+			require.Nil(t, err)
+			require.Equal(t, frames, streamed, "params %v, batch size %d", params, batchSize)
+		}
+	}
+}
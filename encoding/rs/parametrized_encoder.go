@@ -64,6 +64,7 @@ func (g *ParametrizedEncoder) MakeFrames(
 			jobChan,
 			results,
 			frames,
+			0,
 		)
 	}
 
@@ -91,6 +92,122 @@ func (g *ParametrizedEncoder) MakeFrames(
 	return frames, indices, nil
 }
 
+// MakeFramesStream computes the same frames as MakeFrames from the coefficients of the polynomial, and passes them to
+// the handler in batches of at most batchSize frames, in frame order, rather than returning all of them at once.
+//
+// Frames are computed in batches of a power of two number of frames, without extending the whole polynomial. The
+// frame at index i interpolates the polynomial over the coset w^j * H, where w is the root of unity of the extended
+// domain, H the subgroup of size ChunkLength and j the bit reversal of i. The cosets of an aligned batch of B frames
+// form a single coset w^j0 * H' of the subgroup H' of size B*ChunkLength, so the evaluations of the batch are given by
+// an FFT of that size over the polynomial shifted by w^j0 and folded modulo x^(B*ChunkLength) - 1.
+func (g *ParametrizedEncoder) MakeFramesStream(
+	coeffs []fr.Element,
+	batchSize uint64,
+	handler func(frames []Frame) error,
+) error {
+	if batchSize == 0 {
+		return fmt.Errorf("batch size must be greater than zero")
+	}
+
+	batch := uint64(1)
+	for batch*2 <= batchSize && batch*2 <= g.NumChunks {
+		batch *= 2
+	}
+	numBatches := g.NumChunks / batch
+	batchEvaluations := batch * g.ChunkLength
+
+	folded := make([]fr.Element, batchEvaluations)
+	evals := make([]fr.Element, batchEvaluations)
+	for start := uint64(0); start < g.NumChunks; start += batch {
+		j0 := rb.ReverseBitsLimited(uint32(numBatches), uint32(start/batch))
+		shift := g.Fs.ExpandedRootsOfUnity[j0]
+
+		for i := range folded {
+			folded[i].SetZero()
+		}
+		var power, term fr.Element
+		power.SetOne()
+		for i := range coeffs {
+			term.Mul(&coeffs[i], &power)
+			k := uint64(i) % batchEvaluations
+			folded[k].Add(&folded[k], &term)
+			power.Mul(&power, &shift)
+		}
+		if err := g.Fs.InplaceFFT(folded, evals, false); err != nil {
+			return err
+		}
+
+		frames, err := g.makeFrameBatch(evals, start, batch, j0)
+		if err != nil {
+			return err
+		}
+
+		if err := handler(frames); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// makeFrameBatch computes the frames in [start, start+batch) from the evaluations of the polynomial over the coset
+// w^j0 * H' of the batch, as described in MakeFramesStream.
+func (g *ParametrizedEncoder) makeFrameBatch(evals []fr.Element, start, batch uint64, j0 uint32) ([]Frame, error) {
+	frames := make([]Frame, batch)
+	stride := uint32(g.NumChunks / batch)
+
+	numWorker := uint64(g.Config.NumWorker)
+	if numWorker > batch {
+		numWorker = batch
+	}
+
+	jobChan := make(chan JobRequest, numWorker)
+	results := make(chan error, numWorker)
+
+	for w := uint64(0); w < numWorker; w++ {
+		go func() {
+			ys := make([]fr.Element, g.ChunkLength)
+			var workerErr error
+			for jr := range jobChan {
+				u := jr.Index
+				v := uint64(rb.ReverseBitsLimited(uint32(batch), uint32(u)))
+				// the evaluations over the coset of the frame, in the natural order of H
+				for s := uint64(0); s < g.ChunkLength; s++ {
+					ys[s].Set(&evals[v+batch*s])
+				}
+				coeffs, err := g.GetInterpolationPolyCoeff(ys, j0+uint32(v)*stride)
+				if err != nil {
+					workerErr = err
+					continue
+				}
+				frames[u].Coeffs = coeffs
+			}
+			results <- workerErr
+		}()
+	}
+
+	for u := uint64(0); u < batch; u++ {
+		jobChan <- JobRequest{
+			Index: u,
+		}
+	}
+	close(jobChan)
+
+	var err error
+	for w := uint64(0); w < numWorker; w++ {
+		interPolyErr := <-results
+		if interPolyErr != nil {
+			err = interPolyErr
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("proof worker error: %v", err)
+	}
+
+	return frames, nil
+}
+
 type JobRequest struct {
 	Index uint64
 }
@@ -100,6 +217,7 @@ func (g *ParametrizedEncoder) interpolyWorker(
 	jobChan <-chan JobRequest,
 	results chan<- error,
 	frames []Frame,
+	frameOffset uint64,
 ) {
 
 	for jr := range jobChan {
@@ -117,7 +235,7 @@ func (g *ParametrizedEncoder) interpolyWorker(
 			continue
 		}
 
-		frames[i].Coeffs = coeffs
+		frames[i-frameOffset].Coeffs = coeffs
 	}
 
 	results <- nil
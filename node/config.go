@@ -11,7 +11,9 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/node/flags"
 
@@ -28,6 +30,9 @@ const (
 	minExpirationPollIntervalSec   = 3
 	minReachabilityPollIntervalSec = 10
 	AppName                        = "da-node"
+
+	// DefaultGetChunksRequestMaxAge is used if GetChunksRateLimitConfig.RequestMaxAge is not set.
+	DefaultGetChunksRequestMaxAge = time.Minute
 )
 
 var (
@@ -91,10 +96,52 @@ type Config struct {
 
 	EnableV2                    bool
 	OnchainStateRefreshInterval time.Duration
+	OnchainStateWatcherConfig   eth.OnchainStateWatcherConfig
 	ChunkDownloadTimeout        time.Duration
+	ChainStateCacheMaxWeight    uint64
 
 	PprofHttpPort string
 	EnablePprof   bool
+
+	HealthConfig healthcheck.Config
+
+	GetChunksRateLimits GetChunksRateLimitConfig
+}
+
+// GetChunksRateLimitConfig is the configuration of the rate limits of v2 GetChunks requests. Every request is charged
+// to the client limits of its IP address, see ClientIPHeader, and signed requests are also charged to the client
+// limits of the account that signed them.
+type GetChunksRateLimitConfig struct {
+	// Enabled enables the rate limits. GetChunks requests are not limited if false.
+	Enabled bool
+
+	// MaxOpsPerSecond is the maximum number of GetChunks requests served per second.
+	MaxOpsPerSecond float64
+	// OpsBurstiness is the maximum burst size of the MaxOpsPerSecond rate limiter.
+	OpsBurstiness int
+	// MaxBytesPerSecond is the maximum bandwidth, in bytes, that GetChunks requests may consume per second.
+	MaxBytesPerSecond float64
+	// BytesBurstiness is the maximum burst size of the MaxBytesPerSecond rate limiter. A reply larger than this
+	// is never served.
+	BytesBurstiness int
+	// MaxConcurrentOps is the maximum number of GetChunks requests served concurrently.
+	MaxConcurrentOps int
+
+	// MaxOpsPerSecondClient is the maximum number of GetChunks requests served per second for a single client.
+	MaxOpsPerSecondClient float64
+	// OpsBurstinessClient is the maximum burst size of the MaxOpsPerSecondClient rate limiter.
+	OpsBurstinessClient int
+	// MaxBytesPerSecondClient is the maximum bandwidth, in bytes, that GetChunks requests of a single client may
+	// consume per second.
+	MaxBytesPerSecondClient float64
+	// BytesBurstinessClient is the maximum burst size of the MaxBytesPerSecondClient rate limiter.
+	BytesBurstinessClient int
+	// MaxConcurrentOpsClient is the maximum number of GetChunks requests served concurrently for a single client.
+	MaxConcurrentOpsClient int
+
+	// RequestMaxAge is the maximum difference between the timestamp of a signed GetChunks request and the time it
+	// is received. If 0, DefaultGetChunksRequestMaxAge is used.
+	RequestMaxAge time.Duration
 }
 
 // NewConfig parses the Config from the provided flags or environment variables and
@@ -241,8 +288,25 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		BLSRemoteSignerEnabled:         blsRemoteSignerEnabled,
 		EnableV2:                       ctx.GlobalBool(flags.EnableV2Flag.Name),
 		OnchainStateRefreshInterval:    ctx.GlobalDuration(flags.OnchainStateRefreshIntervalFlag.Name),
+		OnchainStateWatcherConfig:      eth.ReadOnchainStateWatcherCLIConfig(ctx),
 		ChunkDownloadTimeout:           ctx.GlobalDuration(flags.ChunkDownloadTimeoutFlag.Name),
+		ChainStateCacheMaxWeight:       ctx.GlobalUint64(flags.ChainStateCacheMaxWeightFlag.Name),
 		PprofHttpPort:                  ctx.GlobalString(flags.PprofHttpPort.Name),
 		EnablePprof:                    ctx.GlobalBool(flags.EnablePprof.Name),
+		HealthConfig:                   healthcheck.ReadCLIConfig(ctx),
+		GetChunksRateLimits: GetChunksRateLimitConfig{
+			Enabled:                 !ctx.GlobalBool(flags.DisableGetChunksRateLimitsFlag.Name),
+			MaxOpsPerSecond:         ctx.GlobalFloat64(flags.MaxGetChunksOpsPerSecondFlag.Name),
+			OpsBurstiness:           ctx.GlobalInt(flags.GetChunksOpsBurstinessFlag.Name),
+			MaxBytesPerSecond:       ctx.GlobalFloat64(flags.MaxGetChunksBytesPerSecondFlag.Name),
+			BytesBurstiness:         ctx.GlobalInt(flags.GetChunksBytesBurstinessFlag.Name),
+			MaxConcurrentOps:        ctx.GlobalInt(flags.MaxConcurrentGetChunksOpsFlag.Name),
+			MaxOpsPerSecondClient:   ctx.GlobalFloat64(flags.MaxGetChunksOpsPerSecondClientFlag.Name),
+			OpsBurstinessClient:     ctx.GlobalInt(flags.GetChunksOpsBurstinessClientFlag.Name),
+			MaxBytesPerSecondClient: ctx.GlobalFloat64(flags.MaxGetChunksBytesPerSecondClientFlag.Name),
+			BytesBurstinessClient:   ctx.GlobalInt(flags.GetChunksBytesBurstinessClientFlag.Name),
+			MaxConcurrentOpsClient:  ctx.GlobalInt(flags.MaxConcurrentGetChunksOpsClientFlag.Name),
+			RequestMaxAge:           ctx.GlobalDuration(flags.GetChunksRequestMaxAgeFlag.Name),
+		},
 	}, nil
 }
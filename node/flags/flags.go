@@ -5,6 +5,8 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
 )
@@ -238,6 +240,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_DOWNLOAD_TIMEOUT"),
 		Value:    20 * time.Second,
 	}
+	ChainStateCacheMaxWeightFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-state-cache-max-weight"),
+		Usage:    "Maximum number of operator entries held by the operator state cache. 0 disables the cache (default: 10000)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHAIN_STATE_CACHE_MAX_WEIGHT"),
+		Value:    10_000,
+	}
 
 	// Test only, DO NOT USE the following flags in production
 
@@ -333,6 +342,89 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_PPROF"),
 	}
+	DisableGetChunksRateLimitsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disable-get-chunks-rate-limits"),
+		Usage:    "Disable the rate limits of v2 GetChunks requests",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISABLE_GET_CHUNKS_RATE_LIMITS"),
+	}
+	MaxGetChunksOpsPerSecondFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-get-chunks-ops-per-second"),
+		Usage:    "Max number of v2 GetChunks requests served per second",
+		Required: false,
+		Value:    1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_GET_CHUNKS_OPS_PER_SECOND"),
+	}
+	GetChunksOpsBurstinessFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-chunks-ops-burstiness"),
+		Usage:    "Burstiness of the v2 GetChunks rate limiter",
+		Required: false,
+		Value:    1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_OPS_BURSTINESS"),
+	}
+	MaxGetChunksBytesPerSecondFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-get-chunks-bytes-per-second"),
+		Usage:    "Max bandwidth of v2 GetChunks requests in bytes per second",
+		Required: false,
+		Value:    100 * 1024 * 1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_GET_CHUNKS_BYTES_PER_SECOND"),
+	}
+	GetChunksBytesBurstinessFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-chunks-bytes-burstiness"),
+		Usage:    "Burstiness of the v2 GetChunks bandwidth rate limiter. Replies larger than this are never served",
+		Required: false,
+		Value:    100 * 1024 * 1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_BYTES_BURSTINESS"),
+	}
+	MaxConcurrentGetChunksOpsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-concurrent-get-chunks-ops"),
+		Usage:    "Max number of v2 GetChunks requests served concurrently",
+		Required: false,
+		Value:    1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_CONCURRENT_GET_CHUNKS_OPS"),
+	}
+	MaxGetChunksOpsPerSecondClientFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-get-chunks-ops-per-second-client"),
+		Usage:    "Max number of v2 GetChunks requests served per second per client",
+		Required: false,
+		Value:    32,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_GET_CHUNKS_OPS_PER_SECOND_CLIENT"),
+	}
+	GetChunksOpsBurstinessClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-chunks-ops-burstiness-client"),
+		Usage:    "Burstiness of the v2 GetChunks rate limiter per client",
+		Required: false,
+		Value:    32,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_OPS_BURSTINESS_CLIENT"),
+	}
+	MaxGetChunksBytesPerSecondClientFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-get-chunks-bytes-per-second-client"),
+		Usage:    "Max bandwidth of v2 GetChunks requests in bytes per second per client",
+		Required: false,
+		Value:    10 * 1024 * 1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_GET_CHUNKS_BYTES_PER_SECOND_CLIENT"),
+	}
+	GetChunksBytesBurstinessClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-chunks-bytes-burstiness-client"),
+		Usage:    "Burstiness of the v2 GetChunks bandwidth rate limiter per client. Replies larger than this are never served",
+		Required: false,
+		Value:    32 * 1024 * 1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_BYTES_BURSTINESS_CLIENT"),
+	}
+	MaxConcurrentGetChunksOpsClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-concurrent-get-chunks-ops-client"),
+		Usage:    "Max number of v2 GetChunks requests served concurrently per client",
+		Required: false,
+		Value:    8,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_CONCURRENT_GET_CHUNKS_OPS_CLIENT"),
+	}
+	GetChunksRequestMaxAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-chunks-request-max-age"),
+		Usage:    "Max difference between the timestamp of a signed v2 GetChunks request and the time it is received",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_REQUEST_MAX_AGE"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -382,15 +474,30 @@ var optionalFlags = []cli.Flag{
 	EnableV2Flag,
 	OnchainStateRefreshIntervalFlag,
 	ChunkDownloadTimeoutFlag,
+	ChainStateCacheMaxWeightFlag,
 	PprofHttpPort,
 	EnablePprof,
+	DisableGetChunksRateLimitsFlag,
+	MaxGetChunksOpsPerSecondFlag,
+	GetChunksOpsBurstinessFlag,
+	MaxGetChunksBytesPerSecondFlag,
+	GetChunksBytesBurstinessFlag,
+	MaxConcurrentGetChunksOpsFlag,
+	MaxGetChunksOpsPerSecondClientFlag,
+	GetChunksOpsBurstinessClientFlag,
+	MaxGetChunksBytesPerSecondClientFlag,
+	GetChunksBytesBurstinessClientFlag,
+	MaxConcurrentGetChunksOpsClientFlag,
+	GetChunksRequestMaxAgeFlag,
 }
 
 func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, kzg.CLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(EnvVarPrefix)...)
+	Flags = append(Flags, eth.OnchainStateWatcherCLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(EnvVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, healthcheck.CLIFlags(EnvVarPrefix)...)
 }
 
 // Flags contains the list of configuration options available to the binary.
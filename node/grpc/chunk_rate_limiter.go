@@ -0,0 +1,197 @@
+package grpc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/node"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+// maxTrackedClients is the maximum number of clients whose limits are tracked. When exceeded, the least recently
+// seen client is forgotten, and starts over with full limits when seen again.
+const maxTrackedClients = 65536
+
+// clientLimits holds the limiters of a single client.
+type clientLimits struct {
+	opLimiter          *rate.Limiter
+	bandwidthLimiter   *rate.Limiter
+	operationsInFlight int
+}
+
+// ChunkRateLimiter enforces global and per-client rate limits on v2 GetChunks requests. It follows the design of
+// the relay's limiter.ChunkRateLimiter. A nil ChunkRateLimiter does not limit anything.
+type ChunkRateLimiter struct {
+	config *node.GetChunksRateLimitConfig
+
+	// globalOpLimiter enforces the global rate of GetChunks requests.
+	globalOpLimiter *rate.Limiter
+	// globalBandwidthLimiter enforces the global bandwidth consumed by GetChunks requests.
+	globalBandwidthLimiter *rate.Limiter
+	// globalOperationsInFlight is the number of GetChunks requests currently in flight.
+	globalOperationsInFlight int
+
+	// clients holds the limits of each client, keyed by requester ID.
+	clients *lru.Cache[string, *clientLimits]
+
+	metrics *MetricsV2
+
+	// lock guards the in flight counters and clients
+	lock sync.Mutex
+}
+
+// NewChunkRateLimiter creates a new ChunkRateLimiter. Metrics are not reported if metrics is nil.
+func NewChunkRateLimiter(config *node.GetChunksRateLimitConfig, metrics *MetricsV2) (*ChunkRateLimiter, error) {
+	clients, err := lru.New[string, *clientLimits](maxTrackedClients)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client cache: %w", err)
+	}
+
+	return &ChunkRateLimiter{
+		config:                 config,
+		globalOpLimiter:        rate.NewLimiter(rate.Limit(config.MaxOpsPerSecond), config.OpsBurstiness),
+		globalBandwidthLimiter: rate.NewLimiter(rate.Limit(config.MaxBytesPerSecond), config.BytesBurstiness),
+		clients:                clients,
+		metrics:                metrics,
+	}, nil
+}
+
+func (l *ChunkRateLimiter) reportRateLimited(reason string) {
+	if l.metrics != nil {
+		l.metrics.ReportGetChunksRateLimited(reason)
+	}
+}
+
+func (l *ChunkRateLimiter) reportOperationsInFlight() {
+	if l.metrics != nil {
+		l.metrics.ReportGetChunksInFlight(l.globalOperationsInFlight)
+	}
+}
+
+// getClient returns the limits of a client, creating them if the client is not tracked yet.
+func (l *ChunkRateLimiter) getClient(requesterID string) *clientLimits {
+	client, ok := l.clients.Get(requesterID)
+	if !ok {
+		client = &clientLimits{
+			opLimiter: rate.NewLimiter(
+				rate.Limit(l.config.MaxOpsPerSecondClient),
+				l.config.OpsBurstinessClient),
+			bandwidthLimiter: rate.NewLimiter(
+				rate.Limit(l.config.MaxBytesPerSecondClient),
+				l.config.BytesBurstinessClient),
+		}
+		l.clients.Add(requesterID, client)
+	}
+	return client
+}
+
+// BeginGetChunksOperation should be called when a GetChunks request is about to be served. The request is charged
+// to the global limits once and to the limits of every given requester ID, e.g. the client IP and the account that
+// signed the request. If it returns an error, nothing is charged and the request should be rejected. Otherwise
+// FinishGetChunksOperation must be called with the same requester IDs when the request completes.
+func (l *ChunkRateLimiter) BeginGetChunksOperation(now time.Time, requesterIDs ...string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.globalOperationsInFlight >= l.config.MaxConcurrentOps {
+		l.reportRateLimited("global concurrency")
+		return fmt.Errorf("global concurrent request limit %d exceeded for GetChunks, try again later",
+			l.config.MaxConcurrentOps)
+	}
+	if l.globalOpLimiter.TokensAt(now) < 1 {
+		l.reportRateLimited("global rate")
+		return fmt.Errorf("global rate limit %0.1fhz exceeded for GetChunks, try again later",
+			l.config.MaxOpsPerSecond)
+	}
+
+	clients := make([]*clientLimits, 0, len(requesterIDs))
+	for _, requesterID := range requesterIDs {
+		client := l.getClient(requesterID)
+		if client.operationsInFlight >= l.config.MaxConcurrentOpsClient {
+			l.reportRateLimited("client concurrency")
+			return fmt.Errorf("client concurrent request limit %d exceeded for GetChunks",
+				l.config.MaxConcurrentOpsClient)
+		}
+		if client.opLimiter.TokensAt(now) < 1 {
+			l.reportRateLimited("client rate")
+			return fmt.Errorf("client rate limit %0.1fhz exceeded for GetChunks, try again later",
+				l.config.MaxOpsPerSecondClient)
+		}
+		clients = append(clients, client)
+	}
+
+	l.globalOperationsInFlight++
+	l.globalOpLimiter.AllowN(now, 1)
+	for _, client := range clients {
+		client.operationsInFlight++
+		client.opLimiter.AllowN(now, 1)
+	}
+	l.reportOperationsInFlight()
+
+	return nil
+}
+
+// FinishGetChunksOperation should be called when a GetChunks request admitted by BeginGetChunksOperation completes.
+func (l *ChunkRateLimiter) FinishGetChunksOperation(requesterIDs ...string) {
+	if l == nil {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.globalOperationsInFlight--
+	for _, requesterID := range requesterIDs {
+		// the client may have been evicted while its request was in flight
+		if client, ok := l.clients.Peek(requesterID); ok && client.operationsInFlight > 0 {
+			client.operationsInFlight--
+		}
+	}
+	l.reportOperationsInFlight()
+}
+
+// RequestGetChunksBandwidth should be called before the chunks of an admitted GetChunks request are sent, with the
+// requester IDs the request was admitted with. If it returns an error, nothing is charged and the chunks should not
+// be sent.
+func (l *ChunkRateLimiter) RequestGetChunksBandwidth(now time.Time, bytes int, requesterIDs ...string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.globalBandwidthLimiter.TokensAt(now) < float64(bytes) {
+		l.reportRateLimited("global bandwidth")
+		return fmt.Errorf("global rate limit %dMiB exceeded for GetChunks bandwidth, try again later",
+			int(l.config.MaxBytesPerSecond/1024/1024))
+	}
+
+	clients := make([]*clientLimits, 0, len(requesterIDs))
+	for _, requesterID := range requesterIDs {
+		client, ok := l.clients.Peek(requesterID)
+		if !ok {
+			// the client was evicted while its request was in flight, it is not limited for this request
+			continue
+		}
+		if client.bandwidthLimiter.TokensAt(now) < float64(bytes) {
+			l.reportRateLimited("client bandwidth")
+			return fmt.Errorf("client rate limit %dMiB exceeded for GetChunks bandwidth, try again later",
+				int(l.config.MaxBytesPerSecondClient/1024/1024))
+		}
+		clients = append(clients, client)
+	}
+
+	l.globalBandwidthLimiter.AllowN(now, bytes)
+	for _, client := range clients {
+		client.bandwidthLimiter.AllowN(now, bytes)
+	}
+
+	return nil
+}
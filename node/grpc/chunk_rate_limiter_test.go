@@ -0,0 +1,129 @@
+package grpc_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/grpc"
+	"github.com/stretchr/testify/require"
+)
+
+func defaultGetChunksRateLimitConfig() *node.GetChunksRateLimitConfig {
+	return &node.GetChunksRateLimitConfig{
+		Enabled:                 true,
+		MaxOpsPerSecond:         1024,
+		OpsBurstiness:           1024,
+		MaxBytesPerSecond:       100 * 1024 * 1024,
+		BytesBurstiness:         100 * 1024 * 1024,
+		MaxConcurrentOps:        1024,
+		MaxOpsPerSecondClient:   32,
+		OpsBurstinessClient:     32,
+		MaxBytesPerSecondClient: 10 * 1024 * 1024,
+		BytesBurstinessClient:   32 * 1024 * 1024,
+		MaxConcurrentOpsClient:  8,
+	}
+}
+
+func TestChunkRateLimiterConcurrency(t *testing.T) {
+	config := defaultGetChunksRateLimitConfig()
+	config.MaxConcurrentOps = 3
+	config.MaxConcurrentOpsClient = 2
+
+	limiter, err := grpc.NewChunkRateLimiter(config, nil)
+	require.NoError(t, err)
+	now := time.Now()
+
+	// per-client concurrency limit
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+	require.Error(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+
+	// global concurrency limit
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "2.2.2.2"))
+	require.Error(t, limiter.BeginGetChunksOperation(now, "3.3.3.3"))
+
+	// finishing an operation permits exactly one more
+	limiter.FinishGetChunksOperation("1.1.1.1")
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "3.3.3.3"))
+	require.Error(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+}
+
+func TestChunkRateLimiterOpRate(t *testing.T) {
+	config := defaultGetChunksRateLimitConfig()
+	config.MaxConcurrentOps = math.MaxInt32
+	config.MaxConcurrentOpsClient = math.MaxInt32
+	config.MaxOpsPerSecondClient = 2
+	config.OpsBurstinessClient = 4
+
+	limiter, err := grpc.NewChunkRateLimiter(config, nil)
+	require.NoError(t, err)
+	now := time.Now()
+
+	for i := 0; i < config.OpsBurstinessClient; i++ {
+		require.NoError(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+	}
+	require.Error(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+	// other clients are not limited by this client
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "2.2.2.2"))
+
+	// tokens are refilled over time
+	now = now.Add(time.Second)
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+	require.Error(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+}
+
+func TestChunkRateLimiterBandwidth(t *testing.T) {
+	config := defaultGetChunksRateLimitConfig()
+	config.MaxBytesPerSecond = 1024
+	config.BytesBurstiness = 3 * 1024
+	config.MaxBytesPerSecondClient = 1024
+	config.BytesBurstinessClient = 2 * 1024
+
+	limiter, err := grpc.NewChunkRateLimiter(config, nil)
+	require.NoError(t, err)
+	now := time.Now()
+
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "1.1.1.1"))
+	require.NoError(t, limiter.RequestGetChunksBandwidth(now, 2*1024, "1.1.1.1"))
+	// the client has used up its burst
+	require.Error(t, limiter.RequestGetChunksBandwidth(now, 1, "1.1.1.1"))
+	limiter.FinishGetChunksOperation("1.1.1.1")
+
+	// the rejected bytes were not taken from the global limit
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "2.2.2.2"))
+	require.NoError(t, limiter.RequestGetChunksBandwidth(now, 1024, "2.2.2.2"))
+	require.Error(t, limiter.RequestGetChunksBandwidth(now, 1, "2.2.2.2"))
+	limiter.FinishGetChunksOperation("2.2.2.2")
+}
+
+func TestChunkRateLimiterMultipleRequesterIDs(t *testing.T) {
+	config := defaultGetChunksRateLimitConfig()
+	config.MaxConcurrentOpsClient = math.MaxInt32
+	config.MaxOpsPerSecondClient = 2
+	config.OpsBurstinessClient = 2
+
+	limiter, err := grpc.NewChunkRateLimiter(config, nil)
+	require.NoError(t, err)
+	now := time.Now()
+
+	// signed requests are charged to both the IP and the account
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "1.1.1.1", "0xaaaa"))
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "1.1.1.1", "0xbbbb"))
+	// a fresh account does not bypass the limit of the IP
+	require.Error(t, limiter.BeginGetChunksOperation(now, "1.1.1.1", "0xcccc"))
+	// the account is limited from another IP, and the rejected request did not charge the other IP
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "2.2.2.2", "0xaaaa"))
+	require.Error(t, limiter.BeginGetChunksOperation(now, "3.3.3.3", "0xaaaa"))
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "3.3.3.3"))
+	require.NoError(t, limiter.BeginGetChunksOperation(now, "3.3.3.3", "0xcccc"))
+}
+
+func TestNilChunkRateLimiter(t *testing.T) {
+	var limiter *grpc.ChunkRateLimiter
+	require.NoError(t, limiter.BeginGetChunksOperation(time.Now(), "1.1.1.1"))
+	require.NoError(t, limiter.RequestGetChunksBandwidth(time.Now(), math.MaxInt32, "1.1.1.1"))
+	limiter.FinishGetChunksOperation("1.1.1.1")
+}
@@ -22,8 +22,10 @@ type MetricsV2 struct {
 	storeChunksLatency     *prometheus.SummaryVec
 	storeChunksRequestSize *prometheus.GaugeVec
 
-	getChunksLatency  *prometheus.SummaryVec
-	getChunksDataSize *prometheus.GaugeVec
+	getChunksLatency     *prometheus.SummaryVec
+	getChunksDataSize    *prometheus.GaugeVec
+	getChunksRateLimited *prometheus.CounterVec
+	getChunksInFlight    *prometheus.GaugeVec
 }
 
 // NewV2Metrics creates a new MetricsV2 instance. dbSizePollPeriod is the period at which the database size is polled.
@@ -78,6 +80,24 @@ func NewV2Metrics(logger logging.Logger, registry *prometheus.Registry) (*Metric
 		[]string{},
 	)
 
+	getChunksRateLimited := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "get_chunks_rate_limited_count",
+			Help:      "The number of GetChunks() RPC calls rejected by the rate limiter.",
+		},
+		[]string{"reason"},
+	)
+
+	getChunksInFlight := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "get_chunks_in_flight",
+			Help:      "The number of GetChunks() RPC calls admitted by the rate limiter and currently being served.",
+		},
+		[]string{},
+	)
+
 	return &MetricsV2{
 		logger:                 logger,
 		registry:               registry,
@@ -86,6 +106,8 @@ func NewV2Metrics(logger logging.Logger, registry *prometheus.Registry) (*Metric
 		storeChunksRequestSize: storeChunksRequestSize,
 		getChunksLatency:       getChunksLatency,
 		getChunksDataSize:      getChunksDataSize,
+		getChunksRateLimited:   getChunksRateLimited,
+		getChunksInFlight:      getChunksInFlight,
 	}, nil
 }
 
@@ -109,3 +131,11 @@ func (m *MetricsV2) ReportGetChunksLatency(latency time.Duration) {
 func (m *MetricsV2) ReportGetChunksDataSize(size int) {
 	m.getChunksDataSize.WithLabelValues().Set(float64(size))
 }
+
+func (m *MetricsV2) ReportGetChunksRateLimited(reason string) {
+	m.getChunksRateLimited.WithLabelValues(reason).Inc()
+}
+
+func (m *MetricsV2) ReportGetChunksInFlight(count int) {
+	m.getChunksInFlight.WithLabelValues().Set(float64(count))
+}
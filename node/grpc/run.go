@@ -7,7 +7,6 @@ import (
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
-	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"google.golang.org/grpc"
@@ -42,7 +41,7 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 			pb.RegisterDispersalServer(gs, serverV1)
 			pbv2.RegisterDispersalServer(gs, serverV2)
 
-			healthcheck.RegisterHealthServer("node.Dispersal", gs)
+			serverV2.node.HealthRegistry.RegisterHealthServer("node.Dispersal", gs)
 
 			logger.Info("port", config.InternalDispersalPort, "address", listener.Addr().String(), "GRPC Listening")
 			if err := gs.Serve(listener); err != nil {
@@ -68,7 +67,7 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 
 			pb.RegisterRetrievalServer(gs, serverV1)
 			pbv2.RegisterRetrievalServer(gs, serverV2)
-			healthcheck.RegisterHealthServer("node.Retrieval", gs)
+			serverV2.node.HealthRegistry.RegisterHealthServer("node.Retrieval", gs)
 
 			logger.Info("port", config.InternalRetrievalPort, "address", listener.Addr().String(), "GRPC Listening")
 			if err := gs.Serve(listener); err != nil {
@@ -12,9 +12,11 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/core"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/shirou/gopsutil/mem"
 )
@@ -29,6 +31,11 @@ type ServerV2 struct {
 	ratelimiter common.RateLimiter
 	logger      logging.Logger
 	metrics     *MetricsV2
+
+	// chunkRateLimiter limits GetChunks requests. It is nil if GetChunks requests are not limited.
+	chunkRateLimiter *ChunkRateLimiter
+	// authenticator verifies the signatures of signed GetChunks requests.
+	authenticator corev2.BlobRequestAuthenticator
 }
 
 // NewServerV2 creates a new Server instance with the provided parameters.
@@ -44,12 +51,22 @@ func NewServerV2(
 		return nil, err
 	}
 
+	var chunkRateLimiter *ChunkRateLimiter
+	if config.GetChunksRateLimits.Enabled {
+		chunkRateLimiter, err = NewChunkRateLimiter(&config.GetChunksRateLimits, metrics)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &ServerV2{
-		config:      config,
-		node:        node,
-		ratelimiter: ratelimiter,
-		logger:      logger,
-		metrics:     metrics,
+		config:           config,
+		node:             node,
+		ratelimiter:      ratelimiter,
+		logger:           logger,
+		metrics:          metrics,
+		chunkRateLimiter: chunkRateLimiter,
+		authenticator:    auth.NewAuthenticator(),
 	}, nil
 }
 
@@ -186,14 +203,29 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 		return nil, api.NewErrorInvalidArg("invalid quorum ID")
 	}
 	quorumID := core.QuorumID(in.GetQuorumId())
+
+	requesterIDs, err := s.getChunksRequesterIDs(ctx, in, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if s.chunkRateLimiter != nil {
+		if err = s.chunkRateLimiter.BeginGetChunksOperation(time.Now(), requesterIDs...); err != nil {
+			return nil, api.NewErrorResourceExhausted(err.Error())
+		}
+		defer s.chunkRateLimiter.FinishGetChunksOperation(requesterIDs...)
+	}
+
 	chunks, err := s.node.StoreV2.GetChunks(blobKey, quorumID)
 	if err != nil {
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get chunks: %v", err))
 	}
 
 	size := 0
-	if len(chunks) > 0 {
-		size = len(chunks[0]) * len(chunks)
+	for _, chunk := range chunks {
+		size += len(chunk)
+	}
+	if err = s.chunkRateLimiter.RequestGetChunksBandwidth(time.Now(), size, requesterIDs...); err != nil {
+		return nil, api.NewErrorResourceExhausted(err.Error())
 	}
 	s.metrics.ReportGetChunksDataSize(size)
 
@@ -203,3 +235,48 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 		Chunks: chunks,
 	}, nil
 }
+
+// getChunksRequesterIDs returns the IDs under which a GetChunks request is rate limited. Every request is limited
+// per client IP address, since fresh accounts cost nothing to create. Signed requests are authenticated and limited
+// per account on top of that. Account IDs are hex encoded addresses, so they never collide with IP addresses. No IDs
+// are returned if GetChunks requests are not limited.
+func (s *ServerV2) getChunksRequesterIDs(
+	ctx context.Context,
+	in *pb.GetChunksRequest,
+	now time.Time) ([]string, error) {
+
+	var requesterIDs []string
+	if s.chunkRateLimiter != nil {
+		clientAddress, err := common.GetClientAddress(ctx, s.config.ClientIPHeader, 1, false)
+		if err != nil {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to get client address: %v", err))
+		}
+		requesterIDs = append(requesterIDs, clientAddress)
+	}
+
+	accountID := in.GetAccountId()
+	if accountID == "" {
+		return requesterIDs, nil
+	}
+
+	maxAge := s.config.GetChunksRateLimits.RequestMaxAge
+	if maxAge == 0 {
+		maxAge = node.DefaultGetChunksRequestMaxAge
+	}
+	requestTime := time.Unix(0, int64(in.GetTimestamp()))
+	if requestTime.Before(now.Add(-maxAge)) || requestTime.After(now.Add(maxAge)) {
+		return nil, api.NewErrorUnauthenticated(fmt.Sprintf(
+			"request timestamp %d is more than %s away from the node time", in.GetTimestamp(), maxAge))
+	}
+
+	err := s.authenticator.AuthenticateGetChunksRequest(
+		in.GetSignature(), accountID, in.GetBlobKey(), in.GetQuorumId(), in.GetTimestamp())
+	if err != nil {
+		return nil, api.NewErrorUnauthenticated(fmt.Sprintf("authentication failed: %v", err))
+	}
+
+	if s.chunkRateLimiter != nil {
+		requesterIDs = append(requesterIDs, gethcommon.HexToAddress(accountID).Hex())
+	}
+	return requesterIDs, nil
+}
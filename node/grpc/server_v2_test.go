@@ -2,10 +2,13 @@ package grpc_test
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"net"
 	"os"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
@@ -15,6 +18,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	commonmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	coremockv2 "github.com/Layr-Labs/eigenda/core/mock/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
@@ -22,11 +26,13 @@ import (
 	"github.com/Layr-Labs/eigenda/node/grpc"
 	nodemock "github.com/Layr-Labs/eigenda/node/mock"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -334,6 +340,118 @@ func TestV2GetChunksInputValidation(t *testing.T) {
 	requireErrorStatus(t, err, codes.InvalidArgument)
 }
 
+func TestV2GetChunksRateLimit(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	config.GetChunksRateLimits = *defaultGetChunksRateLimitConfig()
+	config.GetChunksRateLimits.MaxOpsPerSecondClient = 1
+	config.GetChunksRateLimits.OpsBurstinessClient = 2
+	config.GetChunksRateLimits.MaxBytesPerSecondClient = 1
+	config.GetChunksRateLimits.BytesBurstinessClient = 100
+	c := newTestComponents(t, config)
+
+	chunks := [][]byte{make([]byte, 40), make([]byte, 40)}
+	c.store.On("GetChunks", mock.Anything, mock.Anything).Return(chunks, nil)
+
+	bk := [32]byte{1}
+	req := &pbv2.GetChunksRequest{
+		BlobKey:  bk[:],
+		QuorumId: 0,
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 1234},
+	})
+	reply, err := c.server.GetChunks(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, chunks, reply.GetChunks())
+
+	// the second reply exceeds the bandwidth burst of the client
+	_, err = c.server.GetChunks(ctx, req)
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+
+	// the third request exceeds the request burst of the client
+	_, err = c.server.GetChunks(ctx, req)
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+
+	// another client is served
+	otherCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("2.2.2.2"), Port: 1234},
+	})
+	_, err = c.server.GetChunks(otherCtx, req)
+	require.NoError(t, err)
+}
+
+func TestV2GetChunksRateLimitPerAccount(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	config.GetChunksRateLimits = *defaultGetChunksRateLimitConfig()
+	config.GetChunksRateLimits.MaxOpsPerSecondClient = 1
+	config.GetChunksRateLimits.OpsBurstinessClient = 2
+	config.GetChunksRateLimits.RequestMaxAge = time.Minute
+	c := newTestComponents(t, config)
+
+	chunks := [][]byte{make([]byte, 40), make([]byte, 40)}
+	c.store.On("GetChunks", mock.Anything, mock.Anything).Return(chunks, nil)
+
+	bk := [32]byte{1}
+	signedRequest := func(signer *auth.LocalBlobRequestSigner, timestamp time.Time) *pbv2.GetChunksRequest {
+		accountID, err := signer.GetAccountID()
+		require.NoError(t, err)
+		signature, err := signer.SignGetChunksRequest(bk[:], 0, uint64(timestamp.UnixNano()))
+		require.NoError(t, err)
+		return &pbv2.GetChunksRequest{
+			BlobKey:   bk[:],
+			QuorumId:  0,
+			AccountId: accountID,
+			Timestamp: uint64(timestamp.UnixNano()),
+			Signature: signature,
+		}
+	}
+	peerContext := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234},
+		})
+	}
+
+	signer := auth.NewLocalBlobRequestSigner(newPrivateKeyHex(t))
+	otherSigner := auth.NewLocalBlobRequestSigner(newPrivateKeyHex(t))
+
+	// the requests of an account are limited together, whichever address they come from
+	_, err := c.server.GetChunks(peerContext("1.1.1.1"), signedRequest(signer, time.Now()))
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(peerContext("2.2.2.2"), signedRequest(signer, time.Now()))
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(peerContext("3.3.3.3"), signedRequest(signer, time.Now()))
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+
+	// signed requests are also limited per address, so a fresh account does not bypass the limits of the address
+	_, err = c.server.GetChunks(peerContext("1.1.1.1"), &pbv2.GetChunksRequest{BlobKey: bk[:], QuorumId: 0})
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(peerContext("1.1.1.1"), signedRequest(otherSigner, time.Now()))
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+
+	// the requests of other accounts from other addresses are limited separately
+	_, err = c.server.GetChunks(peerContext("5.5.5.5"), signedRequest(otherSigner, time.Now()))
+	require.NoError(t, err)
+
+	// a request cannot be attributed to an account it was not signed by
+	forged := signedRequest(otherSigner, time.Now())
+	forged.AccountId, err = signer.GetAccountID()
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(peerContext("4.4.4.4"), forged)
+	requireErrorStatus(t, err, codes.Unauthenticated)
+
+	// old signatures are rejected
+	_, err = c.server.GetChunks(peerContext("4.4.4.4"), signedRequest(otherSigner, time.Now().Add(-2*time.Minute)))
+	requireErrorStatus(t, err, codes.Unauthenticated)
+}
+
+func newPrivateKeyHex(t *testing.T) string {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return hex.EncodeToString(crypto.FromECDSA(privateKey))
+}
+
 func requireErrorStatus(t *testing.T, err error, code codes.Code) {
 	require.Error(t, err)
 	s, ok := status.FromError(err)
@@ -38,6 +38,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/core/statecache"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -47,6 +48,7 @@ import (
 	"github.com/gammazero/workerpool"
 
 	blssignerV1 "github.com/Layr-Labs/cerberus-api/pkg/api/v1"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 )
 
 const (
@@ -81,12 +83,20 @@ type Node struct {
 
 	RelayClient atomic.Value
 
+	// HealthRegistry aggregates the health of the dependencies of the node. It may be nil.
+	HealthRegistry *healthcheck.Registry
+
 	mu            sync.Mutex
 	CurrentSocket string
 
 	// BlobVersionParams is a map of blob version parameters loaded from the chain.
 	// It is used to determine blob parameters based on the version number.
 	BlobVersionParams atomic.Pointer[corev2.BlobVersionParameterMap]
+
+	// onchainStateWatcher is set if the node refreshes its onchain state when blob versions or relays are added,
+	// in addition to refreshing it every OnchainStateRefreshInterval.
+	onchainStateWatcher *eth.OnchainStateWatcher
+	onchainStateUpdates <-chan eth.OnchainStateUpdate
 }
 
 // NewNode creates a new Node with the provided config.
@@ -130,7 +140,13 @@ func NewNode(
 	}
 
 	// Create ChainState Client
-	cst := eth.NewChainState(tx, client)
+	var cst core.ChainState = eth.NewChainState(tx, client)
+	if config.ChainStateCacheMaxWeight > 0 {
+		cst, err = statecache.NewChainState(cst, statecache.Config{MaxWeight: config.ChainStateCacheMaxWeight}, reg, Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create chain state cache: %w", err)
+		}
+	}
 
 	var keyPair *core.KeyPair
 	var blsClient blssignerV1.SignerClient
@@ -240,7 +256,9 @@ func NewNode(
 		OperatorSocketsFilterer: socketsFilterer,
 		ChainID:                 chainID,
 		BLSSigner:               blsClient,
+		HealthRegistry:          healthcheck.NewRegistry(logger, config.HealthConfig.CheckInterval, config.HealthConfig.ProbeTimeout),
 	}
+	n.HealthRegistry.Register("EthClient", healthcheck.Readiness, healthcheck.BlockFreshnessProbe(client, config.HealthConfig.MaxBlockAge))
 
 	if !config.EnableV2 {
 		return n, nil
@@ -289,6 +307,14 @@ func NewNode(
 		}
 
 		n.RelayClient.Store(relayClient)
+
+		if config.OnchainStateWatcherConfig.Enabled {
+			n.onchainStateWatcher, err = eth.NewOnchainStateWatcher(logger, client, tx.Reader, config.OnchainStateWatcherConfig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create onchain state watcher: %w", err)
+			}
+			n.onchainStateUpdates = n.onchainStateWatcher.Subscribe(eth.BlobVersionAdded, eth.RelayAdded)
+		}
 	}
 
 	n.StoreV2 = storeV2
@@ -312,11 +338,20 @@ func (n *Node) Start(ctx context.Context) error {
 		n.NodeApi.Start()
 		n.Logger.Info("Enabled node api", "port", n.Config.NodeApiPort)
 	}
+	if n.HealthRegistry != nil {
+		n.HealthRegistry.Start(ctx)
+		n.HealthRegistry.StartHTTPServer(ctx, n.Config.HealthConfig.HTTPPort)
+	}
 
 	go n.expireLoop()
 	go n.checkNodeReachability()
 
 	if n.Config.EnableV2 {
+		if n.onchainStateWatcher != nil {
+			if err := n.onchainStateWatcher.Start(ctx); err != nil {
+				return fmt.Errorf("failed to start onchain state watcher: %w", err)
+			}
+		}
 		go func() {
 			_ = n.RefreshOnchainState(ctx)
 		}()
@@ -414,67 +449,80 @@ func (n *Node) expireLoop() {
 }
 
 // RefreshOnchainState refreshes the onchain state of the node.
-// It fetches the latest blob parameters from the chain and updates the BlobVersionParams.
-// It runs periodically based on the OnchainStateRefreshInterval.
+// It fetches the latest blob parameters and relay URLs from the chain and updates the BlobVersionParams and the
+// RelayClient. It runs periodically based on the OnchainStateRefreshInterval, and whenever the onchain state watcher
+// observes a new blob version or relay.
 // WARNING: this method is not thread-safe and should not be called concurrently.
 func (n *Node) RefreshOnchainState(ctx context.Context) error {
-	if !n.Config.EnableV2 || n.Config.OnchainStateRefreshInterval <= 0 {
+	if !n.Config.EnableV2 {
+		return nil
+	}
+	var tick <-chan time.Time
+	if n.Config.OnchainStateRefreshInterval > 0 {
+		ticker := time.NewTicker(n.Config.OnchainStateRefreshInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	} else if n.onchainStateUpdates == nil {
 		return nil
 	}
-	ticker := time.NewTicker(n.Config.OnchainStateRefreshInterval)
-	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-tick:
 			n.Logger.Info("Refreshing onchain state")
-			existingBlobParams := n.BlobVersionParams.Load()
-			blobParams, err := n.Transactor.GetAllVersionedBlobParams(ctx)
-			if err == nil {
-				if existingBlobParams == nil || !existingBlobParams.Equal(blobParams) {
-					n.BlobVersionParams.Store(v2.NewBlobVersionParameterMap(blobParams))
-				}
-			} else {
-				n.Logger.Error("error fetching blob params", "err", err)
-			}
+		case update := <-n.onchainStateUpdates:
+			n.Logger.Info("Refreshing onchain state on update", "kind", update.Kind, "block", update.BlockNumber)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		n.refreshOnchainState(ctx)
+	}
+}
 
-			existingRelayClient, ok := n.RelayClient.Load().(clients.RelayClient)
-			if !ok {
-				n.Logger.Error("error fetching relay client")
-				continue
-			}
+func (n *Node) refreshOnchainState(ctx context.Context) {
+	existingBlobParams := n.BlobVersionParams.Load()
+	blobParams, err := n.Transactor.GetAllVersionedBlobParams(ctx)
+	if err == nil {
+		if existingBlobParams == nil || !existingBlobParams.Equal(blobParams) {
+			n.BlobVersionParams.Store(v2.NewBlobVersionParameterMap(blobParams))
+		}
+	} else {
+		n.Logger.Error("error fetching blob params", "err", err)
+	}
 
-			existingURLs := map[v2.RelayKey]string{}
-			if existingRelayClient != nil {
-				existingURLs = existingRelayClient.GetSockets()
-			}
-			relayURLs, err := n.Transactor.GetRelayURLs(ctx)
-			if err != nil {
-				n.Logger.Error("error fetching relay URLs", "err", err)
-				continue
-			}
+	existingRelayClient, ok := n.RelayClient.Load().(clients.RelayClient)
+	if !ok {
+		n.Logger.Error("error fetching relay client")
+		return
+	}
 
-			if maps.Equal(existingURLs, relayURLs) {
-				n.Logger.Info("No change in relay URLs")
-				continue
-			}
+	existingURLs := map[v2.RelayKey]string{}
+	if existingRelayClient != nil {
+		existingURLs = existingRelayClient.GetSockets()
+	}
+	relayURLs, err := n.Transactor.GetRelayURLs(ctx)
+	if err != nil {
+		n.Logger.Error("error fetching relay URLs", "err", err)
+		return
+	}
 
-			relayClient, err := clients.NewRelayClient(&clients.RelayClientConfig{
-				Sockets:           relayURLs,
-				UseSecureGrpcFlag: n.Config.UseSecureGrpc,
-				OperatorID:        &n.Config.ID,
-				MessageSigner:     n.SignMessage,
-			}, n.Logger)
-			if err != nil {
-				n.Logger.Error("error creating relay client", "err", err)
-				continue
-			}
+	if maps.Equal(existingURLs, relayURLs) {
+		n.Logger.Info("No change in relay URLs")
+		return
+	}
 
-			n.RelayClient.Store(clients.RelayClient(relayClient))
-		case <-ctx.Done():
-			return ctx.Err()
-		}
+	relayClient, err := clients.NewRelayClient(&clients.RelayClientConfig{
+		Sockets:           relayURLs,
+		UseSecureGrpcFlag: n.Config.UseSecureGrpc,
+		OperatorID:        &n.Config.ID,
+		MessageSigner:     n.SignMessage,
+	}, n.Logger)
+	if err != nil {
+		n.Logger.Error("error creating relay client", "err", err)
+		return
 	}
+
+	n.RelayClient.Store(clients.RelayClient(relayClient))
 }
 
 // ProcessBatch validates the batch is correct, stores data into the node's Store, and then returns a signature for the entire batch.
@@ -7,11 +7,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/pubip"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigenda/node/plugin"
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
@@ -35,6 +37,11 @@ func main() {
 		plugin.ChurnerUrlFlag,
 		plugin.NumConfirmationsFlag,
 		plugin.PubIPProviderFlag,
+		plugin.G1PathFlag,
+		plugin.G2PathFlag,
+		plugin.G2PowerOf2PathFlag,
+		plugin.SRSOrderFlag,
+		plugin.UseSecureGrpcFlag,
 	}
 	app.Name = "eigenda-node-plugin"
 	app.Usage = "EigenDA Node Plugin"
@@ -160,6 +167,38 @@ func pluginOps(ctx *cli.Context) {
 			return
 		}
 		log.Printf("Info: operator ID: %x, operator address: %x, current quorums: %v", operatorID, sk.Address, quorumIds)
+	} else if config.Operation == plugin.OperationDiagnose {
+		log.Printf("Info: diagnosing operator ID: %x, operator address: %x", operatorID, sk.Address)
+		diagnostics := plugin.NewDiagnostics(
+			plugin.DiagnosticsConfig{
+				Socket:         socket,
+				QuorumIDs:      config.QuorumIDList,
+				G1Path:         config.G1Path,
+				G2Path:         config.G2Path,
+				G2PowerOf2Path: config.G2PowerOf2Path,
+				SRSOrder:       config.SRSOrder,
+				Timeout:        operator.Timeout,
+			},
+			tx,
+			keyPair,
+			sk.Address,
+			func(sockets map[corev2.RelayKey]string) (clients.RelayClient, error) {
+				return clients.NewRelayClient(&clients.RelayClientConfig{
+					Sockets:           sockets,
+					UseSecureGrpcFlag: config.UseSecureGrpc,
+					OperatorID:        &operatorID,
+					MessageSigner: func(ctx context.Context, data [32]byte) (*core.Signature, error) {
+						return keyPair.SignMessage(data), nil
+					},
+				}, logger)
+			})
+		report := diagnostics.Run(context.Background())
+		log.Printf("Info: diagnostic report:\n%s", report)
+		if !report.Passed() {
+			log.Printf("Error: diagnose found failed checks for operator ID: %x, operator address: %x", operatorID, sk.Address)
+			return
+		}
+		log.Printf("Info: all diagnostic checks passed for operator ID: %x, operator address: %x", operatorID, sk.Address)
 	} else {
 		log.Fatalf("Fatal: unsupported operation: %s", config.Operation)
 	}
@@ -16,6 +16,7 @@ const (
 	OperationOptOut       = "opt-out"
 	OperationUpdateSocket = "update-socket"
 	OperationListQuorums  = "list-quorums"
+	OperationDiagnose     = "diagnose"
 )
 
 var (
@@ -32,7 +33,7 @@ var (
 	OperationFlag = cli.StringFlag{
 		Name:     "operation",
 		Required: true,
-		Usage:    "Supported operations: opt-in, opt-out, update-socket, list-quorums",
+		Usage:    "Supported operations: opt-in, opt-out, update-socket, list-quorums, diagnose",
 		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "OPERATION"),
 	}
 
@@ -110,6 +111,40 @@ var (
 		Value:    3,
 		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "NUM_CONFIRMATIONS"),
 	}
+
+	// The SRS files validated by the diagnose operation, with the same environment variables as the node.
+	G1PathFlag = cli.StringFlag{
+		Name:     "g1-path",
+		Usage:    "Path to G1 SRS, validated by the diagnose operation",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "G1_PATH"),
+	}
+	G2PathFlag = cli.StringFlag{
+		Name:     "g2-path",
+		Usage:    "Path to G2 SRS, validated by the diagnose operation",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "G2_PATH"),
+	}
+	G2PowerOf2PathFlag = cli.StringFlag{
+		Name:     "g2-power-of-2-path",
+		Usage:    "Path to G2 SRS points that are on power of 2, validated by the diagnose operation",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "G2_POWER_OF_2_PATH"),
+	}
+	SRSOrderFlag = cli.Uint64Flag{
+		Name:     "srs-order",
+		Usage:    "Order of the SRS, validated by the diagnose operation",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "SRS_ORDER"),
+	}
+	// Read from the same environment variable as the node's churner-use-secure-grpc flag, which the node also uses
+	// for its relay connections.
+	UseSecureGrpcFlag = cli.BoolTFlag{
+		Name:     "use-secure-grpc",
+		Usage:    "Whether to use secure GRPC connections to the relays, validated by the diagnose operation",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "CHURNER_USE_SECURE_GRPC"),
+	}
 )
 
 type Config struct {
@@ -126,6 +161,11 @@ type Config struct {
 	EigenDAServiceManagerAddr     string
 	ChurnerUrl                    string
 	NumConfirmations              int
+	G1Path                        string
+	G2Path                        string
+	G2PowerOf2Path                string
+	SRSOrder                      uint64
+	UseSecureGrpc                 bool
 }
 
 func NewConfig(ctx *cli.Context) (*Config, error) {
@@ -146,7 +186,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	if len(op) == 0 {
 		return nil, errors.New("operation type not provided")
 	}
-	if op != OperationOptIn && op != OperationOptOut && op != OperationUpdateSocket && op != OperationListQuorums {
+	if op != OperationOptIn && op != OperationOptOut && op != OperationUpdateSocket && op != OperationListQuorums && op != OperationDiagnose {
 		return nil, errors.New("unsupported operation type")
 	}
 
@@ -164,5 +204,10 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		EigenDAServiceManagerAddr:     ctx.GlobalString(EigenDAServiceManagerFlag.Name),
 		ChurnerUrl:                    ctx.GlobalString(ChurnerUrlFlag.Name),
 		NumConfirmations:              ctx.GlobalInt(NumConfirmationsFlag.Name),
+		G1Path:                        ctx.GlobalString(G1PathFlag.Name),
+		G2Path:                        ctx.GlobalString(G2PathFlag.Name),
+		G2PowerOf2Path:                ctx.GlobalString(G2PowerOf2PathFlag.Name),
+		SRSOrder:                      ctx.GlobalUint64(SRSOrderFlag.Name),
+		UseSecureGrpc:                 ctx.GlobalBoolT(UseSecureGrpcFlag.Name),
 	}, nil
 }
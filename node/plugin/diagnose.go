@@ -0,0 +1,394 @@
+package plugin
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckStatus is the outcome of a single diagnostic check.
+type CheckStatus string
+
+const (
+	CheckPass CheckStatus = "PASS"
+	CheckFail CheckStatus = "FAIL"
+	// CheckSkip is reported for checks which could not run because a check they depend on failed,
+	// or because the configuration they need was not provided.
+	CheckSkip CheckStatus = "SKIP"
+)
+
+// CheckResult is the result of a single diagnostic check.
+type CheckResult struct {
+	Name    string      `json:"name"`
+	Status  CheckStatus `json:"status"`
+	Message string      `json:"message"`
+}
+
+// DiagnosticReport is the structured result of the diagnose operation.
+type DiagnosticReport struct {
+	OperatorID      string        `json:"operator_id"`
+	OperatorAddress string        `json:"operator_address"`
+	Checks          []CheckResult `json:"checks"`
+}
+
+// Passed returns true if none of the checks in the report failed.
+func (r *DiagnosticReport) Passed() bool {
+	for _, check := range r.Checks {
+		if check.Status == CheckFail {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the report as a table with one check per line.
+func (r *DiagnosticReport) String() string {
+	width := 0
+	for _, check := range r.Checks {
+		width = max(width, len(check.Name))
+	}
+
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "operator ID: %s, operator address: %s\n", r.OperatorID, r.OperatorAddress)
+	for _, check := range r.Checks {
+		fmt.Fprintf(&sb, "%-4s  %-*s  %s\n", check.Status, width, check.Name, check.Message)
+	}
+	return sb.String()
+}
+
+func (r *DiagnosticReport) add(name string, checkStatus CheckStatus, format string, args ...any) {
+	r.Checks = append(r.Checks, CheckResult{
+		Name:    name,
+		Status:  checkStatus,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// RelayClientFactory creates a relay client for the relays with the given sockets. The client must sign
+// GetChunks requests with the operator's BLS key.
+type RelayClientFactory func(sockets map[corev2.RelayKey]string) (clients.RelayClient, error)
+
+// DiagnosticsConfig is the configuration of the diagnose operation.
+type DiagnosticsConfig struct {
+	// Socket is the socket the node is expected to be registered with, i.e. the configured socket with
+	// a localhost address replaced by the public IP of the node.
+	Socket string
+	// QuorumIDs are the quorums the operator is expected to be registered in.
+	QuorumIDs []core.QuorumID
+
+	G1Path         string
+	G2Path         string
+	G2PowerOf2Path string
+	SRSOrder       uint64
+
+	// Timeout bounds each network check (port dials and relay requests).
+	Timeout time.Duration
+}
+
+// Diagnostics runs the checks of the diagnose operation, which help an operator find out why the node is not
+// signing batches: its on-chain registration and stake, the reachability of its ports, its connectivity to
+// the relays, and the consistency of its BLS key and SRS files.
+type Diagnostics struct {
+	config          DiagnosticsConfig
+	reader          core.Reader
+	keyPair         *core.KeyPair
+	operatorAddress gethcommon.Address
+	newRelayClient  RelayClientFactory
+}
+
+func NewDiagnostics(
+	config DiagnosticsConfig,
+	reader core.Reader,
+	keyPair *core.KeyPair,
+	operatorAddress gethcommon.Address,
+	newRelayClient RelayClientFactory,
+) *Diagnostics {
+	return &Diagnostics{
+		config:          config,
+		reader:          reader,
+		keyPair:         keyPair,
+		operatorAddress: operatorAddress,
+		newRelayClient:  newRelayClient,
+	}
+}
+
+// Run runs all checks and returns the report. A failed check does not stop the remaining checks, except for
+// the checks which depend on it, which are skipped.
+func (d *Diagnostics) Run(ctx context.Context) *DiagnosticReport {
+	operatorID := d.keyPair.GetPubKeyG1().GetOperatorID()
+	report := &DiagnosticReport{
+		OperatorID:      operatorID.Hex(),
+		OperatorAddress: d.operatorAddress.Hex(),
+	}
+
+	d.checkBLSKey(ctx, report, operatorID)
+	registered := d.checkRegistration(ctx, report, operatorID)
+	d.checkStakes(ctx, report, registered)
+	registeredSocket := d.checkSocket(ctx, report, operatorID)
+	d.checkPorts(report, registeredSocket)
+	d.checkRelays(ctx, report, operatorID)
+	d.checkSRS(report)
+
+	return report
+}
+
+// checkBLSKey checks that the local BLS key is well formed and that it is the key registered for the operator.
+func (d *Diagnostics) checkBLSKey(ctx context.Context, report *DiagnosticReport, operatorID core.OperatorID) {
+	ok, err := d.keyPair.GetPubKeyG1().VerifyEquivalence(d.keyPair.GetPubKeyG2())
+	if err != nil || !ok {
+		report.add("bls-key", CheckFail, "the G1 and G2 public keys of the local BLS key do not match")
+		return
+	}
+
+	registeredID, err := d.reader.OperatorAddressToID(ctx, d.operatorAddress)
+	if err != nil {
+		report.add("bls-key", CheckFail, "failed to get the registered pubkey of the operator: %v", err)
+		return
+	}
+	if registeredID == (core.OperatorID{}) {
+		report.add("bls-key", CheckFail, "no BLS pubkey is registered for operator address %s", d.operatorAddress.Hex())
+		return
+	}
+	if registeredID != operatorID {
+		report.add("bls-key", CheckFail,
+			"the local BLS key does not match the registered pubkey: registered operator ID %s, local operator ID %s",
+			registeredID.Hex(), operatorID.Hex())
+		return
+	}
+	report.add("bls-key", CheckPass, "the local BLS key matches the registered pubkey")
+}
+
+// checkRegistration checks that the operator is registered in the expected quorums, and returns the quorums
+// it is registered in.
+func (d *Diagnostics) checkRegistration(
+	ctx context.Context,
+	report *DiagnosticReport,
+	operatorID core.OperatorID) []core.QuorumID {
+
+	quorumIDs, err := d.reader.GetRegisteredQuorumIdsForOperator(ctx, operatorID)
+	if err != nil {
+		report.add("registration", CheckFail, "failed to get the registered quorums: %v", err)
+		return nil
+	}
+	if len(quorumIDs) == 0 {
+		report.add("registration", CheckFail, "the operator is not registered in any quorum")
+		return nil
+	}
+
+	missing := make([]core.QuorumID, 0)
+	for _, expected := range d.config.QuorumIDs {
+		found := false
+		for _, quorumID := range quorumIDs {
+			if quorumID == expected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, expected)
+		}
+	}
+	if len(missing) > 0 {
+		report.add("registration", CheckFail,
+			"the operator is registered in quorums %v, but not in quorums %v", quorumIDs, missing)
+	} else {
+		report.add("registration", CheckPass, "the operator is registered in quorums %v", quorumIDs)
+	}
+	return quorumIDs
+}
+
+// checkStakes checks that the operator has stake in each of the quorums it is registered in.
+func (d *Diagnostics) checkStakes(ctx context.Context, report *DiagnosticReport, quorumIDs []core.QuorumID) {
+	if len(quorumIDs) == 0 {
+		report.add("stake", CheckSkip, "the operator is not registered in any quorum")
+		return
+	}
+	for _, quorumID := range quorumIDs {
+		name := fmt.Sprintf("stake-quorum-%d", quorumID)
+		stake, err := d.reader.WeightOfOperatorForQuorum(ctx, quorumID, d.operatorAddress)
+		if err != nil {
+			report.add(name, CheckFail, "failed to get the stake: %v", err)
+			continue
+		}
+		if stake == nil || stake.Cmp(big.NewInt(0)) <= 0 {
+			report.add(name, CheckFail, "the operator has no stake in quorum %d", quorumID)
+			continue
+		}
+		report.add(name, CheckPass, "stake: %s", stake.String())
+	}
+}
+
+// checkSocket checks that the registered socket matches the expected socket, and returns the registered socket,
+// or the expected socket if the registered one could not be fetched.
+func (d *Diagnostics) checkSocket(ctx context.Context, report *DiagnosticReport, operatorID core.OperatorID) string {
+	socket, err := d.reader.GetOperatorSocket(ctx, operatorID)
+	if err != nil {
+		report.add("socket", CheckFail, "failed to get the registered socket: %v", err)
+		return d.config.Socket
+	}
+	if socket != d.config.Socket {
+		report.add("socket", CheckFail,
+			"the registered socket %s does not match the expected socket %s, run the update-socket operation to fix it",
+			socket, d.config.Socket)
+		return socket
+	}
+	report.add("socket", CheckPass, "the registered socket is %s", socket)
+	return socket
+}
+
+// checkPorts checks that the dispersal and retrieval ports of the socket accept TCP connections.
+func (d *Diagnostics) checkPorts(report *DiagnosticReport, socket string) {
+	host, dispersalPort, retrievalPort, err := core.ParseOperatorSocket(socket)
+	if err != nil {
+		report.add("dispersal-port", CheckSkip, "invalid socket: %v", err)
+		report.add("retrieval-port", CheckSkip, "invalid socket: %v", err)
+		return
+	}
+	for _, port := range []struct {
+		name string
+		port string
+	}{
+		{"dispersal-port", dispersalPort},
+		{"retrieval-port", retrievalPort},
+	} {
+		address := net.JoinHostPort(host, port.port)
+		conn, err := net.DialTimeout("tcp", address, d.config.Timeout)
+		if err != nil {
+			report.add(port.name, CheckFail, "%s is not reachable: %v", address, err)
+			continue
+		}
+		_ = conn.Close()
+		report.add(port.name, CheckPass, "%s is reachable", address)
+	}
+}
+
+// checkRelays sends a signed GetChunks request for a random blob to every relay. A relay that authenticates the
+// request replies that the blob is not found, so any other reply means the relay is unreachable or rejects
+// the operator.
+func (d *Diagnostics) checkRelays(ctx context.Context, report *DiagnosticReport, operatorID core.OperatorID) {
+	relayURLs, err := d.reader.GetRelayURLs(ctx)
+	if err != nil {
+		report.add("relays", CheckFail, "failed to get the relay URLs: %v", err)
+		return
+	}
+	if len(relayURLs) == 0 {
+		report.add("relays", CheckSkip, "no relays are registered")
+		return
+	}
+
+	sockets := make(map[corev2.RelayKey]string, len(relayURLs))
+	keys := make([]corev2.RelayKey, 0, len(relayURLs))
+	for key, url := range relayURLs {
+		sockets[corev2.RelayKey(key)] = url
+		keys = append(keys, corev2.RelayKey(key))
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	relayClient, err := d.newRelayClient(sockets)
+	if err != nil {
+		report.add("relays", CheckFail, "failed to create the relay client: %v", err)
+		return
+	}
+	defer func() {
+		_ = relayClient.Close()
+	}()
+
+	blobKey := corev2.BlobKey{}
+	_, _ = rand.Read(blobKey[:])
+	for _, key := range keys {
+		name := fmt.Sprintf("relay-%d", key)
+		requestCtx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+		_, err := relayClient.GetChunksByRange(requestCtx, key, []*clients.ChunkRequestByRange{
+			{BlobKey: blobKey, Start: 0, End: 1},
+		})
+		cancel()
+
+		switch status.Code(err) {
+		case codes.OK, codes.NotFound:
+			report.add(name, CheckPass, "%s accepted a signed GetChunks request", sockets[key])
+		case codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied:
+			report.add(name, CheckFail, "%s rejected a signed GetChunks request: %v", sockets[key], err)
+		default:
+			report.add(name, CheckFail, "%s is not reachable: %v", sockets[key], err)
+		}
+	}
+}
+
+// checkSRS checks that the G1 SRS file holds SRSOrder points starting with the generator, and that its points
+// are consistent with the G2 SRS file, i.e. e([tau]_1, [1]_2) = e([1]_1, [tau]_2).
+func (d *Diagnostics) checkSRS(report *DiagnosticReport) {
+	if d.config.G1Path == "" {
+		report.add("srs", CheckSkip, "no G1 SRS path is configured")
+		return
+	}
+	if err := verifySRS(d.config.G1Path, d.config.G2Path, d.config.G2PowerOf2Path, d.config.SRSOrder); err != nil {
+		report.add("srs", CheckFail, "%v", err)
+		return
+	}
+	report.add("srs", CheckPass, "the SRS files are valid")
+}
+
+func verifySRS(g1Path string, g2Path string, g2PowerOf2Path string, srsOrder uint64) error {
+	info, err := os.Stat(g1Path)
+	if err != nil {
+		return fmt.Errorf("cannot read the G1 SRS file: %w", err)
+	}
+	if srsOrder > 0 && uint64(info.Size()) < srsOrder*kzg.G1PointBytes {
+		return fmt.Errorf("the G1 SRS file %s holds %d points, less than the SRS order %d",
+			g1Path, uint64(info.Size())/kzg.G1PointBytes, srsOrder)
+	}
+	g1Points, err := kzg.ReadG1PointSection(g1Path, 0, 2, 1)
+	if err != nil {
+		return fmt.Errorf("cannot read the G1 SRS file: %w", err)
+	}
+	_, _, g1Generator, g2Generator := bn254.Generators()
+	if !g1Points[0].Equal(&g1Generator) {
+		return fmt.Errorf("the first point of the G1 SRS file %s is not the generator", g1Path)
+	}
+
+	var g2Tau bn254.G2Affine
+	switch {
+	case g2PowerOf2Path != "":
+		g2Tau, err = kzg.ReadG2PointOnPowerOf2(0, max(srsOrder, 2), g2PowerOf2Path)
+	case g2Path != "":
+		var g2Points []bn254.G2Affine
+		g2Points, err = kzg.ReadG2PointSection(g2Path, 1, 2, 1)
+		if err == nil {
+			g2Tau = g2Points[0]
+		}
+	default:
+		return errors.New("neither a G2 SRS path nor a G2 power of 2 SRS path is configured")
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read the G2 SRS file: %w", err)
+	}
+
+	var negG1Generator bn254.G1Affine
+	negG1Generator.Neg(&g1Generator)
+	ok, err := bn254.PairingCheck(
+		[]bn254.G1Affine{g1Points[1], negG1Generator},
+		[]bn254.G2Affine{g2Generator, g2Tau})
+	if err != nil {
+		return fmt.Errorf("failed to check the SRS pairing: %w", err)
+	}
+	if !ok {
+		return errors.New("the G1 and G2 SRS files are not from the same trusted setup")
+	}
+	return nil
+}
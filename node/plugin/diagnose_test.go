@@ -0,0 +1,174 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	g1Path         = "../../inabox/resources/kzg/g1.point"
+	g2Path         = "../../inabox/resources/kzg/g2.point"
+	g2PowerOf2Path = "../../inabox/resources/kzg/g2.point.powerOf2"
+	srsOrder       = 3000
+)
+
+func checkStatuses(report *DiagnosticReport) map[string]CheckStatus {
+	statuses := make(map[string]CheckStatus)
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func checkMessages(report *DiagnosticReport) map[string]string {
+	messages := make(map[string]string)
+	for _, check := range report.Checks {
+		messages[check.Name] = check.Message
+	}
+	return messages
+}
+
+func TestDiagnose(t *testing.T) {
+	keyPair, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	operatorID := keyPair.GetPubKeyG1().GetOperatorID()
+	operatorAddress := gethcommon.HexToAddress("0x1234")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	socket := core.MakeOperatorSocket("127.0.0.1", port, port).String()
+
+	reader := &coremock.MockWriter{}
+	reader.On("OperatorAddressToID").Return(operatorID, nil)
+	reader.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{0, 1}, nil)
+	reader.On("WeightOfOperatorForQuorum").Return(big.NewInt(100), nil)
+	reader.On("GetOperatorSocket").Return(socket, nil)
+	reader.On("GetRelayURLs").Return(
+		map[uint32]string{0: "relay0:443", 1: "relay1:443", 2: "relay2:443", 3: "relay3:443"}, nil)
+
+	relayClient := clientsmock.NewRelayClient()
+	relayClient.On("GetChunksByRange", mock.Anything, corev2.RelayKey(0), mock.Anything).
+		Return(nil, api.NewErrorNotFound("blob not found"))
+	relayClient.On("GetChunksByRange", mock.Anything, corev2.RelayKey(1), mock.Anything).
+		Return(nil, api.NewErrorUnauthenticated("auth failed"))
+	relayClient.On("GetChunksByRange", mock.Anything, corev2.RelayKey(2), mock.Anything).
+		Return(nil, status.Error(codes.PermissionDenied, "operator not allowed"))
+	relayClient.On("GetChunksByRange", mock.Anything, corev2.RelayKey(3), mock.Anything).
+		Return(nil, status.Error(codes.Unavailable, "connection refused"))
+	relayClient.On("Close").Return(nil)
+
+	diagnostics := NewDiagnostics(
+		DiagnosticsConfig{
+			Socket:         socket,
+			QuorumIDs:      []core.QuorumID{0, 1},
+			G1Path:         g1Path,
+			G2PowerOf2Path: g2PowerOf2Path,
+			SRSOrder:       srsOrder,
+			Timeout:        time.Second,
+		},
+		reader,
+		keyPair,
+		operatorAddress,
+		func(sockets map[corev2.RelayKey]string) (clients.RelayClient, error) {
+			return relayClient, nil
+		})
+
+	report := diagnostics.Run(context.Background())
+	assert.Equal(t, operatorID.Hex(), report.OperatorID)
+	assert.Equal(t, map[string]CheckStatus{
+		"bls-key":        CheckPass,
+		"registration":   CheckPass,
+		"stake-quorum-0": CheckPass,
+		"stake-quorum-1": CheckPass,
+		"socket":         CheckPass,
+		"dispersal-port": CheckPass,
+		"retrieval-port": CheckPass,
+		"relay-0":        CheckPass,
+		"relay-1":        CheckFail,
+		"relay-2":        CheckFail,
+		"relay-3":        CheckFail,
+		"srs":            CheckPass,
+	}, checkStatuses(report), report.String())
+	messages := checkMessages(report)
+	assert.Contains(t, messages["relay-1"], "rejected a signed GetChunks request")
+	assert.Contains(t, messages["relay-2"], "rejected a signed GetChunks request")
+	assert.Contains(t, messages["relay-3"], "is not reachable")
+	assert.False(t, report.Passed())
+}
+
+func TestDiagnoseUnregisteredOperator(t *testing.T) {
+	keyPair, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	otherKeyPair, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+
+	reader := &coremock.MockWriter{}
+	// the operator address is registered with another BLS key
+	reader.On("OperatorAddressToID").Return(otherKeyPair.GetPubKeyG1().GetOperatorID(), nil)
+	reader.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{}, nil)
+	reader.On("GetOperatorSocket").Return("", errors.New("operator socket string is empty"))
+	reader.On("GetRelayURLs").Return(map[uint32]string{}, nil)
+
+	diagnostics := NewDiagnostics(
+		DiagnosticsConfig{
+			// nothing listens on the reserved port 0
+			Socket:    core.MakeOperatorSocket("127.0.0.1", "0", "0").String(),
+			QuorumIDs: []core.QuorumID{0},
+			G1Path:    g1Path,
+			G2Path:    g2Path,
+			// the test SRS has fewer points than this
+			SRSOrder: 2 * srsOrder,
+			Timeout:  time.Second,
+		},
+		reader,
+		keyPair,
+		gethcommon.HexToAddress("0x1234"),
+		nil)
+
+	report := diagnostics.Run(context.Background())
+	assert.Equal(t, map[string]CheckStatus{
+		"bls-key":        CheckFail,
+		"registration":   CheckFail,
+		"stake":          CheckSkip,
+		"socket":         CheckFail,
+		"dispersal-port": CheckFail,
+		"retrieval-port": CheckFail,
+		"relays":         CheckSkip,
+		"srs":            CheckFail,
+	}, checkStatuses(report), report.String())
+	assert.False(t, report.Passed())
+}
+
+func TestVerifySRS(t *testing.T) {
+	require.NoError(t, verifySRS(g1Path, g2Path, "", srsOrder))
+	require.NoError(t, verifySRS(g1Path, "", g2PowerOf2Path, srsOrder))
+
+	// a G2 file whose first point is the generator rather than [tau]_2
+	require.Error(t, verifySRS(g1Path, "", g2Path, srsOrder))
+	// no G2 SRS
+	require.Error(t, verifySRS(g1Path, "", "", srsOrder))
+	// missing G1 SRS
+	require.Error(t, verifySRS("does-not-exist", g2Path, "", srsOrder))
+}
@@ -85,8 +85,14 @@ func (s *storeV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]k
 		return nil, 0, fmt.Errorf("failed to serialize batch header: %v", err)
 	}
 
+	// the batch header is kept as long as the blob with the longest retention tier in the batch
+	batchHeaderTTL := s.ttl
+	for _, bundles := range rawBundles {
+		batchHeaderTTL = max(batchHeaderTTL, bundles.BlobCertificate.BlobHeader.RetentionTier.Retention(s.ttl))
+	}
+
 	keys = append(keys, batchHeaderKey)
-	dbBatch.PutWithTTL(batchHeaderKey, batchHeaderBytes, s.ttl)
+	dbBatch.PutWithTTL(batchHeaderKey, batchHeaderBytes, batchHeaderTTL)
 	size += uint64(len(batchHeaderBytes))
 
 	// Store blob shards
@@ -95,6 +101,7 @@ func (s *storeV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]k
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to get blob key: %v", err)
 		}
+		ttl := bundles.BlobCertificate.BlobHeader.RetentionTier.Retention(s.ttl)
 
 		// Store bundles
 		for quorum, bundle := range bundles.Bundles {
@@ -109,7 +116,7 @@ func (s *storeV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]k
 			}
 
 			keys = append(keys, bundlesKeyBuilder.Key(k))
-			dbBatch.PutWithTTL(bundlesKeyBuilder.Key(k), bundle, s.ttl)
+			dbBatch.PutWithTTL(bundlesKeyBuilder.Key(k), bundle, ttl)
 			size += uint64(len(bundle))
 		}
 	}
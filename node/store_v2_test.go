@@ -102,6 +102,68 @@ func TestStoreBatchV2(t *testing.T) {
 	}
 }
 
+func TestStoreBatchV2RetentionTier(t *testing.T) {
+	_, batch, bundles := nodemock.MockBatch(t)
+	// the second blob is held for four times as long as the others
+	batch.BlobCertificates[1].BlobHeader.RetentionTier = corev2.RetentionTierArchival
+
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+
+	logger := logging.NewNoopLogger()
+	config := tablestore.DefaultLevelDBConfig(t.TempDir())
+	config.Schema = []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName}
+	config.GarbageCollectionInterval = 50 * time.Millisecond
+	db, err := tablestore.Start(logger, config)
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	s := node.NewLevelDBStoreV2(db, logger, time.Second)
+
+	_, _, err = s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	bundleKeyBuilder, err := db.GetKeyBuilder(node.BundleTableName)
+	require.NoError(t, err)
+	bundleExists := func(i int) bool {
+		blobKey, err := rawBundles[i].BlobCertificate.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		for quorum := range rawBundles[i].Bundles {
+			k, err := node.BundleKey(blobKey, quorum)
+			require.NoError(t, err)
+			if _, err := db.Get(bundleKeyBuilder.Key(k)); err == nil {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the bundles of standard blobs expire after the standard TTL
+	require.Eventually(t, func() bool {
+		return !bundleExists(0) && !bundleExists(2)
+	}, 3*time.Second, 50*time.Millisecond)
+
+	// the bundles of the archival blob and the batch header are still held
+	assert.True(t, bundleExists(1))
+	bhh, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+	batchHeaderKeyBuilder, err := db.GetKeyBuilder(node.BatchHeaderTableName)
+	require.NoError(t, err)
+	_, err = db.Get(batchHeaderKeyBuilder.Key(bhh[:]))
+	assert.NoError(t, err)
+}
+
 func TestGetChunks(t *testing.T) {
 	blobKeys, batch, bundles := nodemock.MockBatch(t)
 
@@ -189,7 +189,11 @@ func TestRandomProofs(t *testing.T) {
 }
 
 func generateRandomFrames(t *testing.T, encoder *rs.Encoder, size int, params encoding.EncodingParams) []*rs.Frame {
-	frames, _, err := encoder.EncodeBytes(codec.ConvertByPaddingEmptyByte(tu.RandomBytes(size)), params)
+	return generateFrames(t, encoder, codec.ConvertByPaddingEmptyByte(tu.RandomBytes(size)), params)
+}
+
+func generateFrames(t *testing.T, encoder *rs.Encoder, data []byte, params encoding.EncodingParams) []*rs.Frame {
+	frames, _, err := encoder.EncodeBytes(data, params)
 	result := make([]*rs.Frame, len(frames))
 	require.NoError(t, err)
 
@@ -291,3 +295,130 @@ func TestCheckProofCoefficientsExist(t *testing.T) {
 		require.Equal(t, metadata, fragmentInfo)
 	}
 }
+
+func TestStreamedCoefficients(t *testing.T) {
+	tu.InitializeRandom()
+	client := mock.NewS3Client()
+	logger := logging.NewNoopLogger()
+
+	chunkSize := uint64(rand.Intn(1024) + 100)
+	fragmentSize := int(chunkSize / 2)
+
+	params := encoding.ParamsFromSysPar(3, 1, chunkSize)
+	cfg := encoding.DefaultConfig()
+	encoder, err := rs.NewEncoder(cfg)
+	require.NoError(t, err)
+
+	writer := NewChunkWriter(logger, client, bucket, fragmentSize)
+	reader := NewChunkReader(logger, client, bucket)
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		data := codec.ConvertByPaddingEmptyByte(tu.RandomBytes(int(chunkSize)))
+		inputFr, err := rs.ToFrArray(data)
+		require.NoError(t, err)
+
+		// write the same frames all at once and streamed, and expect identical results
+		expected := generateFrames(t, encoder, data, params)
+		expectedKey := corev2.BlobKey(tu.RandomBytes(32))
+		expectedInfo, err := writer.PutChunkCoefficients(ctx, expectedKey, expected)
+		require.NoError(t, err)
+
+		key := corev2.BlobKey(tu.RandomBytes(32))
+		coefficientsWriter, err := writer.NewCoefficientsWriter(ctx, key, uint32(params.NumChunks), uint32(params.ChunkLength))
+		require.NoError(t, err)
+		batchSize := uint64(rand.Intn(int(params.NumChunks)) + 1)
+		err = encoder.EncodeStream(inputFr, params, batchSize, func(frames []rs.Frame) error {
+			require.LessOrEqual(t, uint64(len(frames)), batchSize)
+			batch := make([]*rs.Frame, len(frames))
+			for j := range frames {
+				batch[j] = &frames[j]
+			}
+			return coefficientsWriter.WriteFrames(batch)
+		})
+		require.NoError(t, err)
+		fragmentInfo, err := coefficientsWriter.Close()
+		require.NoError(t, err)
+		require.Equal(t, expectedInfo, fragmentInfo)
+
+		exist, existingInfo := writer.CoefficientsExists(ctx, key)
+		require.True(t, exist)
+		require.Equal(t, fragmentInfo, existingInfo)
+
+		coefficients, err := reader.GetChunkCoefficients(ctx, key, fragmentInfo)
+		require.NoError(t, err)
+		require.Equal(t, len(expected), len(coefficients))
+		for j := range expected {
+			require.Equal(t, *expected[j], *coefficients[j])
+		}
+	}
+
+	// closing before all frames are written fails
+	coefficientsWriter, err := writer.NewCoefficientsWriter(ctx, corev2.BlobKey(tu.RandomBytes(32)), 2, 4)
+	require.NoError(t, err)
+	_, err = coefficientsWriter.Close()
+	require.Error(t, err)
+}
+
+func TestReuseEncoding(t *testing.T) {
+	tu.InitializeRandom()
+	client := mock.NewS3Client()
+	logger := logging.NewNoopLogger()
+
+	chunkSize := uint64(rand.Intn(1024) + 100)
+	fragmentSize := int(chunkSize / 2)
+
+	params := encoding.ParamsFromSysPar(3, 1, chunkSize)
+	encoder, err := rs.NewEncoder(encoding.DefaultConfig())
+	require.NoError(t, err)
+
+	writer := NewChunkWriter(logger, client, bucket, fragmentSize)
+	reader := NewChunkReader(logger, client, bucket)
+	ctx := context.Background()
+
+	source := corev2.BlobKey(tu.RandomBytes(32))
+	proofs := getProofs(t, int(params.NumChunks))
+	require.NoError(t, writer.PutChunkProofs(ctx, source, proofs))
+	frames := generateRandomFrames(t, encoder, int(chunkSize), params)
+	fragmentInfo, err := writer.PutChunkCoefficients(ctx, source, frames)
+	require.NoError(t, err)
+
+	encodingHash := "0123456789abcdef"
+	require.NoError(t, writer.PutEncoding(ctx, encodingHash, source, fragmentInfo))
+	recordedSource, recordedInfo, ok := writer.GetEncoding(ctx, encodingHash)
+	require.True(t, ok)
+	require.Equal(t, source, recordedSource)
+	require.Equal(t, fragmentInfo, recordedInfo)
+
+	// chunks are copied by S3, without being downloaded
+	destination := corev2.BlobKey(tu.RandomBytes(32))
+	downloads := client.Called["DownloadObject"] + client.Called["FragmentedDownloadObject"]
+	require.NoError(t, writer.CopyChunks(ctx, source, destination, fragmentInfo))
+	require.Equal(t, downloads, client.Called["DownloadObject"]+client.Called["FragmentedDownloadObject"])
+
+	copiedProofs, err := reader.GetChunkProofs(ctx, destination)
+	require.NoError(t, err)
+	require.Equal(t, proofs, copiedProofs)
+	copiedFrames, err := reader.GetChunkCoefficients(ctx, destination, fragmentInfo)
+	require.NoError(t, err)
+	require.Equal(t, len(frames), len(copiedFrames))
+	for i := range frames {
+		require.Equal(t, *frames[i], *copiedFrames[i])
+	}
+
+	// the encoding is deleted with the chunks of the blob it was recorded for, and deleting it again succeeds
+	require.NoError(t, writer.DeleteEncoding(ctx, destination))
+	_, _, ok = writer.GetEncoding(ctx, encodingHash)
+	require.True(t, ok)
+	require.NoError(t, writer.DeleteEncoding(ctx, source))
+	_, _, ok = writer.GetEncoding(ctx, encodingHash)
+	require.False(t, ok)
+	require.NoError(t, writer.DeleteEncoding(ctx, source))
+	objects, err := client.ListObjects(ctx, bucket, s3.ScopedEncodingReferenceKey(source))
+	require.NoError(t, err)
+	require.Empty(t, objects)
+
+	// copying the chunks of a deleted blob fails
+	err = writer.CopyChunks(ctx, corev2.BlobKey(tu.RandomBytes(32)), destination, fragmentInfo)
+	require.ErrorIs(t, err, s3.ErrObjectNotFound)
+}
@@ -2,6 +2,8 @@ package chunkstore
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
@@ -21,11 +23,54 @@ type ChunkWriter interface {
 		ctx context.Context,
 		blobKey corev2.BlobKey,
 		frames []*rs.Frame) (*encoding.FragmentInfo, error)
+	// NewCoefficientsWriter creates a writer that uploads frames to the chunk store as they are produced, rather than
+	// all at once. The frames are stored in the same format as PutChunkCoefficients. The number of frames and the
+	// number of coefficients in each frame must be known up front.
+	NewCoefficientsWriter(
+		ctx context.Context,
+		blobKey corev2.BlobKey,
+		numFrames uint32,
+		frameLength uint32) (CoefficientsWriter, error)
 	// ProofExists checks if the proofs for the blob key exist in the chunk store.
 	ProofExists(ctx context.Context, blobKey corev2.BlobKey) bool
 	// CoefficientsExists checks if the coefficients for the blob key exist in the chunk store.
 	// Returns a bool indicating if the coefficients exist and fragment info.
 	CoefficientsExists(ctx context.Context, blobKey corev2.BlobKey) (bool, *encoding.FragmentInfo)
+	// WithRetentionTier returns a ChunkWriter that tags the chunks it writes with the given retention tier, so that
+	// the lifecycle rules of the bucket keep them for as long as the blob. The chunks of a ChunkWriter created with
+	// NewChunkWriter have the standard retention tier.
+	WithRetentionTier(tier corev2.RetentionTier) ChunkWriter
+	// PutEncoding records that the chunks of the blob key, with the given fragment info, are the encoding
+	// identified by encodingHash, so that blobs with the same encoding can copy them instead of encoding again.
+	// The record is deleted by DeleteEncoding when the chunks of the blob are deleted.
+	PutEncoding(
+		ctx context.Context,
+		encodingHash string,
+		blobKey corev2.BlobKey,
+		fragmentInfo *encoding.FragmentInfo) error
+	// GetEncoding returns the blob key and fragment info recorded by PutEncoding for the encoding, and false if the
+	// encoding was never recorded. The chunks of the blob may have been deleted since.
+	GetEncoding(ctx context.Context, encodingHash string) (corev2.BlobKey, *encoding.FragmentInfo, bool)
+	// DeleteEncoding deletes the encoding recorded by PutEncoding for the chunks of the blob, if any, so that no
+	// blob copies the chunks of the blob once they are deleted. Deleting the encoding of a blob without one succeeds.
+	DeleteEncoding(ctx context.Context, blobKey corev2.BlobKey) error
+	// CopyChunks copies the proofs and coefficients of the source blob to the destination blob. The objects are
+	// copied by S3 without being downloaded. The proofs are copied last, so that the destination is never considered
+	// encoded before all of its coefficients are stored.
+	CopyChunks(
+		ctx context.Context,
+		source corev2.BlobKey,
+		destination corev2.BlobKey,
+		fragmentInfo *encoding.FragmentInfo) error
+}
+
+// CoefficientsWriter writes the frames of a single blob to the chunk store incrementally.
+type CoefficientsWriter interface {
+	// WriteFrames writes the next frames of the blob, in frame order.
+	WriteFrames(frames []*rs.Frame) error
+	// Close waits for all frames to be uploaded and returns the fragment info of the stored coefficients.
+	// It is an error to close the writer before all frames have been written.
+	Close() (*encoding.FragmentInfo, error)
 }
 
 var _ ChunkWriter = (*chunkWriter)(nil)
@@ -35,6 +80,8 @@ type chunkWriter struct {
 	s3Client     s3.Client
 	bucketName   string
 	fragmentSize int
+	// tags are the tags of the uploaded objects
+	tags []s3.Tag
 }
 
 // NewChunkWriter creates a new ChunkWriter.
@@ -49,9 +96,16 @@ func NewChunkWriter(
 		s3Client:     s3Client,
 		bucketName:   bucketName,
 		fragmentSize: fragmentSize,
+		tags:         s3.RetentionTierTags(corev2.RetentionTierStandard),
 	}
 }
 
+func (c *chunkWriter) WithRetentionTier(tier corev2.RetentionTier) ChunkWriter {
+	writer := *c
+	writer.tags = s3.RetentionTierTags(tier)
+	return &writer
+}
+
 func (c *chunkWriter) PutChunkProofs(ctx context.Context, blobKey corev2.BlobKey, proofs []*encoding.Proof) error {
 	if len(proofs) == 0 {
 		return fmt.Errorf("no proofs to upload")
@@ -63,7 +117,7 @@ func (c *chunkWriter) PutChunkProofs(ctx context.Context, blobKey corev2.BlobKey
 		bytes = append(bytes, proofBytes[:]...)
 	}
 
-	err := c.s3Client.UploadObject(ctx, c.bucketName, s3.ScopedProofKey(blobKey), bytes)
+	err := c.s3Client.UploadObject(ctx, c.bucketName, s3.ScopedProofKey(blobKey), bytes, c.tags...)
 	if err != nil {
 		c.logger.Errorf("Failed to upload chunk proofs to S3: %v", err)
 		return fmt.Errorf("failed to upload chunk proofs to S3: %v", err)
@@ -85,7 +139,7 @@ func (c *chunkWriter) PutChunkCoefficients(
 		return nil, fmt.Errorf("failed to encode frames: %v", err)
 	}
 
-	err = c.s3Client.FragmentedUploadObject(ctx, c.bucketName, s3.ScopedChunkKey(blobKey), bytes, c.fragmentSize)
+	err = c.s3Client.FragmentedUploadObject(ctx, c.bucketName, s3.ScopedChunkKey(blobKey), bytes, c.fragmentSize, c.tags...)
 	if err != nil {
 		c.logger.Errorf("Failed to upload chunk coefficients to S3: %v", err)
 		return nil, fmt.Errorf("failed to upload chunk coefficients to S3: %v", err)
@@ -97,6 +151,106 @@ func (c *chunkWriter) PutChunkCoefficients(
 	}, nil
 }
 
+// maxFragmentsInFlight is the number of coefficient fragments that a CoefficientsWriter buffers while they are
+// being uploaded.
+const maxFragmentsInFlight = 4
+
+func (c *chunkWriter) NewCoefficientsWriter(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	numFrames uint32,
+	frameLength uint32) (CoefficientsWriter, error) {
+
+	if numFrames == 0 {
+		return nil, fmt.Errorf("no frames to upload")
+	}
+
+	// the layout must match rs.GnarkEncodeFrames
+	totalSize := 4 + int(numFrames)*(4+encoding.BYTES_PER_SYMBOL*int(frameLength))
+
+	writer, err := s3.NewFragmentWriter(
+		ctx,
+		c.s3Client,
+		c.bucketName,
+		s3.ScopedChunkKey(blobKey),
+		totalSize,
+		c.fragmentSize,
+		maxFragmentsInFlight,
+		c.tags...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fragment writer: %v", err)
+	}
+
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, numFrames)
+	if _, err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write frame count: %v", err)
+	}
+
+	return &coefficientsWriter{
+		logger:       c.logger,
+		writer:       writer,
+		numFrames:    numFrames,
+		frameLength:  frameLength,
+		totalSize:    uint32(totalSize),
+		fragmentSize: uint32(c.fragmentSize),
+	}, nil
+}
+
+type coefficientsWriter struct {
+	logger       logging.Logger
+	writer       *s3.FragmentWriter
+	numFrames    uint32
+	frameLength  uint32
+	written      uint32
+	totalSize    uint32
+	fragmentSize uint32
+	buffer       []byte
+}
+
+func (w *coefficientsWriter) WriteFrames(frames []*rs.Frame) error {
+	if w.written+uint32(len(frames)) > w.numFrames {
+		return fmt.Errorf("cannot write %d frames, only %d of %d frames remain",
+			len(frames), w.numFrames-w.written, w.numFrames)
+	}
+
+	for _, frame := range frames {
+		if uint32(len(frame.Coeffs)) != w.frameLength {
+			return fmt.Errorf("frame has %d coefficients, expected %d", len(frame.Coeffs), w.frameLength)
+		}
+
+		size := 4 + rs.GnarkFrameSize(frame)
+		if uint32(cap(w.buffer)) < size {
+			w.buffer = make([]byte, size)
+		}
+		n := rs.GnarkEncodeFrame(frame, w.buffer[:size])
+
+		if _, err := w.writer.Write(w.buffer[:n]); err != nil {
+			w.logger.Errorf("Failed to upload chunk coefficients to S3: %v", err)
+			return fmt.Errorf("failed to upload chunk coefficients to S3: %v", err)
+		}
+		w.written++
+	}
+
+	return nil
+}
+
+func (w *coefficientsWriter) Close() (*encoding.FragmentInfo, error) {
+	if w.written != w.numFrames {
+		return nil, fmt.Errorf("expected %d frames to be written, got %d", w.numFrames, w.written)
+	}
+
+	if err := w.writer.Close(); err != nil {
+		w.logger.Errorf("Failed to upload chunk coefficients to S3: %v", err)
+		return nil, fmt.Errorf("failed to upload chunk coefficients to S3: %v", err)
+	}
+
+	return &encoding.FragmentInfo{
+		TotalChunkSizeBytes: w.totalSize,
+		FragmentSizeBytes:   w.fragmentSize,
+	}, nil
+}
+
 func (c *chunkWriter) ProofExists(ctx context.Context, blobKey corev2.BlobKey) bool {
 	size, err := c.s3Client.HeadObject(ctx, c.bucketName, s3.ScopedProofKey(blobKey))
 	if err == nil && size != nil && *size > 0 {
@@ -125,3 +279,109 @@ func (c *chunkWriter) CoefficientsExists(ctx context.Context, blobKey corev2.Blo
 		FragmentSizeBytes:   uint32(c.fragmentSize),
 	}
 }
+
+// encodingRecordSize is the size of the record written by PutEncoding: a blob key, followed by the total chunk size
+// and the fragment size of its coefficients.
+const encodingRecordSize = 32 + 4 + 4
+
+func (c *chunkWriter) PutEncoding(
+	ctx context.Context,
+	encodingHash string,
+	blobKey corev2.BlobKey,
+	fragmentInfo *encoding.FragmentInfo) error {
+
+	record := make([]byte, encodingRecordSize)
+	copy(record, blobKey[:])
+	binary.BigEndian.PutUint32(record[32:], fragmentInfo.TotalChunkSizeBytes)
+	binary.BigEndian.PutUint32(record[36:], fragmentInfo.FragmentSizeBytes)
+
+	// Both objects expire with the chunks they point to. The reference is stored first, so that DeleteEncoding
+	// always finds the record.
+	err := c.s3Client.UploadObject(
+		ctx, c.bucketName, s3.ScopedEncodingReferenceKey(blobKey), []byte(encodingHash), c.tags...)
+	if err != nil {
+		c.logger.Errorf("Failed to upload encoding reference to S3: %v", err)
+		return fmt.Errorf("failed to upload encoding reference to S3: %v", err)
+	}
+	err = c.s3Client.UploadObject(ctx, c.bucketName, s3.ScopedEncodingKey(encodingHash), record, c.tags...)
+	if err != nil {
+		c.logger.Errorf("Failed to upload encoding to S3: %v", err)
+		return fmt.Errorf("failed to upload encoding to S3: %v", err)
+	}
+	return nil
+}
+
+func (c *chunkWriter) GetEncoding(
+	ctx context.Context,
+	encodingHash string) (corev2.BlobKey, *encoding.FragmentInfo, bool) {
+
+	record, err := c.s3Client.DownloadObject(ctx, c.bucketName, s3.ScopedEncodingKey(encodingHash))
+	if err != nil || len(record) != encodingRecordSize {
+		return corev2.BlobKey{}, nil, false
+	}
+
+	var blobKey corev2.BlobKey
+	copy(blobKey[:], record)
+	return blobKey, &encoding.FragmentInfo{
+		TotalChunkSizeBytes: binary.BigEndian.Uint32(record[32:]),
+		FragmentSizeBytes:   binary.BigEndian.Uint32(record[36:]),
+	}, true
+}
+
+func (c *chunkWriter) DeleteEncoding(ctx context.Context, blobKey corev2.BlobKey) error {
+	encodingHash, err := c.s3Client.DownloadObject(ctx, c.bucketName, s3.ScopedEncodingReferenceKey(blobKey))
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download encoding reference from S3: %w", err)
+	}
+
+	// the encoding may have been recorded for another blob with the same encoding since
+	source, _, ok := c.GetEncoding(ctx, string(encodingHash))
+	if ok && source == blobKey {
+		if err := c.s3Client.DeleteObject(ctx, c.bucketName, s3.ScopedEncodingKey(string(encodingHash))); err != nil {
+			return fmt.Errorf("failed to delete encoding from S3: %w", err)
+		}
+	}
+
+	// the reference is deleted last, so that a failed deletion can be retried
+	if err := c.s3Client.DeleteObject(ctx, c.bucketName, s3.ScopedEncodingReferenceKey(blobKey)); err != nil {
+		return fmt.Errorf("failed to delete encoding reference from S3: %w", err)
+	}
+	return nil
+}
+
+func (c *chunkWriter) CopyChunks(
+	ctx context.Context,
+	source corev2.BlobKey,
+	destination corev2.BlobKey,
+	fragmentInfo *encoding.FragmentInfo) error {
+
+	if fragmentInfo.TotalChunkSizeBytes == 0 || fragmentInfo.FragmentSizeBytes == 0 {
+		return fmt.Errorf("invalid fragment info %v", fragmentInfo)
+	}
+
+	fragmentCount := int((fragmentInfo.TotalChunkSizeBytes + fragmentInfo.FragmentSizeBytes - 1) /
+		fragmentInfo.FragmentSizeBytes)
+	sourceKeys, err := s3.GetFragmentKeys(s3.ScopedChunkKey(source), fragmentCount)
+	if err != nil {
+		return err
+	}
+	destinationKeys, err := s3.GetFragmentKeys(s3.ScopedChunkKey(destination), fragmentCount)
+	if err != nil {
+		return err
+	}
+	for i := range sourceKeys {
+		err := c.s3Client.CopyObject(ctx, c.bucketName, sourceKeys[i], destinationKeys[i], c.tags...)
+		if err != nil {
+			return fmt.Errorf("failed to copy chunk coefficients: %w", err)
+		}
+	}
+
+	err = c.s3Client.CopyObject(ctx, c.bucketName, s3.ScopedProofKey(source), s3.ScopedProofKey(destination), c.tags...)
+	if err != nil {
+		return fmt.Errorf("failed to copy chunk proofs: %w", err)
+	}
+	return nil
+}
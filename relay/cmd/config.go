@@ -3,11 +3,16 @@ package main
 import (
 	"fmt"
 
+	"github.com/Layr-Labs/eigenda/api/gateway"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/relay"
 	"github.com/Layr-Labs/eigenda/relay/cmd/flags"
 	"github.com/Layr-Labs/eigenda/relay/limiter"
@@ -37,6 +42,19 @@ type Config struct {
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 	ChainStateConfig              thegraph.Config
+
+	// OnchainStateWatcherConfig configures refreshing the on-chain state when the contracts emit events.
+	OnchainStateWatcherConfig eth.OnchainStateWatcherConfig
+
+	// HealthConfig configures the health probes of the relay and the endpoints that report them.
+	HealthConfig healthcheck.Config
+
+	// GatewayConfig configures the HTTP/JSON gateway of the relay API.
+	GatewayConfig gateway.Config
+
+	// EncodingConfig configures the prover that computes the proofs of GetBlobSymbolProofs. The RPC is not
+	// implemented if the G1 path is empty.
+	EncodingConfig kzg.KzgConfig
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -49,6 +67,18 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 	if len(relayIDs) == 0 {
 		return Config{}, fmt.Errorf("no relay IDs specified")
 	}
+	encodingConfig := kzg.ReadCLIConfig(ctx)
+	if encodingConfig.G1Path != "" {
+		if encodingConfig.G2Path == "" {
+			return Config{}, fmt.Errorf("G2Path must be specified to serve symbol proofs")
+		}
+		if encodingConfig.SRSOrder <= 0 {
+			return Config{}, fmt.Errorf("SRSOrder must be specified to serve symbol proofs")
+		}
+		if encodingConfig.SRSNumberToLoad <= 0 {
+			return Config{}, fmt.Errorf("SRSNumberToLoad must be specified to serve symbol proofs")
+		}
+	}
 	config := Config{
 		Log:               *loggerConfig,
 		AWS:               awsClientConfig,
@@ -81,6 +111,12 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 				MaxGetChunkBytesPerSecondClient: ctx.Float64(flags.MaxGetChunkBytesPerSecondClientFlag.Name),
 				GetChunkBytesBurstinessClient:   ctx.Int(flags.GetChunkBytesBurstinessClientFlag.Name),
 				MaxConcurrentGetChunkOpsClient:  ctx.Int(flags.MaxConcurrentGetChunkOpsClientFlag.Name),
+				SymbolOpenings: ratelimit.SymbolOpeningLimitConfig{
+					MaxSymbolOpeningsPerSecond:       ctx.Float64(flags.MaxSymbolOpeningsPerSecondFlag.Name),
+					SymbolOpeningsBurstiness:         ctx.Int(flags.SymbolOpeningsBurstinessFlag.Name),
+					MaxSymbolOpeningsPerSecondClient: ctx.Float64(flags.MaxSymbolOpeningsPerSecondClientFlag.Name),
+					SymbolOpeningsBurstinessClient:   ctx.Int(flags.SymbolOpeningsBurstinessClientFlag.Name),
+				},
 			},
 			AuthenticationKeyCacheSize:  ctx.Int(flags.AuthenticationKeyCacheSizeFlag.Name),
 			AuthenticationTimeout:       ctx.Duration(flags.AuthenticationTimeoutFlag.Name),
@@ -100,6 +136,10 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		BLSOperatorStateRetrieverAddr: ctx.String(flags.BlsOperatorStateRetrieverAddrFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.String(flags.EigenDAServiceManagerAddrFlag.Name),
 		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		OnchainStateWatcherConfig:     eth.ReadOnchainStateWatcherCLIConfig(ctx),
+		HealthConfig:                  healthcheck.ReadCLIConfig(ctx),
+		GatewayConfig:                 gateway.ReadCLIConfig(ctx),
+		EncodingConfig:                encodingConfig,
 	}
 	for i, id := range relayIDs {
 		config.RelayConfig.RelayIDs[i] = core.RelayKey(id)
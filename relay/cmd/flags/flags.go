@@ -1,12 +1,18 @@
 package flags
 
 import (
+	"runtime"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/gateway"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
 )
 
@@ -201,6 +207,34 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONCURRENT_GET_CHUNK_OPS_CLIENT"),
 		Value:    1,
 	}
+	MaxSymbolOpeningsPerSecondFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-symbol-openings-per-second"),
+		Usage:    "Max number of symbols opened per second by GetBlobSymbolProofs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_SYMBOL_OPENINGS_PER_SECOND"),
+		Value:    ratelimit.DefaultMaxSymbolOpeningsPerSecond,
+	}
+	SymbolOpeningsBurstinessFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "symbol-openings-burstiness"),
+		Usage:    "Burstiness of the GetBlobSymbolProofs symbol opening rate limiter",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SYMBOL_OPENINGS_BURSTINESS"),
+		Value:    ratelimit.DefaultSymbolOpeningsBurstiness,
+	}
+	MaxSymbolOpeningsPerSecondClientFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-symbol-openings-per-second-client"),
+		Usage:    "Max number of symbols opened per second by GetBlobSymbolProofs per client",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_SYMBOL_OPENINGS_PER_SECOND_CLIENT"),
+		Value:    ratelimit.DefaultMaxSymbolOpeningsPerSecondClient,
+	}
+	SymbolOpeningsBurstinessClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "symbol-openings-burstiness-client"),
+		Usage:    "Burstiness of the GetBlobSymbolProofs symbol opening rate limiter per client",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SYMBOL_OPENINGS_BURSTINESS_CLIENT"),
+		Value:    ratelimit.DefaultSymbolOpeningsBurstinessClient,
+	}
 	BlsOperatorStateRetrieverAddrFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever-addr"),
 		Usage:    "Address of the BLS operator state retriever",
@@ -277,7 +311,7 @@ var (
 	}
 	OnchainStateRefreshIntervalFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "onchain-state-refresh-interval"),
-		Usage:    "The interval at which to refresh the onchain state",
+		Usage:    "The interval at which to poll the chain for blob version parameters; 0 disables polling",
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ONCHAIN_STATE_REFRESH_INTERVAL"),
 		Value:    1 * time.Hour,
@@ -324,6 +358,10 @@ var optionalFlags = []cli.Flag{
 	MaxGetChunkBytesPerSecondClientFlag,
 	GetChunkBytesBurstinessClientFlag,
 	MaxConcurrentGetChunkOpsClientFlag,
+	MaxSymbolOpeningsPerSecondFlag,
+	SymbolOpeningsBurstinessFlag,
+	MaxSymbolOpeningsPerSecondClientFlag,
+	SymbolOpeningsBurstinessClientFlag,
 	AuthenticationKeyCacheSizeFlag,
 	AuthenticationTimeoutFlag,
 	AuthenticationDisabledFlag,
@@ -337,12 +375,59 @@ var optionalFlags = []cli.Flag{
 	MetricsPortFlag,
 }
 
+var kzgFlags = []cli.Flag{
+	// KZG flags for computing symbol proofs
+	// These are copied from encoding/kzg/cli.go as optional flags, since they are only needed to serve
+	// GetBlobSymbolProofs. The RPC is not implemented if the G1 path is not set.
+	cli.StringFlag{
+		Name:     kzg.G1PathFlagName,
+		Usage:    "Path to G1 SRS",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "G1_PATH"),
+	},
+	cli.StringFlag{
+		Name:     kzg.G2PathFlagName,
+		Usage:    "Path to G2 SRS",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "G2_PATH"),
+	},
+	cli.StringFlag{
+		Name:     kzg.CachePathFlagName,
+		Usage:    "Path to SRS Table directory",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_PATH"),
+	},
+	cli.Uint64Flag{
+		Name:     kzg.SRSOrderFlagName,
+		Usage:    "Order of the SRS",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SRS_ORDER"),
+	},
+	cli.Uint64Flag{
+		Name:     kzg.SRSLoadingNumberFlagName,
+		Usage:    "Number of SRS points to load into memory",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SRS_LOAD"),
+	},
+	cli.Uint64Flag{
+		Name:     kzg.NumWorkerFlagName,
+		Usage:    "Number of workers for multithreading",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NUM_WORKERS"),
+		Value:    uint64(runtime.GOMAXPROCS(0)),
+	},
+}
+
 var Flags []cli.Flag
 
 func init() {
 	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, kzgFlags...)
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
+	Flags = append(Flags, eth.OnchainStateWatcherCLIFlags(envVarPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, healthcheck.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, gateway.CLIFlags(envVarPrefix)...)
 }
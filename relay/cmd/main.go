@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/Layr-Labs/eigenda/api/gateway"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
@@ -14,7 +15,10 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/Layr-Labs/eigenda/relay"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	"github.com/Layr-Labs/eigenda/relay/cmd/flags"
@@ -56,6 +60,10 @@ func RunRelay(ctx *cli.Context) error {
 	}
 	logger.Info(fmt.Sprintf("Relay configuration: %#v", config))
 
+	// serverCtx bounds the background loops started below, so that they stop when the server stops serving.
+	serverCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	dynamoClient, err := dynamodb.NewClient(config.AWS, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create dynamodb client: %w", err)
@@ -79,9 +87,35 @@ func RunRelay(ctx *cli.Context) error {
 		return fmt.Errorf("failed to create eth writer: %w", err)
 	}
 
+	var onchainStateWatcher *coreeth.OnchainStateWatcher
+	if config.OnchainStateWatcherConfig.Enabled {
+		onchainStateWatcher, err = coreeth.NewOnchainStateWatcher(logger, client, tx.Reader, config.OnchainStateWatcherConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create onchain state watcher: %w", err)
+		}
+		if err := onchainStateWatcher.Start(serverCtx); err != nil {
+			return fmt.Errorf("failed to start onchain state watcher: %w", err)
+		}
+	}
+
 	cs := coreeth.NewChainState(tx, client)
 	ics := thegraph.MakeIndexedChainState(config.ChainStateConfig, cs, logger)
 
+	healthRegistry := healthcheck.NewRegistry(logger, config.HealthConfig.CheckInterval, config.HealthConfig.ProbeTimeout)
+	healthRegistry.Register("EthClient", healthcheck.Readiness, healthcheck.BlockFreshnessProbe(client, config.HealthConfig.MaxBlockAge))
+	healthRegistry.Register("Subgraph", healthcheck.Readiness, ics.CheckHealth)
+
+	var symbolProver encoding.Prover
+	if config.EncodingConfig.G1Path != "" {
+		config.EncodingConfig.LoadG2Points = true
+		symbolProver, err = prover.NewProver(&config.EncodingConfig, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create prover: %w", err)
+		}
+	} else {
+		logger.Info("No SRS configured, symbol proofs will not be served")
+	}
+
 	server, err := relay.NewServer(
 		context.Background(),
 		logger,
@@ -89,14 +123,21 @@ func RunRelay(ctx *cli.Context) error {
 		metadataStore,
 		blobStore,
 		chunkReader,
+		symbolProver,
 		tx,
 		ics,
+		onchainStateWatcher,
+		healthRegistry,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create relay server: %w", err)
 	}
 
-	err = server.Start(context.Background())
+	healthRegistry.Start(serverCtx)
+	healthRegistry.StartHTTPServer(serverCtx, config.HealthConfig.HTTPPort)
+	gateway.Start(serverCtx, logger, config.GatewayConfig, server.GatewayHandler(config.GatewayConfig))
+
+	err = server.Start(serverCtx)
 	if err != nil {
 		return fmt.Errorf("failed to start relay server: %w", err)
 	}
@@ -0,0 +1,58 @@
+package relay
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/gateway"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/gin-gonic/gin"
+)
+
+// GatewayHandler returns the HTTP/JSON gateway of the relay API, whose requests are served by this server:
+//
+//	GET  /relay/blobs/:blob_key GetBlob, replying with the raw bytes of the blob
+//	POST /relay/chunks          GetChunks
+func (s *Server) GatewayHandler(config gateway.Config) http.Handler {
+	router := gateway.NewRouter(config, gateway.RelayOpenAPI)
+	group := router.Group("/relay")
+	{
+		group.GET("/blobs/:blob_key", s.gatewayGetBlob)
+		group.POST("/chunks", func(c *gin.Context) { s.gatewayGetChunks(c, config.MaxRequestSize) })
+	}
+	return router
+}
+
+func (s *Server) gatewayGetBlob(c *gin.Context) {
+	blobKey, err := hex.DecodeString(strings.TrimPrefix(c.Param("blob_key"), "0x"))
+	if err != nil {
+		gateway.WriteError(c, api.NewErrorInvalidArg("invalid blob key"))
+		return
+	}
+
+	reply, err := gateway.Invoke(gateway.IncomingContext(c), s.metrics.GetGRPCServerInterceptor(), s,
+		pb.Relay_GetBlob_FullMethodName, &pb.GetBlobRequest{BlobKey: blobKey}, s.GetBlob)
+	if err != nil {
+		gateway.WriteError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, gateway.ContentTypeOctetStream, reply.GetBlob())
+}
+
+func (s *Server) gatewayGetChunks(c *gin.Context, maxRequestSize int64) {
+	req := &pb.GetChunksRequest{}
+	if err := gateway.ReadJSON(c, maxRequestSize, req); err != nil {
+		gateway.WriteError(c, err)
+		return
+	}
+
+	reply, err := gateway.Invoke(gateway.IncomingContext(c), s.metrics.GetGRPCServerInterceptor(), s,
+		pb.Relay_GetChunks_FullMethodName, req, s.GetChunks)
+	if err != nil {
+		gateway.WriteError(c, err)
+		return
+	}
+	gateway.WriteJSON(c, reply)
+}
@@ -0,0 +1,102 @@
+package relay
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/gateway"
+	"github.com/Layr-Labs/eigenda/common"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/core"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayGetBlob(t *testing.T) {
+	rand := random.NewTestRandom(t)
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+
+	metadataStore := buildMetadataStore(t)
+	blobStore := buildBlobStore(t, logger)
+	chainReader := newMockChainReader()
+
+	ics := &mock.IndexedChainState{}
+	blockNumber := uint(rand.Uint32())
+	ics.Mock.On("GetCurrentBlockNumber").Return(blockNumber, nil)
+	operatorInfo := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	ics.Mock.On("GetIndexedOperators", blockNumber).Return(operatorInfo, nil)
+
+	server, err := NewServer(
+		context.Background(),
+		logger,
+		defaultConfig(),
+		metadataStore,
+		blobStore,
+		nil, /* not used in this test */
+		nil, /* not used in this test */
+		chainReader,
+		ics,
+		nil,
+		nil)
+	require.NoError(t, err)
+	handler := server.GatewayHandler(gateway.Config{MaxRequestSize: 1024 * 1024})
+
+	header, data := randomBlob(t)
+	blobKey, err := header.BlobKey()
+	require.NoError(t, err)
+	err = metadataStore.PutBlobCertificate(
+		context.Background(),
+		&v2.BlobCertificate{
+			BlobHeader: header,
+		},
+		&encoding.FragmentInfo{})
+	require.NoError(t, err)
+	err = blobStore.StoreBlob(context.Background(), blobKey, data)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/relay/blobs/"+blobKey.Hex(), nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, gateway.ContentTypeOctetStream, recorder.Header().Get("Content-Type"))
+	require.Equal(t, data, recorder.Body.Bytes())
+
+	// unknown blobs are not found
+	unknownKey, err := v2.BytesToBlobKey(tu.RandomBytes(32))
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/relay/blobs/"+unknownKey.Hex(), nil))
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// chunk requests must not be empty
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/relay/chunks", strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	response := gateway.ErrorResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, "InvalidArgument", response.Code)
+
+	// chunk requests of unknown operators are not authenticated
+	body := fmt.Sprintf(`{"chunk_requests":[{"by_index":{"blob_key":"%s","chunk_indices":[0]}}],"operator_id":"%s"}`,
+		base64.StdEncoding.EncodeToString(blobKey[:]), base64.StdEncoding.EncodeToString(tu.RandomBytes(32)))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/relay/chunks", strings.NewReader(body)))
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	response = gateway.ErrorResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, "Unauthenticated", response.Code)
+	require.True(t, strings.HasPrefix(response.Error, "auth failed: "), response.Error)
+}
@@ -1,5 +1,7 @@
 package limiter
 
+import "github.com/Layr-Labs/eigenda/common/ratelimit"
+
 // Config is the configuration for the relay rate limiting.
 type Config struct {
 
@@ -62,4 +64,10 @@ type Config struct {
 	// MaxConcurrentGetChunkOpsClient is the maximum number of concurrent GetChunk operations that are permitted.
 	// Default is 1.
 	MaxConcurrentGetChunkOpsClient int
+
+	// Symbol opening rate limiting for GetBlobSymbolProofs operations
+
+	// SymbolOpenings bounds the symbols opened by GetBlobSymbolProofs, globally and per client. Zero values are
+	// replaced by the defaults of the ratelimit package.
+	SymbolOpenings ratelimit.SymbolOpeningLimitConfig
 }
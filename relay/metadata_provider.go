@@ -25,6 +25,8 @@ type blobMetadata struct {
 	totalChunkSizeBytes uint32
 	// the fragment size used for uploading the encoded chunks
 	fragmentSizeBytes uint32
+	// the commitments of the blob, which symbol proofs are computed against
+	blobCommitments *encoding.BlobCommitments
 }
 
 // metadataProvider encapsulates logic for fetching metadata for blobs. Utilized by the relay Server.
@@ -207,6 +209,7 @@ func (m *metadataProvider) fetchMetadata(key v2.BlobKey) (*blobMetadata, error)
 		chunkSizeBytes:      chunkSize,
 		totalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
 		fragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+		blobCommitments:     &cert.BlobHeader.BlobCommitments,
 	}
 
 	return metadata, nil
@@ -19,9 +19,10 @@ import (
 const namespace = "eigenda_relay"
 
 type RelayMetrics struct {
-	logger           logging.Logger
-	grpcServerOption grpc.ServerOption
-	server           *http.Server
+	logger                logging.Logger
+	grpcServerOption      grpc.ServerOption
+	grpcServerInterceptor grpc.UnaryServerInterceptor
+	server                *http.Server
 
 	// Cache metrics
 	MetadataCacheMetrics *cache.CacheAccessorMetrics
@@ -44,6 +45,9 @@ type RelayMetrics struct {
 	getBlobDataLatency     *prometheus.SummaryVec
 	getBlobRateLimited     *prometheus.CounterVec
 	getBlobDataSize        *prometheus.GaugeVec
+
+	// GetBlobSymbolProofs metrics
+	getBlobSymbolProofsLatency *prometheus.SummaryVec
 }
 
 // NewRelayMetrics creates a new RelayMetrics instance, which encapsulates all metrics related to the relay.
@@ -67,9 +71,8 @@ func NewRelayMetrics(logger logging.Logger, port int) *RelayMetrics {
 
 	grpcMetrics := grpcprom.NewServerMetrics()
 	registry.MustRegister(grpcMetrics)
-	grpcServerOption := grpc.UnaryInterceptor(
-		grpcMetrics.UnaryServerInterceptor(),
-	)
+	grpcServerInterceptor := grpcMetrics.UnaryServerInterceptor()
+	grpcServerOption := grpc.UnaryInterceptor(grpcServerInterceptor)
 
 	metadataCacheMetrics := cache.NewCacheAccessorMetrics(registry, "metadata")
 	chunkCacheMetrics := cache.NewCacheAccessorMetrics(registry, "chunk")
@@ -163,6 +166,16 @@ func NewRelayMetrics(logger logging.Logger, port int) *RelayMetrics {
 		[]string{},
 	)
 
+	getBlobSymbolProofsLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "get_blob_symbol_proofs_latency_ms",
+			Help:       "Latency of the GetBlobSymbolProofs RPC",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
 	getBlobMetadataLatency := promauto.With(registry).NewSummaryVec(
 		prometheus.SummaryOpts{
 			Namespace:  namespace,
@@ -204,6 +217,7 @@ func NewRelayMetrics(logger logging.Logger, port int) *RelayMetrics {
 	return &RelayMetrics{
 		logger:                         logger,
 		grpcServerOption:               grpcServerOption,
+		grpcServerInterceptor:          grpcServerInterceptor,
 		server:                         server,
 		MetadataCacheMetrics:           metadataCacheMetrics,
 		ChunkCacheMetrics:              chunkCacheMetrics,
@@ -221,6 +235,7 @@ func NewRelayMetrics(logger logging.Logger, port int) *RelayMetrics {
 		getBlobDataLatency:             getBlobDataLatency,
 		getBlobRateLimited:             getBlobRateLimited,
 		getBlobDataSize:                getBlobDataSize,
+		getBlobSymbolProofsLatency:     getBlobSymbolProofsLatency,
 	}
 }
 
@@ -244,6 +259,12 @@ func (m *RelayMetrics) GetGRPCServerOption() grpc.ServerOption {
 	return m.grpcServerOption
 }
 
+// GetGRPCServerInterceptor returns the gRPC interceptor that collects the GRPC metrics, for requests that are served
+// without going through the gRPC server, e.g. by the HTTP gateway.
+func (m *RelayMetrics) GetGRPCServerInterceptor() grpc.UnaryServerInterceptor {
+	return m.grpcServerInterceptor
+}
+
 func (m *RelayMetrics) ReportChunkLatency(duration time.Duration) {
 	m.getChunksLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
@@ -288,6 +309,10 @@ func (m *RelayMetrics) ReportBlobDataLatency(duration time.Duration) {
 	m.getBlobDataLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
 
+func (m *RelayMetrics) ReportBlobSymbolProofsLatency(duration time.Duration) {
+	m.getBlobSymbolProofsLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
+
 func (m *RelayMetrics) ReportBlobRateLimited(reason string) {
 	m.getBlobRateLimited.WithLabelValues(reason).Inc()
 }
@@ -4,19 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/Layr-Labs/eigenda/relay/metrics"
 	"net"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/core"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/relay/auth"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	"github.com/Layr-Labs/eigenda/relay/limiter"
+	"github.com/Layr-Labs/eigenda/relay/metrics"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
@@ -44,12 +47,19 @@ type Server struct {
 	// chunkProvider encapsulates logic for fetching chunks.
 	chunkProvider *chunkProvider
 
+	// prover computes the symbol proofs of GetBlobSymbolProofs. It may be nil, in which case GetBlobSymbolProofs is
+	// not implemented.
+	prover encoding.Prover
+
 	// blobRateLimiter enforces rate limits on GetBlob and operations.
 	blobRateLimiter *limiter.BlobRateLimiter
 
 	// chunkRateLimiter enforces rate limits on GetChunk operations.
 	chunkRateLimiter *limiter.ChunkRateLimiter
 
+	// symbolOpeningLimiter enforces rate limits on the symbols opened by GetBlobSymbolProofs operations.
+	symbolOpeningLimiter *ratelimit.SymbolOpeningLimiter
+
 	// grpcServer is the gRPC server.
 	grpcServer *grpc.Server
 
@@ -59,8 +69,15 @@ type Server struct {
 	// chainReader is the core.Reader used to fetch blob parameters.
 	chainReader core.Reader
 
+	// onchainStateUpdates receives the blob version events that trigger a refresh before the next interval. It is
+	// nil if no watcher is configured.
+	onchainStateUpdates <-chan coreeth.OnchainStateUpdate
+
 	// metrics encapsulates the metrics for the relay server.
 	metrics *metrics.RelayMetrics
+
+	// healthRegistry aggregates the health of the dependencies of the relay. It may be nil.
+	healthRegistry *healthcheck.Registry
 }
 
 type Config struct {
@@ -115,7 +132,8 @@ type Config struct {
 	// Timeouts contains configuration for relay timeouts.
 	Timeouts TimeoutConfig
 
-	// OnchainStateRefreshInterval is the interval at which the onchain state is refreshed.
+	// OnchainStateRefreshInterval is the interval at which the onchain state is refreshed. Polling is disabled if it
+	// is not positive.
 	OnchainStateRefreshInterval time.Duration
 
 	// MetricsPort is the port that the relay metrics server listens on.
@@ -130,8 +148,11 @@ func NewServer(
 	metadataStore *blobstore.BlobMetadataStore,
 	blobStore *blobstore.BlobStore,
 	chunkReader chunkstore.ChunkReader,
+	prover encoding.Prover,
 	chainReader core.Reader,
 	ics core.IndexedChainState,
+	onchainStateWatcher *coreeth.OnchainStateWatcher,
+	healthRegistry *healthcheck.Registry,
 ) (*Server, error) {
 
 	if chainReader == nil {
@@ -197,16 +218,32 @@ func NewServer(
 		}
 	}
 
+	if healthRegistry != nil && metadataStore != nil {
+		healthRegistry.Register("BlobMetadataStore", healthcheck.Readiness, metadataStore.CheckHealth)
+	}
+
+	symbolOpeningLimiter, err := ratelimit.NewSymbolOpeningLimiter(config.RateLimits.SymbolOpenings)
+	if err != nil {
+		return nil, fmt.Errorf("error creating symbol opening limiter: %w", err)
+	}
+
 	return &Server{
 		config:           config,
 		logger:           logger,
 		metadataProvider: mp,
 		blobProvider:     bp,
 		chunkProvider:    cp,
+		prover:           prover,
 		blobRateLimiter:  limiter.NewBlobRateLimiter(&config.RateLimits, relayMetrics),
 		chunkRateLimiter: limiter.NewChunkRateLimiter(&config.RateLimits, relayMetrics),
 		authenticator:    authenticator,
+		chainReader:      chainReader,
 		metrics:          relayMetrics,
+
+		symbolOpeningLimiter: symbolOpeningLimiter,
+
+		onchainStateUpdates: onchainStateWatcher.Subscribe(coreeth.BlobVersionAdded),
+		healthRegistry:      healthRegistry,
 	}, nil
 }
 
@@ -222,24 +259,24 @@ func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.G
 
 	err := s.blobRateLimiter.BeginGetBlobOperation(time.Now())
 	if err != nil {
-		return nil, err
+		return nil, api.WrapError(api.NewErrorResourceExhausted("rate limited"), err)
 	}
 	defer s.blobRateLimiter.FinishGetBlobOperation()
 
 	key, err := v2.BytesToBlobKey(request.BlobKey)
 	if err != nil {
-		return nil, fmt.Errorf("invalid blob key: %w", err)
+		return nil, api.WrapError(api.NewErrorInvalidArg("invalid blob key"), err)
 	}
 
 	keys := []v2.BlobKey{key}
 	mMap, err := s.metadataProvider.GetMetadataForBlobs(ctx, keys)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"error fetching metadata for blob, check if blob exists and is assigned to this relay: %w", err)
+		return nil, api.WrapError(api.NewErrorNotFound(
+			"error fetching metadata for blob, check if blob exists and is assigned to this relay"), err)
 	}
 	metadata := mMap[v2.BlobKey(request.BlobKey)]
 	if metadata == nil {
-		return nil, fmt.Errorf("blob not found")
+		return nil, api.NewErrorNotFound("blob not found")
 	}
 
 	finishedFetchingMetadata := time.Now()
@@ -247,7 +284,7 @@ func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.G
 
 	err = s.blobRateLimiter.RequestGetBlobBandwidth(time.Now(), metadata.blobSizeBytes)
 	if err != nil {
-		return nil, err
+		return nil, api.WrapError(api.NewErrorResourceExhausted("rate limited"), err)
 	}
 
 	data, err := s.blobProvider.GetBlob(ctx, key)
@@ -276,11 +313,11 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	}
 
 	if len(request.ChunkRequests) <= 0 {
-		return nil, fmt.Errorf("no chunk requests provided")
+		return nil, api.NewErrorInvalidArg("no chunk requests provided")
 	}
 	if len(request.ChunkRequests) > s.config.MaxKeysPerGetChunksRequest {
-		return nil, fmt.Errorf(
-			"too many chunk requests provided, max is %d", s.config.MaxKeysPerGetChunksRequest)
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf(
+			"too many chunk requests provided, max is %d", s.config.MaxKeysPerGetChunksRequest))
 	}
 	s.metrics.ReportChunkKeyCount(len(request.ChunkRequests))
 
@@ -294,7 +331,7 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 		err := s.authenticator.AuthenticateGetChunksRequest(ctx, clientAddress, request, time.Now())
 		if err != nil {
 			s.metrics.ReportChunkAuthFailure()
-			return nil, fmt.Errorf("auth failed: %w", err)
+			return nil, api.WrapError(api.NewErrorUnauthenticated("auth failed"), err)
 		}
 	}
 
@@ -306,20 +343,20 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	clientID := string(request.OperatorId)
 	err := s.chunkRateLimiter.BeginGetChunkOperation(time.Now(), clientID)
 	if err != nil {
-		return nil, err
+		return nil, api.WrapError(api.NewErrorResourceExhausted("rate limited"), err)
 	}
 	defer s.chunkRateLimiter.FinishGetChunkOperation(clientID)
 
 	// keys might contain duplicate keys
 	keys, err := getKeysFromChunkRequest(request)
 	if err != nil {
-		return nil, err
+		return nil, api.WrapError(api.NewErrorInvalidArg("invalid chunk request"), err)
 	}
 
 	mMap, err := s.metadataProvider.GetMetadataForBlobs(ctx, keys)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"error fetching metadata for blob, check if blob exists and is assigned to this relay: %w", err)
+		return nil, api.WrapError(api.NewErrorNotFound(
+			"error fetching metadata for blob, check if blob exists and is assigned to this relay"), err)
 	}
 
 	finishedFetchingMetadata := time.Now()
@@ -331,7 +368,7 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	}
 	err = s.chunkRateLimiter.RequestGetChunkBandwidth(time.Now(), clientID, requiredBandwidth)
 	if err != nil {
-		return nil, err
+		return nil, api.WrapError(api.NewErrorResourceExhausted("rate limited"), err)
 	}
 	s.metrics.ReportChunkDataSize(requiredBandwidth)
 
@@ -470,6 +507,10 @@ func (s *Server) Start(ctx context.Context) error {
 	s.metrics.Start()
 
 	if s.chainReader != nil && s.metadataProvider != nil {
+		if s.config.OnchainStateRefreshInterval > 0 {
+			s.logger.Info("Relay will poll the chain for blob version parameters",
+				"interval", s.config.OnchainStateRefreshInterval)
+		}
 		go func() {
 			_ = s.RefreshOnchainState(ctx)
 		}()
@@ -490,7 +531,7 @@ func (s *Server) Start(ctx context.Context) error {
 
 	// Register Server for Health Checks
 	name := pb.Relay_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, s.grpcServer)
+	s.healthRegistry.RegisterHealthServer(name, s.grpcServer)
 
 	s.logger.Info("GRPC Listening", "port", s.config.GRPCPort, "address", listener.Addr().String())
 	if err = s.grpcServer.Serve(listener); err != nil {
@@ -500,23 +541,34 @@ func (s *Server) Start(ctx context.Context) error {
 	return nil
 }
 
+// RefreshOnchainState refreshes the blob version parameters every OnchainStateRefreshInterval, and whenever a new
+// blob version is added on-chain if a watcher is configured. It returns when the context is done.
 func (s *Server) RefreshOnchainState(ctx context.Context) error {
-	ticker := time.NewTicker(s.config.OnchainStateRefreshInterval)
-	defer ticker.Stop()
+	var tick <-chan time.Time
+	if s.config.OnchainStateRefreshInterval > 0 {
+		ticker := time.NewTicker(s.config.OnchainStateRefreshInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	} else if s.onchainStateUpdates == nil {
+		return nil
+	}
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-tick:
 			s.logger.Info("refreshing onchain state")
-			blobParams, err := s.chainReader.GetAllVersionedBlobParams(ctx)
-			if err != nil {
-				s.logger.Error("error fetching blob params", "err", err)
-				continue
-			}
-			s.metadataProvider.UpdateBlobVersionParameters(v2.NewBlobVersionParameterMap(blobParams))
+		case update := <-s.onchainStateUpdates:
+			s.logger.Info("refreshing onchain state on update", "blobVersion", update.BlobVersion, "block", update.BlockNumber)
 		case <-ctx.Done():
 			return ctx.Err()
 		}
+
+		blobParams, err := s.chainReader.GetAllVersionedBlobParams(ctx)
+		if err != nil {
+			s.logger.Error("error fetching blob params", "err", err)
+			continue
+		}
+		s.metadataProvider.UpdateBlobVersionParameters(v2.NewBlobVersionParameterMap(blobParams))
 	}
 }
 
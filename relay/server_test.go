@@ -138,8 +138,11 @@ func TestReadWriteBlobs(t *testing.T) {
 		metadataStore,
 		blobStore,
 		nil, /* not used in this test*/
+		nil, /* not used in this test */
 		chainReader,
-		ics)
+		ics,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -227,8 +230,11 @@ func TestReadNonExistentBlob(t *testing.T) {
 		metadataStore,
 		blobStore,
 		nil, /* not used in this test */
+		nil, /* not used in this test */
 		chainReader,
-		ics)
+		ics,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -291,8 +297,11 @@ func TestReadWriteBlobsWithSharding(t *testing.T) {
 		metadataStore,
 		blobStore,
 		nil, /* not used in this test*/
+		nil, /* not used in this test */
 		chainReader,
-		ics)
+		ics,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -434,8 +443,11 @@ func TestReadWriteChunks(t *testing.T) {
 		metadataStore,
 		nil, /* not used in this test*/
 		chunkReader,
+		nil, /* not used in this test */
 		chainReader,
-		ics)
+		ics,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -655,8 +667,11 @@ func TestBatchedReadWriteChunks(t *testing.T) {
 		metadataStore,
 		nil, /* not used in this test */
 		chunkReader,
+		nil, /* not used in this test */
 		chainReader,
-		ics)
+		ics,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -806,8 +821,11 @@ func TestReadWriteChunksWithSharding(t *testing.T) {
 		metadataStore,
 		nil, /* not used in this test*/
 		chunkReader,
+		nil, /* not used in this test */
 		chainReader,
-		ics)
+		ics,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -1106,8 +1124,11 @@ func TestBatchedReadWriteChunksWithSharding(t *testing.T) {
 		metadataStore,
 		nil, /* not used in this test */
 		chunkReader,
+		nil, /* not used in this test */
 		chainReader,
-		ics)
+		ics,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -1247,3 +1268,40 @@ func TestBatchedReadWriteChunksWithSharding(t *testing.T) {
 		}
 	}
 }
+
+func TestRefreshOnchainState(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	chainReader := newMockChainReader()
+	config := defaultConfig()
+	config.AuthenticationDisabled = true
+	config.OnchainStateRefreshInterval = 10 * time.Millisecond
+	server, err := NewServer(
+		context.Background(),
+		logger,
+		config,
+		nil, /* not used in this test */
+		nil, /* not used in this test */
+		nil, /* not used in this test */
+		nil, /* not used in this test */
+		chainReader,
+		nil, /* not used in this test */
+		nil,
+		nil)
+	require.NoError(t, err)
+
+	// The blob params are fetched once when the server is created, and then on every poll until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*config.OnchainStateRefreshInterval)
+	defer cancel()
+	err = server.RefreshOnchainState(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	calls := len(chainReader.Calls)
+	require.Greater(t, calls, 2)
+
+	// Without an interval or a watcher there is nothing to refresh on.
+	config.OnchainStateRefreshInterval = 0
+	err = server.RefreshOnchainState(context.Background())
+	require.NoError(t, err)
+	require.Len(t, chainReader.Calls, calls)
+}
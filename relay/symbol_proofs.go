@@ -0,0 +1,132 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pbdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+// GetBlobSymbolProofs returns KZG opening proofs for symbols of a blob stored by the relay. It is subject to the same
+// rate limits as GetBlob, since it reads the whole blob, and every opened symbol is charged to the symbol opening
+// budgets of the client and of the relay.
+func (s *Server) GetBlobSymbolProofs(
+	ctx context.Context,
+	request *pbdisperser.BlobSymbolProofsRequest) (*pbdisperser.BlobSymbolProofsReply, error) {
+
+	start := time.Now()
+
+	if s.prover == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+
+	if s.config.Timeouts.GetBlobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.Timeouts.GetBlobTimeout)
+		defer cancel()
+	}
+
+	indices := request.GetSymbolIndices()
+	if len(indices) == 0 {
+		return nil, api.NewErrorInvalidArg("symbol indices are empty")
+	}
+	if err := encoding.CheckSymbolOpeningCount(len(indices), request.GetBatched()); err != nil {
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
+
+	key, err := v2.BytesToBlobKey(request.GetBlobKey())
+	if err != nil {
+		return nil, api.WrapError(api.NewErrorInvalidArg("invalid blob key"), err)
+	}
+
+	clientAddress, err := common.GetClientAddress(ctx, "", 0, true)
+	if err != nil {
+		return nil, api.WrapError(api.NewErrorInvalidArg("could not get client address"), err)
+	}
+	err = s.symbolOpeningLimiter.AllowOpenings(time.Now(), clientAddress, len(indices))
+	if err != nil {
+		return nil, api.WrapError(api.NewErrorResourceExhausted("rate limited"), err)
+	}
+
+	err = s.blobRateLimiter.BeginGetBlobOperation(time.Now())
+	if err != nil {
+		return nil, api.WrapError(api.NewErrorResourceExhausted("rate limited"), err)
+	}
+	defer s.blobRateLimiter.FinishGetBlobOperation()
+
+	mMap, err := s.metadataProvider.GetMetadataForBlobs(ctx, []v2.BlobKey{key})
+	if err != nil {
+		return nil, api.WrapError(api.NewErrorNotFound(
+			"error fetching metadata for blob, check if blob exists and is assigned to this relay"), err)
+	}
+	metadata := mMap[key]
+	if metadata == nil {
+		return nil, api.NewErrorNotFound("blob not found")
+	}
+
+	commitments := metadata.blobCommitments
+	domainSize := encoding.NextPowerOf2(uint64(commitments.Length))
+	for _, index := range indices {
+		if index >= domainSize {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf(
+				"symbol index %d is out of range for a blob of length %d", index, commitments.Length))
+		}
+	}
+
+	err = s.blobRateLimiter.RequestGetBlobBandwidth(time.Now(), metadata.blobSizeBytes)
+	if err != nil {
+		return nil, api.WrapError(api.NewErrorResourceExhausted("rate limited"), err)
+	}
+
+	data, err := s.blobProvider.GetBlob(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching blob %s: %w", key.Hex(), err)
+	}
+
+	commitmentProto, err := commitments.ToProtobuf()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing blob commitment: %w", err)
+	}
+	reply := &pbdisperser.BlobSymbolProofsReply{
+		BlobCommitment: commitmentProto,
+		SymbolProofs:   make([]*pbdisperser.SymbolProof, len(indices)),
+	}
+
+	if request.GetBatched() {
+		opening, err := s.prover.OpenSymbolsBatch(data, uint64(commitments.Length), indices)
+		if err != nil {
+			return nil, fmt.Errorf("error opening symbols of blob %s: %w", key.Hex(), err)
+		}
+		for i := range opening.Indices {
+			value := opening.Values[i].Bytes()
+			reply.SymbolProofs[i] = &pbdisperser.SymbolProof{
+				Index: opening.Indices[i],
+				Value: value[:],
+			}
+		}
+		proof := opening.Proof.Bytes()
+		reply.BatchedProof = proof[:]
+	} else {
+		openings, err := s.prover.OpenSymbols(data, uint64(commitments.Length), indices)
+		if err != nil {
+			return nil, fmt.Errorf("error opening symbols of blob %s: %w", key.Hex(), err)
+		}
+		for i, opening := range openings {
+			value := opening.Value.Bytes()
+			proof := opening.Proof.Bytes()
+			reply.SymbolProofs[i] = &pbdisperser.SymbolProof{
+				Index: opening.Index,
+				Value: value[:],
+				Proof: proof[:],
+			}
+		}
+	}
+
+	s.metrics.ReportBlobSymbolProofsLatency(time.Since(start))
+	return reply, nil
+}
@@ -0,0 +1,148 @@
+package relay
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	pbdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/core"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/relay/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetBlobSymbolProofs(t *testing.T) {
+	rand := random.NewTestRandom(t)
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+
+	metadataStore := buildMetadataStore(t)
+	blobStore := buildBlobStore(t, logger)
+
+	ics := &mock.IndexedChainState{}
+	blockNumber := uint(rand.Uint32())
+	ics.Mock.On("GetCurrentBlockNumber").Return(blockNumber, nil)
+	operatorInfo := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	ics.Mock.On("GetIndexedOperators", blockNumber).Return(operatorInfo, nil)
+
+	config := defaultConfig()
+	config.RateLimits.SymbolOpenings = ratelimit.SymbolOpeningLimitConfig{
+		MaxSymbolOpeningsPerSecondClient: 0.001,
+		SymbolOpeningsBurstinessClient:   8,
+	}
+	server, err := NewServer(
+		context.Background(),
+		logger,
+		config,
+		metadataStore,
+		blobStore,
+		nil, /* not used in this test */
+		prover,
+		newMockChainReader(),
+		ics,
+		nil,
+		nil)
+	require.NoError(t, err)
+
+	header, data := randomBlob(t)
+	blobKey, err := header.BlobKey()
+	require.NoError(t, err)
+	err = metadataStore.PutBlobCertificate(
+		context.Background(),
+		&v2.BlobCertificate{
+			BlobHeader: header,
+		},
+		&encoding.FragmentInfo{})
+	require.NoError(t, err)
+	err = blobStore.StoreBlob(context.Background(), blobKey, data)
+	require.NoError(t, err)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234},
+	})
+
+	v, err := verifier.NewVerifier(prover.KzgConfig, nil)
+	require.NoError(t, err)
+	length := uint64(header.BlobCommitments.Length)
+
+	// separate proofs
+	reply, err := server.GetBlobSymbolProofs(ctx, &pbdisperser.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: []uint64{0, 3, length - 1},
+	})
+	require.NoError(t, err)
+	values, err := verification.VerifyBlobSymbolProofs(v, header.BlobCommitments, reply)
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+
+	// batched proof
+	reply, err = server.GetBlobSymbolProofs(ctx, &pbdisperser.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: []uint64{2, 1},
+		Batched:       true,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, reply.GetBatchedProof())
+	_, err = verification.VerifyBlobSymbolProofs(v, header.BlobCommitments, reply)
+	require.NoError(t, err)
+
+	// batched proofs open a limited number of symbols
+	tooMany := make([]uint64, encoding.MaxBatchedSymbolOpenings+1)
+	for i := range tooMany {
+		tooMany[i] = uint64(i)
+	}
+	_, err = server.GetBlobSymbolProofs(ctx, &pbdisperser.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: tooMany,
+		Batched:       true,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// symbols past the end of the blob cannot be opened
+	_, err = server.GetBlobSymbolProofs(ctx, &pbdisperser.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: []uint64{encoding.NextPowerOf2(length)},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// every opened symbol is charged to the client, which has spent 6 of its 8 openings
+	_, err = server.GetBlobSymbolProofs(ctx, &pbdisperser.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: []uint64{0, 1, 2},
+	})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// without a prover, symbol proofs are not served
+	server, err = NewServer(
+		context.Background(),
+		logger,
+		defaultConfig(),
+		metadataStore,
+		blobStore,
+		nil, /* not used in this test */
+		nil,
+		newMockChainReader(),
+		ics,
+		nil,
+		nil)
+	require.NoError(t, err)
+	_, err = server.GetBlobSymbolProofs(ctx, &pbdisperser.BlobSymbolProofsRequest{
+		BlobKey:       blobKey[:],
+		SymbolIndices: []uint64{0},
+	})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -16,8 +16,10 @@ import (
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
+	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 	"github.com/Layr-Labs/eigenda/retriever"
 	retrivereth "github.com/Layr-Labs/eigenda/retriever/eth"
@@ -103,6 +105,12 @@ func RetrieverMain(ctx *cli.Context) error {
 	logger.Info("Connecting to subgraph", "url", config.ChainStateConfig.Endpoint)
 	ics := thegraph.MakeIndexedChainState(config.ChainStateConfig, cs, logger)
 
+	healthRegistry := healthcheck.NewRegistry(logger, config.HealthConfig.CheckInterval, config.HealthConfig.ProbeTimeout)
+	healthRegistry.Register("EthClient", healthcheck.Readiness, healthcheck.BlockFreshnessProbe(gethClient, config.HealthConfig.MaxBlockAge))
+	healthRegistry.Register("Subgraph", healthcheck.Readiness, ics.CheckHealth)
+	healthRegistry.Start(context.Background())
+	healthRegistry.StartHTTPServer(context.Background(), config.HealthConfig.HTTPPort)
+
 	if config.EigenDAVersion == 1 {
 		agn := &core.StdAssignmentCoordinator{}
 		retrievalClient, err := clients.NewRetrievalClient(logger, ics, agn, nodeClient, v, config.NumConnections)
@@ -124,14 +132,18 @@ func RetrieverMain(ctx *cli.Context) error {
 
 		// Register Server for Health Checks
 		name := pb.Retriever_ServiceDesc.ServiceName
-		healthcheck.RegisterHealthServer(name, gs)
+		healthRegistry.RegisterHealthServer(name, gs)
 
 		log.Printf("server listening at %s", addr)
 		return gs.Serve(listener)
 	}
 
 	if config.EigenDAVersion == 2 {
-		retrievalClient := clientsv2.NewRetrievalClient(logger, tx, ics, v, config.NumConnections)
+		var signer corev2.BlobRequestSigner
+		if config.GetChunksSignerPrivateKey != "" {
+			signer = authv2.NewLocalBlobRequestSigner(config.GetChunksSignerPrivateKey)
+		}
+		retrievalClient := clientsv2.NewRetrievalClient(logger, tx, ics, v, config.NumConnections, signer)
 		retrieverServiceServer := retrieverv2.NewServer(config, logger, retrievalClient, ics)
 		if err = retrieverServiceServer.Start(context.Background()); err != nil {
 			log.Fatalln("failed to start retriever service server", err)
@@ -145,7 +157,7 @@ func RetrieverMain(ctx *cli.Context) error {
 
 		// Register Server for Health Checks
 		name := pb.Retriever_ServiceDesc.ServiceName
-		healthcheck.RegisterHealthServer(name, gs)
+		healthRegistry.RegisterHealthServer(name, gs)
 
 		log.Printf("server listening at %s", addr)
 		return gs.Serve(listener)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/retriever/flags"
@@ -23,8 +24,15 @@ type Config struct {
 	NumConnections                int
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
+	// BlobCacheSize is the size in bytes of the cache of recovered blobs. It is only used by the v2 retriever.
+	BlobCacheSize uint64
+	// GetChunksSignerPrivateKey is the hex encoded private key that signs GetChunks requests. It is only used by the
+	// v2 retriever. Requests are not signed if empty.
+	GetChunksSignerPrivateKey string
 
 	EigenDAVersion int
+
+	HealthConfig healthcheck.Config
 }
 
 func NewConfig(ctx *cli.Context) (*Config, error) {
@@ -49,6 +57,9 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		NumConnections:                ctx.Int(flags.NumConnectionsFlag.Name),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+		BlobCacheSize:                 ctx.GlobalUint64(flags.BlobCacheSizeFlag.Name),
+		GetChunksSignerPrivateKey:     ctx.GlobalString(flags.GetChunksSignerPrivateKeyFlag.Name),
 		EigenDAVersion:                version,
+		HealthConfig:                  healthcheck.ReadCLIConfig(ctx),
 	}, nil
 }
@@ -3,6 +3,7 @@ package flags
 import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
@@ -68,6 +69,20 @@ var (
 		EnvVar:   common.PrefixEnvVar(envPrefix, "EIGENDA_VERSION"),
 		Value:    1,
 	}
+	BlobCacheSizeFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-cache-size"),
+		Usage:    "Size in bytes of the cache of recovered blobs, only used by the v2 retriever. Set to 0 to disable caching",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_CACHE_SIZE"),
+		Value:    256 * 1024 * 1024,
+	}
+	GetChunksSignerPrivateKeyFlag = cli.StringFlag{
+		Name: common.PrefixFlag(FlagPrefix, "get-chunks-signer-private-key-hex"),
+		Usage: "Hex encoded private key that signs the GetChunks requests of the v2 retriever. Validators limit " +
+			"signed requests per account on top of the limits of the client IP address. Requests are not signed if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "GET_CHUNKS_SIGNER_PRIVATE_KEY_HEX"),
+	}
 )
 
 func RetrieverFlags(envPrefix string) []cli.Flag {
@@ -80,6 +95,8 @@ func RetrieverFlags(envPrefix string) []cli.Flag {
 		NumConnectionsFlag,
 		MetricsHTTPPortFlag,
 		EigenDAVersionFlag,
+		BlobCacheSizeFlag,
+		GetChunksSignerPrivateKeyFlag,
 	}
 }
 
@@ -92,4 +109,5 @@ func init() {
 	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envPrefix)...)
+	Flags = append(Flags, healthcheck.CLIFlags(envPrefix)...)
 }
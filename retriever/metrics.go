@@ -24,6 +24,7 @@ type Metrics struct {
 	registry *prometheus.Registry
 
 	NumRetrievalRequest prometheus.Counter
+	NumBlobCacheHit     prometheus.Counter
 
 	httpPort string
 	logger   logging.Logger
@@ -43,6 +44,13 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 				Help:      "the number of retrieval requests",
 			},
 		),
+		NumBlobCacheHit: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "blob_cache_hit",
+				Help:      "the number of retrieval requests served from the blob cache",
+			},
+		),
 		httpPort: httpPort,
 		logger:   logger.With("component", "RetrieverMetrics"),
 	}
@@ -56,6 +64,11 @@ func (g *Metrics) IncrementRetrievalRequestCounter() {
 	g.NumRetrievalRequest.Inc()
 }
 
+// IncrementBlobCacheHitCounter increments the number of retrieval requests served from the blob cache
+func (g *Metrics) IncrementBlobCacheHitCounter() {
+	g.NumBlobCacheHit.Inc()
+}
+
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
 	addr := fmt.Sprintf(":%s", g.httpPort)
@@ -5,12 +5,17 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"sync"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever/v2"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/Layr-Labs/eigenda/relay/cache"
 	"github.com/Layr-Labs/eigenda/retriever"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
@@ -25,6 +30,11 @@ type Server struct {
 	indexedState    core.IndexedChainState
 	logger          logging.Logger
 	metrics         *retriever.Metrics
+
+	// blobCache holds recently reconstructed blobs, cut to their length, keyed by blob key, so that repeated requests
+	// for a blob do not reconstruct it from the validators again.
+	blobCache     cache.Cache[corev2.BlobKey, []byte]
+	blobCacheLock sync.Mutex
 }
 
 func NewServer(
@@ -41,9 +51,15 @@ func NewServer(
 		indexedState:    indexedState,
 		logger:          logger.With("component", "RetrieverServer"),
 		metrics:         metrics,
+		blobCache:       cache.NewFIFOCache[corev2.BlobKey, []byte](config.BlobCacheSize, computeBlobCacheWeight),
 	}
 }
 
+// computeBlobCacheWeight computes the weight of a blob in the blob cache, which is its size in bytes.
+func computeBlobCacheWeight(_ corev2.BlobKey, value []byte) uint64 {
+	return uint64(len(value))
+}
+
 func (s *Server) Start(ctx context.Context) error {
 	s.metrics.Start(ctx)
 	return s.indexedState.Start(ctx)
@@ -56,6 +72,9 @@ func (s *Server) RetrieveBlob(ctx context.Context, req *pb.BlobRequest) (*pb.Blo
 	if req.GetReferenceBlockNumber() == 0 {
 		return nil, errors.New("reference block number is 0")
 	}
+	if _, ok := pb.BlobDecoding_name[int32(req.GetDecoding())]; !ok {
+		return nil, fmt.Errorf("unknown blob decoding %d", req.GetDecoding())
+	}
 
 	blobHeader, err := corev2.BlobHeaderFromProtobuf(req.GetBlobHeader())
 	if err != nil {
@@ -67,19 +86,120 @@ func (s *Server) RetrieveBlob(ctx context.Context, req *pb.BlobRequest) (*pb.Blo
 		return nil, err
 	}
 
-	s.logger.Info("Received request: ", "blobKey", hex.EncodeToString(blobKey[:]), "referenceBlockNumber", req.GetReferenceBlockNumber(), "quorumId", req.GetQuorumId())
+	quorums, err := retrievalQuorums(blobHeader, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Received request: ", "blobKey", hex.EncodeToString(blobKey[:]), "referenceBlockNumber", req.GetReferenceBlockNumber(), "quorumIds", quorums)
 	s.metrics.IncrementRetrievalRequestCounter()
 
+	if blob, ok := s.getCachedBlob(blobKey); ok {
+		s.metrics.IncrementBlobCacheHitCounter()
+		return decodeReply(blob, req.GetDecoding())
+	}
+
+	var errs []error
+	for _, quorumID := range quorums {
+		blob, err := s.retrieveBlob(ctx, blobHeader, uint64(req.GetReferenceBlockNumber()), quorumID)
+		if err != nil {
+			s.logger.Warn("failed to retrieve blob from quorum", "blobKey", hex.EncodeToString(blobKey[:]), "quorumId", quorumID, "err", err)
+			errs = append(errs, fmt.Errorf("quorum %d: %w", quorumID, err))
+			continue
+		}
+		s.putCachedBlob(blobKey, blob)
+		return decodeReply(blob, req.GetDecoding())
+	}
+	return nil, fmt.Errorf("failed to retrieve blob from quorums %v: %w", quorums, errors.Join(errs...))
+}
+
+// retrieveBlob reconstructs a blob from the chunks of one quorum, and cuts it to its length in symbols from the blob
+// commitments. Each quorum gets the full timeout, so that a quorum that times out leaves time for the next one.
+func (s *Server) retrieveBlob(
+	ctx context.Context,
+	blobHeader *corev2.BlobHeader,
+	referenceBlockNumber uint64,
+	quorumID core.QuorumID,
+) ([]byte, error) {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, s.config.Timeout)
 	defer cancel()
-	data, err := s.retrievalClient.GetBlob(ctxWithTimeout, blobHeader, uint64(req.GetReferenceBlockNumber()), core.QuorumID(req.GetQuorumId()))
+
+	data, err := s.retrievalClient.GetBlob(ctxWithTimeout, blobHeader, referenceBlockNumber, quorumID)
 	if err != nil {
 		return nil, err
 	}
-	restored := bytes.TrimRight(data, "\x00")
-	restored = codec.RemoveEmptyByteFromPaddedBytes(restored)
+
+	blobSize := uint64(blobHeader.BlobCommitments.Length) * encoding.BYTES_PER_SYMBOL
+	if uint64(len(data)) > blobSize {
+		data = data[:blobSize]
+	}
+	return data, nil
+}
+
+func (s *Server) getCachedBlob(blobKey corev2.BlobKey) ([]byte, bool) {
+	s.blobCacheLock.Lock()
+	defer s.blobCacheLock.Unlock()
+	return s.blobCache.Get(blobKey)
+}
+
+func (s *Server) putCachedBlob(blobKey corev2.BlobKey, blob []byte) {
+	s.blobCacheLock.Lock()
+	defer s.blobCacheLock.Unlock()
+	s.blobCache.Put(blobKey, blob)
+}
+
+// retrievalQuorums returns the quorums to retrieve a blob from, in order of preference. These are the quorums of the
+// request if any, or else the requested quorum followed by the other quorums of the blob.
+func retrievalQuorums(blobHeader *corev2.BlobHeader, req *pb.BlobRequest) ([]core.QuorumID, error) {
+	blobQuorums := make(map[core.QuorumID]struct{}, len(blobHeader.QuorumNumbers))
+	for _, q := range blobHeader.QuorumNumbers {
+		blobQuorums[q] = struct{}{}
+	}
+
+	if len(req.GetQuorumIds()) > 0 {
+		quorums := make([]core.QuorumID, 0, len(req.GetQuorumIds()))
+		for _, q := range req.GetQuorumIds() {
+			if _, ok := blobQuorums[core.QuorumID(q)]; !ok || q > core.MaxQuorumID {
+				return nil, fmt.Errorf("blob is not dispersed to quorum %d", q)
+			}
+			quorums = append(quorums, core.QuorumID(q))
+		}
+		return quorums, nil
+	}
+
+	if _, ok := blobQuorums[core.QuorumID(req.GetQuorumId())]; !ok || req.GetQuorumId() > core.MaxQuorumID {
+		return nil, fmt.Errorf("blob is not dispersed to quorum %d", req.GetQuorumId())
+	}
+	quorums := []core.QuorumID{core.QuorumID(req.GetQuorumId())}
+	for _, q := range blobHeader.QuorumNumbers {
+		if q != quorums[0] {
+			quorums = append(quorums, q)
+		}
+	}
+	return quorums, nil
+}
+
+// decodeReply turns a reconstructed blob into the reply requested by the client. By default, the blob is decoded to
+// the exact payload recorded in its codec header. A blob that was not encoded with a payload codec can be returned
+// raw, or, if the client opts in, with its trailing zero bytes trimmed, which is lossy.
+func decodeReply(blob []byte, decoding pb.BlobDecoding) (*pb.BlobReply, error) {
+	var data []byte
+	switch decoding {
+	case pb.BlobDecoding_PAYLOAD:
+		payload, err := codecs.GenericDecodeBlob(blob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode blob: %w", err)
+		}
+		data = payload
+	case pb.BlobDecoding_RAW:
+		data = blob
+	case pb.BlobDecoding_TRIM_PADDING:
+		data = codec.RemoveEmptyByteFromPaddedBytes(bytes.TrimRight(blob, "\x00"))
+	default:
+		return nil, fmt.Errorf("unknown blob decoding %d", decoding)
+	}
 
 	return &pb.BlobReply{
-		Data: restored,
+		Data: data,
 	}, nil
 }
@@ -2,16 +2,20 @@ package v2_test
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"runtime"
 	"testing"
+	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
 	commonpbv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever/v2"
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
@@ -60,7 +64,10 @@ func makeTestComponents() (encoding.Prover, encoding.Verifier, error) {
 
 func newTestServer(t *testing.T) *retriever.Server {
 	var err error
-	config := &retriever.Config{}
+	config := &retriever.Config{
+		Timeout:       time.Second,
+		BlobCacheSize: 1024 * 1024,
+	}
 
 	logger := logging.NewNoopLogger()
 
@@ -79,11 +86,8 @@ func newTestServer(t *testing.T) *retriever.Server {
 	return retriever.NewServer(config, logger, retrievalClient, indexedChainState)
 }
 
-func TestRetrieveBlob(t *testing.T) {
-	server := newTestServer(t)
-	data := codec.ConvertByPaddingEmptyByte(gettysburgAddressBytes)
-	retrievalClient.On("GetBlob").Return(data, nil)
-
+// makeBlobHeader returns a blob header of the given length in symbols, dispersed to the given quorums.
+func makeBlobHeader(t *testing.T, length uint, quorums []uint32) *commonpbv2.BlobHeader {
 	var X1, Y1 fp.Element
 	X1 = *X1.SetBigInt(big.NewInt(1))
 	Y1 = *Y1.SetBigInt(big.NewInt(2))
@@ -113,22 +117,179 @@ func TestRetrieveBlob(t *testing.T) {
 		},
 		LengthCommitment: (*encoding.G2Commitment)(&lengthCommitment),
 		LengthProof:      (*encoding.G2Commitment)(&lengthProof),
-		Length:           16,
+		Length:           length,
 	}
 	c, err := mockCommitment.ToProtobuf()
 	require.NoError(t, err)
-	retrievalReply, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
-		BlobHeader: &commonpbv2.BlobHeader{
-			Version:       0,
-			QuorumNumbers: []uint32{0},
-			Commitment:    c,
-			PaymentHeader: &commonpb.PaymentHeader{
-				AccountId: "account_id",
-			},
+	return &commonpbv2.BlobHeader{
+		Version:       0,
+		QuorumNumbers: quorums,
+		Commitment:    c,
+		PaymentHeader: &commonpb.PaymentHeader{
+			AccountId: "account_id",
 		},
+	}
+}
+
+// blobLength returns the length in symbols of a blob, padded to a power of 2 as the encoder does.
+func blobLength(data []byte) uint {
+	return encoding.GetBlobLengthPowerOf2(uint(len(data)))
+}
+
+func TestRetrieveBlob(t *testing.T) {
+	server := newTestServer(t)
+	data, err := codecs.DefaultBlobCodec{}.EncodeBlob(gettysburgAddressBytes)
+	require.NoError(t, err)
+	retrievalClient.On("GetBlob").Return(data, nil)
+
+	retrievalReply, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BlobHeader:           makeBlobHeader(t, blobLength(data), []uint32{0}),
+		ReferenceBlockNumber: 100,
+		QuorumId:             0,
+	})
+	require.NoError(t, err)
+	require.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
+}
+
+func TestRetrieveBlobTrailingZeros(t *testing.T) {
+	server := newTestServer(t)
+	payload := append(append([]byte{}, gettysburgAddressBytes...), make([]byte, 40)...)
+	data, err := codecs.DefaultBlobCodec{}.EncodeBlob(payload)
+	require.NoError(t, err)
+	length := blobLength(data)
+	// the reconstructed blob is padded with zeros up to its length
+	data = append(data, make([]byte, int(length)*encoding.BYTES_PER_SYMBOL-len(data))...)
+	retrievalClient.On("GetBlob").Return(data, nil)
+
+	retrievalReply, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BlobHeader:           makeBlobHeader(t, length, []uint32{0}),
+		ReferenceBlockNumber: 100,
+		QuorumId:             0,
+		Decoding:             pb.BlobDecoding_PAYLOAD,
+	})
+	require.NoError(t, err)
+	require.Equal(t, payload, retrievalReply.Data)
+
+	// blobs without a codec header cannot be decoded as a payload
+	server = newTestServer(t)
+	data = codec.ConvertByPaddingEmptyByte(gettysburgAddressBytes)
+	retrievalClient.On("GetBlob").Return(data, nil)
+	_, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BlobHeader:           makeBlobHeader(t, blobLength(data), []uint32{0}),
+		ReferenceBlockNumber: 100,
+		QuorumId:             0,
+		Decoding:             pb.BlobDecoding_PAYLOAD,
+	})
+	require.Error(t, err)
+}
+
+func TestRetrieveBlobRaw(t *testing.T) {
+	server := newTestServer(t)
+	// a blob without a codec header, which ends in zero bytes
+	data := codec.ConvertByPaddingEmptyByte(append(append([]byte{}, gettysburgAddressBytes...), make([]byte, 40)...))
+	length := blobLength(data)
+	reconstructed := append(append([]byte{}, data...), make([]byte, int(length)*encoding.BYTES_PER_SYMBOL-len(data))...)
+	retrievalClient.On("GetBlob").Return(reconstructed, nil)
+
+	request := &pb.BlobRequest{
+		BlobHeader:           makeBlobHeader(t, length, []uint32{0}),
 		ReferenceBlockNumber: 100,
 		QuorumId:             0,
+		Decoding:             pb.BlobDecoding_RAW,
+	}
+	retrievalReply, err := server.RetrieveBlob(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, reconstructed, retrievalReply.Data)
+
+	// trimming the trailing zero bytes is opt-in, since it truncates the data
+	request.Decoding = pb.BlobDecoding_TRIM_PADDING
+	retrievalReply, err = server.RetrieveBlob(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
+
+	request.Decoding = 3
+	_, err = server.RetrieveBlob(context.Background(), request)
+	require.Error(t, err)
+}
+
+func TestRetrieveBlobCache(t *testing.T) {
+	server := newTestServer(t)
+	data, err := codecs.DefaultBlobCodec{}.EncodeBlob(gettysburgAddressBytes)
+	require.NoError(t, err)
+	retrievalClient.On("GetBlob").Return(data, nil)
+
+	request := &pb.BlobRequest{
+		BlobHeader:           makeBlobHeader(t, blobLength(data), []uint32{0}),
+		ReferenceBlockNumber: 100,
+		QuorumId:             0,
+		Decoding:             pb.BlobDecoding_PAYLOAD,
+	}
+	for i := 0; i < 3; i++ {
+		retrievalReply, err := server.RetrieveBlob(context.Background(), request)
+		require.NoError(t, err)
+		require.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
+	}
+	retrievalClient.AssertNumberOfCalls(t, "GetBlob", 1)
+}
+
+func TestRetrieveBlobQuorumFallback(t *testing.T) {
+	server := newTestServer(t)
+	data, err := codecs.DefaultBlobCodec{}.EncodeBlob(gettysburgAddressBytes)
+	require.NoError(t, err)
+	retrievalClient.On("GetBlob").Return([]byte(nil), errors.New("not enough chunks")).Once()
+	retrievalClient.On("GetBlob").Return(data, nil).Once()
+
+	retrievalReply, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BlobHeader:           makeBlobHeader(t, blobLength(data), []uint32{0, 1}),
+		ReferenceBlockNumber: 100,
+		QuorumIds:            []uint32{1, 0},
+		Decoding:             pb.BlobDecoding_PAYLOAD,
+	})
+	require.NoError(t, err)
+	require.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
+	retrievalClient.AssertNumberOfCalls(t, "GetBlob", 2)
+
+	// quorums the blob was not dispersed to are rejected
+	_, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BlobHeader:           makeBlobHeader(t, blobLength(data), []uint32{0, 1}),
+		ReferenceBlockNumber: 100,
+		QuorumIds:            []uint32{2},
+	})
+	require.Error(t, err)
+}
+
+// timeoutRetrievalClient times out on its first request, and returns a blob on the following ones.
+type timeoutRetrievalClient struct {
+	data     []byte
+	requests int
+}
+
+func (c *timeoutRetrievalClient) GetBlob(ctx context.Context, _ *corev2.BlobHeader, _ uint64, _ core.QuorumID) ([]byte, error) {
+	c.requests++
+	if c.requests == 1 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.data, nil
+}
+
+func TestRetrieveBlobQuorumTimeout(t *testing.T) {
+	data, err := codecs.DefaultBlobCodec{}.EncodeBlob(gettysburgAddressBytes)
+	require.NoError(t, err)
+	client := &timeoutRetrievalClient{data: data}
+	server := retriever.NewServer(&retriever.Config{Timeout: 50 * time.Millisecond}, logging.NewNoopLogger(), client, indexedChainState)
+
+	// the quorum that times out does not use up the time of the next one
+	retrievalReply, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BlobHeader:           makeBlobHeader(t, blobLength(data), []uint32{0, 1}),
+		ReferenceBlockNumber: 100,
+		QuorumIds:            []uint32{0, 1},
+		Decoding:             pb.BlobDecoding_PAYLOAD,
 	})
 	require.NoError(t, err)
 	require.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
+	require.Equal(t, 2, client.requests)
 }
@@ -294,8 +294,8 @@ func mustMakeDisperser(t *testing.T, cst core.IndexedChainState, store disperser
 		panic("failed to make initial query to the on-chain state")
 	}
 
-	mt := meterer.NewMeterer(meterer.Config{}, mockState, offchainStore, logger)
-	server := apiserver.NewDispersalServer(serverConfig, store, tx, logger, disperserMetrics, grpcprom.NewServerMetrics(), mt, ratelimiter, rateConfig, testMaxBlobSize)
+	mt := meterer.NewMeterer(meterer.Config{}, mockState, offchainStore, nil, logger)
+	server := apiserver.NewDispersalServer(serverConfig, store, tx, logger, disperserMetrics, grpcprom.NewServerMetrics(), mt, ratelimiter, rateConfig, testMaxBlobSize, nil)
 
 	return TestDisperser{
 		batcher:       batcher,
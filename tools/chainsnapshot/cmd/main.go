@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/snapshot"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/tools/chainsnapshot"
+	"github.com/Layr-Labs/eigenda/tools/chainsnapshot/flags"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s,%s,%s", version, gitCommit, gitDate)
+	app.Name = "chainsnapshot"
+	app.Description = "records the on-chain state at a reference block for offline replay"
+	app.Usage = ""
+	app.Flags = flags.Flags
+	app.Action = RunRecord
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func RunRecord(ctx *cli.Context) error {
+	config, err := chainsnapshot.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return err
+	}
+
+	gethClient, err := geth.NewClient(config.EthClientConfig, gethcommon.Address{}, 0, logger)
+	if err != nil {
+		logger.Error("Cannot create chain.Client", "err", err)
+		return err
+	}
+
+	tx, err := eth.NewReader(logger, gethClient, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create eth reader: %w", err)
+	}
+	chainState := eth.NewChainState(tx, gethClient)
+
+	logger.Info("Connecting to subgraph", "url", config.ChainStateConfig.Endpoint)
+	ics := thegraph.MakeIndexedChainState(config.ChainStateConfig, chainState, logger)
+
+	blockNumber := config.BlockNumber
+	if blockNumber == 0 {
+		blockNumber, err = ics.GetCurrentBlockNumber()
+		if err != nil {
+			return fmt.Errorf("failed to fetch current block number: %w", err)
+		}
+	}
+
+	s, err := snapshot.Record(context.Background(), tx, ics, blockNumber, config.Quorums)
+	if err != nil {
+		return err
+	}
+	if err := s.Save(config.OutputPath); err != nil {
+		return err
+	}
+
+	logger.Info("Recorded chain state snapshot", "blockNumber", blockNumber, "quorums", len(s.Quorums), "operators", len(s.Operators), "path", config.OutputPath)
+	return nil
+}
@@ -0,0 +1,59 @@
+package chainsnapshot
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/tools/chainsnapshot/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	LoggerConfig common.LoggerConfig
+	OutputPath   string
+	BlockNumber  uint
+	Quorums      []core.QuorumID
+
+	ChainStateConfig thegraph.Config
+	EthClientConfig  geth.EthClientConfig
+
+	BLSOperatorStateRetrieverAddr string
+	EigenDAServiceManagerAddr     string
+}
+
+func ReadConfig(ctx *cli.Context) (*Config, error) {
+	quorums := make([]core.QuorumID, 0)
+	for _, quorum := range ctx.IntSlice(flags.QuorumsFlag.Name) {
+		if quorum < 0 || quorum > 255 {
+			return nil, fmt.Errorf("invalid quorum %d", quorum)
+		}
+		quorums = append(quorums, core.QuorumID(quorum))
+	}
+
+	return &Config{
+		OutputPath:                    ctx.String(flags.OutputFlag.Name),
+		BlockNumber:                   ctx.Uint(flags.BlockNumberFlag.Name),
+		Quorums:                       quorums,
+		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		EthClientConfig:               geth.ReadEthClientConfig(ctx),
+		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
+		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+	}, nil
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ReadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config.LoggerConfig = *loggerConfig
+	return config, nil
+}
@@ -0,0 +1,70 @@
+package flags
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = ""
+	envPrefix  = "CHAINSNAPSHOT"
+)
+
+var (
+	/* Required Flags*/
+	BlsOperatorStateRetrieverFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
+		Usage:    "Address of the BLS Operator State Retriever",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLS_OPERATOR_STATE_RETRIVER"),
+	}
+	EigenDAServiceManagerFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "eigenda-service-manager"),
+		Usage:    "Address of the EigenDA Service Manager",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "EIGENDA_SERVICE_MANAGER"),
+	}
+	OutputFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "output"),
+		Usage:    "Path of the JSON file the snapshot is written to",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OUTPUT"),
+	}
+	/* Optional Flags*/
+	BlockNumberFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "block-number"),
+		Usage:    "Reference block number to record the state at. If 0, the current block is used",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOCK_NUMBER"),
+		Value:    0,
+	}
+	QuorumsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quorums"),
+		Usage:    "Quorums to record the operator state of (default: all the registered quorums)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "QUORUMS"),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	BlsOperatorStateRetrieverFlag,
+	EigenDAServiceManagerFlag,
+	OutputFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	BlockNumberFlag,
+	QuorumsFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
+	Flags = append(Flags, thegraph.CLIFlags(envPrefix)...)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/Layr-Labs/eigenda/tools/srs-utils/parser"
+	"github.com/Layr-Labs/eigenda/tools/srs-utils/tables"
 	"github.com/Layr-Labs/eigenda/tools/srs-utils/verifier"
 	"github.com/urfave/cli"
 )
@@ -36,6 +37,24 @@ func main() {
 					return nil
 				},
 			},
+			{
+				Name:  "generate-tables",
+				Usage: "precompute the SRS tables used by the prover for the given encoding params",
+				Flags: tables.Flags,
+				Action: func(cCtx *cli.Context) error {
+					config := tables.ReadCLIConfig(cCtx)
+					return tables.GenerateTables(config)
+				},
+			},
+			{
+				Name:  "verify-tables",
+				Usage: "verify if the precomputed SRS tables are consistent with the SRS",
+				Flags: tables.Flags,
+				Action: func(cCtx *cli.Context) error {
+					config := tables.ReadCLIConfig(cCtx)
+					return tables.VerifyTables(config)
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,90 @@
+package tables
+
+import (
+	"runtime"
+
+	"github.com/urfave/cli"
+)
+
+var (
+	/* Required Flags */
+	G1PathFlag = cli.StringFlag{
+		Name:     "g1-path",
+		Usage:    "File path to SRS g1 point",
+		Required: true,
+		EnvVar:   "G1_PATH",
+	}
+	CacheDirFlag = cli.StringFlag{
+		Name:     "cache-path",
+		Usage:    "Directory of the precomputed SRS tables",
+		Required: true,
+		EnvVar:   "CACHE_PATH",
+	}
+
+	/* Optional Flags */
+	EncodingParamsFlag = cli.StringSliceFlag{
+		Name:     "encoding-params",
+		Usage:    "Encoding parameters of a table, formatted as <numChunks>:<chunkLength>. Can be repeated. Verification defaults to every table in the cache directory",
+		Required: false,
+		EnvVar:   "ENCODING_PARAMS",
+	}
+	NumSamplesFlag = cli.Uint64Flag{
+		Name:     "num-samples",
+		Usage:    "Number of sub tables per table to recompute from the SRS during verification. 0 recomputes every sub table",
+		Required: false,
+		EnvVar:   "NUM_SAMPLES",
+		Value:    uint64(8),
+	}
+	PruneFlag = cli.BoolFlag{
+		Name:     "prune",
+		Usage:    "Delete tables that fail verification",
+		Required: false,
+		EnvVar:   "PRUNE",
+	}
+	WriteChecksumsFlag = cli.BoolFlag{
+		Name:     "write-checksums",
+		Usage:    "Append a checksum to verified tables that were written without one",
+		Required: false,
+		EnvVar:   "WRITE_CHECKSUMS",
+	}
+	NumWorkerFlag = cli.IntFlag{
+		Name:     "num-worker",
+		Usage:    "Set total number of worker thread",
+		Required: false,
+		EnvVar:   "NUM_WORKER",
+		Value:    runtime.GOMAXPROCS(0),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	G1PathFlag,
+	CacheDirFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	EncodingParamsFlag,
+	NumSamplesFlag,
+	PruneFlag,
+	WriteChecksumsFlag,
+	NumWorkerFlag,
+}
+
+func ReadCLIConfig(ctx *cli.Context) Config {
+	cfg := Config{}
+	cfg.G1Path = ctx.String(G1PathFlag.Name)
+	cfg.CacheDir = ctx.String(CacheDirFlag.Name)
+	cfg.EncodingParams = ctx.StringSlice(EncodingParamsFlag.Name)
+	cfg.NumSamples = ctx.Uint64(NumSamplesFlag.Name)
+	cfg.Prune = ctx.Bool(PruneFlag.Name)
+	cfg.WriteChecksums = ctx.Bool(WriteChecksumsFlag.Name)
+	cfg.NumWorker = ctx.Int(NumWorkerFlag.Name)
+
+	return cfg
+}
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
@@ -0,0 +1,206 @@
+package tables
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+)
+
+type Config struct {
+	G1Path         string
+	CacheDir       string
+	EncodingParams []string
+	NumSamples     uint64
+	Prune          bool
+	WriteChecksums bool
+	NumWorker      int
+}
+
+// GenerateTables precomputes the tables for the configured encoding parameters. Existing tables that fail to load
+// are regenerated.
+func GenerateTables(config Config) error {
+	params, err := parseTableParams(config.EncodingParams)
+	if err != nil {
+		return err
+	}
+	if len(params) == 0 {
+		return errors.New("no encoding params specified")
+	}
+
+	table, err := loadSRSTable(config, params)
+	if err != nil {
+		return err
+	}
+
+	for _, param := range params {
+		begin := time.Now()
+		if _, err := table.GetSubTables(param.DimE, param.CosetSize); err != nil {
+			return fmt.Errorf("failed to generate table %v: %w", prover.TableFileName(param), err)
+		}
+		fmt.Printf("Table %v is ready. Took %v\n", prover.TableFileName(param), time.Since(begin))
+	}
+
+	return nil
+}
+
+// VerifyTables checks the tables in the cache directory against their checksums and recomputes a sample of each
+// table from the SRS. It returns an error if any table fails verification.
+func VerifyTables(config Config) error {
+	params, err := parseTableParams(config.EncodingParams)
+	if err != nil {
+		return err
+	}
+	if len(params) == 0 {
+		params, err = listTableParams(config.CacheDir)
+		if err != nil {
+			return err
+		}
+	}
+	if len(params) == 0 {
+		fmt.Printf("No table found in %v\n", config.CacheDir)
+		return nil
+	}
+
+	table, err := loadSRSTable(config, params)
+	if err != nil {
+		return err
+	}
+
+	numFailed := 0
+	for _, param := range params {
+		filePath := path.Join(config.CacheDir, prover.TableFileName(param))
+		err := verifyTable(config, table, param)
+		if err == nil {
+			continue
+		}
+
+		numFailed++
+		fmt.Printf("Table %v is corrupted or stale: %v\n", prover.TableFileName(param), err)
+		if config.Prune {
+			if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			fmt.Printf("Table %v is deleted\n", prover.TableFileName(param))
+		}
+	}
+
+	if numFailed > 0 {
+		return fmt.Errorf("%v out of %v tables failed verification", numFailed, len(params))
+	}
+	fmt.Println("Done. Everything is correct")
+	return nil
+}
+
+func verifyTable(config Config, table *prover.SRSTable, param prover.TableParam) error {
+	filePath := path.Join(config.CacheDir, prover.TableFileName(param))
+	hasChecksum, err := prover.TableHasChecksum(filePath, param)
+	if err != nil {
+		return err
+	}
+
+	var rows []uint64
+	if config.NumSamples > 0 && config.NumSamples < param.CosetSize {
+		for _, j := range rand.Perm(int(param.CosetSize))[:config.NumSamples] {
+			rows = append(rows, uint64(j))
+		}
+	}
+	if err := table.VerifyTable(param.DimE, param.CosetSize, rows); err != nil {
+		return err
+	}
+
+	if hasChecksum {
+		fmt.Printf("Table %v is correct\n", prover.TableFileName(param))
+		return nil
+	}
+
+	if config.WriteChecksums {
+		if err := prover.AppendTableChecksum(filePath, param); err != nil {
+			return err
+		}
+		fmt.Printf("Table %v is correct, checksum is added\n", prover.TableFileName(param))
+		return nil
+	}
+
+	fmt.Printf("Table %v is correct, but has no checksum\n", prover.TableFileName(param))
+	return nil
+}
+
+// loadSRSTable reads as many G1 points as the largest table needs.
+func loadSRSTable(config Config, params []prover.TableParam) (*prover.SRSTable, error) {
+	numPoint := uint64(0)
+	for _, param := range params {
+		if param.DimE*param.CosetSize > numPoint {
+			numPoint = param.DimE * param.CosetSize
+		}
+	}
+
+	s1, err := kzg.ReadG1Points(config.G1Path, numPoint, uint64(config.NumWorker))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %v G1 points: %w", numPoint, err)
+	}
+
+	return prover.NewSRSTable(config.CacheDir, s1, uint64(config.NumWorker))
+}
+
+func parseTableParams(encodingParams []string) ([]prover.TableParam, error) {
+	params := make([]prover.TableParam, 0, len(encodingParams))
+	for _, p := range encodingParams {
+		tokens := strings.Split(p, ":")
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("invalid encoding params %v, expect <numChunks>:<chunkLength>", p)
+		}
+		numChunks, err := strconv.ParseUint(tokens[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number of chunks in %v: %w", p, err)
+		}
+		chunkLength, err := strconv.ParseUint(tokens[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunk length in %v: %w", p, err)
+		}
+		if numChunks == 0 || chunkLength == 0 {
+			return nil, fmt.Errorf("invalid encoding params %v, values must be positive", p)
+		}
+
+		params = append(params, prover.TableParam{
+			DimE:      numChunks,
+			CosetSize: chunkLength,
+		})
+	}
+	return params, nil
+}
+
+func listTableParams(cacheDir string) ([]prover.TableParam, error) {
+	files, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]prover.TableParam, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		param, err := prover.ParseTableFileName(file.Name())
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+
+	sort.Slice(params, func(i, j int) bool {
+		if params[i].DimE != params[j].DimE {
+			return params[i].DimE < params[j].DimE
+		}
+		return params[i].CosetSize < params[j].CosetSize
+	})
+	return params, nil
+}
@@ -40,6 +40,11 @@ type Config struct {
 	NodeClientTimeout time.Duration
 	// The amount of time to sleep after launching each worker thread.
 	InstanceLaunchInterval time.Duration
+
+	// The version of the disperser to send traffic to, either 1 or 2.
+	DisperserVersion uint
+	// Configures the traffic sent through the v2 disperser. Only used if DisperserVersion is 2.
+	V2 V2Config
 }
 
 func NewConfig(ctx *cli.Context) (*Config, error) {
@@ -90,6 +95,14 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 
 		InstanceLaunchInterval: ctx.Duration(InstanceLaunchIntervalFlag.Name),
 
+		DisperserVersion: ctx.GlobalUint(DisperserVersionFlag.Name),
+		V2: V2Config{
+			BlobVersion:                 uint16(ctx.GlobalUint(BlobVersionFlag.Name)),
+			ReservationSignerPrivateKey: ctx.String(ReservationSignerPrivateKeyFlag.Name),
+			OnDemandSignerPrivateKey:    ctx.String(OnDemandSignerPrivateKeyFlag.Name),
+			WorkloadReportPath:          ctx.String(WorkloadReportFileFlag.Name),
+		},
+
 		WorkerConfig: WorkerConfig{
 			NumWriteInstances:    ctx.GlobalUint(NumWriteInstancesFlag.Name),
 			WriteRequestInterval: ctx.Duration(WriteRequestIntervalFlag.Name),
@@ -116,6 +129,23 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		},
 	}
 
+	if config.DisperserVersion != 1 && config.DisperserVersion != 2 {
+		return nil, fmt.Errorf("invalid disperser version %d", config.DisperserVersion)
+	}
+	if config.DisperserVersion == 2 && config.V2.ReservationSignerPrivateKey == "" && config.V2.OnDemandSignerPrivateKey == "" {
+		return nil, errors.New("a reservation or an on-demand signer private key is required to disperse to the v2 disperser")
+	}
+
+	if workloadPath := ctx.String(WorkloadFileFlag.Name); workloadPath != "" {
+		if config.DisperserVersion != 2 {
+			return nil, errors.New("workloads are only supported with the v2 disperser")
+		}
+		config.V2.Workload, err = LoadWorkload(workloadPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = config.EigenDAClientConfig.CheckAndSetDefaults()
 	if err != nil {
 		return nil, err
@@ -220,6 +220,47 @@ var (
 		Value:    5 * time.Second,
 		EnvVar:   common.PrefixEnvVar(envPrefix, "RETRIEVE_BLOB_CHUNKS_TIMEOUT"),
 	}
+
+	/* Configuration for the v2 dispersal path. */
+
+	DisperserVersionFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-version"),
+		Usage:    "Version of the disperser to send traffic to, either 1 or 2.",
+		Required: false,
+		Value:    1,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DISPERSER_VERSION"),
+	}
+	BlobVersionFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-version"),
+		Usage:    "Blob version of the blobs dispersed to the v2 disperser.",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_VERSION"),
+	}
+	ReservationSignerPrivateKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "reservation-signer-private-key-hex"),
+		Usage:    "Private key of an account that pays for v2 dispersals with its reservation.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RESERVATION_SIGNER_PRIVATE_KEY_HEX"),
+	}
+	OnDemandSignerPrivateKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "on-demand-signer-private-key-hex"),
+		Usage:    "Private key of an account without a reservation that pays for v2 dispersals on demand.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "ON_DEMAND_SIGNER_PRIVATE_KEY_HEX"),
+	}
+	WorkloadFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "workload-file"),
+		Usage:    "Path to a YAML or JSON workload file. If set, the v2 writers and readers follow the phases of the workload instead of the fixed rates.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "WORKLOAD_FILE"),
+	}
+	WorkloadReportFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "workload-report-file"),
+		Usage:    "Path the JSON summary of the workload phases is written to. If empty, the summary is only logged.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "WORKLOAD_REPORT_FILE"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -254,6 +295,12 @@ var optionalFlags = []cli.Flag{
 	VerificationChannelCapacityFlag,
 	MetricsBlacklistFlag,
 	MetricsFuzzyBlacklistFlag,
+	DisperserVersionFlag,
+	BlobVersionFlag,
+	ReservationSignerPrivateKeyFlag,
+	OnDemandSignerPrivateKeyFlag,
+	WorkloadFileFlag,
+	WorkloadReportFileFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
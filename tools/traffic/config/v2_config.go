@@ -0,0 +1,21 @@
+package config
+
+// V2Config configures the traffic generator to send traffic through the v2 disperser, and to read blobs back from
+// the relays and the validators.
+type V2Config struct {
+	// The blob version of the dispersed blobs.
+	BlobVersion uint16
+
+	// The private key of an account that pays for dispersals with its reservation. If set, NumWriteInstances
+	// writers disperse blobs with this account.
+	ReservationSignerPrivateKey string
+	// The private key of an account that pays for dispersals on demand. If set, NumWriteInstances writers disperse
+	// blobs with this account. The account should not have a reservation, since a reservation is always used first.
+	OnDemandSignerPrivateKey string
+
+	// The workload followed by the writers and readers. If nil, the writers and readers run at the fixed rates of
+	// the worker config.
+	Workload *Workload
+	// The path the JSON summary of the workload phases is written to. If empty, the summary is only logged.
+	WorkloadReportPath string
+}
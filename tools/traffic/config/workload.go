@@ -0,0 +1,243 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The supported blob size distributions.
+const (
+	// FixedSize disperses blobs of a single size.
+	FixedSize = "fixed"
+	// UniformSize disperses blobs with sizes drawn uniformly from [min, max].
+	UniformSize = "uniform"
+	// WeightedSize disperses blobs with sizes drawn from a list of weighted choices.
+	WeightedSize = "weighted"
+)
+
+// The supported arrival processes.
+const (
+	// ConstantArrival writes blobs at a constant rate.
+	ConstantArrival = "constant"
+	// PoissonArrival writes blobs with exponentially distributed gaps, with an average rate.
+	PoissonArrival = "poisson"
+	// BurstyArrival writes bursts of blobs at a fixed interval.
+	BurstyArrival = "bursty"
+)
+
+// DefaultMaxInFlight is the number of writes a phase keeps in flight at once when it does not configure a limit.
+const DefaultMaxInFlight = 256
+
+// The supported payment modes. They match the payment types of the v2 blob writers.
+const (
+	ReservationPaymentMode = "reservation"
+	OnDemandPaymentMode    = "on_demand"
+)
+
+// Workload describes time-varying traffic as a sequence of phases. Workloads are read from YAML or JSON files, e.g.
+//
+//	phases:
+//	  - name: warmup
+//	    duration: 5m
+//	    blob_size: {type: fixed, size: 1024}
+//	    arrival: {type: constant, rate: 1}
+//	  - name: peak
+//	    duration: 10m
+//	    blob_size:
+//	      type: weighted
+//	      choices: [{size: 1024, weight: 9}, {size: 1048576, weight: 1}]
+//	    arrival: {type: poisson, rate: 20}
+//	    quorums: [{quorums: [0, 1], weight: 3}, {quorums: [0, 1, 2], weight: 1}]
+//	    payments: [{payment: reservation, weight: 1}, {payment: on_demand, weight: 1}]
+//	    read_write_ratio: 2
+type Workload struct {
+	// The phases of the workload, executed in sequence.
+	Phases []*WorkloadPhase `yaml:"phases"`
+}
+
+// WorkloadPhase describes the traffic of one phase of a workload.
+type WorkloadPhase struct {
+	// The name of the phase, used in the summary report.
+	Name string `yaml:"name"`
+	// How long the phase lasts.
+	Duration time.Duration `yaml:"duration"`
+	// The distribution of the sizes of the dispersed blobs, in bytes.
+	BlobSize SizeDistribution `yaml:"blob_size"`
+	// The process by which blobs are written.
+	Arrival ArrivalProcess `yaml:"arrival"`
+	// The quorums blobs are dispersed to. If empty, the custom quorums of the worker config are used.
+	Quorums []*QuorumMix `yaml:"quorums"`
+	// The payment modes of the dispersals. If empty, every configured payment mode is used with equal weight.
+	Payments []*PaymentMix `yaml:"payments"`
+	// The average number of reads per write. Reads are spread over the certified blobs.
+	ReadWriteRatio float64 `yaml:"read_write_ratio"`
+	// The maximum number of writes, with the reads that follow them, in flight at once. Arrivals while the limit is
+	// reached are dropped and counted in the summary report. Defaults to DefaultMaxInFlight.
+	MaxInFlight uint `yaml:"max_in_flight"`
+}
+
+// SizeDistribution describes the distribution of blob sizes.
+type SizeDistribution struct {
+	// One of FixedSize, UniformSize or WeightedSize.
+	Type string `yaml:"type"`
+	// The size of every blob. Only used by FixedSize.
+	Size uint64 `yaml:"size"`
+	// The smallest and largest blob sizes. Only used by UniformSize.
+	Min uint64 `yaml:"min"`
+	Max uint64 `yaml:"max"`
+	// The weighted sizes to choose from. Only used by WeightedSize.
+	Choices []*WeightedBlobSize `yaml:"choices"`
+}
+
+// WeightedBlobSize is a blob size chosen with a relative weight.
+type WeightedBlobSize struct {
+	Size   uint64  `yaml:"size"`
+	Weight float64 `yaml:"weight"`
+}
+
+// ArrivalProcess describes when blobs are written.
+type ArrivalProcess struct {
+	// One of ConstantArrival, PoissonArrival or BurstyArrival.
+	Type string `yaml:"type"`
+	// The number of blobs written per second. Used by ConstantArrival and PoissonArrival.
+	Rate float64 `yaml:"rate"`
+	// The number of blobs written at once. Only used by BurstyArrival.
+	BurstSize uint `yaml:"burst_size"`
+	// The time between bursts. Only used by BurstyArrival.
+	BurstInterval time.Duration `yaml:"burst_interval"`
+}
+
+// QuorumMix is a set of quorums chosen with a relative weight.
+type QuorumMix struct {
+	Quorums []uint8 `yaml:"quorums"`
+	Weight  float64 `yaml:"weight"`
+}
+
+// PaymentMix is a payment mode chosen with a relative weight.
+type PaymentMix struct {
+	// One of ReservationPaymentMode or OnDemandPaymentMode.
+	Payment string  `yaml:"payment"`
+	Weight  float64 `yaml:"weight"`
+}
+
+// LoadWorkload reads a workload from a YAML or JSON file, and validates it.
+func LoadWorkload(path string) (*Workload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read workload file: %w", err)
+	}
+	return ParseWorkload(data)
+}
+
+// ParseWorkload parses a workload from YAML or JSON, and validates it. Durations are written as Go durations,
+// e.g. "1m30s".
+func ParseWorkload(data []byte) (*Workload, error) {
+	// JSON is a subset of YAML, so a single decoder reads both formats.
+	workload := &Workload{}
+	if err := yaml.Unmarshal(data, workload); err != nil {
+		return nil, fmt.Errorf("parse workload: %w", err)
+	}
+	if err := workload.Validate(); err != nil {
+		return nil, err
+	}
+	return workload, nil
+}
+
+// Validate checks that every phase of the workload is well formed.
+func (w *Workload) Validate() error {
+	if len(w.Phases) == 0 {
+		return errors.New("workload has no phases")
+	}
+	for i, phase := range w.Phases {
+		if phase == nil {
+			return fmt.Errorf("phase %d is empty", i)
+		}
+		if phase.Name == "" {
+			phase.Name = fmt.Sprintf("phase-%d", i)
+		}
+		if phase.MaxInFlight == 0 {
+			phase.MaxInFlight = DefaultMaxInFlight
+		}
+		if err := phase.validate(); err != nil {
+			return fmt.Errorf("phase %s: %w", phase.Name, err)
+		}
+	}
+	return nil
+}
+
+func (p *WorkloadPhase) validate() error {
+	if p.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if p.ReadWriteRatio < 0 {
+		return errors.New("read_write_ratio must not be negative")
+	}
+	if err := p.BlobSize.validate(); err != nil {
+		return fmt.Errorf("blob_size: %w", err)
+	}
+	if err := p.Arrival.validate(); err != nil {
+		return fmt.Errorf("arrival: %w", err)
+	}
+	for _, mix := range p.Quorums {
+		if len(mix.Quorums) == 0 {
+			return errors.New("quorums: quorum mix has no quorums")
+		}
+		if mix.Weight <= 0 {
+			return errors.New("quorums: weight must be positive")
+		}
+	}
+	for _, mix := range p.Payments {
+		if mix.Payment != ReservationPaymentMode && mix.Payment != OnDemandPaymentMode {
+			return fmt.Errorf("payments: unknown payment mode %q", mix.Payment)
+		}
+		if mix.Weight <= 0 {
+			return errors.New("payments: weight must be positive")
+		}
+	}
+	return nil
+}
+
+func (d *SizeDistribution) validate() error {
+	switch d.Type {
+	case FixedSize:
+		if d.Size == 0 {
+			return errors.New("size must be positive")
+		}
+	case UniformSize:
+		if d.Min == 0 || d.Max < d.Min {
+			return errors.New("min must be positive and not greater than max")
+		}
+	case WeightedSize:
+		if len(d.Choices) == 0 {
+			return errors.New("no choices")
+		}
+		for _, choice := range d.Choices {
+			if choice.Size == 0 || choice.Weight <= 0 {
+				return errors.New("choice size and weight must be positive")
+			}
+		}
+	default:
+		return fmt.Errorf("unknown type %q", d.Type)
+	}
+	return nil
+}
+
+func (a *ArrivalProcess) validate() error {
+	switch a.Type {
+	case ConstantArrival, PoissonArrival:
+		if a.Rate <= 0 {
+			return errors.New("rate must be positive")
+		}
+	case BurstyArrival:
+		if a.BurstSize == 0 || a.BurstInterval <= 0 {
+			return errors.New("burst_size and burst_interval must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", a.Type)
+	}
+	return nil
+}
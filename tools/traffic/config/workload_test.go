@@ -0,0 +1,96 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorkloadYAML(t *testing.T) {
+	workload, err := ParseWorkload([]byte(`
+phases:
+  - name: warmup
+    duration: 1m30s
+    blob_size: {type: fixed, size: 1024}
+    arrival: {type: constant, rate: 2}
+  - duration: 10m
+    blob_size:
+      type: weighted
+      choices: [{size: 1024, weight: 9}, {size: 1048576, weight: 1}]
+    arrival: {type: bursty, burst_size: 10, burst_interval: 5s}
+    quorums: [{quorums: [0, 1], weight: 3}, {quorums: [0, 1, 2], weight: 1}]
+    payments: [{payment: reservation, weight: 1}, {payment: on_demand, weight: 1}]
+    read_write_ratio: 1.5
+    max_in_flight: 8
+`))
+	require.NoError(t, err)
+	require.Len(t, workload.Phases, 2)
+
+	warmup := workload.Phases[0]
+	require.Equal(t, "warmup", warmup.Name)
+	require.Equal(t, 90*time.Second, warmup.Duration)
+	require.Equal(t, uint64(1024), warmup.BlobSize.Size)
+	require.Equal(t, 2.0, warmup.Arrival.Rate)
+	require.Equal(t, uint(DefaultMaxInFlight), warmup.MaxInFlight)
+
+	peak := workload.Phases[1]
+	require.Equal(t, "phase-1", peak.Name)
+	require.Len(t, peak.BlobSize.Choices, 2)
+	require.Equal(t, 5*time.Second, peak.Arrival.BurstInterval)
+	require.Equal(t, []uint8{0, 1, 2}, peak.Quorums[1].Quorums)
+	require.Equal(t, OnDemandPaymentMode, peak.Payments[1].Payment)
+	require.Equal(t, 1.5, peak.ReadWriteRatio)
+	require.Equal(t, uint(8), peak.MaxInFlight)
+}
+
+func TestParseWorkloadJSON(t *testing.T) {
+	workload, err := ParseWorkload([]byte(`{"phases": [{
+		"name": "steady",
+		"duration": "30s",
+		"blob_size": {"type": "uniform", "min": 100, "max": 200},
+		"arrival": {"type": "poisson", "rate": 5}
+	}]}`))
+	require.NoError(t, err)
+	require.Len(t, workload.Phases, 1)
+	require.Equal(t, 30*time.Second, workload.Phases[0].Duration)
+	require.Equal(t, uint64(200), workload.Phases[0].BlobSize.Max)
+	require.Equal(t, PoissonArrival, workload.Phases[0].Arrival.Type)
+}
+
+func TestParseInvalidWorkload(t *testing.T) {
+	phase := func(body string) []byte {
+		return []byte("phases:\n  - " + body)
+	}
+
+	_, err := ParseWorkload([]byte("phases: []"))
+	require.Error(t, err)
+	// no duration
+	_, err = ParseWorkload(phase(`{blob_size: {type: fixed, size: 1}, arrival: {type: constant, rate: 1}}`))
+	require.Error(t, err)
+	// unknown size distribution
+	_, err = ParseWorkload(phase(`{duration: 1s, blob_size: {type: normal}, arrival: {type: constant, rate: 1}}`))
+	require.Error(t, err)
+	// empty uniform range
+	_, err = ParseWorkload(phase(`{duration: 1s, blob_size: {type: uniform, min: 10, max: 5}, arrival: {type: constant, rate: 1}}`))
+	require.Error(t, err)
+	// bursty arrival without a burst size
+	_, err = ParseWorkload(phase(`{duration: 1s, blob_size: {type: fixed, size: 1}, arrival: {type: bursty, burst_interval: 1s}}`))
+	require.Error(t, err)
+	// unknown payment mode
+	_, err = ParseWorkload(phase(`{duration: 1s, blob_size: {type: fixed, size: 1}, arrival: {type: constant, rate: 1},
+    payments: [{payment: free, weight: 1}]}`))
+	require.Error(t, err)
+	// negative read/write ratio
+	_, err = ParseWorkload(phase(`{duration: 1s, blob_size: {type: fixed, size: 1}, arrival: {type: constant, rate: 1},
+    read_write_ratio: -1}`))
+	require.Error(t, err)
+}
+
+func TestLoadExampleWorkload(t *testing.T) {
+	workload, err := LoadWorkload("../workloads/example.yaml")
+	require.NoError(t, err)
+	require.Len(t, workload.Phases, 3)
+	require.Equal(t, "burst", workload.Phases[2].Name)
+	require.Equal(t, uint(50), workload.Phases[2].Arrival.BurstSize)
+}
@@ -56,6 +56,14 @@ type Generator struct {
 	writers       []*workers.BlobWriter
 	statusTracker *workers.BlobStatusTracker
 	readers       []*workers.BlobReader
+
+	// The workers of the v2 dispersal path. Set instead of the workers above if the disperser version is 2.
+	writersV2       []*workers.BlobWriterV2
+	statusTrackerV2 *workers.BlobStatusTrackerV2
+	readersV2       []*workers.BlobReaderV2
+
+	// Drives the v2 writers and readers if a workload is configured.
+	workloadRunner *workers.WorkloadRunner
 }
 
 func NewTrafficGeneratorV2(config *config.Config) (*Generator, error) {
@@ -64,6 +72,10 @@ func NewTrafficGeneratorV2(config *config.Config) (*Generator, error) {
 		return nil, err
 	}
 
+	if config.DisperserVersion == 2 {
+		return newV2DispersalGenerator(config, logger)
+	}
+
 	var signer core.BlobRequestSigner
 	if config.EigenDAClientConfig.SignerPrivateKeyHex != "" {
 		signer = auth.NewLocalBlobRequestSigner(config.EigenDAClientConfig.SignerPrivateKeyHex)
@@ -206,21 +218,44 @@ func buildRetriever(config *config.Config) (clients.RetrievalClient, retrivereth
 func (generator *Generator) Start() error {
 
 	generator.generatorMetrics.Start()
-	generator.statusTracker.Start()
+	if generator.statusTracker != nil {
+		generator.statusTracker.Start()
+	}
+	if generator.statusTrackerV2 != nil {
+		generator.statusTrackerV2.Start()
+	}
 
 	for _, writer := range generator.writers {
 		writer.Start()
 		time.Sleep(generator.config.InstanceLaunchInterval)
 	}
+	for _, writer := range generator.writersV2 {
+		writer.Start()
+		time.Sleep(generator.config.InstanceLaunchInterval)
+	}
 
 	for _, reader := range generator.readers {
 		reader.Start()
 		time.Sleep(generator.config.InstanceLaunchInterval)
 	}
+	for _, reader := range generator.readersV2 {
+		reader.Start()
+		time.Sleep(generator.config.InstanceLaunchInterval)
+	}
+
+	// A nil channel never fires, so without a workload the generator runs until it is interrupted.
+	var workloadDone <-chan struct{}
+	if generator.workloadRunner != nil {
+		generator.workloadRunner.Start()
+		workloadDone = generator.workloadRunner.Done()
+	}
 
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
-	<-signals
+	select {
+	case <-signals:
+	case <-workloadDone:
+	}
 
 	(*generator.cancel)()
 	generator.waitGroup.Wait()
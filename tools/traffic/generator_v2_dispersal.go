@@ -0,0 +1,236 @@
+package traffic
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	clientsv2 "github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/tools/traffic/config"
+	"github.com/Layr-Labs/eigenda/tools/traffic/metrics"
+	"github.com/Layr-Labs/eigenda/tools/traffic/table"
+	"github.com/Layr-Labs/eigenda/tools/traffic/workers"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// newV2DispersalGenerator creates a generator that disperses blobs through the v2 disperser, tracks their v2
+// statuses, and reads them back from the relays and the validators.
+//
+// The writers pay for dispersals with the configured accounts: NumWriteInstances writers per account. Each account
+// has its own disperser client, so that the writers of an account share its payment accounting. If a workload is
+// configured, the writes and reads follow its phases instead.
+func newV2DispersalGenerator(config *config.Config, logger logging.Logger) (*Generator, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	waitGroup := sync.WaitGroup{}
+
+	generatorMetrics := metrics.NewMetrics(
+		config.MetricsHTTPPort,
+		logger,
+		config.WorkerConfig.MetricsBlacklist,
+		config.WorkerConfig.MetricsFuzzyBlacklist)
+
+	blobTable := table.NewBlobStore()
+	unconfirmedKeyChannel := make(chan *workers.UnconfirmedKeyV2, 100)
+
+	disperserClients := make(map[string]clientsv2.DisperserClient)
+	accounts := map[string]string{
+		workers.ReservationPayment: config.V2.ReservationSignerPrivateKey,
+		workers.OnDemandPayment:    config.V2.OnDemandSignerPrivateKey,
+	}
+	for paymentType, privateKey := range accounts {
+		if privateKey == "" {
+			continue
+		}
+		disperserClient, err := clientsv2.NewDisperserClient(&clientsv2.DisperserClientConfig{
+			Hostname:          config.DisperserClientConfig.Hostname,
+			Port:              config.DisperserClientConfig.Port,
+			UseSecureGrpcFlag: config.DisperserClientConfig.UseSecureGrpcFlag,
+		}, authv2.NewLocalBlobRequestSigner(privateKey), nil, nil)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("new %s disperser client: %w", paymentType, err)
+		}
+		disperserClients[paymentType] = disperserClient
+	}
+	if len(disperserClients) == 0 {
+		cancel()
+		return nil, fmt.Errorf("no signer private key configured for v2 dispersal")
+	}
+
+	// Any of the clients can track the statuses, since blob statuses are not tied to an account.
+	var statusClient clientsv2.DisperserClient
+	for _, disperserClient := range disperserClients {
+		statusClient = disperserClient
+		break
+	}
+	statusTracker := workers.NewBlobStatusTrackerV2(
+		&ctx,
+		&waitGroup,
+		logger,
+		&config.WorkerConfig,
+		unconfirmedKeyChannel,
+		blobTable,
+		statusClient,
+		generatorMetrics)
+
+	relayClient, retrievalClient, err := buildV2Retrievers(ctx, config, logger)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	generator := &Generator{
+		ctx:              &ctx,
+		cancel:           &cancel,
+		waitGroup:        &waitGroup,
+		generatorMetrics: generatorMetrics,
+		logger:           &logger,
+		config:           config,
+		statusTrackerV2:  &statusTracker,
+	}
+
+	if config.V2.Workload != nil {
+		// The workload decides when to write and read, so a single writer per account and a single reader suffice.
+		writers := make(map[string]*workers.BlobWriterV2)
+		for paymentType, disperserClient := range disperserClients {
+			writer := workers.NewBlobWriterV2(
+				&ctx,
+				&waitGroup,
+				logger,
+				&config.WorkerConfig,
+				corev2.BlobVersion(config.V2.BlobVersion),
+				disperserClient,
+				paymentType,
+				unconfirmedKeyChannel,
+				generatorMetrics)
+			writers[paymentType] = &writer
+		}
+		reader := workers.NewBlobReaderV2(
+			&ctx,
+			&waitGroup,
+			logger,
+			&config.WorkerConfig,
+			relayClient,
+			retrievalClient,
+			blobTable,
+			generatorMetrics)
+
+		generator.workloadRunner, err = workers.NewWorkloadRunner(
+			&ctx,
+			&waitGroup,
+			logger,
+			&config.WorkerConfig,
+			config.V2.Workload,
+			writers,
+			&reader,
+			config.V2.WorkloadReportPath)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("new workload runner: %w", err)
+		}
+		return generator, nil
+	}
+
+	for paymentType, disperserClient := range disperserClients {
+		for i := 0; i < int(config.WorkerConfig.NumWriteInstances); i++ {
+			writer := workers.NewBlobWriterV2(
+				&ctx,
+				&waitGroup,
+				logger,
+				&config.WorkerConfig,
+				corev2.BlobVersion(config.V2.BlobVersion),
+				disperserClient,
+				paymentType,
+				unconfirmedKeyChannel,
+				generatorMetrics)
+			generator.writersV2 = append(generator.writersV2, &writer)
+		}
+	}
+
+	for i := 0; i < int(config.WorkerConfig.NumReadInstances); i++ {
+		reader := workers.NewBlobReaderV2(
+			&ctx,
+			&waitGroup,
+			logger,
+			&config.WorkerConfig,
+			relayClient,
+			retrievalClient,
+			blobTable,
+			generatorMetrics)
+		generator.readersV2 = append(generator.readersV2, &reader)
+	}
+
+	return generator, nil
+}
+
+// buildV2Retrievers creates the clients that read blobs back from the relays registered on chain, and from the
+// validators.
+func buildV2Retrievers(
+	ctx context.Context,
+	config *config.Config,
+	logger logging.Logger) (clientsv2.RelayClient, clientsv2.RetrievalClient, error) {
+
+	gethClient, err := geth.NewMultiHomingClient(config.RetrievalClientConfig.EthClientConfig, gethcommon.Address{}, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new geth client: %w", err)
+	}
+
+	reader, err := eth.NewReader(
+		logger,
+		gethClient,
+		config.RetrievalClientConfig.BLSOperatorStateRetrieverAddr,
+		config.RetrievalClientConfig.EigenDAServiceManagerAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new eth reader: %w", err)
+	}
+
+	relayURLs, err := reader.GetRelayURLs(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get relay URLs: %w", err)
+	}
+	sockets := make(map[corev2.RelayKey]string, len(relayURLs))
+	for key, url := range relayURLs {
+		sockets[corev2.RelayKey(key)] = url
+	}
+	relayClient, err := clientsv2.NewRelayClient(&clientsv2.RelayClientConfig{
+		Sockets:           sockets,
+		UseSecureGrpcFlag: config.DisperserClientConfig.UseSecureGrpcFlag,
+	}, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new relay client: %w", err)
+	}
+
+	chainState := thegraph.MakeIndexedChainState(*config.TheGraphConfig, eth.NewChainState(reader, gethClient), logger)
+
+	config.RetrievalClientConfig.EncoderConfig.LoadG2Points = true
+	v, err := verifier.NewVerifier(&config.RetrievalClientConfig.EncoderConfig, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new verifier: %w", err)
+	}
+
+	// GetChunks requests are signed by one of the dispersal accounts, if any is configured.
+	var signer corev2.BlobRequestSigner
+	for _, privateKey := range []string{config.V2.ReservationSignerPrivateKey, config.V2.OnDemandSignerPrivateKey} {
+		if privateKey != "" {
+			signer = authv2.NewLocalBlobRequestSigner(privateKey)
+			break
+		}
+	}
+
+	retrievalClient := clientsv2.NewRetrievalClient(
+		logger,
+		reader,
+		chainState,
+		v,
+		config.RetrievalClientConfig.NumConnections,
+		signer)
+
+	return relayClient, retrievalClient, nil
+}
@@ -1,6 +1,10 @@
 package table
 
-import "errors"
+import (
+	"errors"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+)
 
 // BlobMetadata encapsulates various information about a blob written by the traffic generator.
 type BlobMetadata struct {
@@ -22,6 +26,15 @@ type BlobMetadata struct {
 	// RemainingReadPermits describes the maximum number of remaining reads permitted against this blob.
 	// If -1 then an unlimited number of reads are permitted.
 	RemainingReadPermits int
+
+	// BlobHeader of the blob. Only set for blobs dispersed through the v2 disperser.
+	BlobHeader *corev2.BlobHeader
+
+	// RelayKeys of the relays that serve the blob. Only set for blobs dispersed through the v2 disperser.
+	RelayKeys []corev2.RelayKey
+
+	// ReferenceBlockNumber of the batch that certified the blob. Only set for blobs dispersed through the v2 disperser.
+	ReferenceBlockNumber uint64
 }
 
 // NewBlobMetadata creates a new BlobMetadata instance. The readPermits parameter describes the maximum number of
@@ -47,3 +60,32 @@ func NewBlobMetadata(
 		RemainingReadPermits: readPermits,
 	}, nil
 }
+
+// NewBlobMetadataV2 creates a new BlobMetadata instance for a blob dispersed through the v2 disperser. The readPermits
+// parameter describes the maximum number of remaining reads permitted against this blob. If -1 then an unlimited number
+// of reads are permitted.
+func NewBlobMetadataV2(
+	blobKey corev2.BlobKey,
+	checksum [16]byte,
+	size uint,
+	certificate *corev2.BlobCertificate,
+	referenceBlockNumber uint64,
+	readPermits int) (*BlobMetadata, error) {
+
+	if readPermits == 0 {
+		return nil, errors.New("read permits must not be zero")
+	}
+	if certificate == nil || certificate.BlobHeader == nil {
+		return nil, errors.New("blob certificate must contain a blob header")
+	}
+
+	return &BlobMetadata{
+		Key:                  blobKey[:],
+		Checksum:             checksum,
+		Size:                 size,
+		RemainingReadPermits: readPermits,
+		BlobHeader:           certificate.BlobHeader,
+		RelayKeys:            certificate.RelayKeys,
+		ReferenceBlockNumber: referenceBlockNumber,
+	}, nil
+}
@@ -0,0 +1,195 @@
+package workers
+
+import (
+	"context"
+	"crypto/md5"
+	"math/rand"
+	"sync"
+	"time"
+
+	clientsv2 "github.com/Layr-Labs/eigenda/api/clients/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/tools/traffic/config"
+	"github.com/Layr-Labs/eigenda/tools/traffic/metrics"
+	"github.com/Layr-Labs/eigenda/tools/traffic/table"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// BlobReaderV2 reads blobs dispersed through the v2 disperser at a configured rate. Each blob is read both from one
+// of its relays and from the validators, and the payload read from each is checked against the dispersed payload.
+type BlobReaderV2 struct {
+	// The context for the generator. All work should cease when this context is cancelled.
+	ctx *context.Context
+
+	// Tracks the number of active goroutines within the generator.
+	waitGroup *sync.WaitGroup
+
+	// All logs should be written using this logger.
+	logger logging.Logger
+
+	// config contains the configuration for the generator.
+	config *config.WorkerConfig
+
+	relayClient     clientsv2.RelayClient
+	retrievalClient clientsv2.RetrievalClient
+
+	// blobsToRead blobs we are required to read a certain number of times.
+	blobsToRead *table.BlobStore
+
+	// metrics for the blob reader.
+	metrics *blobReaderV2Metrics
+}
+
+type blobReaderV2Metrics struct {
+	relayReadLatencyMetric     metrics.LatencyMetric
+	relayReadSuccessMetric     metrics.CountMetric
+	relayReadFailureMetric     metrics.CountMetric
+	relayValidBlobMetric       metrics.CountMetric
+	relayInvalidBlobMetric     metrics.CountMetric
+	validatorReadLatencyMetric metrics.LatencyMetric
+	validatorReadSuccessMetric metrics.CountMetric
+	validatorReadFailureMetric metrics.CountMetric
+	validatorValidBlobMetric   metrics.CountMetric
+	validatorInvalidBlobMetric metrics.CountMetric
+	requiredReadPoolSizeMetric metrics.GaugeMetric
+}
+
+// NewBlobReaderV2 creates a new BlobReaderV2 instance.
+func NewBlobReaderV2(
+	ctx *context.Context,
+	waitGroup *sync.WaitGroup,
+	logger logging.Logger,
+	config *config.WorkerConfig,
+	relayClient clientsv2.RelayClient,
+	retrievalClient clientsv2.RetrievalClient,
+	blobStore *table.BlobStore,
+	generatorMetrics metrics.Metrics) BlobReaderV2 {
+
+	return BlobReaderV2{
+		ctx:             ctx,
+		waitGroup:       waitGroup,
+		logger:          logger,
+		config:          config,
+		relayClient:     relayClient,
+		retrievalClient: retrievalClient,
+		blobsToRead:     blobStore,
+		metrics: &blobReaderV2Metrics{
+			relayReadLatencyMetric:     generatorMetrics.NewLatencyMetric("v2_relay_read"),
+			relayReadSuccessMetric:     generatorMetrics.NewCountMetric("v2_relay_read_success"),
+			relayReadFailureMetric:     generatorMetrics.NewCountMetric("v2_relay_read_failure"),
+			relayValidBlobMetric:       generatorMetrics.NewCountMetric("v2_relay_valid_blob"),
+			relayInvalidBlobMetric:     generatorMetrics.NewCountMetric("v2_relay_invalid_blob"),
+			validatorReadLatencyMetric: generatorMetrics.NewLatencyMetric("v2_validator_read"),
+			validatorReadSuccessMetric: generatorMetrics.NewCountMetric("v2_validator_read_success"),
+			validatorReadFailureMetric: generatorMetrics.NewCountMetric("v2_validator_read_failure"),
+			validatorValidBlobMetric:   generatorMetrics.NewCountMetric("v2_validator_valid_blob"),
+			validatorInvalidBlobMetric: generatorMetrics.NewCountMetric("v2_validator_invalid_blob"),
+			requiredReadPoolSizeMetric: generatorMetrics.NewGaugeMetric("v2_required_read_pool_size"),
+		},
+	}
+}
+
+// Start begins a blob reader goroutine.
+func (r *BlobReaderV2) Start() {
+	r.waitGroup.Add(1)
+	ticker := time.NewTicker(r.config.ReadRequestInterval)
+	go func() {
+		defer r.waitGroup.Done()
+		for {
+			select {
+			case <-(*r.ctx).Done():
+				err := (*r.ctx).Err()
+				if err != nil {
+					r.logger.Info("blob reader context closed", "err:", err)
+				}
+				return
+			case <-ticker.C:
+				r.randomRead()
+			}
+		}
+	}()
+}
+
+// randomRead reads a random blob from a relay and from the validators. Returns false if there was no blob to read,
+// and otherwise whether both reads returned the dispersed payload.
+func (r *BlobReaderV2) randomRead() (read bool, valid bool) {
+	metadata := r.blobsToRead.GetNext()
+	if metadata == nil {
+		// There are no blobs that we are required to read.
+		return false, false
+	}
+	r.metrics.requiredReadPoolSizeMetric.Set(float64(r.blobsToRead.Size()))
+
+	relayValid := r.readFromRelay(metadata)
+	validatorsValid := r.readFromValidators(metadata)
+	return true, relayValid && validatorsValid
+}
+
+// readFromRelay reads a blob from a random relay that serves it, and returns whether the payload was valid.
+func (r *BlobReaderV2) readFromRelay(metadata *table.BlobMetadata) bool {
+	if len(metadata.RelayKeys) == 0 {
+		r.logger.Error("blob has no relays", "blobKey", corev2.BlobKey(metadata.Key).Hex())
+		r.metrics.relayReadFailureMetric.Increment()
+		return false
+	}
+	relayKey := metadata.RelayKeys[rand.Intn(len(metadata.RelayKeys))]
+
+	ctxTimeout, cancel := context.WithTimeout(*r.ctx, r.config.RetrieveBlobChunksTimeout)
+	defer cancel()
+
+	start := time.Now()
+	data, err := r.relayClient.GetBlob(ctxTimeout, relayKey, corev2.BlobKey(metadata.Key))
+	if err != nil {
+		r.logger.Error("failed to read blob from relay", "relayKey", relayKey, "err:", err)
+		r.metrics.relayReadFailureMetric.Increment()
+		return false
+	}
+	r.metrics.relayReadLatencyMetric.ReportLatency(time.Since(start))
+	r.metrics.relayReadSuccessMetric.Increment()
+
+	if !verifyPayload(metadata, data) {
+		r.logger.Error("relay returned an invalid blob", "relayKey", relayKey, "blobKey", corev2.BlobKey(metadata.Key).Hex())
+		r.metrics.relayInvalidBlobMetric.Increment()
+		return false
+	}
+	r.metrics.relayValidBlobMetric.Increment()
+	return true
+}
+
+// readFromValidators reconstructs a blob from the chunks held by the validators of its first quorum, and returns
+// whether the payload was valid.
+func (r *BlobReaderV2) readFromValidators(metadata *table.BlobMetadata) bool {
+	ctxTimeout, cancel := context.WithTimeout(*r.ctx, r.config.RetrieveBlobChunksTimeout)
+	defer cancel()
+
+	start := time.Now()
+	data, err := r.retrievalClient.GetBlob(
+		ctxTimeout,
+		metadata.BlobHeader,
+		metadata.ReferenceBlockNumber,
+		metadata.BlobHeader.QuorumNumbers[0])
+	if err != nil {
+		r.logger.Error("failed to read blob from validators", "err:", err)
+		r.metrics.validatorReadFailureMetric.Increment()
+		return false
+	}
+	r.metrics.validatorReadLatencyMetric.ReportLatency(time.Since(start))
+	r.metrics.validatorReadSuccessMetric.Increment()
+
+	if !verifyPayload(metadata, data) {
+		r.logger.Error("validators returned an invalid blob", "blobKey", corev2.BlobKey(metadata.Key).Hex())
+		r.metrics.validatorInvalidBlobMetric.Increment()
+		return false
+	}
+	r.metrics.validatorValidBlobMetric.Increment()
+	return true
+}
+
+// verifyPayload checks that the blob read back matches the dispersed blob. The blob reconstructed from the chunks is
+// padded with zeros past the dispersed size, so only the dispersed prefix is compared.
+func verifyPayload(metadata *table.BlobMetadata, data []byte) bool {
+	if uint(len(data)) < metadata.Size {
+		return false
+	}
+	return md5.Sum(data[:metadata.Size]) == metadata.Checksum
+}
@@ -0,0 +1,127 @@
+package workers
+
+import (
+	"context"
+	"crypto/md5"
+	"sync"
+	"testing"
+
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
+	"github.com/Layr-Labs/eigenda/common"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/tools/traffic/config"
+	"github.com/Layr-Labs/eigenda/tools/traffic/metrics"
+	"github.com/Layr-Labs/eigenda/tools/traffic/table"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/rand"
+)
+
+func TestBlobReaderV2(t *testing.T) {
+	tu.InitializeRandom()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waitGroup := sync.WaitGroup{}
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.Nil(t, err)
+
+	blobTable := table.NewBlobStore()
+	readerMetrics := metrics.NewMockMetrics()
+	relayClient := clientsmock.NewRelayClient()
+	retrievalClient := clientsmock.NewRetrievalClient()
+
+	blobReader := NewBlobReaderV2(
+		&ctx,
+		&waitGroup,
+		logger,
+		&config.WorkerConfig{},
+		relayClient,
+		retrievalClient,
+		blobTable,
+		readerMetrics)
+
+	blobSize := 1024
+	readPermits := 2
+	blobCount := 100
+
+	invalidBlobCount := 0
+
+	// Insert some blobs into the table.
+	for i := 0; i < blobCount; i++ {
+		var key corev2.BlobKey
+		_, err = rand.Read(key[:])
+		assert.Nil(t, err)
+
+		blobData := make([]byte, blobSize)
+		_, err = rand.Read(blobData)
+		assert.Nil(t, err)
+
+		var checksum [16]byte
+		if i%10 == 0 {
+			// Simulate an invalid blob
+			invalidBlobCount++
+			_, err = rand.Read(checksum[:])
+			assert.Nil(t, err)
+		} else {
+			checksum = md5.Sum(blobData)
+		}
+
+		certificate := &corev2.BlobCertificate{
+			BlobHeader: &corev2.BlobHeader{QuorumNumbers: []uint8{0, 1}},
+			RelayKeys:  []corev2.RelayKey{0, 1},
+		}
+		blobMetadata, err := table.NewBlobMetadataV2(key, checksum, uint(blobSize), certificate, 100, readPermits)
+		assert.Nil(t, err)
+
+		relayClient.On("GetBlob", key).Return(blobData, nil)
+		blobTable.Add(blobMetadata)
+	}
+
+	// The validators pad the reconstructed blob with zeros past the dispersed size.
+	retrievalClient.On("GetBlob").Return(make([]byte, blobSize*2), nil)
+
+	// Do a bunch of reads.
+	expectedTotalReads := uint(readPermits * blobCount)
+	for i := uint(0); i < expectedTotalReads; i++ {
+		blobReader.randomRead()
+
+		relayClient.AssertNumberOfCalls(t, "GetBlob", int(i+1))
+		retrievalClient.AssertNumberOfCalls(t, "GetBlob", int(i+1))
+
+		remainingPermits := uint(0)
+		for _, metadata := range blobTable.GetAll() {
+			remainingPermits += uint(metadata.RemainingReadPermits)
+		}
+		assert.Equal(t, remainingPermits, expectedTotalReads-i-1)
+
+		assert.Equal(t, i+1, uint(readerMetrics.GetCount("v2_relay_read_success")))
+		assert.Equal(t, i+1, uint(readerMetrics.GetCount("v2_validator_read_success")))
+	}
+
+	expectedInvalidBlobs := uint(invalidBlobCount * readPermits)
+	expectedValidBlobs := expectedTotalReads - expectedInvalidBlobs
+
+	assert.Equal(t, expectedValidBlobs, uint(readerMetrics.GetCount("v2_relay_valid_blob")))
+	assert.Equal(t, expectedInvalidBlobs, uint(readerMetrics.GetCount("v2_relay_invalid_blob")))
+
+	// The validators return zeros, which never match the dispersed data.
+	assert.Equal(t, uint(0), uint(readerMetrics.GetCount("v2_validator_valid_blob")))
+	assert.Equal(t, expectedTotalReads, uint(readerMetrics.GetCount("v2_validator_invalid_blob")))
+	assert.Equal(t, uint(0), uint(readerMetrics.GetGaugeValue("v2_required_read_pool_size")))
+
+	// Table is empty, so doing a random read should have no effect.
+	blobReader.randomRead()
+	relayClient.AssertNumberOfCalls(t, "GetBlob", int(expectedTotalReads))
+
+	cancel()
+}
+
+func TestVerifyPayload(t *testing.T) {
+	data := []byte{1, 2, 3, 4}
+	metadata := &table.BlobMetadata{Checksum: md5.Sum(data), Size: uint(len(data))}
+
+	assert.True(t, verifyPayload(metadata, data))
+	assert.True(t, verifyPayload(metadata, append(data, 0, 0, 0, 0)))
+	assert.False(t, verifyPayload(metadata, data[:3]))
+	assert.False(t, verifyPayload(metadata, []byte{1, 2, 3, 5}))
+}
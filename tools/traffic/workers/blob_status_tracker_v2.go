@@ -0,0 +1,234 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	clientsv2 "github.com/Layr-Labs/eigenda/api/clients/v2"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/tools/traffic/config"
+	"github.com/Layr-Labs/eigenda/tools/traffic/metrics"
+	"github.com/Layr-Labs/eigenda/tools/traffic/table"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// BlobStatusTrackerV2 periodically polls the v2 disperser service to track the status of blobs that were recently
+// written. When blobs become certified, the status tracker adds them to the blobs to read.
+type BlobStatusTrackerV2 struct {
+
+	// The context for the generator. All work should cease when this context is cancelled.
+	ctx *context.Context
+
+	// Tracks the number of active goroutines within the generator.
+	waitGroup *sync.WaitGroup
+
+	// All logs should be written using this logger.
+	logger logging.Logger
+
+	// config contains the configuration for the generator.
+	config *config.WorkerConfig
+
+	// Contains certified blobs. Blobs are added here when they are certified by the disperser service.
+	certifiedBlobs *table.BlobStore
+
+	// The disperser client used to monitor the disperser service.
+	disperser clientsv2.DisperserClient
+
+	// The keys of blobs that have not yet been certified by the disperser service.
+	unconfirmedBlobs []*UnconfirmedKeyV2
+
+	// Newly added keys that require verification.
+	keyChannel chan *UnconfirmedKeyV2
+
+	generatorMetrics metrics.Metrics
+
+	blobsInFlightMetric       metrics.GaugeMetric
+	getStatusLatencyMetric    metrics.LatencyMetric
+	getStatusErrorCountMetric metrics.CountMetric
+	statusCountMetrics        map[disperser_rpc.BlobStatus]metrics.CountMetric
+
+	// The latency from submission to each stage, by payment type.
+	encodingLatencyMetrics      map[string]metrics.LatencyMetric
+	certificationLatencyMetrics map[string]metrics.LatencyMetric
+}
+
+// NewBlobStatusTrackerV2 creates a new BlobStatusTrackerV2 instance.
+func NewBlobStatusTrackerV2(
+	ctx *context.Context,
+	waitGroup *sync.WaitGroup,
+	logger logging.Logger,
+	config *config.WorkerConfig,
+	keyChannel chan *UnconfirmedKeyV2,
+	table *table.BlobStore,
+	disperser clientsv2.DisperserClient,
+	generatorMetrics metrics.Metrics) BlobStatusTrackerV2 {
+
+	statusCountMetrics := make(map[disperser_rpc.BlobStatus]metrics.CountMetric)
+	for value, name := range disperser_rpc.BlobStatus_name {
+		statusCountMetrics[disperser_rpc.BlobStatus(value)] = generatorMetrics.NewCountMetric("v2_get_status_" + name)
+	}
+
+	return BlobStatusTrackerV2{
+		ctx:                         ctx,
+		waitGroup:                   waitGroup,
+		logger:                      logger,
+		config:                      config,
+		keyChannel:                  keyChannel,
+		certifiedBlobs:              table,
+		disperser:                   disperser,
+		unconfirmedBlobs:            make([]*UnconfirmedKeyV2, 0),
+		generatorMetrics:            generatorMetrics,
+		blobsInFlightMetric:         generatorMetrics.NewGaugeMetric("v2_blobs_in_flight"),
+		getStatusLatencyMetric:      generatorMetrics.NewLatencyMetric("v2_get_status"),
+		getStatusErrorCountMetric:   generatorMetrics.NewCountMetric("v2_get_status_ERROR"),
+		statusCountMetrics:          statusCountMetrics,
+		encodingLatencyMetrics:      make(map[string]metrics.LatencyMetric),
+		certificationLatencyMetrics: make(map[string]metrics.LatencyMetric),
+	}
+}
+
+// Start begins the status goroutine, which periodically polls
+// the disperser service to track the status of blobs.
+func (tracker *BlobStatusTrackerV2) Start() {
+	tracker.waitGroup.Add(1)
+	go tracker.monitor()
+}
+
+// monitor periodically polls the disperser service to track the status of blobs.
+func (tracker *BlobStatusTrackerV2) monitor() {
+	ticker := time.NewTicker(tracker.config.TrackerInterval)
+	for {
+		select {
+		case <-(*tracker.ctx).Done():
+			tracker.waitGroup.Done()
+			return
+		case key := <-tracker.keyChannel:
+			tracker.unconfirmedBlobs = append(tracker.unconfirmedBlobs, key)
+		case <-ticker.C:
+			tracker.poll()
+		}
+	}
+}
+
+// poll checks the status of all uncertified keys. If a key is certified, it is added to the certified blobs.
+// Keys with a terminal status are removed from the list of uncertified keys.
+func (tracker *BlobStatusTrackerV2) poll() {
+	nonFinalBlobs := make([]*UnconfirmedKeyV2, 0)
+	for _, key := range tracker.unconfirmedBlobs {
+
+		blobStatus, err := tracker.getBlobStatus(key)
+		if err != nil {
+			tracker.logger.Error("failed to get blob status: ", "err:", err)
+			// There was an error getting status. Try again later.
+			nonFinalBlobs = append(nonFinalBlobs, key)
+			continue
+		}
+
+		status := blobStatus.GetStatus()
+		tracker.updateStatusMetrics(status)
+
+		if !key.Encoded && (status == disperser_rpc.BlobStatus_ENCODED || status == disperser_rpc.BlobStatus_CERTIFIED) {
+			key.Encoded = true
+			tracker.latencyMetric(tracker.encodingLatencyMetrics, "encoding", key.PaymentType).
+				ReportLatency(time.Since(key.SubmissionTime))
+		}
+
+		switch status {
+		case disperser_rpc.BlobStatus_CERTIFIED:
+			tracker.latencyMetric(tracker.certificationLatencyMetrics, "certification", key.PaymentType).
+				ReportLatency(time.Since(key.SubmissionTime))
+			tracker.forwardToReader(key, blobStatus)
+		case disperser_rpc.BlobStatus_FAILED, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+			tracker.logger.Warn("blob was not certified", "blobKey", key.BlobKey.Hex(), "status", status.String())
+		default:
+			// try again later
+			nonFinalBlobs = append(nonFinalBlobs, key)
+		}
+	}
+	tracker.unconfirmedBlobs = nonFinalBlobs
+	tracker.blobsInFlightMetric.Set(float64(len(tracker.unconfirmedBlobs)))
+}
+
+// latencyMetric returns the latency metric of a stage for a payment type, creating it if needed.
+func (tracker *BlobStatusTrackerV2) latencyMetric(
+	stageMetrics map[string]metrics.LatencyMetric,
+	stage string,
+	paymentType string) metrics.LatencyMetric {
+
+	metric, ok := stageMetrics[paymentType]
+	if !ok {
+		metric = tracker.generatorMetrics.NewLatencyMetric(fmt.Sprintf("v2_%s_%s", stage, paymentType))
+		stageMetrics[paymentType] = metric
+	}
+	return metric
+}
+
+// updateStatusMetrics updates the metrics for the reported status of a blob.
+func (tracker *BlobStatusTrackerV2) updateStatusMetrics(status disperser_rpc.BlobStatus) {
+	metric, ok := tracker.statusCountMetrics[status]
+	if !ok {
+		tracker.logger.Error("unknown blob status", "status:", status)
+		return
+	}
+	metric.Increment()
+}
+
+// getBlobStatus gets the status of a blob from the disperser service.
+func (tracker *BlobStatusTrackerV2) getBlobStatus(key *UnconfirmedKeyV2) (*disperser_rpc.BlobStatusReply, error) {
+	ctxTimeout, cancel := context.WithTimeout(*tracker.ctx, tracker.config.GetBlobStatusTimeout)
+	defer cancel()
+
+	start := time.Now()
+	status, err := tracker.disperser.GetBlobStatus(ctxTimeout, key.BlobKey)
+	if err != nil {
+		tracker.getStatusErrorCountMetric.Increment()
+		return nil, err
+	}
+	tracker.getStatusLatencyMetric.ReportLatency(time.Since(start))
+
+	return status, nil
+}
+
+// forwardToReader forwards a blob to the readers. Only called once the blob is certified.
+func (tracker *BlobStatusTrackerV2) forwardToReader(key *UnconfirmedKeyV2, status *disperser_rpc.BlobStatusReply) {
+	requiredDownloads := tracker.config.RequiredDownloads
+	var downloadCount int32
+	if requiredDownloads < 0 {
+		// Allow unlimited downloads.
+		downloadCount = -1
+	} else if requiredDownloads == 0 {
+		// Do not download blob.
+		return
+	} else if requiredDownloads < 1 {
+		// Download blob with probability equal to requiredDownloads.
+		if rand.Float64() < requiredDownloads {
+			// Download the blob once.
+			downloadCount = 1
+		} else {
+			// Do not download blob.
+			return
+		}
+	} else {
+		// Download blob requiredDownloads times.
+		downloadCount = int32(requiredDownloads)
+	}
+
+	certificate, err := corev2.BlobCertificateFromProtobuf(status.GetBlobVerificationInfo().GetBlobCertificate())
+	if err != nil {
+		tracker.logger.Error("failed to parse blob certificate", "blobKey", key.BlobKey.Hex(), "err:", err)
+		return
+	}
+	referenceBlockNumber := status.GetSignedBatch().GetHeader().GetReferenceBlockNumber()
+
+	blobMetadata, err := table.NewBlobMetadataV2(
+		key.BlobKey, key.Checksum, key.Size, certificate, referenceBlockNumber, int(downloadCount))
+	if err != nil {
+		tracker.logger.Error("failed to create blob metadata", "err:", err)
+		return
+	}
+	tracker.certifiedBlobs.Add(blobMetadata)
+}
@@ -0,0 +1,196 @@
+package workers
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	clientsv2 "github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/Layr-Labs/eigenda/tools/traffic/config"
+	"github.com/Layr-Labs/eigenda/tools/traffic/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// The payment types of the accounts that pay for v2 dispersals.
+const (
+	ReservationPayment = config.ReservationPaymentMode
+	OnDemandPayment    = config.OnDemandPaymentMode
+)
+
+// defaultQuorumsV2 are the quorums blobs are dispersed to if no custom quorums are configured.
+var defaultQuorumsV2 = []core.QuorumID{0, 1}
+
+// BlobWriterV2 sends blobs to a v2 disperser at a configured rate.
+type BlobWriterV2 struct {
+	// The context for the generator. All work should cease when this context is cancelled.
+	ctx *context.Context
+
+	// Tracks the number of active goroutines within the generator.
+	waitGroup *sync.WaitGroup
+
+	// All logs should be written using this logger.
+	logger logging.Logger
+
+	// Config contains the configuration for the generator.
+	config *config.WorkerConfig
+
+	// blobVersion is the blob version of the dispersed blobs.
+	blobVersion corev2.BlobVersion
+
+	// disperser is the client used to send blobs to the disperser. It pays with the account of the writer.
+	disperser clientsv2.DisperserClient
+
+	// paymentType is the payment type of the account of the writer.
+	paymentType string
+
+	// Unconfirmed keys are sent here.
+	unconfirmedKeyChannel chan *UnconfirmedKeyV2
+
+	// fixedRandomData contains random data for blobs if RandomizeBlobs is false, and nil otherwise.
+	fixedRandomData []byte
+
+	// writeLatencyMetric is used to record latency for write requests.
+	writeLatencyMetric metrics.LatencyMetric
+
+	// writeSuccessMetric is used to record the number of successful write requests.
+	writeSuccessMetric metrics.CountMetric
+
+	// writeFailureMetric is used to record the number of failed write requests.
+	writeFailureMetric metrics.CountMetric
+}
+
+// NewBlobWriterV2 creates a new BlobWriterV2 instance. The paymentType labels the metrics of the writer.
+func NewBlobWriterV2(
+	ctx *context.Context,
+	waitGroup *sync.WaitGroup,
+	logger logging.Logger,
+	config *config.WorkerConfig,
+	blobVersion corev2.BlobVersion,
+	disperser clientsv2.DisperserClient,
+	paymentType string,
+	unconfirmedKeyChannel chan *UnconfirmedKeyV2,
+	generatorMetrics metrics.Metrics) BlobWriterV2 {
+
+	var fixedRandomData []byte
+	if !config.RandomizeBlobs {
+		// Use this random data for each blob.
+		fixedRandomData = make([]byte, config.DataSize)
+		_, err := rand.Read(fixedRandomData)
+		if err != nil {
+			panic(fmt.Sprintf("unable to read random data: %s", err))
+		}
+		fixedRandomData = codec.ConvertByPaddingEmptyByte(fixedRandomData)
+	}
+
+	return BlobWriterV2{
+		ctx:                   ctx,
+		waitGroup:             waitGroup,
+		logger:                logger,
+		config:                config,
+		blobVersion:           blobVersion,
+		disperser:             disperser,
+		paymentType:           paymentType,
+		unconfirmedKeyChannel: unconfirmedKeyChannel,
+		fixedRandomData:       fixedRandomData,
+		writeLatencyMetric:    generatorMetrics.NewLatencyMetric(fmt.Sprintf("v2_write_%s", paymentType)),
+		writeSuccessMetric:    generatorMetrics.NewCountMetric(fmt.Sprintf("v2_write_%s_success", paymentType)),
+		writeFailureMetric:    generatorMetrics.NewCountMetric(fmt.Sprintf("v2_write_%s_failure", paymentType)),
+	}
+}
+
+// Start begins the blob writer goroutine.
+func (writer *BlobWriterV2) Start() {
+	writer.waitGroup.Add(1)
+	ticker := time.NewTicker(writer.config.WriteRequestInterval)
+
+	go func() {
+		defer writer.waitGroup.Done()
+
+		for {
+			select {
+			case <-(*writer.ctx).Done():
+				return
+			case <-ticker.C:
+				writer.writeNextBlob()
+			}
+		}
+	}()
+}
+
+// writeNextBlob attempts to send a random blob to the disperser.
+func (writer *BlobWriterV2) writeNextBlob() {
+	data, err := writer.getRandomData()
+	if err != nil {
+		writer.logger.Error("failed to get random data", "err", err)
+		return
+	}
+	_, _ = writer.writeBlob(data, writer.config.CustomQuorums)
+}
+
+// writeBlob sends a blob to the disperser and hands its key to the status tracker. If quorums is empty, the blob is
+// dispersed to the default quorums. Returns the latency of the request.
+func (writer *BlobWriterV2) writeBlob(data []byte, quorums []core.QuorumID) (time.Duration, error) {
+	start := time.Now()
+	key, err := writer.sendRequest(data, quorums)
+	if err != nil {
+		writer.writeFailureMetric.Increment()
+		writer.logger.Error("failed to send blob request", "paymentType", writer.paymentType, "err", err)
+		return 0, err
+	}
+	latency := time.Since(start)
+	writer.writeLatencyMetric.ReportLatency(latency)
+	writer.writeSuccessMetric.Increment()
+
+	writer.unconfirmedKeyChannel <- &UnconfirmedKeyV2{
+		BlobKey:        key,
+		Checksum:       md5.Sum(data),
+		Size:           uint(len(data)),
+		PaymentType:    writer.paymentType,
+		SubmissionTime: start,
+	}
+	return latency, nil
+}
+
+// getRandomData returns a slice of random data to be used for a blob.
+func (writer *BlobWriterV2) getRandomData() ([]byte, error) {
+	if writer.fixedRandomData != nil {
+		return writer.fixedRandomData, nil
+	}
+	return randomBlobData(writer.config.DataSize)
+}
+
+// randomBlobData returns size bytes of random data, padded so that the blob is a valid sequence of field elements.
+func randomBlobData(size uint64) ([]byte, error) {
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read random data: %w", err)
+	}
+	return codec.ConvertByPaddingEmptyByte(data), nil
+}
+
+// sendRequest sends a blob to the disperser. Each request uses a random salt, so that dispersing the same data
+// twice does not produce the same blob key.
+func (writer *BlobWriterV2) sendRequest(data []byte, quorums []core.QuorumID) (corev2.BlobKey, error) {
+	ctxTimeout, cancel := context.WithTimeout(*writer.ctx, writer.config.WriteTimeout)
+	defer cancel()
+
+	if len(quorums) == 0 {
+		quorums = defaultQuorumsV2
+	}
+
+	var salt [4]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return corev2.BlobKey{}, fmt.Errorf("unable to read random salt: %w", err)
+	}
+
+	_, key, err := writer.disperser.DisperseBlob(ctxTimeout, data, writer.blobVersion, quorums, binary.BigEndian.Uint32(salt[:]))
+	return key, err
+}
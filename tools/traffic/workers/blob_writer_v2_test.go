@@ -0,0 +1,127 @@
+package workers
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/Layr-Labs/eigenda/tools/traffic/config"
+	"github.com/Layr-Labs/eigenda/tools/traffic/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/exp/rand"
+)
+
+func TestBlobWriterV2(t *testing.T) {
+	tu.InitializeRandom()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waitGroup := sync.WaitGroup{}
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.Nil(t, err)
+
+	dataSize := rand.Uint64()%1024 + 64
+	encodedDataSize := len(codec.ConvertByPaddingEmptyByte(make([]byte, dataSize)))
+
+	randomizeBlobs := rand.Intn(2) == 0
+
+	useCustomQuorum := rand.Intn(2) == 0
+	expectedQuorums := defaultQuorumsV2
+	var customQuorum []uint8
+	if useCustomQuorum {
+		customQuorum = []uint8{1, 2, 3}
+		expectedQuorums = customQuorum
+	}
+
+	config := &config.WorkerConfig{
+		DataSize:       dataSize,
+		RandomizeBlobs: randomizeBlobs,
+		CustomQuorums:  customQuorum,
+	}
+
+	disperserClient := &MockDisperserClientV2{}
+	unconfirmedKeyChannel := make(chan *UnconfirmedKeyV2, 100)
+
+	generatorMetrics := metrics.NewMockMetrics()
+
+	writer := NewBlobWriterV2(
+		&ctx,
+		&waitGroup,
+		logger,
+		config,
+		corev2.BlobVersion(0),
+		disperserClient,
+		ReservationPayment,
+		unconfirmedKeyChannel,
+		generatorMetrics)
+
+	errorCount := 0
+
+	var previousData []byte
+
+	for i := 0; i < 100; i++ {
+		var errorToReturn error
+		if i%10 == 0 {
+			errorToReturn = fmt.Errorf("intentional error for testing purposes")
+			errorCount++
+		}
+
+		// This is the key that will be assigned to the next blob.
+		var keyToReturn corev2.BlobKey
+		_, err = rand.Read(keyToReturn[:])
+		assert.Nil(t, err)
+
+		status := dispv2.Queued
+		disperserClient.mock = mock.Mock{} // reset mock state
+		disperserClient.mock.On("DisperseBlob", mock.Anything, corev2.BlobVersion(0), []core.QuorumID(expectedQuorums)).
+			Return(&status, keyToReturn, errorToReturn)
+
+		// Simulate the advancement of time (i.e. allow the writer to write the next blob).
+		writer.writeNextBlob()
+
+		disperserClient.mock.AssertNumberOfCalls(t, "DisperseBlob", 1)
+
+		if errorToReturn == nil {
+			dataSentToDisperser := disperserClient.mock.Calls[0].Arguments.Get(0).([]byte)
+			assert.NotNil(t, dataSentToDisperser)
+
+			// Strip away the extra encoding bytes. We should have data of the expected size.
+			decodedData := codec.RemoveEmptyByteFromPaddedBytes(dataSentToDisperser)
+			assert.Equal(t, dataSize, uint64(len(decodedData)))
+
+			// Verify that the proper data was sent to the status tracker.
+			unconfirmedKey, ok := <-unconfirmedKeyChannel
+			assert.True(t, ok)
+			assert.Equal(t, keyToReturn, unconfirmedKey.BlobKey)
+			assert.Equal(t, uint(encodedDataSize), unconfirmedKey.Size)
+			assert.Equal(t, md5.Sum(dataSentToDisperser), unconfirmedKey.Checksum)
+			assert.Equal(t, ReservationPayment, unconfirmedKey.PaymentType)
+
+			// Verify that data has the proper amount of randomness.
+			if previousData != nil {
+				if randomizeBlobs {
+					// We expect each blob to be different.
+					assert.NotEqual(t, previousData, dataSentToDisperser)
+				} else {
+					// We expect each blob to be the same.
+					assert.Equal(t, previousData, dataSentToDisperser)
+				}
+			}
+			previousData = dataSentToDisperser
+		}
+
+		// Verify metrics.
+		assert.Equal(t, float64(i+1-errorCount), generatorMetrics.GetCount("v2_write_reservation_success"))
+		assert.Equal(t, float64(errorCount), generatorMetrics.GetCount("v2_write_reservation_failure"))
+	}
+
+	cancel()
+}
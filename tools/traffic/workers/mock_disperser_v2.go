@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"context"
+
+	clientsv2 "github.com/Layr-Labs/eigenda/api/clients/v2"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ clientsv2.DisperserClient = (*MockDisperserClientV2)(nil)
+
+type MockDisperserClientV2 struct {
+	mock mock.Mock
+}
+
+func (m *MockDisperserClientV2) DisperseBlob(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	quorums []core.QuorumID,
+	salt uint32) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+
+	args := m.mock.Called(data, blobVersion, quorums)
+	return args.Get(0).(*dispv2.BlobStatus), args.Get(1).(corev2.BlobKey), args.Error(2)
+}
+
+func (m *MockDisperserClientV2) GetBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*disperser_rpc.BlobStatusReply, error) {
+	args := m.mock.Called(blobKey)
+	return args.Get(0).(*disperser_rpc.BlobStatusReply), args.Error(1)
+}
+
+func (m *MockDisperserClientV2) GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error) {
+	args := m.mock.Called(data)
+	return args.Get(0).(*disperser_rpc.BlobCommitmentReply), args.Error(1)
+}
+
+func (m *MockDisperserClientV2) GetBlobSymbolProofs(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	symbolIndices []uint64,
+	batched bool) (*disperser_rpc.BlobSymbolProofsReply, error) {
+
+	args := m.mock.Called(blobKey, symbolIndices, batched)
+	return args.Get(0).(*disperser_rpc.BlobSymbolProofsReply), args.Error(1)
+}
+
+func (m *MockDisperserClientV2) Close() error {
+	args := m.mock.Called()
+	return args.Error(0)
+}
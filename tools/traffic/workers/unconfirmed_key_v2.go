@@ -0,0 +1,23 @@
+package workers
+
+import (
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// UnconfirmedKeyV2 is the key of a blob dispersed through the v2 disperser that has not yet been certified.
+type UnconfirmedKeyV2 struct {
+	// The BlobKey of the blob.
+	BlobKey corev2.BlobKey
+	// The Size of the blob in bytes.
+	Size uint
+	// The Checksum of the blob.
+	Checksum [16]byte
+	// The PaymentType of the account that paid for the blob, used to label metrics.
+	PaymentType string
+	// The time the blob was submitted to the disperser service.
+	SubmissionTime time.Time
+	// Whether the blob has been observed to be encoded.
+	Encoded bool
+}
@@ -0,0 +1,419 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/tools/traffic/config"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// WorkloadRunner executes the phases of a workload in sequence. Within a phase, blobs are written following the
+// arrival process of the phase, with sizes, quorums and payment modes drawn from the distributions of the phase.
+// Each write is followed by reads of certified blobs, according to the read/write ratio of the phase.
+// When the workload is done, a summary of each phase is logged, and written to the report file if one is configured.
+type WorkloadRunner struct {
+	// The context for the generator. All work should cease when this context is cancelled.
+	ctx *context.Context
+
+	// Tracks the number of active goroutines within the generator.
+	waitGroup *sync.WaitGroup
+
+	// All logs should be written using this logger.
+	logger logging.Logger
+
+	// The workload to execute.
+	workload *config.Workload
+
+	// The quorums blobs are dispersed to by phases that do not configure quorums.
+	defaultQuorums []core.QuorumID
+
+	// The writers, by payment mode.
+	writers map[string]*BlobWriterV2
+
+	// The payment modes of the writers, sorted so that the payment modes are drawn deterministically.
+	paymentModes []string
+
+	// The reader used for the reads of every phase.
+	reader *BlobReaderV2
+
+	// The path the summary report is written to. If empty, the report is only logged.
+	reportPath string
+
+	// Draws the sizes, quorums, payment modes and arrivals. Only used by the goroutine that runs the phases.
+	rand *rand.Rand
+
+	// The summaries of the completed phases.
+	summaries []*PhaseSummary
+
+	// Closed once the workload is done.
+	done chan struct{}
+}
+
+// PhaseSummary summarizes the traffic of one phase of a workload.
+type PhaseSummary struct {
+	Name    string `json:"name"`
+	Elapsed string `json:"elapsed"`
+
+	Writes        uint64            `json:"writes"`
+	WriteFailures uint64            `json:"writeFailures"`
+	BytesWritten  uint64            `json:"bytesWritten"`
+	WritesByMode  map[string]uint64 `json:"writesByPaymentMode"`
+
+	WriteLatencyP50 string `json:"writeLatencyP50"`
+	WriteLatencyP99 string `json:"writeLatencyP99"`
+	WriteLatencyMax string `json:"writeLatencyMax"`
+
+	// The writes that were dropped because the phase had the maximum number of writes in flight.
+	DroppedWrites uint64 `json:"droppedWrites"`
+
+	Reads        uint64 `json:"reads"`
+	ReadFailures uint64 `json:"readFailures"`
+	// The reads that were skipped because no certified blob was available.
+	SkippedReads uint64 `json:"skippedReads"`
+
+	lock           sync.Mutex
+	writeLatencies []time.Duration
+}
+
+// NewWorkloadRunner creates a new WorkloadRunner instance. Every payment mode used by the workload must have a writer.
+func NewWorkloadRunner(
+	ctx *context.Context,
+	waitGroup *sync.WaitGroup,
+	logger logging.Logger,
+	workerConfig *config.WorkerConfig,
+	workload *config.Workload,
+	writers map[string]*BlobWriterV2,
+	reader *BlobReaderV2,
+	reportPath string) (*WorkloadRunner, error) {
+
+	if len(writers) == 0 {
+		return nil, fmt.Errorf("no writers")
+	}
+	for _, phase := range workload.Phases {
+		for _, mix := range phase.Payments {
+			if _, ok := writers[mix.Payment]; !ok {
+				return nil, fmt.Errorf("phase %s uses payment mode %s, which has no configured account",
+					phase.Name, mix.Payment)
+			}
+		}
+	}
+
+	paymentModes := make([]string, 0, len(writers))
+	for mode := range writers {
+		paymentModes = append(paymentModes, mode)
+	}
+	sort.Strings(paymentModes)
+
+	return &WorkloadRunner{
+		ctx:            ctx,
+		waitGroup:      waitGroup,
+		logger:         logger,
+		workload:       workload,
+		defaultQuorums: workerConfig.CustomQuorums,
+		writers:        writers,
+		paymentModes:   paymentModes,
+		reader:         reader,
+		reportPath:     reportPath,
+		rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		summaries:      make([]*PhaseSummary, 0, len(workload.Phases)),
+		done:           make(chan struct{}),
+	}, nil
+}
+
+// Start begins executing the workload.
+func (r *WorkloadRunner) Start() {
+	r.waitGroup.Add(1)
+	go func() {
+		defer r.waitGroup.Done()
+		defer close(r.done)
+
+		for _, phase := range r.workload.Phases {
+			if (*r.ctx).Err() != nil {
+				break
+			}
+			r.logger.Info("starting workload phase", "phase", phase.Name, "duration", phase.Duration)
+			r.summaries = append(r.summaries, r.runPhase(phase))
+		}
+		r.report()
+	}()
+}
+
+// Done returns a channel that is closed once every phase has run, or the workload was interrupted.
+func (r *WorkloadRunner) Done() <-chan struct{} {
+	return r.done
+}
+
+// Summaries returns the summaries of the completed phases. Only safe to call once the workload is done.
+func (r *WorkloadRunner) Summaries() []*PhaseSummary {
+	return r.summaries
+}
+
+// runPhase writes and reads blobs until the phase is over, then waits for the requests in flight. At most
+// MaxInFlight writes of the phase are in flight at once, arrivals beyond that are dropped.
+func (r *WorkloadRunner) runPhase(phase *config.WorkloadPhase) *PhaseSummary {
+	summary := &PhaseSummary{
+		Name:         phase.Name,
+		WritesByMode: make(map[string]uint64),
+	}
+	clock := newArrivalClock(&phase.Arrival, r.rand)
+
+	maxInFlight := phase.MaxInFlight
+	if maxInFlight == 0 {
+		maxInFlight = config.DefaultMaxInFlight
+	}
+	slots := make(chan struct{}, maxInFlight)
+
+	start := time.Now()
+	end := start.Add(phase.Duration)
+	next := start
+	inFlight := sync.WaitGroup{}
+	for {
+		next = next.Add(clock.next())
+		if !next.Before(end) || !r.sleepUntil(next) {
+			break
+		}
+
+		size := r.sampleSize(&phase.BlobSize)
+		quorums := r.sampleQuorums(phase.Quorums)
+		mode := r.samplePaymentMode(phase.Payments)
+		reads := r.sampleReads(phase.ReadWriteRatio)
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			summary.lock.Lock()
+			summary.DroppedWrites++
+			summary.lock.Unlock()
+			continue
+		}
+
+		inFlight.Add(1)
+		go func() {
+			defer inFlight.Done()
+			defer func() { <-slots }()
+			r.write(summary, size, quorums, mode)
+			for i := 0; i < reads; i++ {
+				r.read(summary)
+			}
+		}()
+	}
+	inFlight.Wait()
+
+	summary.finish(time.Since(start))
+	return summary
+}
+
+// sleepUntil sleeps until the given time. Returns false if the context is cancelled first.
+func (r *WorkloadRunner) sleepUntil(t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-(*r.ctx).Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// write disperses one blob of the given size, and records the outcome in the summary.
+func (r *WorkloadRunner) write(summary *PhaseSummary, size uint64, quorums []core.QuorumID, mode string) {
+	data, err := randomBlobData(size)
+	if err != nil {
+		r.logger.Error("failed to get random data", "err", err)
+		return
+	}
+	latency, err := r.writers[mode].writeBlob(data, quorums)
+
+	summary.lock.Lock()
+	defer summary.lock.Unlock()
+	if err != nil {
+		summary.WriteFailures++
+		return
+	}
+	summary.Writes++
+	summary.BytesWritten += size
+	summary.WritesByMode[mode]++
+	summary.writeLatencies = append(summary.writeLatencies, latency)
+}
+
+// read reads one certified blob, and records the outcome in the summary.
+func (r *WorkloadRunner) read(summary *PhaseSummary) {
+	read, valid := r.reader.randomRead()
+
+	summary.lock.Lock()
+	defer summary.lock.Unlock()
+	if !read {
+		summary.SkippedReads++
+		return
+	}
+	summary.Reads++
+	if !valid {
+		summary.ReadFailures++
+	}
+}
+
+// sampleSize draws a blob size from a size distribution.
+func (r *WorkloadRunner) sampleSize(distribution *config.SizeDistribution) uint64 {
+	switch distribution.Type {
+	case config.UniformSize:
+		return distribution.Min + uint64(r.rand.Int63n(int64(distribution.Max-distribution.Min+1)))
+	case config.WeightedSize:
+		weights := make([]float64, len(distribution.Choices))
+		for i, choice := range distribution.Choices {
+			weights[i] = choice.Weight
+		}
+		return distribution.Choices[r.sampleWeighted(weights)].Size
+	default:
+		return distribution.Size
+	}
+}
+
+// sampleQuorums draws the quorums of a blob from a quorum mix.
+func (r *WorkloadRunner) sampleQuorums(mixes []*config.QuorumMix) []core.QuorumID {
+	if len(mixes) == 0 {
+		return r.defaultQuorums
+	}
+	weights := make([]float64, len(mixes))
+	for i, mix := range mixes {
+		weights[i] = mix.Weight
+	}
+	return mixes[r.sampleWeighted(weights)].Quorums
+}
+
+// samplePaymentMode draws the payment mode of a blob from a payment mix. Without a mix, every configured payment
+// mode is equally likely.
+func (r *WorkloadRunner) samplePaymentMode(mixes []*config.PaymentMix) string {
+	if len(mixes) == 0 {
+		return r.paymentModes[r.rand.Intn(len(r.paymentModes))]
+	}
+	weights := make([]float64, len(mixes))
+	for i, mix := range mixes {
+		weights[i] = mix.Weight
+	}
+	return mixes[r.sampleWeighted(weights)].Payment
+}
+
+// sampleReads draws the number of reads that follow a write, so that the average number of reads per write is the
+// read/write ratio.
+func (r *WorkloadRunner) sampleReads(ratio float64) int {
+	reads, fraction := math.Modf(ratio)
+	if r.rand.Float64() < fraction {
+		reads++
+	}
+	return int(reads)
+}
+
+// sampleWeighted draws an index with a probability proportional to its weight.
+func (r *WorkloadRunner) sampleWeighted(weights []float64) int {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	target := r.rand.Float64() * total
+	for i, weight := range weights {
+		if target < weight {
+			return i
+		}
+		target -= weight
+	}
+	return len(weights) - 1
+}
+
+// report logs the summary of each phase, and writes the summaries to the report file if one is configured.
+func (r *WorkloadRunner) report() {
+	for _, summary := range r.summaries {
+		r.logger.Info("workload phase summary",
+			"phase", summary.Name,
+			"elapsed", summary.Elapsed,
+			"writes", summary.Writes,
+			"writeFailures", summary.WriteFailures,
+			"bytesWritten", summary.BytesWritten,
+			"writesByPaymentMode", summary.WritesByMode,
+			"writeLatencyP50", summary.WriteLatencyP50,
+			"writeLatencyP99", summary.WriteLatencyP99,
+			"writeLatencyMax", summary.WriteLatencyMax,
+			"droppedWrites", summary.DroppedWrites,
+			"reads", summary.Reads,
+			"readFailures", summary.ReadFailures,
+			"skippedReads", summary.SkippedReads)
+	}
+
+	if r.reportPath == "" {
+		return
+	}
+	data, err := json.MarshalIndent(r.summaries, "", "  ")
+	if err != nil {
+		r.logger.Error("failed to marshal workload report", "err", err)
+		return
+	}
+	if err = os.WriteFile(r.reportPath, data, 0644); err != nil {
+		r.logger.Error("failed to write workload report", "path", r.reportPath, "err", err)
+	}
+}
+
+// finish sets the elapsed time and the write latency percentiles of a completed phase.
+func (s *PhaseSummary) finish(elapsed time.Duration) {
+	s.Elapsed = elapsed.String()
+
+	sort.Slice(s.writeLatencies, func(i, j int) bool {
+		return s.writeLatencies[i] < s.writeLatencies[j]
+	})
+	s.WriteLatencyP50 = percentile(s.writeLatencies, 0.5).String()
+	s.WriteLatencyP99 = percentile(s.writeLatencies, 0.99).String()
+	s.WriteLatencyMax = percentile(s.writeLatencies, 1).String()
+}
+
+// percentile returns the given percentile of sorted latencies, or zero if there are none.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+// arrivalClock produces the gaps between the writes of an arrival process.
+type arrivalClock struct {
+	process *config.ArrivalProcess
+	rand    *rand.Rand
+
+	// The number of arrivals produced so far.
+	count uint
+}
+
+func newArrivalClock(process *config.ArrivalProcess, random *rand.Rand) *arrivalClock {
+	return &arrivalClock{
+		process: process,
+		rand:    random,
+	}
+}
+
+// next returns the time until the next write. The first write of a phase happens after one gap, except for bursty
+// arrivals, whose first burst starts the phase.
+func (c *arrivalClock) next() time.Duration {
+	defer func() { c.count++ }()
+
+	switch c.process.Type {
+	case config.PoissonArrival:
+		return time.Duration(c.rand.ExpFloat64() / c.process.Rate * float64(time.Second))
+	case config.BurstyArrival:
+		if c.count > 0 && c.count%c.process.BurstSize == 0 {
+			return c.process.BurstInterval
+		}
+		return 0
+	default:
+		return time.Duration(float64(time.Second) / c.process.Rate)
+	}
+}
@@ -0,0 +1,259 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
+	"github.com/Layr-Labs/eigenda/common"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/tools/traffic/config"
+	"github.com/Layr-Labs/eigenda/tools/traffic/metrics"
+	"github.com/Layr-Labs/eigenda/tools/traffic/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkloadRunner(t *testing.T) {
+	tu.InitializeRandom()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	waitGroup := sync.WaitGroup{}
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.Nil(t, err)
+
+	workerConfig := &config.WorkerConfig{WriteTimeout: time.Second}
+	generatorMetrics := metrics.NewMockMetrics()
+	unconfirmedKeyChannel := make(chan *UnconfirmedKeyV2, 1000)
+
+	status := dispv2.Queued
+	writers := make(map[string]*BlobWriterV2)
+	disperserClients := make(map[string]*MockDisperserClientV2)
+	for _, paymentType := range []string{ReservationPayment, OnDemandPayment} {
+		disperserClient := &MockDisperserClientV2{}
+		disperserClient.mock.On("DisperseBlob", mock.Anything, mock.Anything, mock.Anything).
+			Return(&status, corev2.BlobKey{}, nil)
+		writer := NewBlobWriterV2(
+			&ctx,
+			&waitGroup,
+			logger,
+			workerConfig,
+			corev2.BlobVersion(0),
+			disperserClient,
+			paymentType,
+			unconfirmedKeyChannel,
+			generatorMetrics)
+		writers[paymentType] = &writer
+		disperserClients[paymentType] = disperserClient
+	}
+
+	// No blob is ever certified, so every read is skipped.
+	reader := NewBlobReaderV2(
+		&ctx,
+		&waitGroup,
+		logger,
+		workerConfig,
+		clientsmock.NewRelayClient(),
+		clientsmock.NewRetrievalClient(),
+		table.NewBlobStore(),
+		generatorMetrics)
+
+	workload := &config.Workload{
+		Phases: []*config.WorkloadPhase{
+			{
+				Name:     "constant",
+				Duration: 200 * time.Millisecond,
+				BlobSize: config.SizeDistribution{Type: config.UniformSize, Min: 100, Max: 200},
+				Arrival:  config.ArrivalProcess{Type: config.ConstantArrival, Rate: 100},
+				Quorums:  []*config.QuorumMix{{Quorums: []uint8{0, 1, 2}, Weight: 1}},
+				Payments: []*config.PaymentMix{{Payment: config.OnDemandPaymentMode, Weight: 1}},
+
+				ReadWriteRatio: 2,
+			},
+			{
+				Name:     "bursty",
+				Duration: 200 * time.Millisecond,
+				BlobSize: config.SizeDistribution{Type: config.FixedSize, Size: 64},
+				Arrival: config.ArrivalProcess{
+					Type:          config.BurstyArrival,
+					BurstSize:     5,
+					BurstInterval: 150 * time.Millisecond,
+				},
+				Payments: []*config.PaymentMix{{Payment: config.ReservationPaymentMode, Weight: 1}},
+			},
+		},
+	}
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+
+	runner, err := NewWorkloadRunner(
+		&ctx, &waitGroup, logger, workerConfig, workload, writers, &reader, reportPath)
+	assert.Nil(t, err)
+
+	runner.Start()
+	select {
+	case <-runner.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("workload did not finish")
+	}
+
+	summaries := runner.Summaries()
+	assert.Len(t, summaries, 2)
+
+	constant := summaries[0]
+	assert.Equal(t, "constant", constant.Name)
+	assert.GreaterOrEqual(t, constant.Writes, uint64(15))
+	assert.LessOrEqual(t, constant.Writes, uint64(20))
+	assert.Equal(t, uint64(0), constant.WriteFailures)
+	assert.Equal(t, constant.Writes, constant.WritesByMode[OnDemandPayment])
+	assert.GreaterOrEqual(t, constant.BytesWritten, constant.Writes*100)
+	assert.LessOrEqual(t, constant.BytesWritten, constant.Writes*200)
+	assert.Equal(t, 2*constant.Writes, constant.SkippedReads)
+	assert.Equal(t, uint64(0), constant.Reads)
+
+	for _, call := range disperserClients[OnDemandPayment].mock.Calls {
+		assert.Equal(t, []uint8{0, 1, 2}, call.Arguments.Get(2))
+	}
+
+	// Two bursts of five blobs, at 0ms and 150ms.
+	bursty := summaries[1]
+	assert.Equal(t, uint64(10), bursty.Writes)
+	assert.Equal(t, uint64(10), bursty.WritesByMode[ReservationPayment])
+	assert.Equal(t, uint64(640), bursty.BytesWritten)
+	assert.Equal(t, uint64(0), bursty.SkippedReads)
+
+	assert.Equal(t, float64(constant.Writes), generatorMetrics.GetCount("v2_write_on_demand_success"))
+	assert.Equal(t, float64(10), generatorMetrics.GetCount("v2_write_reservation_success"))
+
+	data, err := os.ReadFile(reportPath)
+	assert.Nil(t, err)
+	var report []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &report))
+	assert.Len(t, report, 2)
+	assert.Equal(t, "bursty", report[1]["name"])
+	assert.Equal(t, float64(10), report[1]["writes"])
+}
+
+func TestWorkloadRunnerMaxInFlight(t *testing.T) {
+	tu.InitializeRandom()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	waitGroup := sync.WaitGroup{}
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.Nil(t, err)
+
+	workerConfig := &config.WorkerConfig{WriteTimeout: time.Second}
+	generatorMetrics := metrics.NewMockMetrics()
+	unconfirmedKeyChannel := make(chan *UnconfirmedKeyV2, 1000)
+
+	// Every dispersal outlasts the burst, so only the first writes of a burst find a free slot.
+	status := dispv2.Queued
+	disperserClient := &MockDisperserClientV2{}
+	disperserClient.mock.On("DisperseBlob", mock.Anything, mock.Anything, mock.Anything).
+		Return(&status, corev2.BlobKey{}, nil).After(100 * time.Millisecond)
+	writer := NewBlobWriterV2(
+		&ctx,
+		&waitGroup,
+		logger,
+		workerConfig,
+		corev2.BlobVersion(0),
+		disperserClient,
+		ReservationPayment,
+		unconfirmedKeyChannel,
+		generatorMetrics)
+	writers := map[string]*BlobWriterV2{ReservationPayment: &writer}
+
+	reader := NewBlobReaderV2(
+		&ctx,
+		&waitGroup,
+		logger,
+		workerConfig,
+		clientsmock.NewRelayClient(),
+		clientsmock.NewRetrievalClient(),
+		table.NewBlobStore(),
+		generatorMetrics)
+
+	workload := &config.Workload{
+		Phases: []*config.WorkloadPhase{
+			{
+				Name:     "bursty",
+				Duration: 300 * time.Millisecond,
+				BlobSize: config.SizeDistribution{Type: config.FixedSize, Size: 64},
+				Arrival: config.ArrivalProcess{
+					Type:          config.BurstyArrival,
+					BurstSize:     5,
+					BurstInterval: 200 * time.Millisecond,
+				},
+				MaxInFlight: 2,
+			},
+		},
+	}
+
+	runner, err := NewWorkloadRunner(&ctx, &waitGroup, logger, workerConfig, workload, writers, &reader, "")
+	assert.Nil(t, err)
+
+	runner.Start()
+	select {
+	case <-runner.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("workload did not finish")
+	}
+
+	// Two bursts of five blobs, at 0ms and 200ms, of which two blobs each are written.
+	summary := runner.Summaries()[0]
+	assert.Equal(t, uint64(4), summary.Writes)
+	assert.Equal(t, uint64(6), summary.DroppedWrites)
+	assert.Equal(t, 4, len(disperserClient.mock.Calls))
+}
+
+func TestWorkloadRunnerUnknownPaymentMode(t *testing.T) {
+	ctx := context.Background()
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.Nil(t, err)
+
+	writers := map[string]*BlobWriterV2{ReservationPayment: {}}
+	workload := &config.Workload{
+		Phases: []*config.WorkloadPhase{
+			{Payments: []*config.PaymentMix{{Payment: config.OnDemandPaymentMode, Weight: 1}}},
+		},
+	}
+	_, err = NewWorkloadRunner(
+		&ctx, &sync.WaitGroup{}, logger, &config.WorkerConfig{}, workload, writers, &BlobReaderV2{}, "")
+	assert.NotNil(t, err)
+}
+
+func TestArrivalClock(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	constant := newArrivalClock(&config.ArrivalProcess{Type: config.ConstantArrival, Rate: 4}, random)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 250*time.Millisecond, constant.next())
+	}
+
+	bursty := newArrivalClock(&config.ArrivalProcess{
+		Type:          config.BurstyArrival,
+		BurstSize:     3,
+		BurstInterval: time.Second,
+	}, random)
+	expected := []time.Duration{0, 0, 0, time.Second, 0, 0, time.Second}
+	for _, gap := range expected {
+		assert.Equal(t, gap, bursty.next())
+	}
+
+	// The average gap of a poisson process is the inverse of its rate.
+	poisson := newArrivalClock(&config.ArrivalProcess{Type: config.PoissonArrival, Rate: 10}, random)
+	total := time.Duration(0)
+	for i := 0; i < 10000; i++ {
+		total += poisson.next()
+	}
+	assert.InDelta(t, float64(100*time.Millisecond), float64(total/10000), float64(5*time.Millisecond))
+}